@@ -28,16 +28,24 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	_ "crypto/sha512"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/binary"
 	"encoding/pem"
 	"errors"
-	"fmt"
+	"hash"
 	"io/ioutil"
 	"math"
 	"math/big"
+	"path/filepath"
+	"sync"
 	"sync/atomic"
-	"time"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmsm/sm3"
 )
 
 const (
@@ -45,7 +53,9 @@ const (
 	AlgNull = 0x0000
 	AlgRSA  = 0x0001
 	AlgAES  = 0x0006
+	AlgSM3  = 0x0012
 	AlgOAEP = 0x0017
+	AlgSM2  = 0x001B
 	AlgCTR  = 0x0040
 	AlgOFB  = 0x0041
 	AlgCBC  = 0x0042
@@ -61,7 +71,9 @@ const (
 	headRsaPubKey  = "RSA PUBLIC KEY"
 	modKey         = 0600
 	headCert       = "CERTIFICATE"
-	modCert        = 0644
+	// GeneralName directoryName tag in subject alternative name
+	tagDirectoryName = 4
+	modCert          = 0644
 )
 
 type (
@@ -81,10 +93,34 @@ type (
 		EncryptParam []byte
 	}
 
+	// EKHardwareInfo keeps the TPM hardware fields in EK certificate.
+	EKHardwareInfo struct {
+		Manufacturer string
+		Model        string
+		Version      string
+	}
+
+	// EKTrustStore keeps the TPM vendor root and intermediate certificates
+	// which are used to verify EK certificates.
+	EKTrustStore struct {
+		roots         *x509.CertPool
+		intermediates *x509.CertPool
+		count         int
+	}
+
 	TPMAsymKeyParams struct {
 		TPMAsymAlgorithm string
 		TPMEncscheme     string
 	}
+
+	// SignatureVerifier verifies the TPM signature of digest by public key,
+	// h is the crypto hash of digest, or 0 if crypto package doesn't have it.
+	SignatureVerifier func(pub crypto.PublicKey, h crypto.Hash, digest []byte, sig *tpm2.Signature) error
+
+	sigVerifierKey struct {
+		sigAlg  tpm2.Algorithm
+		hashAlg tpm2.Algorithm
+	}
 )
 
 var (
@@ -96,8 +132,43 @@ var (
 	ErrDecodePEM   = errors.New("failed to decode PEM information")
 	ErrParseKey    = errors.New("failed to parse the key")
 	ErrWrongParams = errors.New("wrong input parameter")
+	// ErrUnsupportedAlg means the signature scheme or hash algorithm isn't supported
+	ErrUnsupportedAlg = errors.New("unsupported signature or hash algorithm")
+	// ErrVerifySignature means the signature doesn't match the data
+	ErrVerifySignature = errors.New("failed to verify signature")
+
+	// ErrEKCertNoHWInfo means EK certificate doesn't have TPM hardware fields
+	ErrEKCertNoHWInfo = errors.New("EK certificate has no TPM manufacturer information")
+	// ErrEKCertUsage means EK certificate extended key usage is wrong
+	ErrEKCertUsage = errors.New("EK certificate extended key usage is not tcg-kp-EKCertificate")
+
+	// OIDs defined by TCG EK credential profile
+	OidTPMManufacturer    = asn1.ObjectIdentifier{2, 23, 133, 2, 1}
+	OidTPMModel           = asn1.ObjectIdentifier{2, 23, 133, 2, 2}
+	OidTPMVersion         = asn1.ObjectIdentifier{2, 23, 133, 2, 3}
+	OidTCGKpEKCertificate = asn1.ObjectIdentifier{2, 23, 133, 8, 1}
+	oidSubjectAltName     = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+	// signature verifiers for quote, keyed by signature scheme and hash algorithm
+	sigVerifiersLock sync.RWMutex
+	sigVerifiers     = map[sigVerifierKey]SignatureVerifier{}
+
+	// OidIKName is a private certificate extension which keeps the TPM name
+	// of the identity key, PCA adds it into IK certificate so that the quote
+	// qualified signer could be checked later.
+	OidIKName = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2011, 2, 330, 1}
 )
 
+func init() {
+	for _, h := range []tpm2.Algorithm{tpm2.AlgSHA1, tpm2.AlgSHA256,
+		tpm2.AlgSHA384, tpm2.AlgSHA512} {
+		RegisterSignatureVerifier(tpm2.AlgRSASSA, h, verifyRSASSA)
+		RegisterSignatureVerifier(tpm2.AlgRSAPSS, h, verifyRSAPSS)
+		RegisterSignatureVerifier(tpm2.AlgECDSA, h, verifyECDSA)
+	}
+	RegisterSignatureVerifier(AlgSM2, AlgSM3, verifySM2)
+}
+
 // GetRandomBytes gets random bytes
 func GetRandomBytes(size int) ([]byte, error) {
 	b := make([]byte, size)
@@ -349,6 +420,8 @@ If KDFa() were used to produce a 521-bit ECC private key, the returned value
 would occupy 66 octets, with the upper 7 bits of the octet at offset zero
 set to 0.
 */
+
+// KDFa derives a key stream of bits by the TPM 2.0 KDFa quoted above.
 func KDFa(alg crypto.Hash, key []byte, label string, contextU, contextV []byte, bits int) ([]byte, error) {
 	bufLen := ((bits + 7) / 8)
 	if bufLen > math.MaxInt16 {
@@ -639,6 +712,10 @@ the endorsement or platform hierarchy. The obfuscation is consistent when
 using the same key so the receiver can detect a change in the values while
 not seeing the actual values.
 */
+
+// MakeCredential protects the credential for the key of name by ekPubKey
+// as the TPM 2.0 credential protection quoted above, returns the credential
+// blob and the seed encrypted by ekPubKey.
 func MakeCredential(ekPubKey crypto.PublicKey, credential, name []byte) ([]byte, []byte, error) {
 	if len(credential) == 0 || len(name) == 0 || len(credential) > crypto.SHA256.Size() {
 		return nil, nil, ErrWrongParams
@@ -830,7 +907,7 @@ func DecodeKeyCertFromFile(fileName string) (*x509.Certificate, []byte, error) {
 	return DecodeKeyCertFromPEM(data)
 }
 
-//DecodeKeyCertFromNVFile decode the cert from NVRAM's file
+// DecodeKeyCertFromNVFile decode the cert from NVRAM's file
 func DecodeKeyCertFromNVFile(fileName string) (*x509.Certificate, []byte, error) {
 	data, err := ioutil.ReadFile(fileName)
 	if err != nil {
@@ -912,49 +989,335 @@ func EncryptIKCert(ekPubKey crypto.PublicKey, ikCert []byte, ikName []byte) (*IK
 	return &ikCertChallenge, nil
 }
 
-//verifyComCert will get the all cert from certificate file to verify the cert is validated
-func verifyComCert(pathname string, cert *x509.Certificate) bool {
-	timeNow := time.Now()
-	if !timeNow.Before(cert.NotAfter) {
-		fmt.Println("The certificate has expired!")
-		return false
+// NewIKNameExtension returns a certificate extension which saves the IK name
+// as an asn1 octet string.
+func NewIKNameExtension(ikName []byte) (pkix.Extension, error) {
+	v, err := asn1.Marshal(ikName)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{
+		Id:       OidIKName,
+		Critical: false,
+		Value:    v,
+	}, nil
+}
+
+// GetIKNameFromCert returns the IK name saved in the IK certificate extension,
+// or nil if the certificate doesn't have one(issued by an old PCA).
+func GetIKNameFromCert(cert *x509.Certificate) []byte {
+	if cert == nil {
+		return nil
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(OidIKName) {
+			var name []byte
+			_, err := asn1.Unmarshal(ext.Value, &name)
+			if err != nil {
+				return nil
+			}
+			return name
+		}
+	}
+	return nil
+}
+
+// RegisterSignatureVerifier registers the verifier for the TPM signature
+// scheme sigAlg with digest algorithm hashAlg, it replaces the old one if
+// exists, so that customer could plug in another implementation.
+func RegisterSignatureVerifier(sigAlg, hashAlg tpm2.Algorithm, v SignatureVerifier) {
+	sigVerifiersLock.Lock()
+	defer sigVerifiersLock.Unlock()
+	sigVerifiers[sigVerifierKey{sigAlg, hashAlg}] = v
+}
+
+func getSignatureVerifier(sigAlg, hashAlg tpm2.Algorithm) SignatureVerifier {
+	sigVerifiersLock.RLock()
+	defer sigVerifiersLock.RUnlock()
+	return sigVerifiers[sigVerifierKey{sigAlg, hashAlg}]
+}
+
+// getSignatureHashAlg returns the digest algorithm used in the signature.
+func getSignatureHashAlg(sig *tpm2.Signature) (tpm2.Algorithm, error) {
+	switch {
+	case sig.RSA != nil:
+		return sig.RSA.HashAlg, nil
+	case sig.ECC != nil:
+		return sig.ECC.HashAlg, nil
+	}
+	return AlgNull, ErrWrongParams
+}
+
+// digestData calculates the digest of data with TPM hash algorithm hashAlg.
+func digestData(hashAlg tpm2.Algorithm, data []byte) ([]byte, crypto.Hash, error) {
+	var h hash.Hash
+	var ch crypto.Hash
+	switch hashAlg {
+	case tpm2.AlgSHA1:
+		ch = crypto.SHA1
+	case tpm2.AlgSHA256:
+		ch = crypto.SHA256
+	case tpm2.AlgSHA384:
+		ch = crypto.SHA384
+	case tpm2.AlgSHA512:
+		ch = crypto.SHA512
+	case AlgSM3:
+		// crypto package doesn't define sm3, use 0 instead.
+		h = sm3.New()
+	default:
+		return nil, 0, ErrUnsupportedAlg
+	}
+	if h == nil {
+		h = ch.New()
+	}
+	h.Write(data)
+	return h.Sum(nil), ch, nil
+}
+
+// VerifySignature verifies the TPM signature of data by public key, it
+// dispatches to the verifier registered for the signature scheme and hash.
+func VerifySignature(pub crypto.PublicKey, data []byte, sig *tpm2.Signature) error {
+	if pub == nil || sig == nil {
+		return ErrWrongParams
+	}
+	hashAlg, err := getSignatureHashAlg(sig)
+	if err != nil {
+		return err
+	}
+	v := getSignatureVerifier(sig.Alg, hashAlg)
+	if v == nil {
+		return ErrUnsupportedAlg
+	}
+	digest, ch, err := digestData(hashAlg, data)
+	if err != nil {
+		return err
+	}
+	return v(pub, ch, digest, sig)
+}
+
+func verifyRSASSA(pub crypto.PublicKey, h crypto.Hash, digest []byte, sig *tpm2.Signature) error {
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok || sig.RSA == nil || h == 0 {
+		return ErrWrongParams
+	}
+	return rsa.VerifyPKCS1v15(rsaPub, h, digest, sig.RSA.Signature)
+}
+
+func verifyRSAPSS(pub crypto.PublicKey, h crypto.Hash, digest []byte, sig *tpm2.Signature) error {
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok || sig.RSA == nil || h == 0 {
+		return ErrWrongParams
+	}
+	// TPM uses the max salt length, so let rsa package detect it.
+	return rsa.VerifyPSS(rsaPub, h, digest, sig.RSA.Signature,
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+}
+
+func verifyECDSA(pub crypto.PublicKey, h crypto.Hash, digest []byte, sig *tpm2.Signature) error {
+	eccPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok || sig.ECC == nil || sig.ECC.R == nil || sig.ECC.S == nil {
+		return ErrWrongParams
+	}
+	if !ecdsa.Verify(eccPub, digest, sig.ECC.R, sig.ECC.S) {
+		return ErrVerifySignature
+	}
+	return nil
+}
+
+// verifySM2 verifies the sm2 signature, TPM signs the sm3 digest of data
+// directly without the ZA value, so use the raw verify.
+func verifySM2(pub crypto.PublicKey, h crypto.Hash, digest []byte, sig *tpm2.Signature) error {
+	var sm2Pub *sm2.PublicKey
+	switch k := pub.(type) {
+	case *sm2.PublicKey:
+		sm2Pub = k
+	case *ecdsa.PublicKey:
+		if k.Curve != sm2.P256Sm2() {
+			return ErrWrongParams
+		}
+		sm2Pub = &sm2.PublicKey{Curve: k.Curve, X: k.X, Y: k.Y}
+	default:
+		return ErrWrongParams
+	}
+	if sig.ECC == nil || sig.ECC.R == nil || sig.ECC.S == nil {
+		return ErrWrongParams
+	}
+	if !sm2.Verify(sm2Pub, digest, sig.ECC.R, sig.ECC.S) {
+		return ErrVerifySignature
+	}
+	return nil
+}
+
+// NewEKTrustStore returns an empty EK trust store.
+func NewEKTrustStore() *EKTrustStore {
+	return &EKTrustStore{
+		roots:         x509.NewCertPool(),
+		intermediates: x509.NewCertPool(),
+	}
+}
+
+// AddCert adds a TPM vendor certificate into trust store, the self-signed
+// one is used as root and the others as intermediates.
+func (s *EKTrustStore) AddCert(cert *x509.Certificate) {
+	if cert == nil {
+		return
+	}
+	if bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignatureFrom(cert) == nil {
+		s.roots.AddCert(cert)
+	} else {
+		s.intermediates.AddCert(cert)
 	}
-	rd, err := ioutil.ReadDir(pathname)
+	s.count++
+}
+
+// Len returns the number of certificates in trust store.
+func (s *EKTrustStore) Len() int {
+	return s.count
+}
+
+// LoadDir loads all vendor certificates in PEM or DER format from the files
+// in dir, a PEM file may contain a certificates chain. Files which are not
+// certificate are skipped.
+func (s *EKTrustStore) LoadDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
 	if err != nil {
-		return false
+		return err
 	}
-	for _, fi := range rd {
-		if !fi.IsDir() {
-			ca, _, err := DecodeKeyCertFromFile(pathname + "/" + fi.Name())
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		found := false
+		for {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+			if block.Type != headCert {
+				continue
+			}
+			found = true
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err == nil {
+				s.AddCert(cert)
+			}
+		}
+		if !found {
+			cert, err := x509.ParseCertificate(data)
+			if err == nil {
+				s.AddCert(cert)
+			}
+		}
+	}
+	return nil
+}
+
+// GetEKHardwareInfo returns the TPM manufacturer, model and version which are
+// saved as directoryName in the subject alternative name of EK certificate,
+// according to TCG EK credential profile.
+func GetEKHardwareInfo(cert *x509.Certificate) (*EKHardwareInfo, error) {
+	if cert == nil {
+		return nil, ErrWrongParams
+	}
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		var names []asn1.RawValue
+		_, err := asn1.Unmarshal(ext.Value, &names)
+		if err != nil {
+			return nil, ErrEKCertNoHWInfo
+		}
+		info := &EKHardwareInfo{}
+		for _, name := range names {
+			if name.Class != asn1.ClassContextSpecific || name.Tag != tagDirectoryName {
+				continue
+			}
+			var rdns pkix.RDNSequence
+			_, err = asn1.Unmarshal(name.Bytes, &rdns)
 			if err != nil {
-				return false
+				continue
 			}
-			if validateCert(cert, ca) == nil {
-				return true
+			for _, rdn := range rdns {
+				for _, atv := range rdn {
+					v, ok := atv.Value.(string)
+					if !ok {
+						continue
+					}
+					switch {
+					case atv.Type.Equal(OidTPMManufacturer):
+						info.Manufacturer = v
+					case atv.Type.Equal(OidTPMModel):
+						info.Model = v
+					case atv.Type.Equal(OidTPMVersion):
+						info.Version = v
+					}
+				}
 			}
 		}
+		if info.Manufacturer == "" {
+			return nil, ErrEKCertNoHWInfo
+		}
+		return info, nil
+	}
+	return nil, ErrEKCertNoHWInfo
+}
+
+// hasEKCertUsage checks the extended key usage of EK certificate, it is
+// optional, but must have tcg-kp-EKCertificate if exists.
+func hasEKCertUsage(cert *x509.Certificate) bool {
+	if len(cert.ExtKeyUsage) == 0 && len(cert.UnknownExtKeyUsage) == 0 {
+		return true
+	}
+	for _, u := range cert.ExtKeyUsage {
+		if u == x509.ExtKeyUsageAny {
+			return true
+		}
+	}
+	for _, u := range cert.UnknownExtKeyUsage {
+		if u.Equal(OidTCGKpEKCertificate) {
+			return true
+		}
 	}
 	return false
 }
 
-//validateCert will check the signature is or not validated by parent cert
-func validateCert(cert, parent *x509.Certificate) error {
-	//check the period validate
-	timeNow := time.Now()
-	if !timeNow.Before(parent.NotAfter) {
-		return errors.New("The certificate has expired!")
+// VerifyEKCert verifies the EK certificate, checks the TCG fields and builds
+// the certificate chain to a vendor root in trust store, returns the TPM
+// hardware information if it is trusted.
+func (s *EKTrustStore) VerifyEKCert(cert *x509.Certificate) (*EKHardwareInfo, error) {
+	if cert == nil {
+		return nil, ErrWrongParams
 	}
-	// er := cert.CheckSignatureFrom(parent)
-	// if er == nil {
-	// 	fmt.Println("success")
-	// 	return er
-	// }
-	if cert.Issuer.CommonName != parent.Subject.CommonName {
-		return errors.New("cert issuer name is not the parent subject name")
+	info, err := GetEKHardwareInfo(cert)
+	if err != nil {
+		return nil, err
 	}
-	err := parent.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+	if !hasEKCertUsage(cert) {
+		return nil, ErrEKCertUsage
+	}
+	// the SAN only has directoryName which go x509 package doesn't handle,
+	// it is checked above, so remove it from the unhandled list.
+	ekCert := *cert
+	ekCert.UnhandledCriticalExtensions = nil
+	for _, id := range cert.UnhandledCriticalExtensions {
+		if !id.Equal(oidSubjectAltName) {
+			ekCert.UnhandledCriticalExtensions = append(ekCert.UnhandledCriticalExtensions, id)
+		}
+	}
+	_, err = ekCert.Verify(x509.VerifyOptions{
+		Roots:         s.roots,
+		Intermediates: s.intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return info, nil
 }
@@ -7,8 +7,10 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -22,7 +24,10 @@ import (
 
 	"github.com/google/go-tpm-tools/simulator"
 	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpmutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmsm/sm3"
 )
 
 var (
@@ -811,14 +816,212 @@ func TestNilJudge(t *testing.T) {
 	}
 
 }
+
+// createTestEKSAN creates the subject alternative name extension of EK
+// certificate which keeps TPM hardware fields in directoryName.
+func createTestEKSAN(t *testing.T) pkix.Extension {
+	rdns := pkix.RDNSequence{{
+		{Type: OidTPMManufacturer, Value: "id:4E544300"},
+		{Type: OidTPMModel, Value: "test model"},
+		{Type: OidTPMVersion, Value: "id:00010002"},
+	}}
+	dn, err := asn1.Marshal(rdns)
+	if err != nil {
+		t.Fatalf("marshal directoryName error, %v", err)
+	}
+	san, err := asn1.Marshal([]asn1.RawValue{{Class: asn1.ClassContextSpecific,
+		Tag: tagDirectoryName, IsCompound: true, Bytes: dn}})
+	if err != nil {
+		t.Fatalf("marshal SAN error, %v", err)
+	}
+	return pkix.Extension{Id: oidSubjectAltName, Critical: true, Value: san}
+}
+
+func createTestCA(t *testing.T, cn string) (*rsa.PrivateKey, *x509.Certificate) {
+	priv, _ := rsa.GenerateKey(rand.Reader, RsaKeySize)
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("create ca cert error, %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return priv, cert
+}
+
+func createTestEKCert(t *testing.T, caKey *rsa.PrivateKey, ca *x509.Certificate,
+	exts []pkix.Extension, eku []asn1.ObjectIdentifier) *x509.Certificate {
+	ekKey, _ := rsa.GenerateKey(rand.Reader, RsaKeySize)
+	tmpl := x509.Certificate{
+		SerialNumber:       big.NewInt(2),
+		NotBefore:          time.Now().Add(-time.Hour),
+		NotAfter:           time.Now().AddDate(1, 0, 0),
+		KeyUsage:           x509.KeyUsageKeyEncipherment,
+		ExtraExtensions:    exts,
+		UnknownExtKeyUsage: eku,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, ca, &ekKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("create ek cert error, %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse ek cert error, %v", err)
+	}
+	return cert
+}
+
+func TestEKTrustStore(t *testing.T) {
+	store := NewEKTrustStore()
+	err := store.LoadDir("certificates")
+	if err != nil || store.Len() == 0 {
+		t.Fatalf("load EK trust store error, %v", err)
+	}
+	for _, f := range []string{"RSA_EK_cert.bin", "ECC_EK_cert.bin"} {
+		cert, _, err := DecodeKeyCertFromNVFile(f)
+		if err != nil {
+			t.Fatalf("decode %s error, %v", f, err)
+		}
+		info, err := store.VerifyEKCert(cert)
+		if err != nil {
+			t.Errorf("verify vendor EK cert %s error, %v", f, err)
+			continue
+		}
+		if info.Manufacturer == "" {
+			t.Errorf("get EK hardware info of %s error", f)
+		}
+		// an empty trust store trusts nothing
+		_, err = NewEKTrustStore().VerifyEKCert(cert)
+		if err == nil {
+			t.Errorf("verify vendor EK cert %s with empty store error", f)
+		}
+	}
+	_, err = NewEKTrustStore().VerifyEKCert(nil)
+	if err != ErrWrongParams {
+		t.Errorf("VerifyEKCert doesn't handle nil input correctly")
+	}
+}
+
+func TestVerifyEKCert(t *testing.T) {
+	caKey, ca := createTestCA(t, "test vendor ca")
+	otherKey, other := createTestCA(t, "test vendor ca")
+	store := NewEKTrustStore()
+	store.AddCert(ca)
+	san := createTestEKSAN(t)
+
+	testCases := []struct {
+		caKey *rsa.PrivateKey
+		ca    *x509.Certificate
+		exts  []pkix.Extension
+		eku   []asn1.ObjectIdentifier
+		ok    bool
+	}{
+		{caKey, ca, []pkix.Extension{san}, nil, true},
+		{caKey, ca, []pkix.Extension{san}, []asn1.ObjectIdentifier{OidTCGKpEKCertificate}, true},
+		// no TPM hardware fields
+		{caKey, ca, nil, nil, false},
+		// wrong extended key usage
+		{caKey, ca, []pkix.Extension{san}, []asn1.ObjectIdentifier{{1, 2, 3, 4}}, false},
+		// issued by an unknown ca which has the same name as trusted one
+		{otherKey, other, []pkix.Extension{san}, nil, false},
+	}
+	for i, tc := range testCases {
+		cert := createTestEKCert(t, tc.caKey, tc.ca, tc.exts, tc.eku)
+		info, err := store.VerifyEKCert(cert)
+		if (err == nil) != tc.ok {
+			t.Errorf("test VerifyEKCert error at case %d, %v\n", i, err)
+		}
+		if err == nil && info.Model != "test model" {
+			t.Errorf("test VerifyEKCert hardware info error at case %d\n", i)
+		}
+	}
+}
+
 func TestDecodeDerCert(t *testing.T) {
 	cert, _, err := DecodeKeyCertFromNVFile("RSA_EK_cert.bin")
 	if err != nil {
 		fmt.Println(err)
 		assert.NoError(t, err)
 	}
-	rt := verifyComCert("certificates", cert)
-	fmt.Println(rt)
+	s := NewEKTrustStore()
+	if err = s.LoadDir("certificates"); err != nil {
+		t.Fatalf("test load EK trust store error, %v", err)
+	}
+	_, err = s.VerifyEKCert(cert)
+	fmt.Println(err == nil)
+}
+
+// sm2RawSign signs the digest directly as TPM does, without ZA value.
+func sm2RawSign(t *testing.T, priv *sm2.PrivateKey, digest []byte) (*big.Int, *big.Int) {
+	n := priv.Curve.Params().N
+	e := new(big.Int).SetBytes(digest)
+	one := big.NewInt(1)
+	for {
+		k, err := rand.Int(rand.Reader, new(big.Int).Sub(n, one))
+		if err != nil {
+			t.Fatalf("generate random k failed, %v", err)
+		}
+		k.Add(k, one)
+		x1, _ := priv.Curve.ScalarBaseMult(k.Bytes())
+		r := new(big.Int).Add(e, x1)
+		r.Mod(r, n)
+		if r.Sign() == 0 || new(big.Int).Add(r, k).Cmp(n) == 0 {
+			continue
+		}
+		// s = (1+d)^-1 * (k - r*d) mod n
+		d1 := new(big.Int).Add(priv.D, one)
+		d1.ModInverse(d1, n)
+		s := new(big.Int).Mul(r, priv.D)
+		s.Sub(k, s)
+		s.Mul(s, d1)
+		s.Mod(s, n)
+		if s.Sign() != 0 {
+			return r, s
+		}
+	}
+}
+
+// the tpm simulator doesn't support sm2/sm3, so the quote is packed and
+// signed here as a sm2 TPM does.
+func TestVerifySM2Quote(t *testing.T) {
+	priv, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate sm2 key failed, %v", err)
+	}
+	// TPMS_ATTEST of quote: magic, type, qualified signer, extra data,
+	// clock info, firmware version, sm3 pcr 0/7/10 selection and digest.
+	signer := append([]byte{0, byte(tpm2.AlgSHA256)}, make([]byte, 32)...)
+	quoted, err := tpmutil.Pack(uint32(0xff544347), tpm2.TagAttestQuote, tpmutil.U16Bytes(signer),
+		tpmutil.U16Bytes("report hash"), uint64(1), uint32(1), uint32(0), byte(1),
+		uint64(0), uint32(1), tpm2.Algorithm(AlgSM3), byte(3), []byte{0x81, 0x04, 0x00},
+		tpmutil.U16Bytes(make([]byte, 32)))
+	if err != nil {
+		t.Fatalf("pack sm2 quote failed, %v", err)
+	}
+	att, err := tpm2.DecodeAttestationData(quoted)
+	if err != nil || att.AttestedQuoteInfo == nil || att.ClockInfo.ResetCount != 1 {
+		t.Fatalf("decode sm2 quote failed, %v", err)
+	}
+	r, s := sm2RawSign(t, priv, sm3.Sm3Sum(quoted))
+	// RAS receives the signature in json format
+	jsonSig, _ := json.Marshal(&tpm2.Signature{Alg: AlgSM2,
+		ECC: &tpm2.SignatureECC{HashAlg: AlgSM3, R: r, S: s}})
+	sig := new(tpm2.Signature)
+	_ = json.Unmarshal(jsonSig, sig)
+	if err = VerifySignature(&priv.PublicKey, quoted, sig); err != nil {
+		t.Errorf("verify sm2 quote signature failed, %v", err)
+	}
+	quoted[len(quoted)-1] ^= 0xff
+	if err = VerifySignature(&priv.PublicKey, quoted, sig); err == nil {
+		t.Errorf("verify tampered sm2 quote succeeded")
+	}
 }
 
 /*
@@ -0,0 +1,552 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: verify the ima file signatures by the keyring of vendor file
+signing certificates.
+*/
+
+package cryptotools
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmsm/sm3"
+	gmx509 "github.com/tjfoc/gmsm/x509"
+)
+
+const (
+	// the type and version of ima digsig v2 header, see the kernel
+	// security/integrity/integrity.h signature_v2_hdr.
+	imaXattrDigsig = 0x03
+	imaDigsigV2    = 2
+	imaSigHdrLen   = 9
+	imaKeyIDLen    = 4
+	imaKeyFileFmt  = "ima-%08x-%s.pem"
+)
+
+type (
+	// ImaSignature is the ima digsig v2 signature of a file.
+	ImaSignature struct {
+		// HashAlg is the algorithm of the signed file digest, like sha256.
+		HashAlg string
+		// KeyID is the last 4 bytes of the signing key identifier.
+		KeyID uint32
+		Sig   []byte
+	}
+
+	// ImaKeyInfo shows a file signing certificate in keyring.
+	ImaKeyInfo struct {
+		KeyID    string    `json:"keyid"`
+		Subject  string    `json:"subject"`
+		NotAfter time.Time `json:"notafter"`
+		File     string    `json:"file,omitempty"`
+	}
+
+	// ImaKeyring keeps the vendor file signing certificates used to verify
+	// the ima signatures, the certificates added at runtime are also saved
+	// into the keyring directory if it is set.
+	ImaKeyring struct {
+		lock sync.RWMutex
+		dir  string
+		keys map[uint32][]*imaKey
+	}
+
+	imaKey struct {
+		id        uint32
+		subject   string
+		notAfter  time.Time
+		pub       crypto.PublicKey
+		rawIssuer []byte
+		serial    *big.Int
+		skid      []byte
+		file      string
+	}
+
+	// the parts of CMS SignedData used by the module appended signature.
+	pkcs7ContentInfo struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue `asn1:"explicit,tag:0"`
+	}
+	pkcs7SignedData struct {
+		Version          int
+		DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+		ContentInfo      asn1.RawValue
+		Certificates     asn1.RawValue     `asn1:"optional,tag:0"`
+		Crls             asn1.RawValue     `asn1:"optional,tag:1"`
+		SignerInfos      []pkcs7SignerInfo `asn1:"set"`
+	}
+	pkcs7SignerInfo struct {
+		Version            int
+		Sid                asn1.RawValue
+		DigestAlgorithm    pkix.AlgorithmIdentifier
+		SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+		SignatureAlgorithm pkix.AlgorithmIdentifier
+		Signature          []byte
+		UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+	}
+	pkcs7IssuerAndSerial struct {
+		Issuer asn1.RawValue
+		Serial *big.Int
+	}
+	pkcs7Attribute struct {
+		Type   asn1.ObjectIdentifier
+		Values []asn1.RawValue `asn1:"set"`
+	}
+	subjectPublicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	ecSignature struct {
+		R, S *big.Int
+	}
+)
+
+var (
+	// ErrImaSigFormat means the signature isn't ima digsig v2 or pkcs7 format
+	ErrImaSigFormat = errors.New("wrong ima signature format")
+	// ErrImaKeyNotFound means the signing key isn't in ima keyring
+	ErrImaKeyNotFound = errors.New("ima signing key not found in keyring")
+
+	// the kernel hash_algo enum values.
+	imaHashAlgs = map[byte]string{
+		2:  "sha1",
+		4:  "sha256",
+		5:  "sha384",
+		6:  "sha512",
+		7:  "sha224",
+		17: "sm3",
+	}
+	imaCryptoHashes = map[string]crypto.Hash{
+		"sha1":   crypto.SHA1,
+		"sha224": crypto.SHA224,
+		"sha256": crypto.SHA256,
+		"sha384": crypto.SHA384,
+		"sha512": crypto.SHA512,
+	}
+	pkcs7HashOids = map[string]string{
+		"1.3.14.3.2.26":          "sha1",
+		"2.16.840.1.101.3.4.2.4": "sha224",
+		"2.16.840.1.101.3.4.2.1": "sha256",
+		"2.16.840.1.101.3.4.2.2": "sha384",
+		"2.16.840.1.101.3.4.2.3": "sha512",
+		"1.2.156.10197.1.401":    "sm3",
+	}
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+)
+
+// ParseImaSignature parses the ima digsig v2 signature of security.ima xattr.
+func ParseImaSignature(b []byte) (*ImaSignature, error) {
+	if len(b) < imaSigHdrLen || b[0] != imaXattrDigsig || b[1] != imaDigsigV2 {
+		return nil, ErrImaSigFormat
+	}
+	alg, ok := imaHashAlgs[b[2]]
+	if !ok {
+		return nil, ErrUnsupportedAlg
+	}
+	n := int(binary.BigEndian.Uint16(b[7:imaSigHdrLen]))
+	if n == 0 || len(b) != imaSigHdrLen+n {
+		return nil, ErrImaSigFormat
+	}
+	return &ImaSignature{
+		HashAlg: alg,
+		KeyID:   binary.BigEndian.Uint32(b[3:7]),
+		Sig:     b[imaSigHdrLen:],
+	}, nil
+}
+
+// ImaKeyID returns the key id which evmctl puts into ima signature, it is
+// the last 4 bytes of sha1 digest of the public key.
+func ImaKeyID(rawSubjectPublicKeyInfo []byte) (uint32, error) {
+	var spki subjectPublicKeyInfo
+	_, err := asn1.Unmarshal(rawSubjectPublicKeyInfo, &spki)
+	if err != nil {
+		return 0, err
+	}
+	h := sha1.Sum(spki.PublicKey.RightAlign())
+	return binary.BigEndian.Uint32(h[len(h)-imaKeyIDLen:]), nil
+}
+
+// parseImaCert parses a file signing certificate, the sm2 certificate which
+// isn't supported by go x509 package is parsed by gmsm.
+func parseImaCert(der []byte) (*imaKey, error) {
+	k := &imaKey{}
+	var spki []byte
+	cert, err := x509.ParseCertificate(der)
+	if err == nil {
+		k.subject, k.notAfter, k.pub = cert.Subject.String(), cert.NotAfter, cert.PublicKey
+		k.rawIssuer, k.serial, k.skid = cert.RawIssuer, cert.SerialNumber, cert.SubjectKeyId
+		spki = cert.RawSubjectPublicKeyInfo
+	} else {
+		gmCert, err2 := gmx509.ParseCertificate(der)
+		if err2 != nil {
+			return nil, err
+		}
+		k.subject, k.notAfter, k.pub = gmCert.Subject.String(), gmCert.NotAfter, gmCert.PublicKey
+		k.rawIssuer, k.serial, k.skid = gmCert.RawIssuer, gmCert.SerialNumber, gmCert.SubjectKeyId
+		spki = gmCert.RawSubjectPublicKeyInfo
+	}
+	k.id, err = ImaKeyID(spki)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// parseImaCerts parses all certificates in PEM or DER data, and returns
+// them together with their DER encodings.
+func parseImaCerts(data []byte) ([]*imaKey, [][]byte, error) {
+	var keys []*imaKey
+	var ders [][]byte
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != headCert {
+			continue
+		}
+		found = true
+		k, err := parseImaCert(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, k)
+		ders = append(ders, block.Bytes)
+	}
+	if !found {
+		k, err := parseImaCert(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, k)
+		ders = append(ders, data)
+	}
+	return keys, ders, nil
+}
+
+// NewImaKeyring returns an empty ima keyring.
+func NewImaKeyring() *ImaKeyring {
+	return &ImaKeyring{keys: map[uint32][]*imaKey{}}
+}
+
+// add adds key into keyring if the same certificate doesn't exist.
+func (r *ImaKeyring) add(k *imaKey) bool {
+	for _, old := range r.keys[k.id] {
+		if old.serial.Cmp(k.serial) == 0 && bytes.Equal(old.rawIssuer, k.rawIssuer) {
+			return false
+		}
+	}
+	r.keys[k.id] = append(r.keys[k.id], k)
+	return true
+}
+
+// LoadDir loads all certificates in PEM or DER format from the files in dir
+// and uses dir to save the certificates added later. Files which are not
+// certificate are skipped.
+func (r *ImaKeyring) LoadDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.dir = dir
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		keys, _, err := parseImaCerts(data)
+		if err != nil {
+			continue
+		}
+		for _, k := range keys {
+			k.file = f.Name()
+			r.add(k)
+		}
+	}
+	return nil
+}
+
+// AddCertData adds the certificates in PEM or DER data into keyring, each
+// new certificate is saved as a PEM file into keyring directory if it is
+// set. It returns the key ids of all certificates in data.
+func (r *ImaKeyring) AddCertData(data []byte) ([]string, error) {
+	keys, ders, err := parseImaCerts(data)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	ids := make([]string, 0, len(keys))
+	for i, k := range keys {
+		ids = append(ids, fmt.Sprintf("%08x", k.id))
+		if r.dir != "" {
+			k.file = fmt.Sprintf(imaKeyFileFmt, k.id, k.serial.Text(16))
+			buf := pem.EncodeToMemory(&pem.Block{Type: headCert, Bytes: ders[i]})
+			err = ioutil.WriteFile(filepath.Join(r.dir, k.file), buf, modCert)
+			if err != nil {
+				return nil, err
+			}
+		}
+		r.add(k)
+	}
+	return ids, nil
+}
+
+// RemoveKey removes all certificates with key id from keyring and deletes
+// their files in keyring directory.
+func (r *ImaKeyring) RemoveKey(keyID string) error {
+	var id uint32
+	_, err := fmt.Sscanf(keyID, "%x", &id)
+	if err != nil || len(keyID) != 2*imaKeyIDLen {
+		return ErrWrongParams
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	keys, ok := r.keys[id]
+	if !ok {
+		return ErrImaKeyNotFound
+	}
+	for _, k := range keys {
+		if r.dir != "" && k.file != "" {
+			err = os.Remove(filepath.Join(r.dir, k.file))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	delete(r.keys, id)
+	return nil
+}
+
+// Keys returns the information of all certificates in keyring.
+func (r *ImaKeyring) Keys() []ImaKeyInfo {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	infos := []ImaKeyInfo{}
+	for _, keys := range r.keys {
+		for _, k := range keys {
+			infos = append(infos, ImaKeyInfo{
+				KeyID:    fmt.Sprintf("%08x", k.id),
+				Subject:  k.subject,
+				NotAfter: k.notAfter,
+				File:     k.file,
+			})
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].KeyID != infos[j].KeyID {
+			return infos[i].KeyID < infos[j].KeyID
+		}
+		return infos[i].File < infos[j].File
+	})
+	return infos
+}
+
+// Len returns the number of certificates in keyring.
+func (r *ImaKeyring) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	n := 0
+	for _, keys := range r.keys {
+		n += len(keys)
+	}
+	return n
+}
+
+// VerifyImaSig verifies the ima digsig v2 signature of a file whose digest
+// is calculated by hashAlg.
+func (r *ImaKeyring) VerifyImaSig(sig []byte, hashAlg string, digest []byte) error {
+	s, err := ParseImaSignature(sig)
+	if err != nil {
+		return err
+	}
+	if s.HashAlg != hashAlg {
+		return ErrUnsupportedAlg
+	}
+	r.lock.RLock()
+	keys := r.keys[s.KeyID]
+	r.lock.RUnlock()
+	if len(keys) == 0 {
+		return ErrImaKeyNotFound
+	}
+	for _, k := range keys {
+		err = verifyImaDigest(k.pub, hashAlg, digest, s.Sig)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// VerifyModsig verifies the PKCS#7 module appended signature whose signed
+// content has the digest calculated by hashAlg, the signer is looked up
+// by issuer and serial number or subject key identifier.
+func (r *ImaKeyring) VerifyModsig(p7 []byte, hashAlg string, digest []byte) error {
+	var ci pkcs7ContentInfo
+	_, err := asn1.Unmarshal(p7, &ci)
+	if err != nil || !ci.ContentType.Equal(oidSignedData) {
+		return ErrImaSigFormat
+	}
+	var sd pkcs7SignedData
+	_, err = asn1.Unmarshal(ci.Content.Bytes, &sd)
+	if err != nil || len(sd.SignerInfos) == 0 {
+		return ErrImaSigFormat
+	}
+	si := sd.SignerInfos[0]
+	if pkcs7HashOids[si.DigestAlgorithm.Algorithm.String()] != hashAlg {
+		return ErrUnsupportedAlg
+	}
+	signed := digest
+	if len(si.SignedAttrs.Bytes) > 0 {
+		signed, err = checkSignedAttrs(si.SignedAttrs, hashAlg, digest)
+		if err != nil {
+			return err
+		}
+	}
+	keys := r.findSigner(si.Sid)
+	if len(keys) == 0 {
+		return ErrImaKeyNotFound
+	}
+	for _, k := range keys {
+		err = verifyImaDigest(k.pub, hashAlg, signed, si.Signature)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// checkSignedAttrs checks the message digest attribute and returns the digest
+// of signed attributes which is signed instead of the content.
+func checkSignedAttrs(raw asn1.RawValue, hashAlg string, digest []byte) ([]byte, error) {
+	var attrs []pkcs7Attribute
+	_, err := asn1.UnmarshalWithParams(raw.FullBytes, &attrs, "set,tag:0")
+	if err != nil {
+		return nil, ErrImaSigFormat
+	}
+	matched := false
+	for _, a := range attrs {
+		if !a.Type.Equal(oidMessageDigest) || len(a.Values) != 1 {
+			continue
+		}
+		var md []byte
+		_, err = asn1.Unmarshal(a.Values[0].FullBytes, &md)
+		if err != nil || !bytes.Equal(md, digest) {
+			return nil, ErrVerifySignature
+		}
+		matched = true
+	}
+	if !matched {
+		return nil, ErrImaSigFormat
+	}
+	// the signed attributes are signed as an explicit SET OF.
+	data := append([]byte{}, raw.FullBytes...)
+	data[0] = asn1.TagSet | 0x20
+	return imaHash(hashAlg, data)
+}
+
+// findSigner finds the keys of the PKCS#7 signer identifier.
+func (r *ImaKeyring) findSigner(sid asn1.RawValue) []*imaKey {
+	var ias pkcs7IssuerAndSerial
+	var skid []byte
+	if sid.Class == asn1.ClassContextSpecific {
+		skid = sid.Bytes
+	} else if _, err := asn1.Unmarshal(sid.FullBytes, &ias); err != nil || ias.Serial == nil {
+		return nil
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var found []*imaKey
+	for _, keys := range r.keys {
+		for _, k := range keys {
+			if skid != nil && bytes.Equal(skid, k.skid) ||
+				skid == nil && ias.Serial.Cmp(k.serial) == 0 &&
+					bytes.Equal(ias.Issuer.FullBytes, k.rawIssuer) {
+				found = append(found, k)
+			}
+		}
+	}
+	return found
+}
+
+func imaHash(hashAlg string, data []byte) ([]byte, error) {
+	if hashAlg == "sm3" {
+		return sm3.Sm3Sum(data), nil
+	}
+	h, ok := imaCryptoHashes[hashAlg]
+	if !ok || !h.Available() {
+		return nil, ErrUnsupportedAlg
+	}
+	hh := h.New()
+	hh.Write(data)
+	return hh.Sum(nil), nil
+}
+
+// verifyImaDigest verifies the RSA PKCS#1 v1.5, ECDSA or SM2 signature of
+// digest. Like the kernel, sm2 signs the file digest directly without the
+// ZA value.
+func verifyImaDigest(pub crypto.PublicKey, hashAlg string, digest, sig []byte) error {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		h, ok := imaCryptoHashes[hashAlg]
+		if !ok {
+			return ErrUnsupportedAlg
+		}
+		if rsa.VerifyPKCS1v15(k, h, digest, sig) != nil {
+			return ErrVerifySignature
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		var es ecSignature
+		rest, err := asn1.Unmarshal(sig, &es)
+		if err != nil || len(rest) != 0 || es.R == nil || es.S == nil ||
+			es.R.Sign() <= 0 || es.S.Sign() <= 0 {
+			return ErrVerifySignature
+		}
+		if k.Curve == sm2.P256Sm2() {
+			if !sm2.Verify(&sm2.PublicKey{Curve: k.Curve, X: k.X, Y: k.Y}, digest, es.R, es.S) {
+				return ErrVerifySignature
+			}
+			return nil
+		}
+		if !ecdsa.Verify(k, digest, es.R, es.S) {
+			return ErrVerifySignature
+		}
+		return nil
+	}
+	return ErrUnsupportedAlg
+}
@@ -0,0 +1,299 @@
+package cryptotools
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tjfoc/gmsm/sm2"
+	gmx509 "github.com/tjfoc/gmsm/x509"
+)
+
+type testImaSigner struct {
+	der  []byte
+	sign func(digest []byte) []byte
+}
+
+func newTestImaCert(t *testing.T, serial int64, pub, priv interface{}) []byte {
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "test file signing"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		SubjectKeyId: []byte{1, 2, 3, byte(serial)},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, pub, priv)
+	if err != nil {
+		t.Fatalf("create file signing cert error, %v", err)
+	}
+	return der
+}
+
+func newTestImaSigners(t *testing.T) map[string]testImaSigner {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, RsaKeySize)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	sm2Key, _ := sm2.GenerateKey(rand.Reader)
+	sm2Tmpl := gmx509.Certificate{
+		SerialNumber:       big.NewInt(3),
+		Subject:            pkix.Name{CommonName: "test sm2 file signing"},
+		NotBefore:          time.Now().Add(-time.Hour),
+		NotAfter:           time.Now().AddDate(1, 0, 0),
+		SignatureAlgorithm: gmx509.SM2WithSM3,
+	}
+	sm2Der, err := gmx509.CreateCertificate(&sm2Tmpl, &sm2Tmpl, &sm2Key.PublicKey, sm2Key)
+	if err != nil {
+		t.Fatalf("create sm2 file signing cert error, %v", err)
+	}
+	return map[string]testImaSigner{
+		"sha256": {
+			der: newTestImaCert(t, 1, &rsaKey.PublicKey, rsaKey),
+			sign: func(digest []byte) []byte {
+				sig, _ := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest)
+				return sig
+			},
+		},
+		"sha512": {
+			der: newTestImaCert(t, 2, &ecKey.PublicKey, ecKey),
+			sign: func(digest []byte) []byte {
+				sig, _ := ecdsa.SignASN1(rand.Reader, ecKey, digest)
+				return sig
+			},
+		},
+		"sm3": {
+			der: sm2Der,
+			sign: func(digest []byte) []byte {
+				r, s := testSm2RawSign(t, sm2Key, digest)
+				sig, _ := asn1.Marshal(ecSignature{r, s})
+				return sig
+			},
+		},
+	}
+}
+
+// testSm2RawSign signs the digest directly as the kernel verifies it.
+func testSm2RawSign(t *testing.T, priv *sm2.PrivateKey, digest []byte) (*big.Int, *big.Int) {
+	n := priv.Curve.Params().N
+	e := new(big.Int).SetBytes(digest)
+	one := big.NewInt(1)
+	for {
+		k, err := rand.Int(rand.Reader, new(big.Int).Sub(n, one))
+		if err != nil {
+			t.Fatalf("generate random k failed, %v", err)
+		}
+		k.Add(k, one)
+		x1, _ := priv.Curve.ScalarBaseMult(k.Bytes())
+		r := new(big.Int).Add(e, x1)
+		r.Mod(r, n)
+		if r.Sign() == 0 || new(big.Int).Add(r, k).Cmp(n) == 0 {
+			continue
+		}
+		d1 := new(big.Int).Add(priv.D, one)
+		d1.ModInverse(d1, n)
+		s := new(big.Int).Mul(r, priv.D)
+		s.Sub(k, s)
+		s.Mul(s, d1)
+		s.Mod(s, n)
+		if s.Sign() != 0 {
+			return r, s
+		}
+	}
+}
+
+func testImaDigsig(t *testing.T, der []byte, alg byte, sig []byte) []byte {
+	k, err := parseImaCert(der)
+	if err != nil {
+		t.Fatalf("parse file signing cert error, %v", err)
+	}
+	b := make([]byte, imaSigHdrLen, imaSigHdrLen+len(sig))
+	b[0], b[1], b[2] = imaXattrDigsig, imaDigsigV2, alg
+	binary.BigEndian.PutUint32(b[3:7], k.id)
+	binary.BigEndian.PutUint16(b[7:9], uint16(len(sig)))
+	return append(b, sig...)
+}
+
+func TestParseImaSignature(t *testing.T) {
+	testCases := []struct {
+		sig string
+		alg string
+		err error
+	}{
+		{"030204a1b2c3d400020102", "sha256", nil},
+		{"030211a1b2c3d400020102", "sm3", nil},
+		{"030204a1b2c3d4000201", "", ErrImaSigFormat},
+		{"030204a1b2c3d400000102", "", ErrImaSigFormat},
+		{"030104a1b2c3d400020102", "", ErrImaSigFormat},
+		{"030201a1b2c3d400020102", "", ErrUnsupportedAlg},
+		{"0302", "", ErrImaSigFormat},
+	}
+	for i, tc := range testCases {
+		b, _ := hex.DecodeString(tc.sig)
+		s, err := ParseImaSignature(b)
+		if err != tc.err || err == nil && (s.HashAlg != tc.alg || s.KeyID != 0xa1b2c3d4) {
+			t.Errorf("test ParseImaSignature error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func TestImaKeyringVerify(t *testing.T) {
+	algs := map[string]byte{"sha256": 4, "sha512": 6, "sm3": 17}
+	signers := newTestImaSigners(t)
+	r := NewImaKeyring()
+	for alg, s := range signers {
+		if _, err := r.AddCertData(s.der); err != nil {
+			t.Fatalf("add %s signing cert error, %v", alg, err)
+		}
+	}
+	if r.Len() != len(signers) || len(r.Keys()) != len(signers) {
+		t.Fatalf("test keyring length error, %d", r.Len())
+	}
+	data := []byte("file content")
+	for alg, s := range signers {
+		digest, _ := imaHash(alg, data)
+		sig := testImaDigsig(t, s.der, algs[alg], s.sign(digest))
+		if err := r.VerifyImaSig(sig, alg, digest); err != nil {
+			t.Errorf("test VerifyImaSig %s error, %v", alg, err)
+		}
+		other, _ := imaHash(alg, []byte("other content"))
+		if err := r.VerifyImaSig(sig, alg, other); err != ErrVerifySignature {
+			t.Errorf("test VerifyImaSig %s with wrong digest error, %v", alg, err)
+		}
+		if err := r.VerifyImaSig(sig, "sha1", digest); err != ErrUnsupportedAlg {
+			t.Errorf("test VerifyImaSig %s with wrong alg error, %v", alg, err)
+		}
+		if err := NewImaKeyring().VerifyImaSig(sig, alg, digest); err != ErrImaKeyNotFound {
+			t.Errorf("test VerifyImaSig %s with empty keyring error, %v", alg, err)
+		}
+	}
+}
+
+func testModsig(t *testing.T, sid asn1.RawValue, attrs []byte, sig []byte) []byte {
+	digestAlg := pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}}
+	si := pkcs7SignerInfo{
+		Version:            1,
+		Sid:                sid,
+		DigestAlgorithm:    digestAlg,
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}},
+		Signature:          sig,
+	}
+	if attrs != nil {
+		si.SignedAttrs = asn1.RawValue{FullBytes: attrs}
+	}
+	data, _ := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1})
+	content, _ := asn1.Marshal(asn1.RawValue{Tag: asn1.TagSequence, IsCompound: true, Bytes: data})
+	sd := pkcs7SignedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{digestAlg},
+		ContentInfo:      asn1.RawValue{FullBytes: content},
+		SignerInfos:      []pkcs7SignerInfo{si},
+	}
+	sdBytes, err := asn1.Marshal(sd)
+	if err != nil {
+		t.Fatalf("marshal signed data error, %v", err)
+	}
+	p7, err := asn1.Marshal(struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}{oidSignedData, asn1.RawValue{Class: asn1.ClassContextSpecific, IsCompound: true, Bytes: sdBytes}})
+	if err != nil {
+		t.Fatalf("marshal content info error, %v", err)
+	}
+	return p7
+}
+
+func TestImaKeyringVerifyModsig(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, RsaKeySize)
+	der := newTestImaCert(t, 5, &rsaKey.PublicKey, rsaKey)
+	cert, _ := x509.ParseCertificate(der)
+	r := NewImaKeyring()
+	if _, err := r.AddCertData(pem.EncodeToMemory(&pem.Block{Type: headCert, Bytes: der})); err != nil {
+		t.Fatalf("add signing cert error, %v", err)
+	}
+	digest := sha256.Sum256([]byte("module content"))
+	ias, _ := asn1.Marshal(pkcs7IssuerAndSerial{Issuer: asn1.RawValue{FullBytes: cert.RawIssuer}, Serial: cert.SerialNumber})
+	skid, _ := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, Bytes: cert.SubjectKeyId})
+
+	// without signed attributes, the content digest is signed.
+	sig, _ := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	p7 := testModsig(t, asn1.RawValue{FullBytes: ias}, nil, sig)
+	if err := r.VerifyModsig(p7, "sha256", digest[:]); err != nil {
+		t.Errorf("test VerifyModsig without attributes error, %v", err)
+	}
+	other := sha256.Sum256([]byte("other module"))
+	if err := r.VerifyModsig(p7, "sha256", other[:]); err != ErrVerifySignature {
+		t.Errorf("test VerifyModsig with wrong digest error, %v", err)
+	}
+
+	// with signed attributes, the digest of attributes is signed.
+	md, _ := asn1.Marshal(digest[:])
+	attr := pkcs7Attribute{Type: oidMessageDigest, Values: []asn1.RawValue{{FullBytes: md}}}
+	attrs, _ := asn1.MarshalWithParams([]pkcs7Attribute{attr}, "set")
+	attrsDigest := sha256.Sum256(attrs)
+	sig, _ = rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, attrsDigest[:])
+	attrs[0] = 0xa0
+	p7 = testModsig(t, asn1.RawValue{FullBytes: skid}, attrs, sig)
+	if err := r.VerifyModsig(p7, "sha256", digest[:]); err != nil {
+		t.Errorf("test VerifyModsig with attributes error, %v", err)
+	}
+	if err := r.VerifyModsig(p7, "sha256", other[:]); err != ErrVerifySignature {
+		t.Errorf("test VerifyModsig attributes with wrong digest error, %v", err)
+	}
+	if err := NewImaKeyring().VerifyModsig(p7, "sha256", digest[:]); err != ErrImaKeyNotFound {
+		t.Errorf("test VerifyModsig with empty keyring error, %v", err)
+	}
+	if err := r.VerifyModsig([]byte{1, 2, 3}, "sha256", digest[:]); err != ErrImaSigFormat {
+		t.Errorf("test VerifyModsig wrong format error, %v", err)
+	}
+}
+
+func TestImaKeyringDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "imakeyring")
+	if err != nil {
+		t.Fatalf("create temp dir error, %v", err)
+	}
+	defer os.RemoveAll(dir)
+	signers := newTestImaSigners(t)
+	r := NewImaKeyring()
+	if err = r.LoadDir(dir); err != nil || r.Len() != 0 {
+		t.Fatalf("load empty keyring dir error, %v", err)
+	}
+	ids, err := r.AddCertData(signers["sha256"].der)
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("add signing cert error, %v", err)
+	}
+	if _, err = r.AddCertData(signers["sm3"].der); err != nil {
+		t.Fatalf("add sm2 signing cert error, %v", err)
+	}
+	ioutil.WriteFile(dir+"/readme.txt", []byte("not a cert"), modCert)
+	r2 := NewImaKeyring()
+	if err = r2.LoadDir(dir); err != nil || r2.Len() != 2 {
+		t.Fatalf("reload keyring dir error, %v, %d", err, r2.Len())
+	}
+	if err = r2.RemoveKey(ids[0]); err != nil || r2.Len() != 1 {
+		t.Errorf("test RemoveKey error, %v", err)
+	}
+	if err = r2.RemoveKey(ids[0]); err != ErrImaKeyNotFound {
+		t.Errorf("test RemoveKey again error, %v", err)
+	}
+	if err = r2.RemoveKey("xyz"); err != ErrWrongParams {
+		t.Errorf("test RemoveKey wrong id error, %v", err)
+	}
+	r3 := NewImaKeyring()
+	if err = r3.LoadDir(dir); err != nil || r3.Len() != 1 {
+		t.Errorf("reload keyring dir after remove error, %v, %d", err, r3.Len())
+	}
+}
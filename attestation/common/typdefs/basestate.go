@@ -0,0 +1,82 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the types and lifecycle states of base value.
+	draft -> approved -> active -> retired, a retired one may be
+	activated again to roll back.
+*/
+
+package typdefs
+
+import (
+	"errors"
+)
+
+const (
+	// the types of base value.
+	BaseTypeHost      = "host"
+	BaseTypeContainer = "container"
+	BaseTypeDevice    = "device"
+
+	// BaseStateDraft is a new base value which isn't reviewed, like the
+	// one extracted from the first trust report of client.
+	BaseStateDraft = "draft"
+	// BaseStateApproved is a reviewed base value waiting to be activated.
+	BaseStateApproved = "approved"
+	// BaseStateActive is the base value used to verify the trust reports,
+	// a client has one active host base value at most.
+	BaseStateActive = "active"
+	// BaseStateRetired is a base value which isn't used any more.
+	BaseStateRetired = "retired"
+
+	// BaseAuthorRas is the author of the base values created by ras.
+	BaseAuthorRas = "ras"
+)
+
+var (
+	ErrBaseStateWrong = errors.New("base value state transition is not allowed")
+)
+
+// PromoteBaseState returns the next state of base value in lifecycle,
+// the retired one is activated again to roll back.
+func PromoteBaseState(state string) (string, error) {
+	switch state {
+	case BaseStateDraft:
+		return BaseStateApproved, nil
+	case BaseStateApproved, BaseStateRetired:
+		return BaseStateActive, nil
+	}
+	return "", ErrBaseStateWrong
+}
+
+// RetireBaseState checks a base value in state can be retired.
+func RetireBaseState(state string) (string, error) {
+	switch state {
+	case BaseStateDraft, BaseStateApproved, BaseStateActive:
+		return BaseStateRetired, nil
+	}
+	return "", ErrBaseStateWrong
+}
+
+// InitBaseState sets the state of a new base value by Enabled if it has
+// none, and keeps Enabled true only for the active one.
+func (b *BaseRow) InitBaseState() {
+	if b.State == "" {
+		if b.Enabled {
+			b.State = BaseStateActive
+		} else {
+			b.State = BaseStateDraft
+		}
+	}
+	b.Enabled = b.State == BaseStateActive
+	if b.UpdateTime.IsZero() {
+		b.UpdateTime = b.CreateTime
+	}
+}
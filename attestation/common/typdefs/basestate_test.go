@@ -0,0 +1,51 @@
+package typdefs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBaseState(t *testing.T) {
+	testCases := []struct {
+		state   string
+		promote string
+		retire  string
+	}{
+		{BaseStateDraft, BaseStateApproved, BaseStateRetired},
+		{BaseStateApproved, BaseStateActive, BaseStateRetired},
+		{BaseStateActive, "", BaseStateRetired},
+		{BaseStateRetired, BaseStateActive, ""},
+		{"", "", ""},
+	}
+	for i, tc := range testCases {
+		s, err := PromoteBaseState(tc.state)
+		if s != tc.promote || (err == nil) != (tc.promote != "") {
+			t.Errorf("test PromoteBaseState error at case %d, %s %v\n", i, s, err)
+		}
+		s, err = RetireBaseState(tc.state)
+		if s != tc.retire || (err == nil) != (tc.retire != "") {
+			t.Errorf("test RetireBaseState error at case %d, %s %v\n", i, s, err)
+		}
+	}
+}
+
+func TestInitBaseState(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		row     BaseRow
+		state   string
+		enabled bool
+	}{
+		{BaseRow{}, BaseStateDraft, false},
+		{BaseRow{Enabled: true}, BaseStateActive, true},
+		{BaseRow{State: BaseStateApproved, Enabled: true}, BaseStateApproved, false},
+		{BaseRow{State: BaseStateActive}, BaseStateActive, true},
+	}
+	for i, tc := range testCases {
+		tc.row.CreateTime = now
+		tc.row.InitBaseState()
+		if tc.row.State != tc.state || tc.row.Enabled != tc.enabled || !tc.row.UpdateTime.Equal(now) {
+			t.Errorf("test InitBaseState error at case %d, %+v\n", i, tc.row)
+		}
+	}
+}
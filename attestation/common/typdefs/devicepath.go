@@ -0,0 +1,257 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: decode the uefi device path into the text form of uefi spec.
+*/
+
+package typdefs
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf16"
+)
+
+// uefi device path node types and subtypes, see uefi spec 10.3.
+const (
+	dpHeaderLen        = 4
+	dpTypeHardware     = 0x01
+	dpTypeACPI         = 0x02
+	dpTypeMessaging    = 0x03
+	dpTypeMedia        = 0x04
+	dpTypeBBS          = 0x05
+	dpTypeEnd          = 0x7f
+	dpSubEndEntire     = 0xff
+	dpSubPci           = 0x01
+	dpSubMemoryMapped  = 0x03
+	dpSubVendor        = 0x04
+	dpSubController    = 0x05
+	dpSubAcpi          = 0x01
+	dpSubScsi          = 0x02
+	dpSubUsb           = 0x05
+	dpSubMsgVendor     = 0x0a
+	dpSubMac           = 0x0b
+	dpSubIPv4          = 0x0c
+	dpSubIPv6          = 0x0d
+	dpSubSata          = 0x12
+	dpSubNvme          = 0x17
+	dpSubUri           = 0x18
+	dpSubHardDrive     = 0x01
+	dpSubCdrom         = 0x02
+	dpSubMediaVendor   = 0x03
+	dpSubFilePath      = 0x04
+	dpSubFvFile        = 0x06
+	dpSubFv            = 0x07
+	dpSubRelOffset     = 0x08
+	acpiPciRootHID     = 0x0a0341d0
+	acpiPcieRootHID    = 0x0a0841d0
+	hdSignatureMBR     = 0x01
+	hdSignatureGPT     = 0x02
+	guidLen            = 16
+	macAddrLen         = 6
+	nvmeEUILen         = 8
+	hardDriveNodeLen   = 38
+	ipv4AddrLen        = 4
+	ipv6AddrLen        = 16
+	efiGUIDFormat      = "%08x-%04x-%04x-%x-%x"
+	unknownPathNodeFmt = "Path(%d,%d,%s)"
+)
+
+// EfiGUID returns the text form of an uefi guid, whose first three fields
+// are little endian.
+func EfiGUID(b []byte) string {
+	if len(b) < guidLen {
+		return hex.EncodeToString(b)
+	}
+	return fmt.Sprintf(efiGUIDFormat, binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint16(b[4:6]), binary.LittleEndian.Uint16(b[6:8]), b[8:10], b[10:16])
+}
+
+// decodeUCS2 decodes the little endian utf-16 string, stops at the first
+// null character.
+func decodeUCS2(b []byte) string {
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			break
+		}
+		u = append(u, c)
+	}
+	return string(utf16.Decode(u))
+}
+
+// DevicePath is a decoded uefi device path.
+type DevicePath struct {
+	// Text is the text form of all nodes, like
+	// PciRoot(0x0)/Pci(0x1,0x1)/Sata(0x0,0xffff,0x0)/HD(1,GPT,...)/\EFI\BOOT\BOOTX64.EFI
+	Text string
+	// File is the file path in the media file path nodes, empty if none.
+	File string
+}
+
+// ParseDevicePath decodes the binary uefi device path, the unknown nodes are
+// shown as Path(type,subtype,hex data).
+func ParseDevicePath(b []byte) (*DevicePath, error) {
+	var nodes []string
+	var file strings.Builder
+	for len(b) > 0 {
+		if len(b) < dpHeaderLen {
+			return nil, ErrBiosLogFormatWrong
+		}
+		typ, sub := b[0], b[1]
+		n := int(binary.LittleEndian.Uint16(b[2:4]))
+		if n < dpHeaderLen || n > len(b) {
+			return nil, ErrBiosLogFormatWrong
+		}
+		data := b[dpHeaderLen:n]
+		b = b[n:]
+		if typ == dpTypeEnd {
+			if sub == dpSubEndEntire {
+				break
+			}
+			nodes = append(nodes, ",")
+			continue
+		}
+		if typ == dpTypeMedia && sub == dpSubFilePath {
+			file.WriteString(decodeUCS2(data))
+		}
+		nodes = append(nodes, devicePathNode(typ, sub, data))
+	}
+	text := strings.ReplaceAll(strings.Join(nodes, "/"), "/,/", ",")
+	return &DevicePath{Text: text, File: file.String()}, nil
+}
+
+// devicePathNode returns the text form of one device path node.
+func devicePathNode(typ, sub byte, data []byte) string {
+	var s string
+	switch typ {
+	case dpTypeHardware:
+		s = hardwarePathNode(sub, data)
+	case dpTypeACPI:
+		s = acpiPathNode(sub, data)
+	case dpTypeMessaging:
+		s = messagingPathNode(sub, data)
+	case dpTypeMedia:
+		s = mediaPathNode(sub, data)
+	case dpTypeBBS:
+		if len(data) >= 4 {
+			s = fmt.Sprintf("BBS(0x%x,%s)", binary.LittleEndian.Uint16(data), trimString(data[4:]))
+		}
+	}
+	if s == "" {
+		s = fmt.Sprintf(unknownPathNodeFmt, typ, sub, hex.EncodeToString(data))
+	}
+	return s
+}
+
+func hardwarePathNode(sub byte, data []byte) string {
+	switch {
+	case sub == dpSubPci && len(data) >= 2:
+		return fmt.Sprintf("Pci(0x%x,0x%x)", data[1], data[0])
+	case sub == dpSubMemoryMapped && len(data) >= 20:
+		return fmt.Sprintf("MemoryMapped(0x%x,0x%x,0x%x)", binary.LittleEndian.Uint32(data),
+			binary.LittleEndian.Uint64(data[4:]), binary.LittleEndian.Uint64(data[12:]))
+	case sub == dpSubVendor && len(data) >= guidLen:
+		return fmt.Sprintf("VenHw(%s)", EfiGUID(data))
+	case sub == dpSubController && len(data) >= 4:
+		return fmt.Sprintf("Ctrl(0x%x)", binary.LittleEndian.Uint32(data))
+	}
+	return ""
+}
+
+func acpiPathNode(sub byte, data []byte) string {
+	if sub != dpSubAcpi || len(data) < 8 {
+		return ""
+	}
+	hid, uid := binary.LittleEndian.Uint32(data), binary.LittleEndian.Uint32(data[4:])
+	switch hid {
+	case acpiPciRootHID:
+		return fmt.Sprintf("PciRoot(0x%x)", uid)
+	case acpiPcieRootHID:
+		return fmt.Sprintf("PcieRoot(0x%x)", uid)
+	}
+	return fmt.Sprintf("Acpi(0x%08x,0x%x)", hid, uid)
+}
+
+func messagingPathNode(sub byte, data []byte) string {
+	switch {
+	case sub == dpSubScsi && len(data) >= 4:
+		return fmt.Sprintf("Scsi(0x%x,0x%x)", binary.LittleEndian.Uint16(data),
+			binary.LittleEndian.Uint16(data[2:]))
+	case sub == dpSubUsb && len(data) >= 2:
+		return fmt.Sprintf("USB(0x%x,0x%x)", data[0], data[1])
+	case sub == dpSubMsgVendor && len(data) >= guidLen:
+		return fmt.Sprintf("VenMsg(%s)", EfiGUID(data))
+	case sub == dpSubMac && len(data) >= 33:
+		return fmt.Sprintf("MAC(%s,0x%x)", hex.EncodeToString(data[:macAddrLen]), data[32])
+	case sub == dpSubIPv4 && len(data) >= 2*ipv4AddrLen:
+		return fmt.Sprintf("IPv4(%s)", net.IP(data[ipv4AddrLen:2*ipv4AddrLen]))
+	case sub == dpSubIPv6 && len(data) >= 2*ipv6AddrLen:
+		return fmt.Sprintf("IPv6(%s)", net.IP(data[ipv6AddrLen:2*ipv6AddrLen]))
+	case sub == dpSubSata && len(data) >= 6:
+		return fmt.Sprintf("Sata(0x%x,0x%x,0x%x)", binary.LittleEndian.Uint16(data),
+			binary.LittleEndian.Uint16(data[2:]), binary.LittleEndian.Uint16(data[4:]))
+	case sub == dpSubNvme && len(data) >= 4+nvmeEUILen:
+		eui := make([]string, nvmeEUILen)
+		for i := range eui {
+			eui[i] = fmt.Sprintf("%02X", data[4+nvmeEUILen-1-i])
+		}
+		return fmt.Sprintf("NVMe(0x%x,%s)", binary.LittleEndian.Uint32(data), strings.Join(eui, "-"))
+	case sub == dpSubUri:
+		return fmt.Sprintf("Uri(%s)", trimString(data))
+	}
+	return ""
+}
+
+func mediaPathNode(sub byte, data []byte) string {
+	switch {
+	case sub == dpSubHardDrive && len(data) >= hardDriveNodeLen:
+		part := binary.LittleEndian.Uint32(data)
+		start, size := binary.LittleEndian.Uint64(data[4:]), binary.LittleEndian.Uint64(data[12:])
+		sig := data[20:36]
+		switch data[hardDriveNodeLen-1] {
+		case hdSignatureGPT:
+			return fmt.Sprintf("HD(%d,GPT,%s,0x%x,0x%x)", part, EfiGUID(sig), start, size)
+		case hdSignatureMBR:
+			return fmt.Sprintf("HD(%d,MBR,0x%08x,0x%x,0x%x)", part,
+				binary.LittleEndian.Uint32(sig), start, size)
+		}
+		return fmt.Sprintf("HD(%d,%d,0,0x%x,0x%x)", part, data[hardDriveNodeLen-1], start, size)
+	case sub == dpSubCdrom && len(data) >= 20:
+		return fmt.Sprintf("CDROM(0x%x,0x%x,0x%x)", binary.LittleEndian.Uint32(data),
+			binary.LittleEndian.Uint64(data[4:]), binary.LittleEndian.Uint64(data[12:]))
+	case sub == dpSubMediaVendor && len(data) >= guidLen:
+		return fmt.Sprintf("VenMedia(%s)", EfiGUID(data))
+	case sub == dpSubFilePath:
+		return decodeUCS2(data)
+	case sub == dpSubFvFile && len(data) >= guidLen:
+		return fmt.Sprintf("FvFile(%s)", EfiGUID(data))
+	case sub == dpSubFv && len(data) >= guidLen:
+		return fmt.Sprintf("Fv(%s)", EfiGUID(data))
+	case sub == dpSubRelOffset && len(data) >= 20:
+		return fmt.Sprintf("Offset(0x%x,0x%x)", binary.LittleEndian.Uint64(data[4:]),
+			binary.LittleEndian.Uint64(data[12:]))
+	}
+	return ""
+}
+
+// trimString returns the ascii string before the first null character.
+func trimString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,718 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: decode the uefi/tcg bios event log into typed events.
+*/
+
+package typdefs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// the event types defined in TCG PC Client Platform Firmware Profile.
+const (
+	EvPrebootCert          uint32 = 0x0
+	EvPostCode             uint32 = 0x1
+	EvNoAction             uint32 = 0x3
+	EvSeparator            uint32 = 0x4
+	EvAction               uint32 = 0x5
+	EvEventTag             uint32 = 0x6
+	EvSCrtmContents        uint32 = 0x7
+	EvSCrtmVersion         uint32 = 0x8
+	EvCPUMicrocode         uint32 = 0x9
+	EvPlatformConfigFlags  uint32 = 0xa
+	EvTableOfDevices       uint32 = 0xb
+	EvCompactHash          uint32 = 0xc
+	EvIPL                  uint32 = 0xd
+	EvIPLPartitionData     uint32 = 0xe
+	EvNonhostCode          uint32 = 0xf
+	EvNonhostConfig        uint32 = 0x10
+	EvNonhostInfo          uint32 = 0x11
+	EvOmitBootDeviceEvents uint32 = 0x12
+
+	EvEFIEventBase               uint32 = 0x80000000
+	EvEFIVariableDriverConfig    uint32 = 0x80000001
+	EvEFIVariableBoot            uint32 = 0x80000002
+	EvEFIBootServicesApplication uint32 = 0x80000003
+	EvEFIBootServicesDriver      uint32 = 0x80000004
+	EvEFIRuntimeServicesDriver   uint32 = 0x80000005
+	EvEFIGPTEvent                uint32 = 0x80000006
+	EvEFIAction                  uint32 = 0x80000007
+	EvEFIPlatformFirmwareBlob    uint32 = 0x80000008
+	EvEFIHandoffTables           uint32 = 0x80000009
+	EvEFIPlatformFirmwareBlob2   uint32 = 0x8000000a
+	EvEFIHandoffTables2          uint32 = 0x8000000b
+	EvEFIVariableBoot2           uint32 = 0x8000000c
+	EvEFIHCRTMEvent              uint32 = 0x80000010
+	EvEFIVariableAuthority       uint32 = 0x800000e0
+	EvEFISPDMFirmwareBlob        uint32 = 0x800000e1
+	EvEFISPDMFirmwareConfig      uint32 = 0x800000e2
+)
+
+// definitions for bios event decoding used only in this package.
+const (
+	specIDHeaderSig     = "Spec ID Event"
+	specIDAlgNumStart   = 24
+	specIDAlgStart      = 28
+	specIDAlgLen        = 4
+	sha384AlgID         = "0c00"
+	sha512AlgID         = "0d00"
+	efiVarHeaderLen     = 32
+	efiImageHeaderLen   = 32
+	efiBlobLen          = 16
+	efiTableLen         = 24
+	gptHeaderSizeOff    = 12
+	gptDiskGUIDOff      = 56
+	gptEntrySizeOff     = 84
+	gptHeaderMinLen     = 92
+	gptEntryMinLen      = 128
+	gptEntryNameOff     = 56
+	loadOptionHeaderLen = 6
+	noActionSigLen      = 16
+	startupLocalitySig  = "StartupLocality\x00"
+	separatorLen        = 4
+	idSeparator         = ":"
+	idDupSeparator      = "#"
+	legacyNameFormat    = "%x-%d"
+	bootVarPrefix       = "Boot"
+	bootVarLen          = 8
+)
+
+var (
+	eventTypeNames = map[uint32]string{
+		EvPrebootCert:                "EV_PREBOOT_CERT",
+		EvPostCode:                   "EV_POST_CODE",
+		EvNoAction:                   "EV_NO_ACTION",
+		EvSeparator:                  "EV_SEPARATOR",
+		EvAction:                     "EV_ACTION",
+		EvEventTag:                   "EV_EVENT_TAG",
+		EvSCrtmContents:              "EV_S_CRTM_CONTENTS",
+		EvSCrtmVersion:               "EV_S_CRTM_VERSION",
+		EvCPUMicrocode:               "EV_CPU_MICROCODE",
+		EvPlatformConfigFlags:        "EV_PLATFORM_CONFIG_FLAGS",
+		EvTableOfDevices:             "EV_TABLE_OF_DEVICES",
+		EvCompactHash:                "EV_COMPACT_HASH",
+		EvIPL:                        "EV_IPL",
+		EvIPLPartitionData:           "EV_IPL_PARTITION_DATA",
+		EvNonhostCode:                "EV_NONHOST_CODE",
+		EvNonhostConfig:              "EV_NONHOST_CONFIG",
+		EvNonhostInfo:                "EV_NONHOST_INFO",
+		EvOmitBootDeviceEvents:       "EV_OMIT_BOOT_DEVICE_EVENTS",
+		EvEFIEventBase:               "EV_EFI_EVENT_BASE",
+		EvEFIVariableDriverConfig:    "EV_EFI_VARIABLE_DRIVER_CONFIG",
+		EvEFIVariableBoot:            "EV_EFI_VARIABLE_BOOT",
+		EvEFIBootServicesApplication: "EV_EFI_BOOT_SERVICES_APPLICATION",
+		EvEFIBootServicesDriver:      "EV_EFI_BOOT_SERVICES_DRIVER",
+		EvEFIRuntimeServicesDriver:   "EV_EFI_RUNTIME_SERVICES_DRIVER",
+		EvEFIGPTEvent:                "EV_EFI_GPT_EVENT",
+		EvEFIAction:                  "EV_EFI_ACTION",
+		EvEFIPlatformFirmwareBlob:    "EV_EFI_PLATFORM_FIRMWARE_BLOB",
+		EvEFIHandoffTables:           "EV_EFI_HANDOFF_TABLES",
+		EvEFIPlatformFirmwareBlob2:   "EV_EFI_PLATFORM_FIRMWARE_BLOB2",
+		EvEFIHandoffTables2:          "EV_EFI_HANDOFF_TABLES2",
+		EvEFIVariableBoot2:           "EV_EFI_VARIABLE_BOOT2",
+		EvEFIHCRTMEvent:              "EV_EFI_HCRTM_EVENT",
+		EvEFIVariableAuthority:       "EV_EFI_VARIABLE_AUTHORITY",
+		EvEFISPDMFirmwareBlob:        "EV_EFI_SPDM_FIRMWARE_BLOB",
+		EvEFISPDMFirmwareConfig:      "EV_EFI_SPDM_FIRMWARE_CONFIG",
+	}
+	// tpm algorithm id in the log byte order to the algorithm name.
+	algIDNames = map[string]string{
+		sha1AlgID:   Sha1AlgStr,
+		sha256AlgID: Sha256AlgStr,
+		sha384AlgID: "sha384",
+		sha512AlgID: "sha512",
+		sm3AlgID:    Sm3AlgStr,
+	}
+)
+
+type (
+	// HexBytes is a byte slice which is shown as hex string in json.
+	HexBytes []byte
+
+	// BiosEvent is one decoded event of the bios event log.
+	BiosEvent struct {
+		// Index is the event index in log, the spec id header isn't counted.
+		Index int `json:"index"`
+		// Pcr is the pcr index which the event is extended into.
+		Pcr uint32 `json:"pcr"`
+		// Type is the event type and TypeName is its name in TCG spec.
+		Type     uint32 `json:"type"`
+		TypeName string `json:"typeName"`
+		// ID is the stable semantic identifier of the event, see assignEventIDs.
+		ID string `json:"id"`
+		// Digests maps the algorithm name to the event digest.
+		Digests map[string]HexBytes `json:"digests"`
+		Data    HexBytes            `json:"data,omitempty"`
+		// Details is the decoded event data, its type depends on Type:
+		// EfiVariable, EfiImageLoad, EfiGpt, EfiFirmwareBlob,
+		// EfiHandoffTables, string or uint32 for EV_SEPARATOR.
+		Details interface{} `json:"details,omitempty"`
+	}
+
+	// EfiVariable is the data of EV_EFI_VARIABLE_* events.
+	EfiVariable struct {
+		GUID string   `json:"guid"`
+		Name string   `json:"name"`
+		Data HexBytes `json:"data,omitempty"`
+		// LoadOption is the decoded data of Boot#### variables.
+		LoadOption *EfiLoadOption `json:"loadOption,omitempty"`
+	}
+
+	// EfiLoadOption is the EFI_LOAD_OPTION stored in Boot#### variables.
+	EfiLoadOption struct {
+		Attributes  uint32 `json:"attributes"`
+		Description string `json:"description"`
+		DevicePath  string `json:"devicePath"`
+	}
+
+	// EfiImageLoad is the data of EV_EFI_BOOT_SERVICES_APPLICATION,
+	// EV_EFI_BOOT_SERVICES_DRIVER and EV_EFI_RUNTIME_SERVICES_DRIVER events.
+	EfiImageLoad struct {
+		Location        uint64 `json:"location"`
+		Length          uint64 `json:"length"`
+		LinkTimeAddress uint64 `json:"linkTimeAddress"`
+		DevicePath      string `json:"devicePath"`
+		File            string `json:"file,omitempty"`
+	}
+
+	// EfiGpt is the data of EV_EFI_GPT_EVENT event.
+	EfiGpt struct {
+		DiskGUID   string         `json:"diskGuid"`
+		Partitions []EfiPartition `json:"partitions"`
+	}
+
+	// EfiPartition is one partition entry of the gpt.
+	EfiPartition struct {
+		TypeGUID    string `json:"typeGuid"`
+		UniqueGUID  string `json:"uniqueGuid"`
+		StartingLBA uint64 `json:"startingLba"`
+		EndingLBA   uint64 `json:"endingLba"`
+		Name        string `json:"name"`
+	}
+
+	// EfiFirmwareBlob is the data of EV_EFI_PLATFORM_FIRMWARE_BLOB(2) events.
+	EfiFirmwareBlob struct {
+		Description string `json:"description,omitempty"`
+		Base        uint64 `json:"base"`
+		Length      uint64 `json:"length"`
+	}
+
+	// EfiHandoffTables is the data of EV_EFI_HANDOFF_TABLES(2) events.
+	EfiHandoffTables struct {
+		Description string           `json:"description,omitempty"`
+		Tables      []EfiConfigTable `json:"tables"`
+	}
+
+	// EfiConfigTable is one uefi configuration table.
+	EfiConfigTable struct {
+		GUID    string `json:"guid"`
+		Address uint64 `json:"address"`
+	}
+)
+
+// MarshalText encodes the bytes to hex string.
+func (h HexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(h)), nil
+}
+
+// UnmarshalText decodes the hex string to bytes.
+func (h *HexBytes) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+// EventTypeName returns the TCG spec name of the bios event type.
+func EventTypeName(t uint32) string {
+	if n, ok := eventTypeNames[t]; ok {
+		return n
+	}
+	return fmt.Sprintf("EV_UNKNOWN_%x", t)
+}
+
+// LegacyName returns the old synthetic name of the event, which is
+// "<type hex>-<index>" and used by the former base values.
+func (e *BiosEvent) LegacyName() string {
+	return fmt.Sprintf(legacyNameFormat, e.Type, e.Index)
+}
+
+// MatchName checks whether the name is the event stable ID or legacy name.
+func (e *BiosEvent) MatchName(name string) bool {
+	return name == e.ID || name == e.LegacyName()
+}
+
+// Digest returns the event digest hex string of the algorithm,
+// or "N/A" if it doesn't exist.
+func (e *BiosEvent) Digest(algStr string) string {
+	if d, ok := e.Digests[algStr]; ok {
+		return hex.EncodeToString(d)
+	}
+	return naStr
+}
+
+// ParseBiosEventLog decodes the bios binary log into typed events. Both
+// the crypto agile log which starts with "Spec ID Event03" header and
+// the TPM1.2 sha1 log are supported.
+func ParseBiosEventLog(bin []byte) ([]*BiosEvent, error) {
+	var point int64
+	first, err := readSHA1BIOSEventLog(bin, &point)
+	if err != nil {
+		return nil, ErrBiosLogFormatWrong
+	}
+	firstData, _ := hex.DecodeString(first.DataHex)
+	var events []*BiosEvent
+	if !bytes.HasPrefix(firstData, []byte(specIDHeaderSig)) {
+		events = append(events, newBiosEvent(len(events), first, firstData))
+	}
+	if !bytes.HasPrefix(firstData, []byte(event2SpecID)) {
+		for point < int64(len(bin)) {
+			item, err := readSHA1BIOSEventLog(bin, &point)
+			if err != nil {
+				return nil, ErrBiosLogFormatWrong
+			}
+			data, _ := hex.DecodeString(item.DataHex)
+			events = append(events, newBiosEvent(len(events), item, data))
+		}
+		assignEventIDs(events)
+		return events, nil
+	}
+	algAndLenMap, err := getSpecIDAlgs(firstData)
+	if err != nil {
+		return nil, err
+	}
+	for point < int64(len(bin)) {
+		item, err := ReadBIOSEvent2Log(bin, &point, algAndLenMap)
+		if err != nil {
+			return nil, ErrBiosLogFormatWrong
+		}
+		data, _ := hex.DecodeString(item.DataHex)
+		events = append(events, newBiosEvent(len(events), item, data))
+	}
+	assignEventIDs(events)
+	return events, nil
+}
+
+// getSpecIDAlgs gets the algorithms and digest sizes from the data of
+// "Spec ID Event03" header.
+func getSpecIDAlgs(data []byte) (map[string]int, error) {
+	if len(data) < specIDAlgStart {
+		return nil, ErrBiosLogFormatWrong
+	}
+	n := int(binary.LittleEndian.Uint32(data[specIDAlgNumStart:]))
+	if n > (len(data)-specIDAlgStart)/specIDAlgLen {
+		return nil, ErrBiosLogFormatWrong
+	}
+	result := map[string]int{}
+	for i := 0; i < n; i++ {
+		p := specIDAlgStart + i*specIDAlgLen
+		algID := hex.EncodeToString(data[p : p+algIDLen])
+		result[algID] = int(binary.LittleEndian.Uint16(data[p+algIDLen:]))
+	}
+	return result, nil
+}
+
+// ParseBiosTxtLog decodes the bios text log made by TransformBIOSBinLogToTxt
+// into typed events, this is used for the stored reports.
+func ParseBiosTxtLog(txt []byte) ([]*BiosEvent, error) {
+	var events []*BiosEvent
+	for _, ln := range bytes.Split(txt, NewLine) {
+		if len(bytes.TrimSpace(ln)) == 0 {
+			continue
+		}
+		words := bytes.Split(ln, Space)
+		if len(words) != BiosLogItemNum && len(words) != SM3BiosLogItemNum {
+			return nil, ErrBiosLogFormatWrong
+		}
+		idx, err1 := strconv.Atoi(string(words[0]))
+		pcr, err2 := strconv.ParseUint(string(words[1]), 10, 32)
+		t := bytes.SplitN(words[2], []byte("-"), 2)
+		typ, err3 := strconv.ParseUint(string(t[0]), 16, 32)
+		data, err4 := hex.DecodeString(string(words[len(words)-1]))
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			return nil, ErrBiosLogFormatWrong
+		}
+		item := &BIOSManifestItem{Pcr: uint32(pcr), BType: uint32(typ)}
+		if string(words[3]) != naStr {
+			item.Digest.Item = append(item.Digest.Item, DigestItem{AlgID: sha1AlgID, Item: string(words[3])})
+		}
+		for _, w := range words[4 : len(words)-1] {
+			i := bytes.Index(w, Colon)
+			if i < 0 {
+				return nil, ErrBiosLogFormatWrong
+			}
+			alg, d := string(w[:i]), string(w[i+1:])
+			if d == naStr {
+				continue
+			}
+			for id, name := range algIDNames {
+				if name == alg {
+					item.Digest.Item = append(item.Digest.Item, DigestItem{AlgID: id, Item: d})
+				}
+			}
+		}
+		item.Digest.Count = uint32(len(item.Digest.Item))
+		events = append(events, newBiosEvent(idx, item, data))
+	}
+	assignEventIDs(events)
+	return events, nil
+}
+
+// newBiosEvent creates a typed event from the raw log item.
+func newBiosEvent(index int, item *BIOSManifestItem, data []byte) *BiosEvent {
+	e := &BiosEvent{
+		Index:    index,
+		Pcr:      item.Pcr,
+		Type:     item.BType,
+		TypeName: EventTypeName(item.BType),
+		Digests:  map[string]HexBytes{},
+		Data:     data,
+	}
+	for _, d := range item.Digest.Item {
+		alg, ok := algIDNames[d.AlgID]
+		if !ok {
+			alg = d.AlgID
+		}
+		e.Digests[alg], _ = hex.DecodeString(d.Item)
+	}
+	e.Details = decodeEventData(e.Type, data)
+	return e
+}
+
+// decodeEventData decodes the event data according to its type, returns
+// nil if the type is unknown or the data is malformed.
+func decodeEventData(typ uint32, data []byte) interface{} {
+	switch typ {
+	case EvEFIVariableDriverConfig, EvEFIVariableBoot, EvEFIVariableBoot2, EvEFIVariableAuthority:
+		return decodeEfiVariable(typ, data)
+	case EvEFIBootServicesApplication, EvEFIBootServicesDriver, EvEFIRuntimeServicesDriver:
+		return decodeEfiImageLoad(data)
+	case EvEFIGPTEvent:
+		return decodeEfiGpt(data)
+	case EvEFIPlatformFirmwareBlob, EvEFIPlatformFirmwareBlob2:
+		return decodeEfiFirmwareBlob(typ == EvEFIPlatformFirmwareBlob2, data)
+	case EvEFIHandoffTables, EvEFIHandoffTables2:
+		return decodeEfiHandoffTables(typ == EvEFIHandoffTables2, data)
+	case EvSeparator:
+		if len(data) == separatorLen {
+			return binary.LittleEndian.Uint32(data)
+		}
+	case EvIPL, EvEFIAction, EvAction, EvSCrtmVersion, EvPostCode, EvOmitBootDeviceEvents:
+		return decodeEventString(data)
+	case EvNoAction:
+		if len(data) >= noActionSigLen {
+			return trimString(data[:noActionSigLen])
+		}
+	}
+	return nil
+}
+
+// decodeEventString decodes the string in event data, which may be
+// ascii or ucs-2 string.
+func decodeEventString(data []byte) string {
+	isUCS2 := len(data) >= 2 && len(data)%2 == 0
+	for i := 1; isUCS2 && i < len(data); i += 2 {
+		if data[i] != 0 {
+			isUCS2 = false
+		}
+	}
+	if isUCS2 {
+		return decodeUCS2(data)
+	}
+	return trimString(data)
+}
+
+func decodeEfiVariable(typ uint32, data []byte) interface{} {
+	if len(data) < efiVarHeaderLen {
+		return nil
+	}
+	nameLen := binary.LittleEndian.Uint64(data[16:])
+	dataLen := binary.LittleEndian.Uint64(data[24:])
+	if nameLen > uint64(len(data)) || dataLen > uint64(len(data)) ||
+		efiVarHeaderLen+2*nameLen+dataLen > uint64(len(data)) {
+		return nil
+	}
+	nameEnd := efiVarHeaderLen + 2*nameLen
+	v := &EfiVariable{
+		GUID: EfiGUID(data[:guidLen]),
+		Name: decodeUCS2(data[efiVarHeaderLen:nameEnd]),
+		Data: data[nameEnd : nameEnd+dataLen],
+	}
+	if typ != EvEFIVariableDriverConfig && len(v.Name) == bootVarLen &&
+		strings.HasPrefix(v.Name, bootVarPrefix) {
+		if _, err := strconv.ParseUint(v.Name[len(bootVarPrefix):], 16, 16); err == nil {
+			v.LoadOption = decodeEfiLoadOption(v.Data)
+		}
+	}
+	return v
+}
+
+func decodeEfiLoadOption(data []byte) *EfiLoadOption {
+	if len(data) < loadOptionHeaderLen {
+		return nil
+	}
+	pathLen := int(binary.LittleEndian.Uint16(data[4:]))
+	desc := data[loadOptionHeaderLen:]
+	descEnd := -1
+	for i := 0; i+1 < len(desc); i += 2 {
+		if desc[i] == 0 && desc[i+1] == 0 {
+			descEnd = i
+			break
+		}
+	}
+	if descEnd < 0 || descEnd+2+pathLen > len(desc) {
+		return nil
+	}
+	lo := &EfiLoadOption{
+		Attributes:  binary.LittleEndian.Uint32(data),
+		Description: decodeUCS2(desc[:descEnd]),
+	}
+	if dp, err := ParseDevicePath(desc[descEnd+2 : descEnd+2+pathLen]); err == nil {
+		lo.DevicePath = dp.Text
+	}
+	return lo
+}
+
+func decodeEfiImageLoad(data []byte) interface{} {
+	if len(data) < efiImageHeaderLen {
+		return nil
+	}
+	pathLen := binary.LittleEndian.Uint64(data[24:])
+	if pathLen > uint64(len(data)-efiImageHeaderLen) {
+		return nil
+	}
+	img := &EfiImageLoad{
+		Location:        binary.LittleEndian.Uint64(data),
+		Length:          binary.LittleEndian.Uint64(data[8:]),
+		LinkTimeAddress: binary.LittleEndian.Uint64(data[16:]),
+	}
+	dp, err := ParseDevicePath(data[efiImageHeaderLen : efiImageHeaderLen+pathLen])
+	if err != nil {
+		return img
+	}
+	img.DevicePath, img.File = dp.Text, dp.File
+	return img
+}
+
+func decodeEfiGpt(data []byte) interface{} {
+	if len(data) < gptHeaderMinLen {
+		return nil
+	}
+	hdrLen := uint64(binary.LittleEndian.Uint32(data[gptHeaderSizeOff:]))
+	entryLen := uint64(binary.LittleEndian.Uint32(data[gptEntrySizeOff:]))
+	if hdrLen < gptHeaderMinLen || entryLen < gptEntryMinLen || hdrLen+8 > uint64(len(data)) {
+		return nil
+	}
+	num := binary.LittleEndian.Uint64(data[hdrLen:])
+	rest := uint64(len(data)) - hdrLen - 8
+	if num > rest/entryLen {
+		return nil
+	}
+	gpt := &EfiGpt{DiskGUID: EfiGUID(data[gptDiskGUIDOff:]), Partitions: []EfiPartition{}}
+	for i := uint64(0); i < num; i++ {
+		p := data[hdrLen+8+i*entryLen : hdrLen+8+(i+1)*entryLen]
+		gpt.Partitions = append(gpt.Partitions, EfiPartition{
+			TypeGUID:    EfiGUID(p[0:]),
+			UniqueGUID:  EfiGUID(p[16:]),
+			StartingLBA: binary.LittleEndian.Uint64(p[32:]),
+			EndingLBA:   binary.LittleEndian.Uint64(p[40:]),
+			Name:        decodeUCS2(p[gptEntryNameOff:gptEntryMinLen]),
+		})
+	}
+	return gpt
+}
+
+// readEventDesc reads the description which has one byte length prefix.
+func readEventDesc(data []byte) (string, []byte, bool) {
+	if len(data) < 1 || int(data[0]) > len(data)-1 {
+		return "", nil, false
+	}
+	n := int(data[0])
+	return trimString(data[1 : 1+n]), data[1+n:], true
+}
+
+func decodeEfiFirmwareBlob(hasDesc bool, data []byte) interface{} {
+	blob := &EfiFirmwareBlob{}
+	if hasDesc {
+		var ok bool
+		if blob.Description, data, ok = readEventDesc(data); !ok {
+			return nil
+		}
+	}
+	if len(data) < efiBlobLen {
+		return nil
+	}
+	blob.Base = binary.LittleEndian.Uint64(data)
+	blob.Length = binary.LittleEndian.Uint64(data[8:])
+	return blob
+}
+
+func decodeEfiHandoffTables(hasDesc bool, data []byte) interface{} {
+	tables := &EfiHandoffTables{Tables: []EfiConfigTable{}}
+	if hasDesc {
+		var ok bool
+		if tables.Description, data, ok = readEventDesc(data); !ok {
+			return nil
+		}
+	}
+	if len(data) < 8 {
+		return nil
+	}
+	num := binary.LittleEndian.Uint64(data)
+	if num > uint64(len(data)-8)/efiTableLen {
+		return nil
+	}
+	for i := uint64(0); i < num; i++ {
+		p := data[8+i*efiTableLen:]
+		tables.Tables = append(tables.Tables, EfiConfigTable{
+			GUID:    EfiGUID(p),
+			Address: binary.LittleEndian.Uint64(p[guidLen:]),
+		})
+	}
+	return tables
+}
+
+// assignEventIDs sets the stable semantic identifier of all events. The
+// identifier is "<pcr>:<type name>[:<key>]", the key depends on type:
+//
+//	variable events: the variable name, like "7:EV_EFI_VARIABLE_DRIVER_CONFIG:PK"
+//	image load events: the file path or the whole device path if no file
+//	EV_IPL: the prefix before ": " and the plain command word after it,
+//	        like "8:EV_IPL:grub_cmd:linux"
+//	action events: the action string
+//	blob2/handoff tables2 events: the description
+//
+// The whitespaces are replaced by "_". If several events have the same
+// identifier, "#n" is appended for the n-th one from the second.
+func assignEventIDs(events []*BiosEvent) {
+	count := map[string]int{}
+	for _, e := range events {
+		id := fmt.Sprintf("%d%s%s", e.Pcr, idSeparator, e.TypeName)
+		if key := eventKey(e); key != "" {
+			id += idSeparator + strings.Join(strings.Fields(key), "_")
+		}
+		count[id]++
+		if count[id] > 1 {
+			id += idDupSeparator + strconv.Itoa(count[id])
+		}
+		e.ID = id
+	}
+}
+
+func eventKey(e *BiosEvent) string {
+	switch d := e.Details.(type) {
+	case *EfiVariable:
+		return d.Name
+	case *EfiImageLoad:
+		if d.File != "" {
+			return d.File
+		}
+		return d.DevicePath
+	case *EfiFirmwareBlob:
+		return d.Description
+	case *EfiHandoffTables:
+		return d.Description
+	case string:
+		switch e.Type {
+		case EvIPL:
+			return iplKey(d)
+		case EvEFIAction, EvAction:
+			return d
+		}
+	}
+	return ""
+}
+
+// iplKey returns the key of EV_IPL event, which doesn't contain the
+// measured arguments, like kernel version or command line.
+func iplKey(s string) string {
+	key, rest := "", s
+	if i := strings.Index(s, ": "); i >= 0 {
+		key, rest = s[:i], s[i+2:]
+	}
+	words := strings.Fields(rest)
+	if len(words) == 0 || !isPlainWord(words[0]) {
+		return key
+	}
+	if key == "" {
+		return words[0]
+	}
+	return key + idSeparator + words[0]
+}
+
+func isPlainWord(s string) bool {
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// BiosPcrReplay is the pcr values replayed from the bios log.
+type BiosPcrReplay struct {
+	// Pcrs are the replayed pcr values keyed by hash algorithm and index.
+	Pcrs map[string]map[int]HexBytes
+	// LastEvents are the last events extended into each pcr.
+	LastEvents map[int]*BiosEvent
+}
+
+// ReplayBiosEvents extends the digests of events into the pcrs of every
+// hash algorithm in the log. The EV_NO_ACTION events aren't extended, but
+// the "StartupLocality" one sets the initial value of pcr 0.
+func ReplayBiosEvents(events []*BiosEvent) *BiosPcrReplay {
+	r := &BiosPcrReplay{
+		Pcrs:       map[string]map[int]HexBytes{},
+		LastEvents: map[int]*BiosEvent{},
+	}
+	var locality byte
+	for _, e := range events {
+		if e.Type == EvNoAction {
+			if len(e.Data) > noActionSigLen && bytes.HasPrefix(e.Data, []byte(startupLocalitySig)) {
+				locality = e.Data[noActionSigLen]
+			}
+			continue
+		}
+		n := int(e.Pcr)
+		if n >= PcrMaxNum {
+			continue
+		}
+		for alg, d := range e.Digests {
+			h, err := GetHFromAlg(alg)
+			if err != nil {
+				continue
+			}
+			if r.Pcrs[alg] == nil {
+				r.Pcrs[alg] = map[int]HexBytes{}
+			}
+			pcr, ok := r.Pcrs[alg][n]
+			if !ok {
+				pcr = make([]byte, h.Size())
+				if n == 0 {
+					pcr[len(pcr)-1] = locality
+				}
+			}
+			h.Write(pcr)
+			h.Write(d)
+			r.Pcrs[alg][n] = h.Sum(nil)
+		}
+		r.LastEvents[n] = e
+	}
+	return r
+}
@@ -0,0 +1,221 @@
+package typdefs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"unicode/utf16"
+)
+
+var (
+	testBiosEvents = []struct {
+		index  int
+		id     string
+		legacy string
+	}{
+		{0, "0:EV_S_CRTM_VERSION", "8-0"},
+		{1, "0:EV_EFI_PLATFORM_FIRMWARE_BLOB", "80000008-1"},
+		{3, "7:EV_EFI_VARIABLE_DRIVER_CONFIG:PK", "80000001-3"},
+		{6, "7:EV_EFI_VARIABLE_DRIVER_CONFIG:dbx", "80000001-6"},
+		{7, "7:EV_SEPARATOR", "4-7"},
+		{9, "1:EV_EFI_VARIABLE_BOOT:Boot0004", "80000002-9"},
+		{22, "4:EV_EFI_ACTION:Calling_EFI_Application_from_Boot_Option", "80000007-22"},
+		{31, "5:EV_EFI_ACTION:Exit_Boot_Services_Returned_with_Success", "80000007-31"},
+	}
+)
+
+func ucs2(s string) []byte {
+	var buf bytes.Buffer
+	for _, c := range utf16.Encode([]rune(s)) {
+		binary.Write(&buf, binary.LittleEndian, c)
+	}
+	return buf.Bytes()
+}
+
+func dpNode(typ, sub byte, data []byte) []byte {
+	n := []byte{typ, sub, 0, 0}
+	binary.LittleEndian.PutUint16(n[2:], uint16(dpHeaderLen+len(data)))
+	return append(n, data...)
+}
+
+func testDevicePath() []byte {
+	acpi := make([]byte, 8)
+	binary.LittleEndian.PutUint32(acpi, acpiPciRootHID)
+	hd := make([]byte, hardDriveNodeLen)
+	binary.LittleEndian.PutUint32(hd, 1)
+	binary.LittleEndian.PutUint64(hd[4:], 0x800)
+	binary.LittleEndian.PutUint64(hd[12:], 0x100000)
+	hd[20] = 0x61
+	hd[36], hd[37] = 2, hdSignatureGPT
+	var dp []byte
+	dp = append(dp, dpNode(dpTypeACPI, dpSubAcpi, acpi)...)
+	dp = append(dp, dpNode(dpTypeHardware, dpSubPci, []byte{1, 2})...)
+	dp = append(dp, dpNode(dpTypeMedia, dpSubHardDrive, hd)...)
+	dp = append(dp, dpNode(dpTypeMedia, dpSubFilePath, append(ucs2(`\EFI\BOOT\BOOTAA64.EFI`), 0, 0))...)
+	dp = append(dp, dpNode(9, 9, []byte{0xab})...)
+	return append(dp, dpNode(dpTypeEnd, dpSubEndEntire, nil)...)
+}
+
+func sha1Event(pcr, typ uint32, data []byte) []byte {
+	ev := make([]byte, 8+Sha1DigestLen+4)
+	binary.LittleEndian.PutUint32(ev, pcr)
+	binary.LittleEndian.PutUint32(ev[4:], typ)
+	ev[8] = byte(typ)
+	binary.LittleEndian.PutUint32(ev[8+Sha1DigestLen:], uint32(len(data)))
+	return append(ev, data...)
+}
+
+func TestParseDevicePath(t *testing.T) {
+	dp, err := ParseDevicePath(testDevicePath())
+	want := `PciRoot(0x0)/Pci(0x2,0x1)/HD(1,GPT,00000061-0000-0000-0000-000000000000,0x800,0x100000)/` +
+		`\EFI\BOOT\BOOTAA64.EFI/Path(9,9,ab)`
+	if err != nil || dp.Text != want || dp.File != `\EFI\BOOT\BOOTAA64.EFI` {
+		t.Errorf("test ParseDevicePath error, %v %+v", err, dp)
+	}
+	if _, err = ParseDevicePath([]byte{4, 4, 0xff, 0}); err != ErrBiosLogFormatWrong {
+		t.Errorf("test ParseDevicePath wrong length error, %v", err)
+	}
+}
+
+func TestParseBiosEventLog(t *testing.T) {
+	bin, err := ioutil.ReadFile(testBiosLogFile)
+	if err != nil {
+		t.Fatalf("read bios log error, %v", err)
+	}
+	events, err := ParseBiosEventLog(bin)
+	if err != nil || len(events) != 32 {
+		t.Fatalf("test ParseBiosEventLog error, %v", err)
+	}
+	for i, tc := range testBiosEvents {
+		e := events[tc.index]
+		if e.ID != tc.id || e.LegacyName() != tc.legacy || !e.MatchName(tc.id) || !e.MatchName(tc.legacy) {
+			t.Errorf("test ParseBiosEventLog error at case %d, %s %s\n", i, e.ID, e.LegacyName())
+		}
+		if len(e.Digests[Sha1AlgStr]) != Sha1DigestLen || len(e.Digests[Sha256AlgStr]) != Sha256DigestLen {
+			t.Errorf("test ParseBiosEventLog digests error at case %d\n", i)
+		}
+	}
+	if v, ok := events[2].Details.(*EfiVariable); !ok || v.Name != "SecureBoot" ||
+		v.GUID != "8be4df61-93ca-11d2-aa0d-00e098032b8c" {
+		t.Errorf("test ParseBiosEventLog variable error, %+v", events[2].Details)
+	}
+	if v, ok := events[9].Details.(*EfiVariable); !ok || v.LoadOption == nil ||
+		v.LoadOption.Description != "ubuntu" {
+		t.Errorf("test ParseBiosEventLog load option error, %+v", events[9].Details)
+	}
+	if s, ok := events[0].Details.(string); !ok || s != "1.08" {
+		t.Errorf("test ParseBiosEventLog crtm version error, %+v", events[0].Details)
+	}
+
+	// the stored text log must be decoded to the same events.
+	txt, _ := TransformBIOSBinLogToTxt(bin)
+	events2, err := ParseBiosTxtLog(txt)
+	if err != nil || len(events2) != len(events) {
+		t.Fatalf("test ParseBiosTxtLog error, %v", err)
+	}
+	for i := range events {
+		j1, _ := json.Marshal(events[i])
+		j2, _ := json.Marshal(events2[i])
+		if !bytes.Equal(j1, j2) {
+			t.Errorf("test ParseBiosTxtLog error at event %d\n", i)
+		}
+	}
+
+	if _, err = ParseBiosEventLog(bin[:len(bin)-1]); err != ErrBiosLogFormatWrong {
+		t.Errorf("test ParseBiosEventLog truncated log error, %v", err)
+	}
+	if _, err = ParseBiosTxtLog([]byte("00 00 8-0 N/A\n")); err != ErrBiosLogFormatWrong {
+		t.Errorf("test ParseBiosTxtLog wrong line error, %v", err)
+	}
+}
+
+func TestParseSha1BiosEventLog(t *testing.T) {
+	img := make([]byte, efiImageHeaderLen)
+	dp := testDevicePath()
+	binary.LittleEndian.PutUint64(img[24:], uint64(len(dp)))
+	img = append(img, dp...)
+
+	gpt := make([]byte, gptHeaderMinLen+8)
+	binary.LittleEndian.PutUint32(gpt[gptHeaderSizeOff:], gptHeaderMinLen)
+	binary.LittleEndian.PutUint32(gpt[gptEntrySizeOff:], gptEntryMinLen)
+	binary.LittleEndian.PutUint64(gpt[gptHeaderMinLen:], 1)
+	entry := make([]byte, gptEntryMinLen)
+	binary.LittleEndian.PutUint64(entry[32:], 0x800)
+	copy(entry[gptEntryNameOff:], ucs2("EFI System Partition"))
+	gpt = append(gpt, entry...)
+
+	var bin []byte
+	bin = append(bin, sha1Event(4, EvEFIBootServicesApplication, img)...)
+	bin = append(bin, sha1Event(5, EvEFIGPTEvent, gpt)...)
+	bin = append(bin, sha1Event(8, EvIPL, []byte("grub_cmd: linux /vmlinuz-5.10 root=/dev/sda2\x00"))...)
+	bin = append(bin, sha1Event(8, EvIPL, []byte("grub_cmd: linux /vmlinuz-5.11\x00"))...)
+	bin = append(bin, sha1Event(8, EvIPL, []byte("kernel_cmdline: BOOT_IMAGE=/vmlinuz-5.10\x00"))...)
+	bin = append(bin, sha1Event(9, EvIPL, []byte("/boot/grub/grub.cfg\x00"))...)
+
+	events, err := ParseBiosEventLog(bin)
+	if err != nil || len(events) != 6 {
+		t.Fatalf("test ParseBiosEventLog sha1 log error, %v", err)
+	}
+	wants := []string{
+		`4:EV_EFI_BOOT_SERVICES_APPLICATION:\EFI\BOOT\BOOTAA64.EFI`,
+		"5:EV_EFI_GPT_EVENT",
+		"8:EV_IPL:grub_cmd:linux",
+		"8:EV_IPL:grub_cmd:linux#2",
+		"8:EV_IPL:kernel_cmdline",
+		"9:EV_IPL",
+	}
+	for i, want := range wants {
+		if events[i].ID != want || len(events[i].Digests) != 1 || events[i].Digests[Sha1AlgStr][0] != byte(events[i].Type) {
+			t.Errorf("test ParseBiosEventLog sha1 log error at case %d, %s\n", i, events[i].ID)
+		}
+	}
+	if g, ok := events[1].Details.(*EfiGpt); !ok || len(g.Partitions) != 1 ||
+		g.Partitions[0].Name != "EFI System Partition" || g.Partitions[0].StartingLBA != 0x800 {
+		t.Errorf("test ParseBiosEventLog gpt error, %+v", events[1].Details)
+	}
+	if _, err = ParseBiosEventLog(bin[:len(bin)-2]); err != ErrBiosLogFormatWrong {
+		t.Errorf("test ParseBiosEventLog truncated sha1 log error, %v", err)
+	}
+}
+
+func TestReplayBiosEvents(t *testing.T) {
+	bin, err := ioutil.ReadFile(testBiosLogFile)
+	if err != nil {
+		t.Fatalf("read bios log error, %v", err)
+	}
+	events, _ := ParseBiosEventLog(bin)
+	r := ReplayBiosEvents(events)
+	txt, _ := TransformBIOSBinLogToTxt(bin)
+	pcrs := NewPcrGroups()
+	ExtendPCRWithBIOSTxtLog(pcrs, txt)
+	for i := 0; i < 8; i++ {
+		if !bytes.Equal(r.Pcrs[Sha1AlgStr][i], pcrs.Pcr(i, Sha1AlgStr)) ||
+			!bytes.Equal(r.Pcrs[Sha256AlgStr][i], pcrs.Pcr(i, Sha256AlgStr)) {
+			t.Errorf("test ReplayBiosEvents error at pcr %d\n", i)
+		}
+	}
+	var last *BiosEvent
+	for _, e := range events {
+		if e.Pcr == 7 {
+			last = e
+		}
+	}
+	if r.LastEvents[7] != last {
+		t.Errorf("test ReplayBiosEvents last event error, %+v", r.LastEvents[7])
+	}
+
+	// the startup locality sets the initial value of pcr 0.
+	loc := append([]byte(startupLocalitySig), 3)
+	r = ReplayBiosEvents([]*BiosEvent{{Pcr: 0, Type: EvNoAction, Data: loc},
+		{Pcr: 0, Type: EvSCrtmVersion, Digests: map[string]HexBytes{Sha1AlgStr: make([]byte, Sha1DigestLen)}}})
+	init := make([]byte, Sha1DigestLen)
+	init[Sha1DigestLen-1] = 3
+	want := NewPcrGroups()
+	want.Sha1Pcrs[0] = init
+	want.ExtendSha1(0, make([]byte, Sha1DigestLen))
+	if !bytes.Equal(r.Pcrs[Sha1AlgStr][0], want.Pcr(0, Sha1AlgStr)) {
+		t.Errorf("test ReplayBiosEvents startup locality error")
+	}
+}
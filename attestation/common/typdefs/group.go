@@ -0,0 +1,84 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the node group of clients with the same hardware/OS profile,
+	which share the base values of the group.
+*/
+
+package typdefs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// the hardware/OS profile keys of the registered client info, the
+	// node group selectors use them usually.
+	ClientInfoProduct     = "productname"
+	ClientInfoBiosVersion = "biosversion"
+	ClientInfoOsRelease   = "osrelease"
+)
+
+var (
+	// ErrGroupWrong means the node group doesn't follow the format.
+	ErrGroupWrong = errors.New("node group format wrong")
+)
+
+type (
+	// Group is a node group of the clients whose registered client info
+	// contains the selector, like:
+	//
+	//	{
+	//	  "name": "taishan200-oe2203",
+	//	  "priority": 10,
+	//	  "selector": {"productname": "TaiShan 200 (Model 2280)",
+	//	    "biosversion": "1.70", "osrelease": "openEuler 22.03 LTS"}
+	//	}
+	//
+	// A client selected by several groups belongs to the one with the
+	// highest priority, or the earliest created one if the same.
+	Group struct {
+		Name     string          `json:"name"`
+		Priority int             `json:"priority"`
+		Selector json.RawMessage `json:"selector"`
+	}
+)
+
+// ParseGroup decodes and validates the JSON node group.
+func ParseGroup(data []byte) (*Group, error) {
+	g := &Group{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(g)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGroupWrong, err)
+	}
+	err = g.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Validate checks the name and selector of node group, the selector must
+// be a json object.
+func (g *Group) Validate() error {
+	if g.Name == "" {
+		return fmt.Errorf("%w: empty name", ErrGroupWrong)
+	}
+	var sel map[string]interface{}
+	if json.Unmarshal(g.Selector, &sel) != nil || sel == nil {
+		return fmt.Errorf("%w: selector is not a json object", ErrGroupWrong)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package typdefs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseGroup(t *testing.T) {
+	testCases := []struct {
+		input  string
+		result bool
+	}{
+		{`{"name":"g","selector":{"productname":"TaiShan 200","osrelease":"openEuler 22.03 LTS"}}`, true},
+		{`{"name":"g","priority":10,"selector":{}}`, true},
+		{`{"name":"","selector":{"productname":"TaiShan 200"}}`, false},
+		{`{"name":"g"}`, false},
+		{`{"name":"g","selector":null}`, false},
+		{`{"name":"g","selector":["productname"]}`, false},
+		{`{"name":"g","selector":{},"unknown":1}`, false},
+		{`{"name":"g","priority":"high","selector":{}}`, false},
+	}
+	for i, tc := range testCases {
+		_, err := ParseGroup([]byte(tc.input))
+		if (err == nil) != tc.result || (err != nil && !errors.Is(err, ErrGroupWrong)) {
+			t.Errorf("test ParseGroup error at case %d, %v\n", i, err)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: parse the ima ascii log of all kernel templates and replay it into pcrs.
+*/
+
+package typdefs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// ima template names.
+const (
+	StrImaSig    = "ima-sig"
+	StrImaBuf    = "ima-buf"
+	StrImaModsig = "ima-modsig"
+	StrBootAggr  = "boot_aggregate"
+)
+
+// ima template field ids, see kernel security/integrity/ima/ima_template.c.
+const (
+	imaFieldD       = "d"
+	imaFieldN       = "n"
+	imaFieldDNg     = "d-ng"
+	imaFieldNNg     = "n-ng"
+	imaFieldSig     = "sig"
+	imaFieldBuf     = "buf"
+	imaFieldDModsig = "d-modsig"
+	imaFieldModsig  = "modsig"
+	// pcr, template hash and template name before the template fields.
+	imaHeadItemNum = 3
+)
+
+var (
+	// imaTemplateFields defines the fields of each supported ima template.
+	imaTemplateFields = map[string][]string{
+		StrIma:       {imaFieldD, imaFieldN},
+		StrImaNg:     {imaFieldDNg, imaFieldNNg},
+		StrImaSig:    {imaFieldDNg, imaFieldNNg, imaFieldSig},
+		StrImaBuf:    {imaFieldDNg, imaFieldNNg, imaFieldBuf},
+		StrImaModsig: {imaFieldDNg, imaFieldNNg, imaFieldSig, imaFieldDModsig, imaFieldModsig},
+	}
+)
+
+type (
+	// ImaEntry is one measurement line of the ima ascii log.
+	ImaEntry struct {
+		Pcr          int
+		TemplateHash []byte
+		Template     string
+		// file data hash and its algorithm(sha1 for ima template).
+		FileHashAlg string
+		FileHash    []byte
+		FileName    string
+		// ima-sig/ima-modsig: the signature in security.ima xattr.
+		Signature []byte
+		// ima-buf: the measured buffer.
+		Buffer []byte
+		// ima-modsig: the file hash without appended signature and the
+		// appended signature.
+		ModsigHashAlg string
+		ModsigHash    []byte
+		Modsig        []byte
+	}
+
+	// ImaLogError reports the line of ima log which can't be parsed or
+	// doesn't match its template hash, Line starts from 1.
+	ImaLogError struct {
+		Line int
+		Err  error
+	}
+)
+
+func (e *ImaLogError) Error() string {
+	return fmt.Sprintf("ima log line %d: %v", e.Line, e.Err)
+}
+
+func (e *ImaLogError) Unwrap() error {
+	return e.Err
+}
+
+// ParseImaEntry parses one line of ima ascii log. The line has the format
+//
+//	pcr template-hash template-name field1 field2 ...
+//
+// every field is led by one space, so the empty signature of ima-sig leaves
+// a trailing space, and the file name may contain spaces.
+func ParseImaEntry(line []byte) (*ImaEntry, error) {
+	words := bytes.Split(bytes.TrimRight(line, "\r\n"), Space)
+	if len(words) < imaHeadItemNum {
+		return nil, ErrImaLogFormatWrong
+	}
+	pcr, err := strconv.Atoi(string(words[0]))
+	if err != nil {
+		return nil, ErrImaLogFormatWrong
+	}
+	if pcr < 0 || pcr >= PcrMaxNum {
+		return nil, ErrPcrIndexWrong
+	}
+	e := &ImaEntry{Pcr: pcr, Template: string(words[2])}
+	e.TemplateHash, err = hex.DecodeString(string(words[1]))
+	if err != nil || !isTemplateHashLen(len(e.TemplateHash)) {
+		return nil, ErrImaLogFormatWrong
+	}
+	fields, ok := imaTemplateFields[e.Template]
+	if !ok {
+		return nil, ErrImaTemplateWrong
+	}
+	values := words[imaHeadItemNum:]
+	if len(values) == 0 {
+		return nil, ErrImaLogFormatWrong
+	}
+	// the trailing empty fields may be trimmed by someone.
+	for len(values) < len(fields) {
+		values = append(values, []byte{})
+	}
+	// the name takes all the words which are not used by the other fields.
+	nameEnd := len(values) - (len(fields) - 2)
+	name := bytes.Join(values[1:nameEnd], Space)
+	values = append([][]byte{values[0], name}, values[nameEnd:]...)
+	for i, f := range fields {
+		err = e.setField(f, values[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func isTemplateHashLen(n int) bool {
+	return n == Sha1DigestLen || n == Sha256DigestLen
+}
+
+func (e *ImaEntry) setField(field string, value []byte) error {
+	var err error
+	switch field {
+	case imaFieldD:
+		e.FileHashAlg = Sha1AlgStr
+		e.FileHash, err = hex.DecodeString(string(value))
+		if err == nil && len(e.FileHash) != Sha1DigestLen {
+			err = ErrImaLogFormatWrong
+		}
+	case imaFieldN, imaFieldNNg:
+		if len(value) == 0 {
+			err = ErrImaLogFormatWrong
+		}
+		e.FileName = string(value)
+	case imaFieldDNg:
+		e.FileHashAlg, e.FileHash, err = parseImaDigest(value)
+		if err == nil && e.FileHashAlg == "" {
+			err = ErrImaLogFormatWrong
+		}
+	case imaFieldSig:
+		e.Signature, err = hex.DecodeString(string(value))
+	case imaFieldBuf:
+		e.Buffer, err = hex.DecodeString(string(value))
+	case imaFieldDModsig:
+		e.ModsigHashAlg, e.ModsigHash, err = parseImaDigest(value)
+	case imaFieldModsig:
+		e.Modsig, err = hex.DecodeString(string(value))
+	}
+	if err != nil {
+		return ErrImaLogFormatWrong
+	}
+	return nil
+}
+
+// parseImaDigest parses the digest field "alg:hex", empty field returns
+// empty algorithm and nil hash.
+func parseImaDigest(value []byte) (string, []byte, error) {
+	if len(value) == 0 {
+		return "", nil, nil
+	}
+	i := bytes.Index(value, Colon)
+	if i <= 0 {
+		return "", nil, ErrImaLogFormatWrong
+	}
+	d, err := hex.DecodeString(string(value[i+1:]))
+	if err != nil || len(d) == 0 {
+		return "", nil, ErrImaLogFormatWrong
+	}
+	return string(value[:i]), d, nil
+}
+
+// FileHashString returns the file hash as it is in log, "alg:hex" for the
+// ng templates and "hex" for ima template.
+func (e *ImaEntry) FileHashString() string {
+	if e.Template == StrIma {
+		return hex.EncodeToString(e.FileHash)
+	}
+	return e.FileHashAlg + string(Colon) + hex.EncodeToString(e.FileHash)
+}
+
+// IsViolation returns true if the entry records a measurement violation,
+// whose template hash is zero and pcr is extended with all 0xff.
+func (e *ImaEntry) IsViolation() bool {
+	for _, b := range e.TemplateHash {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TemplateData returns the template data which the template hash is
+// calculated on, see kernel ima_calc_field_array_hash_tfm.
+func (e *ImaEntry) TemplateData() []byte {
+	var buf bytes.Buffer
+	if e.Template == StrIma {
+		buf.Write(e.FileHash)
+		name := make([]byte, imaItemNameLenMax+1)
+		copy(name, e.FileName)
+		buf.Write(name)
+		return buf.Bytes()
+	}
+	for _, f := range imaTemplateFields[e.Template] {
+		var data []byte
+		switch f {
+		case imaFieldDNg:
+			data = imaDigestData(e.FileHashAlg, e.FileHash)
+		case imaFieldNNg:
+			data = append([]byte(e.FileName), 0)
+		case imaFieldSig:
+			data = e.Signature
+		case imaFieldBuf:
+			data = e.Buffer
+		case imaFieldDModsig:
+			data = imaDigestData(e.ModsigHashAlg, e.ModsigHash)
+		case imaFieldModsig:
+			data = e.Modsig
+		}
+		sLen := make([]byte, uint32Len)
+		binary.LittleEndian.PutUint32(sLen, uint32(len(data)))
+		buf.Write(sLen)
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
+
+// imaDigestData returns "alg:\0" + binary hash, or nil for an empty digest.
+func imaDigestData(alg string, d []byte) []byte {
+	if alg == "" {
+		return nil
+	}
+	data := make([]byte, 0, len(alg)+2+len(d))
+	data = append(data, alg...)
+	data = append(data, ':', 0)
+	return append(data, d...)
+}
+
+// TemplateDigest calculates the template hash with algorithm algStr, the
+// violation entry returns all 0xff.
+func (e *ImaEntry) TemplateDigest(algStr string) ([]byte, error) {
+	if e.IsViolation() {
+		dLen, ok := SupportAlgAndLenMap[algStr]
+		if !ok {
+			return nil, ErrNotSupportAlg
+		}
+		return bytes.Repeat([]byte{0xff}, dLen), nil
+	}
+	h, err := GetHFromAlg(algStr)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(e.TemplateData())
+	return h.Sum(nil), nil
+}
+
+// CheckTemplateHash recalculates the template hash and compares it with the
+// one in log. The log template hash is sha1, or the digest of algStr when it
+// has the same length as algStr digest(sha256 otherwise).
+func (e *ImaEntry) CheckTemplateHash(algStr string) error {
+	if e.IsViolation() {
+		return nil
+	}
+	alg := Sha1AlgStr
+	if len(e.TemplateHash) != Sha1DigestLen {
+		alg = Sha256AlgStr
+		if SupportAlgAndLenMap[algStr] == len(e.TemplateHash) {
+			alg = algStr
+		}
+	}
+	h, err := GetHFromAlg(alg)
+	if err != nil {
+		return err
+	}
+	h.Write(e.TemplateData())
+	if !bytes.Equal(h.Sum(nil), e.TemplateHash) {
+		return ErrValidateIMAFail
+	}
+	return nil
+}
+
+// ParseImaLog parses all lines of ima ascii log, the empty lines are skipped.
+// The error is an *ImaLogError which tells the wrong line.
+func ParseImaLog(imaLog []byte) ([]*ImaEntry, error) {
+	lines := bytes.Split(imaLog, NewLine)
+	entries := make([]*ImaEntry, 0, len(lines))
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		e, err := ParseImaEntry(line)
+		if err != nil {
+			return nil, &ImaLogError{Line: i + 1, Err: err}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// ReplayIMALog parses the ima log, verifies the template hash of each line
+// and extends them into the algStr bank of pcrs. The error is an
+// *ImaLogError which tells the first divergent line.
+func ReplayIMALog(pcrs *PcrGroups, imaLog []byte, algStr string) ([]*ImaEntry, error) {
+	if _, ok := SupportAlgAndLenMap[algStr]; !ok {
+		return nil, ErrNotSupportAlg
+	}
+	lines := bytes.Split(imaLog, NewLine)
+	entries := make([]*ImaEntry, 0, len(lines))
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		e, err := ParseImaEntry(line)
+		if err == nil {
+			err = e.CheckTemplateHash(algStr)
+		}
+		var d []byte
+		if err == nil {
+			d, err = e.TemplateDigest(algStr)
+		}
+		if err != nil {
+			return nil, &ImaLogError{Line: i + 1, Err: err}
+		}
+		pcrs.Extend(e.Pcr, d, algStr)
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// CheckBootAggregate checks the boot_aggregate entry with pcr 0-7 of the
+// bank of its hash algorithm, the non-sha1 aggregate includes pcr 8-9 in
+// the newer kernel, so both are accepted.
+func CheckBootAggregate(pcrs *PcrGroups, e *ImaEntry) error {
+	if e.FileName != StrBootAggr {
+		return ErrBiosAggregateFail
+	}
+	aggr := hex.EncodeToString(e.FileHash)
+	switch e.FileHashAlg {
+	case Sha1AlgStr:
+		if aggr == pcrs.AggregateSha1(0, 8) {
+			return nil
+		}
+	case Sha256AlgStr:
+		if aggr == pcrs.AggregateSha256(0, 8) || aggr == pcrs.AggregateSha256(0, 10) {
+			return nil
+		}
+	case Sm3AlgStr:
+		if aggr == pcrs.AggregateSM3(0, 8) || aggr == pcrs.AggregateSM3(0, 10) {
+			return nil
+		}
+	default:
+		return ErrNotSupportAlg
+	}
+	return ErrBiosAggregateFail
+}
@@ -0,0 +1,39 @@
+//go:build go1.18
+// +build go1.18
+
+package typdefs
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzParseImaEntry(f *testing.F) {
+	for _, tc := range testImaNgLines {
+		f.Add([]byte(tc.line))
+	}
+	f.Add([]byte("10 24b06bd44d31787e126c471843e9ef2c6345a5da ima 5e7bbf27b7dd568610cc1f1ea49ceaa420395690 boot_aggregate"))
+	f.Fuzz(func(t *testing.T, line []byte) {
+		if bytes.ContainsAny(line, "\r\n") {
+			return
+		}
+		e, err := ParseImaEntry(line)
+		if err != nil {
+			return
+		}
+		if e.Pcr < 0 || e.Pcr >= PcrMaxNum || !isTemplateHashLen(len(e.TemplateHash)) {
+			t.Errorf("parse wrong entry %+v", e)
+		}
+		for _, alg := range []string{Sha1AlgStr, Sha256AlgStr, Sm3AlgStr} {
+			if _, err = e.TemplateDigest(alg); err != nil {
+				t.Errorf("template digest of %s error, %v", alg, err)
+			}
+			_ = e.CheckTemplateHash(alg)
+		}
+		// replaying one line never panics and fails only on its template hash.
+		_, err = ReplayIMALog(NewPcrGroups(), line, Sha256AlgStr)
+		if err != nil && e.CheckTemplateHash(Sha256AlgStr) == nil {
+			t.Errorf("replay parsed line error, %v", err)
+		}
+	})
+}
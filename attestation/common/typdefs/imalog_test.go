@@ -0,0 +1,200 @@
+package typdefs
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"testing"
+)
+
+const (
+	testImaLogFile  = "../../rac/cmd/raagent/ascii_runtime_measurements"
+	testBiosLogFile = "../../rac/cmd/raagent/binary_bios_measurements"
+	testFileHash    = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	testModsigHash  = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	testSig         = "030204a1b2c3d400020102"
+)
+
+var (
+	testImaNgLines = []struct {
+		line     string
+		template string
+		name     string
+		sig      string
+		buf      string
+		modsig   string
+		sha256   string
+	}{
+		{
+			"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:" + testFileHash + " /usr/bin/a b",
+			StrImaNg, "/usr/bin/a b", "", "", "",
+			"91e69f436084692a3a728fc6771cb2b0e0bc50aabdeb8ce7244dfad36d10177d",
+		},
+		{
+			"10 c1072321eb7a5620111708ee1f58ea2ffd52de06 ima-sig sha256:" + testFileHash + " /usr/bin/ls " + testSig,
+			StrImaSig, "/usr/bin/ls", testSig, "", "",
+			"3fbe759d410df6b6f0ca3765f9f8ee7a1fb07d15bfcd8423c8c68635f2c980b5",
+		},
+		{
+			"10 856097a48cb157473c949f29973ab5ee3bc5b501 ima-sig sha256:" + testFileHash + " /usr/bin/ls ",
+			StrImaSig, "/usr/bin/ls", "", "", "",
+			"0f1adc18f1ad88d1ac001657bf84afc002e038e8aa3aa00c8755d82e32219c1a",
+		},
+		{
+			"10 856097a48cb157473c949f29973ab5ee3bc5b501 ima-sig sha256:" + testFileHash + " /usr/bin/ls",
+			StrImaSig, "/usr/bin/ls", "", "", "",
+			"0f1adc18f1ad88d1ac001657bf84afc002e038e8aa3aa00c8755d82e32219c1a",
+		},
+		{
+			"10 40a4bf25baf55ad53578808679505f42c2c2c36f ima-buf sha256:" + testFileHash + " kexec-cmdline 6b65726e656c",
+			StrImaBuf, "kexec-cmdline", "", "6b65726e656c", "",
+			"d3306fd0e70a5295ab3ffb6ee2defb7baaf448240151622b86924cc3b84d5ac4",
+		},
+		{
+			"10 8507a0a57273e51dbdd29810fc37b4da2d38e245 ima-modsig sha256:" + testFileHash +
+				" /lib/modules/x.ko  sha256:" + testModsigHash + " " + testSig,
+			StrImaModsig, "/lib/modules/x.ko", "", "", testSig,
+			"9b4e9cd828b791b23bbd0a07bed43621259cc98773a90332782d874019e4a36f",
+		},
+		{
+			"10 1714501ee7e25e6b1c65d25b4eab33a4692cfb44 ima-modsig sha256:" + testFileHash + " /lib/modules/y.ko   ",
+			StrImaModsig, "/lib/modules/y.ko", "", "", "",
+			"9f4c85c28eb837eaba6722fa22ab64d56b17e9d605baa57c6bac097cadb6bd73",
+		},
+	}
+)
+
+func TestParseImaEntry(t *testing.T) {
+	for i, tc := range testImaNgLines {
+		e, err := ParseImaEntry([]byte(tc.line))
+		if err != nil {
+			t.Errorf("test ParseImaEntry error at case %d, %v\n", i, err)
+			continue
+		}
+		if e.Pcr != 10 || e.Template != tc.template || e.FileName != tc.name ||
+			e.FileHashAlg != Sha256AlgStr || hex.EncodeToString(e.FileHash) != testFileHash ||
+			hex.EncodeToString(e.Signature) != tc.sig || hex.EncodeToString(e.Buffer) != tc.buf ||
+			hex.EncodeToString(e.Modsig) != tc.modsig {
+			t.Errorf("test ParseImaEntry fields error at case %d, %+v\n", i, e)
+		}
+		if err = e.CheckTemplateHash(Sha256AlgStr); err != nil {
+			t.Errorf("test CheckTemplateHash error at case %d, %v\n", i, err)
+		}
+		d, err := e.TemplateDigest(Sha256AlgStr)
+		if err != nil || hex.EncodeToString(d) != tc.sha256 {
+			t.Errorf("test TemplateDigest error at case %d, %x\n", i, d)
+		}
+	}
+}
+
+func TestParseImaEntryWrong(t *testing.T) {
+	testCases := []struct {
+		line string
+		err  error
+	}{
+		{"", ErrImaLogFormatWrong},
+		{"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng", ErrImaLogFormatWrong},
+		{"x e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:00 a", ErrImaLogFormatWrong},
+		{"24 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:00 a", ErrPcrIndexWrong},
+		{"10 e7ed ima-ng sha256:00 a", ErrImaLogFormatWrong},
+		{"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-xx sha256:00 a", ErrImaTemplateWrong},
+		{"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng 00 a", ErrImaLogFormatWrong},
+		{"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:zz a", ErrImaLogFormatWrong},
+		{"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima 00 a", ErrImaLogFormatWrong},
+		{"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-sig sha256:00 a zz", ErrImaLogFormatWrong},
+	}
+	for i, tc := range testCases {
+		_, err := ParseImaEntry([]byte(tc.line))
+		if err != tc.err {
+			t.Errorf("test ParseImaEntry wrong line error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func TestImaViolation(t *testing.T) {
+	line := "10 0000000000000000000000000000000000000000 ima-ng sha256:" + testFileHash + " /tmp/a"
+	e, err := ParseImaEntry([]byte(line))
+	if err != nil || !e.IsViolation() {
+		t.Fatalf("test violation entry error, %v", err)
+	}
+	if err = e.CheckTemplateHash(Sha1AlgStr); err != nil {
+		t.Errorf("test violation CheckTemplateHash error, %v", err)
+	}
+	d, _ := e.TemplateDigest(Sha256AlgStr)
+	if !bytes.Equal(d, bytes.Repeat([]byte{0xff}, Sha256DigestLen)) {
+		t.Errorf("test violation TemplateDigest error, %x", d)
+	}
+}
+
+func TestReplayIMALog(t *testing.T) {
+	var buf bytes.Buffer
+	for _, tc := range testImaNgLines {
+		buf.WriteString(tc.line + "\n")
+	}
+	for _, alg := range []string{Sha1AlgStr, Sha256AlgStr, Sm3AlgStr} {
+		pcrs := NewPcrGroups()
+		entries, err := ReplayIMALog(pcrs, buf.Bytes(), alg)
+		if err != nil || len(entries) != len(testImaNgLines) {
+			t.Errorf("test ReplayIMALog %s error, %v", alg, err)
+			continue
+		}
+		want := NewPcrGroups()
+		for _, e := range entries {
+			d, _ := e.TemplateDigest(alg)
+			want.Extend(10, d, alg)
+		}
+		if !bytes.Equal(pcrs.Pcr(10, alg), want.Pcr(10, alg)) {
+			t.Errorf("test ReplayIMALog %s pcr error", alg)
+		}
+	}
+	// change the file hash of the 3rd line.
+	lines := bytes.Split(buf.Bytes(), NewLine)
+	lines[2] = bytes.Replace(lines[2], []byte("sha256:ba"), []byte("sha256:bb"), 1)
+	_, err := ReplayIMALog(NewPcrGroups(), bytes.Join(lines, NewLine), Sha256AlgStr)
+	var le *ImaLogError
+	if !errors.As(err, &le) || le.Line != 3 || !errors.Is(err, ErrValidateIMAFail) {
+		t.Errorf("test ReplayIMALog divergent line error, %v", err)
+	}
+	if _, err = ReplayIMALog(NewPcrGroups(), buf.Bytes(), "md5"); err != ErrNotSupportAlg {
+		t.Errorf("test ReplayIMALog wrong alg error, %v", err)
+	}
+}
+
+func TestExtendPCRWithIMALog(t *testing.T) {
+	bios, err := ioutil.ReadFile(testBiosLogFile)
+	if err != nil {
+		t.Fatalf("read bios log error, %v", err)
+	}
+	ima, err := ioutil.ReadFile(testImaLogFile)
+	if err != nil {
+		t.Fatalf("read ima log error, %v", err)
+	}
+	biosTxt, err := TransformBIOSBinLogToTxt(bios)
+	if err != nil {
+		t.Fatalf("transform bios log error, %v", err)
+	}
+	for _, alg := range []string{Sha1AlgStr, Sha256AlgStr} {
+		pcrs := NewPcrGroups()
+		ExtendPCRWithBIOSTxtLog(pcrs, biosTxt)
+		ok, err := ExtendPCRWithIMALog(pcrs, ima, alg)
+		if !ok || err != nil {
+			t.Errorf("test ExtendPCRWithIMALog %s error, %v", alg, err)
+		}
+	}
+	// without bios log, boot aggregate doesn't match.
+	ok, err := ExtendPCRWithIMALog(NewPcrGroups(), ima, Sha1AlgStr)
+	if ok || err != ErrBiosAggregateFail {
+		t.Errorf("test ExtendPCRWithIMALog boot aggregate error, %v", err)
+	}
+	// the divergent line is reported.
+	lines := bytes.Split(ima, NewLine)
+	lines[99] = bytes.Replace(lines[99], []byte(" ima "), []byte(" ima 0"), 1)
+	pcrs := NewPcrGroups()
+	ExtendPCRWithBIOSTxtLog(pcrs, biosTxt)
+	_, err = ExtendPCRWithIMALog(pcrs, bytes.Join(lines, NewLine), Sha1AlgStr)
+	var le *ImaLogError
+	if !errors.As(err, &le) || le.Line != 100 {
+		t.Errorf("test ExtendPCRWithIMALog divergent line error, %v", err)
+	}
+}
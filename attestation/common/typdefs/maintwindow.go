@@ -0,0 +1,160 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the maintenance window in which the host base value of a
+	client or node group is updated automatically from its trust reports.
+*/
+
+package typdefs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// the parts of host base value which a maintenance window allows
+	// to change.
+	WindowPartPcr  = "pcr"
+	WindowPartBios = "bios"
+	WindowPartIma  = "ima"
+
+	// the states of maintenance window, they are decided by its start
+	// and end time, so a window closes automatically.
+	WindowStateScheduled = "scheduled"
+	WindowStateOpen      = "open"
+	WindowStateClosed    = "closed"
+
+	// WindowAuthorAutoUpdate is the author of the maintenance windows
+	// opened by setting a client to update automatically.
+	WindowAuthorAutoUpdate = "auto-update"
+)
+
+var (
+	// ErrMaintWindowWrong means the maintenance window doesn't follow
+	// the format.
+	ErrMaintWindowWrong = errors.New("maintenance window format wrong")
+)
+
+type (
+	// MaintWindow is a maintenance window of a client or node group, like:
+	//
+	//	{
+	//	  "clientid": 1,
+	//	  "starttime": "2022-06-01T20:00:00+08:00",
+	//	  "endtime": "2022-06-01T22:00:00+08:00",
+	//	  "parts": ["bios", "ima"]
+	//	}
+	//
+	// The window starts now if starttime isn't set. The trust reports in
+	// the window are only verified by the parts not allowed to change,
+	// the changed parts are saved as a new active host base value.
+	MaintWindow struct {
+		ClientID  int64     `json:"clientid,omitempty"`
+		GroupID   int64     `json:"groupid,omitempty"`
+		StartTime time.Time `json:"starttime"`
+		EndTime   time.Time `json:"endtime"`
+		Parts     []string  `json:"parts"`
+	}
+
+	// MaintWindowRow stores a maintenance window in database table
+	// `maint_window`, it is kept after closed for auditing.
+	MaintWindowRow struct {
+		ID         int64
+		ClientID   int64 // 0 for the window of a node group
+		GroupID    int64 // 0 for the window of a client
+		StartTime  time.Time
+		EndTime    time.Time
+		Parts      string // comma separated parts, see WindowPartPcr
+		Author     string // who opens the window
+		CreateTime time.Time
+		ClosedBy   string // who closes the window before its end time
+	}
+)
+
+// ParseMaintWindow decodes and validates the JSON maintenance window.
+func ParseMaintWindow(data []byte) (*MaintWindow, error) {
+	w := &MaintWindow{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(w)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMaintWindowWrong, err)
+	}
+	err = w.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Validate checks the maintenance window is for either a client or a node
+// group, ends after it starts and allows known parts to change.
+func (w *MaintWindow) Validate() error {
+	if (w.ClientID == 0) == (w.GroupID == 0) {
+		return fmt.Errorf("%w: need either clientid or groupid", ErrMaintWindowWrong)
+	}
+	if w.EndTime.IsZero() || (!w.StartTime.IsZero() && !w.EndTime.After(w.StartTime)) {
+		return fmt.Errorf("%w: endtime must be after starttime", ErrMaintWindowWrong)
+	}
+	if len(w.Parts) == 0 {
+		return fmt.Errorf("%w: empty parts", ErrMaintWindowWrong)
+	}
+	seen := map[string]bool{}
+	for _, p := range w.Parts {
+		switch p {
+		case WindowPartPcr, WindowPartBios, WindowPartIma:
+		default:
+			return fmt.Errorf("%w: unknown part %s", ErrMaintWindowWrong, p)
+		}
+		if seen[p] {
+			return fmt.Errorf("%w: duplicated part %s", ErrMaintWindowWrong, p)
+		}
+		seen[p] = true
+	}
+	return nil
+}
+
+// Allows returns whether the maintenance window allows the part of host
+// base value to change, nil window allows nothing.
+func (w *MaintWindowRow) Allows(part string) bool {
+	if w == nil {
+		return false
+	}
+	for _, p := range strings.Split(w.Parts, ",") {
+		if p == part {
+			return true
+		}
+	}
+	return false
+}
+
+// State returns the state of maintenance window at the time, a window
+// closed before its start time is closed too.
+func (w *MaintWindowRow) State(now time.Time) string {
+	switch {
+	case !now.Before(w.EndTime):
+		return WindowStateClosed
+	case now.Before(w.StartTime):
+		return WindowStateScheduled
+	default:
+		return WindowStateOpen
+	}
+}
+
+// AllowsAll returns whether the maintenance window allows all parts of
+// host base value to change.
+func (w *MaintWindowRow) AllowsAll() bool {
+	return w.Allows(WindowPartPcr) && w.Allows(WindowPartBios) && w.Allows(WindowPartIma)
+}
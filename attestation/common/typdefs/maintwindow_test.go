@@ -0,0 +1,73 @@
+package typdefs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseMaintWindow(t *testing.T) {
+	testCases := []struct {
+		input  string
+		result bool
+	}{
+		{`{"clientid":1,"endtime":"2022-06-01T22:00:00Z","parts":["pcr","bios","ima"]}`, true},
+		{`{"groupid":1,"starttime":"2022-06-01T20:00:00Z","endtime":"2022-06-01T22:00:00Z","parts":["ima"]}`, true},
+		{`{"endtime":"2022-06-01T22:00:00Z","parts":["ima"]}`, false},
+		{`{"clientid":1,"groupid":1,"endtime":"2022-06-01T22:00:00Z","parts":["ima"]}`, false},
+		{`{"clientid":1,"parts":["ima"]}`, false},
+		{`{"clientid":1,"starttime":"2022-06-01T22:00:00Z","endtime":"2022-06-01T20:00:00Z","parts":["ima"]}`, false},
+		{`{"clientid":1,"endtime":"2022-06-01T22:00:00Z"}`, false},
+		{`{"clientid":1,"endtime":"2022-06-01T22:00:00Z","parts":["tpm"]}`, false},
+		{`{"clientid":1,"endtime":"2022-06-01T22:00:00Z","parts":["ima","ima"]}`, false},
+		{`{"clientid":1,"endtime":"2022-06-01T22:00:00Z","parts":["ima"],"author":"x"}`, false},
+	}
+	for i, tc := range testCases {
+		_, err := ParseMaintWindow([]byte(tc.input))
+		if (err == nil) != tc.result || (err != nil && !errors.Is(err, ErrMaintWindowWrong)) {
+			t.Errorf("test ParseMaintWindow error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func TestMaintWindowRow(t *testing.T) {
+	now := time.Now()
+	w := &MaintWindowRow{StartTime: now, EndTime: now.Add(time.Hour), Parts: "bios,ima"}
+	testCases := []struct {
+		w      *MaintWindowRow
+		part   string
+		result bool
+	}{
+		{w, WindowPartPcr, false},
+		{w, WindowPartBios, true},
+		{w, WindowPartIma, true},
+		{nil, WindowPartIma, false},
+	}
+	for i, tc := range testCases {
+		if tc.w.Allows(tc.part) != tc.result {
+			t.Errorf("test MaintWindowRow Allows error at case %d\n", i)
+		}
+	}
+	states := []struct {
+		now   time.Time
+		state string
+	}{
+		{now.Add(-time.Second), WindowStateScheduled},
+		{now, WindowStateOpen},
+		{now.Add(time.Hour), WindowStateClosed},
+	}
+	for i, tc := range states {
+		if s := w.State(tc.now); s != tc.state {
+			t.Errorf("test MaintWindowRow State error at case %d, %s\n", i, s)
+		}
+	}
+	// the window closed before it starts.
+	closed := &MaintWindowRow{StartTime: now.Add(time.Hour), EndTime: now.Add(-time.Second),
+		Parts: "pcr,bios,ima"}
+	if s := closed.State(now); s != WindowStateClosed {
+		t.Errorf("test MaintWindowRow State of closed window error, %s\n", s)
+	}
+	if w.AllowsAll() || !closed.AllowsAll() {
+		t.Errorf("test MaintWindowRow AllowsAll error\n")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: parse and format the pcr bank selections negotiated between ras and rac.
+*/
+
+package typdefs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	pcrBankSep  = ";"
+	pcrAlgSep   = ":"
+	pcrIndexSep = ","
+	pcrRangeSep = "-"
+)
+
+// ParsePcrSelections parses the pcr selections of several pcr banks like
+// "sha256:0-7,10;sm3:0-23", returns nil for an empty string.
+func ParsePcrSelections(s string) ([]PcrSelection, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	sels := []PcrSelection{}
+	used := map[string]bool{}
+	for _, bank := range strings.Split(s, pcrBankSep) {
+		words := strings.Split(strings.TrimSpace(bank), pcrAlgSep)
+		if len(words) != 2 {
+			return nil, ErrPcrSelectionWrong
+		}
+		alg := strings.ToLower(strings.TrimSpace(words[0]))
+		if _, ok := SupportAlgAndLenMap[alg]; !ok || used[alg] {
+			return nil, ErrPcrSelectionWrong
+		}
+		used[alg] = true
+		pcrs, err := parsePcrIndexes(words[1])
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, PcrSelection{HashAlg: alg, PCRs: pcrs})
+	}
+	return sels, nil
+}
+
+// parsePcrIndexes parses the pcr index list like "0-7,10" and returns
+// the sorted indexes without duplication.
+func parsePcrIndexes(s string) ([]int, error) {
+	var selected [PcrMaxNum]bool
+	for _, item := range strings.Split(s, pcrIndexSep) {
+		from, to := strings.TrimSpace(item), ""
+		if i := strings.Index(from, pcrRangeSep); i >= 0 {
+			from, to = strings.TrimSpace(from[:i]), strings.TrimSpace(from[i+1:])
+		} else {
+			to = from
+		}
+		f, err1 := strconv.Atoi(from)
+		t, err2 := strconv.Atoi(to)
+		if err1 != nil || err2 != nil || f < 0 || t >= PcrMaxNum || f > t {
+			return nil, ErrPcrSelectionWrong
+		}
+		for i := f; i <= t; i++ {
+			selected[i] = true
+		}
+	}
+	pcrs := []int{}
+	for i, v := range selected {
+		if v {
+			pcrs = append(pcrs, i)
+		}
+	}
+	return pcrs, nil
+}
+
+// FormatPcrSelections formats the pcr selections to the string which can
+// be parsed by ParsePcrSelections.
+func FormatPcrSelections(sels []PcrSelection) string {
+	banks := make([]string, 0, len(sels))
+	for _, sel := range sels {
+		pcrs := append([]int{}, sel.PCRs...)
+		sort.Ints(pcrs)
+		items := []string{}
+		for i := 0; i < len(pcrs); {
+			j := i
+			for j+1 < len(pcrs) && pcrs[j+1] <= pcrs[j]+1 {
+				j++
+			}
+			if pcrs[i] == pcrs[j] {
+				items = append(items, strconv.Itoa(pcrs[i]))
+			} else {
+				items = append(items, fmt.Sprintf("%d%s%d", pcrs[i], pcrRangeSep, pcrs[j]))
+			}
+			i = j + 1
+		}
+		banks = append(banks, sel.HashAlg+pcrAlgSep+strings.Join(items, pcrIndexSep))
+	}
+	return strings.Join(banks, pcrBankSep)
+}
+
+// Contains checks whether all pcrs in other are selected by sel.
+func (sel *PcrSelection) Contains(other *PcrSelection) bool {
+	if sel.HashAlg != other.HashAlg {
+		return false
+	}
+	selected := map[int]bool{}
+	for _, i := range sel.PCRs {
+		selected[i] = true
+	}
+	for _, i := range other.PCRs {
+		if !selected[i] {
+			return false
+		}
+	}
+	return true
+}
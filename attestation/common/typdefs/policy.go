@@ -0,0 +1,241 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the declarative verification policy of a client group and
+	the verdict of evaluating it against a trust report.
+*/
+
+package typdefs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+)
+
+const (
+	// rule severities, a failed rule of fail severity makes the report
+	// untrusted, warn and info are only recorded in verdict.
+	SeverityFail = "fail"
+	SeverityWarn = "warn"
+	SeverityInfo = "info"
+
+	// RulePcr requires the pcrs in Pcrs, or all pcrs of base value if
+	// empty, match the base value.
+	RulePcr = "pcr"
+	// RuleBios requires the bios log matches the base value.
+	RuleBios = "bios"
+	// RuleIma requires the ima log matches the base value by its ima mode.
+	RuleIma = "ima"
+	// RuleImaUnknown allows the files which aren't in the base value only
+	// under Paths, a path matches itself, the files under it and glob.
+	RuleImaUnknown = "imaunknown"
+	// RuleSecureBoot requires the secure boot is enabled.
+	RuleSecureBoot = "secureboot"
+	// RuleBiosPcr requires the pcrs replayed from bios log equal quoted.
+	RuleBiosPcr = "biospcr"
+	// RuleClientInfo requires the Field of report client info, like
+	// "os" or "os.type", matches the regular expression Pattern.
+	RuleClientInfo = "clientinfo"
+
+	// verdict results, the worst severity of the failed rules.
+	VerdictPass = "pass"
+	VerdictWarn = "warn"
+	VerdictFail = "fail"
+)
+
+var (
+	// ErrPolicyWrong means the policy doesn't follow the format.
+	ErrPolicyWrong = errors.New("policy format wrong")
+
+	ruleTypes = map[string]bool{
+		RulePcr:        true,
+		RuleBios:       true,
+		RuleIma:        true,
+		RuleImaUnknown: true,
+		RuleSecureBoot: true,
+		RuleBiosPcr:    true,
+		RuleClientInfo: true,
+	}
+	severities = map[string]bool{
+		SeverityFail: true,
+		SeverityWarn: true,
+		SeverityInfo: true,
+	}
+)
+
+type (
+	// Policy is the JSON form of a verification policy:
+	//
+	//	{
+	//	  "name": "web",
+	//	  "selector": {"os": {"type": "openEuler"}},
+	//	  "enabled": true,
+	//	  "rules": [
+	//	    {"name": "boot", "type": "pcr", "severity": "fail", "pcrs": [0, 2, 4, 7]},
+	//	    {"name": "tmp", "type": "imaunknown", "severity": "warn", "paths": ["/tmp"]},
+	//	    {"name": "os", "type": "clientinfo", "severity": "info", "field": "os.type",
+	//	     "pattern": "^openEuler$"}
+	//	  ]
+	//	}
+	Policy struct {
+		Name string `json:"name"`
+		// Selector selects the client group whose registered client info
+		// contains it, the empty one selects all clients.
+		Selector json.RawMessage `json:"selector,omitempty"`
+		Enabled  bool            `json:"enabled"`
+		Rules    []PolicyRule    `json:"rules"`
+	}
+
+	// PolicyRule is one rule of policy, the parameters depend on type.
+	PolicyRule struct {
+		Name     string   `json:"name"`
+		Type     string   `json:"type"`
+		Severity string   `json:"severity"`
+		Pcrs     []int    `json:"pcrs,omitempty"`
+		Paths    []string `json:"paths,omitempty"`
+		Field    string   `json:"field,omitempty"`
+		Pattern  string   `json:"pattern,omitempty"`
+	}
+
+	// PolicyVerdict is the result of evaluating all policies of a client
+	// against a trust report.
+	PolicyVerdict struct {
+		Result string `json:"result"`
+		// BaseID is the id of host base value used by the rules, 0 if none.
+		BaseID int64              `json:"baseid,omitempty"`
+		Rules  []PolicyRuleResult `json:"rules"`
+	}
+
+	// PolicyRuleResult is the result of one rule.
+	PolicyRuleResult struct {
+		Policy   string `json:"policy"`
+		Version  int    `json:"version"`
+		Rule     string `json:"rule"`
+		Type     string `json:"type"`
+		Severity string `json:"severity"`
+		Passed   bool   `json:"passed"`
+		Detail   string `json:"detail,omitempty"`
+	}
+)
+
+// ParsePolicy decodes and validates the JSON policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	p := &Policy{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(p)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPolicyWrong, err)
+	}
+	err = p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Validate checks the name, selector and all rules of policy.
+func (p *Policy) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: empty name", ErrPolicyWrong)
+	}
+	if len(p.Selector) > 0 {
+		var sel map[string]interface{}
+		if json.Unmarshal(p.Selector, &sel) != nil {
+			return fmt.Errorf("%w: selector is not a json object", ErrPolicyWrong)
+		}
+	}
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("%w: no rule", ErrPolicyWrong)
+	}
+	names := map[string]bool{}
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if r.Name == "" || names[r.Name] {
+			return fmt.Errorf("%w: empty or duplicated rule name %q", ErrPolicyWrong, r.Name)
+		}
+		names[r.Name] = true
+		err := r.validate()
+		if err != nil {
+			return fmt.Errorf("%w: rule %s %v", ErrPolicyWrong, r.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *PolicyRule) validate() error {
+	if !ruleTypes[r.Type] {
+		return fmt.Errorf("unknown type %q", r.Type)
+	}
+	if !severities[r.Severity] {
+		return fmt.Errorf("unknown severity %q", r.Severity)
+	}
+	for _, n := range r.Pcrs {
+		if n < 0 || n >= PcrMaxNum {
+			return fmt.Errorf("wrong pcr %d", n)
+		}
+	}
+	for _, p := range r.Paths {
+		if _, err := path.Match(p, ""); p == "" || err != nil {
+			return fmt.Errorf("wrong path %q", p)
+		}
+	}
+	switch r.Type {
+	case RuleImaUnknown:
+		if len(r.Paths) == 0 {
+			return fmt.Errorf("no paths")
+		}
+	case RuleClientInfo:
+		if r.Field == "" {
+			return fmt.Errorf("no field")
+		}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("wrong pattern, %v", err)
+		}
+	}
+	return nil
+}
+
+// NewPolicyVerdict returns the passed verdict without any rule result.
+func NewPolicyVerdict() *PolicyVerdict {
+	return &PolicyVerdict{Result: VerdictPass, Rules: []PolicyRuleResult{}}
+}
+
+// AddResult records the result of rule, err is nil if the rule passes,
+// and updates the verdict result by the rule severity.
+func (v *PolicyVerdict) AddResult(policy string, version int, r *PolicyRule, err error) {
+	res := PolicyRuleResult{Policy: policy, Version: version, Rule: r.Name,
+		Type: r.Type, Severity: r.Severity, Passed: err == nil}
+	if err != nil {
+		res.Detail = err.Error()
+		switch {
+		case r.Severity == SeverityFail:
+			v.Result = VerdictFail
+		case r.Severity == SeverityWarn && v.Result == VerdictPass:
+			v.Result = VerdictWarn
+		}
+	}
+	v.Rules = append(v.Rules, res)
+}
+
+// FailedRules returns the names of failed rules of fail severity.
+func (v *PolicyVerdict) FailedRules() []string {
+	var names []string
+	for _, r := range v.Rules {
+		if !r.Passed && r.Severity == SeverityFail {
+			names = append(names, r.Policy+"/"+r.Rule)
+		}
+	}
+	return names
+}
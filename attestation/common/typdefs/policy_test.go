@@ -0,0 +1,64 @@
+package typdefs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePolicy(t *testing.T) {
+	testCases := []struct {
+		input  string
+		result bool
+	}{
+		{`{"name":"p","rules":[{"name":"r","type":"bios","severity":"fail"}]}`, true},
+		{`{"name":"p","selector":{"os":{"type":"openEuler"}},"enabled":true,"rules":[` +
+			`{"name":"r1","type":"pcr","severity":"fail","pcrs":[0,2,4,7]},` +
+			`{"name":"r2","type":"imaunknown","severity":"warn","paths":["/tmp","/var/*/cache"]},` +
+			`{"name":"r3","type":"clientinfo","severity":"info","field":"os.type","pattern":"^openEuler$"}]}`, true},
+		{`{"name":"","rules":[{"name":"r","type":"bios","severity":"fail"}]}`, false},
+		{`{"name":"p","rules":[]}`, false},
+		{`{"name":"p","selector":[1],"rules":[{"name":"r","type":"bios","severity":"fail"}]}`, false},
+		{`{"name":"p","unknown":1,"rules":[{"name":"r","type":"bios","severity":"fail"}]}`, false},
+		{`{"name":"p","rules":[{"name":"r","type":"bios","severity":"fail"},` +
+			`{"name":"r","type":"ima","severity":"fail"}]}`, false},
+		{`{"name":"p","rules":[{"name":"r","type":"tpm","severity":"fail"}]}`, false},
+		{`{"name":"p","rules":[{"name":"r","type":"bios","severity":"error"}]}`, false},
+		{`{"name":"p","rules":[{"name":"r","type":"pcr","severity":"fail","pcrs":[24]}]}`, false},
+		{`{"name":"p","rules":[{"name":"r","type":"imaunknown","severity":"fail"}]}`, false},
+		{`{"name":"p","rules":[{"name":"r","type":"imaunknown","severity":"fail","paths":["["]}]}`, false},
+		{`{"name":"p","rules":[{"name":"r","type":"clientinfo","severity":"fail","pattern":"a"}]}`, false},
+		{`{"name":"p","rules":[{"name":"r","type":"clientinfo","severity":"fail","field":"os","pattern":"("}]}`, false},
+	}
+	for i, tc := range testCases {
+		_, err := ParsePolicy([]byte(tc.input))
+		if (err == nil) != tc.result || (err != nil && !errors.Is(err, ErrPolicyWrong)) {
+			t.Errorf("test ParsePolicy error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func TestPolicyVerdict(t *testing.T) {
+	errFail := errors.New("fail")
+	testCases := []struct {
+		severities []string
+		errs       []error
+		result     string
+		failed     int
+	}{
+		{nil, nil, VerdictPass, 0},
+		{[]string{SeverityFail, SeverityWarn}, []error{nil, nil}, VerdictPass, 0},
+		{[]string{SeverityInfo, SeverityWarn}, []error{errFail, nil}, VerdictPass, 0},
+		{[]string{SeverityInfo, SeverityWarn}, []error{errFail, errFail}, VerdictWarn, 0},
+		{[]string{SeverityFail, SeverityWarn}, []error{errFail, errFail}, VerdictFail, 1},
+		{[]string{SeverityWarn, SeverityFail, SeverityFail}, []error{errFail, errFail, nil}, VerdictFail, 1},
+	}
+	for i, tc := range testCases {
+		v := NewPolicyVerdict()
+		for j, s := range tc.severities {
+			v.AddResult("p", 1, &PolicyRule{Name: "r", Type: RuleBios, Severity: s}, tc.errs[j])
+		}
+		if v.Result != tc.result || len(v.FailedRules()) != tc.failed || len(v.Rules) != len(tc.severities) {
+			t.Errorf("test PolicyVerdict error at case %d, %+v\n", i, v)
+		}
+	}
+}
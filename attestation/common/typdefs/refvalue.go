@@ -0,0 +1,378 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the typed and versioned reference values of base value, and
+	the converter from the legacy text base value.
+*/
+
+package typdefs
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// RefValueVersion is the current version of reference values format.
+	RefValueVersion = 1
+	// RefMatchExact matches the entry name exactly, it is the default.
+	RefMatchExact = "exact"
+	// RefMatchGlob matches the entry name as a shell pattern, like
+	// "/usr/lib64/*.so" or "4:EV_EFI_BOOT_SERVICES_APPLICATION:*".
+	RefMatchGlob = "glob"
+
+	legacyItemNum = 3
+)
+
+var (
+	// ErrRefValueWrong means the reference values don't follow the format.
+	ErrRefValueWrong = errors.New("reference value format wrong")
+)
+
+type (
+	// RefValues is the JSON form of the reference values in a base value:
+	//
+	//	{
+	//	  "version": 1,
+	//	  "pcr":  [{"name": "0", "alg": "sha256", "digests": ["3d45..."]}],
+	//	  "bios": [{"name": "0:EV_S_CRTM_VERSION", "alg": "sha256", "digests": ["c42f..."]}],
+	//	  "ima":  [{"name": "/usr/bin/*", "alg": "sha256", "digests": ["ba78...", "e3b0..."],
+	//	            "match": "glob", "optional": true, "template": "ima-ng"}],
+	//	  "rootfs": [{"name": "/", "alg": "sha256", "digests": ["9f86..."]}],
+	//	  "firmware": [{"name": "bmc", "alg": "sha256", "digests": ["60e2..."]}]
+	//	}
+	//
+	// The rootfs entries are only used by container base values, and the
+	// firmware entries are only used by device base values.
+	RefValues struct {
+		Version  int        `json:"version"`
+		Pcr      []RefValue `json:"pcr,omitempty"`
+		Bios     []RefValue `json:"bios,omitempty"`
+		Ima      []RefValue `json:"ima,omitempty"`
+		Rootfs   []RefValue `json:"rootfs,omitempty"`
+		Firmware []RefValue `json:"firmware,omitempty"`
+	}
+
+	// RefValue is one reference value entry.
+	RefValue struct {
+		// Name is the pcr index, the bios event id (or the former
+		// "<type hex>-<index>" name), the ima file path, the container
+		// directory whose files are hashed or the device firmware name.
+		Name string `json:"name"`
+		// Alg is the hash algorithm of all digests, sha1, sha256 or sm3.
+		Alg string `json:"alg"`
+		// Digests are the accepted digests, the measured one must be
+		// any of them.
+		Digests []HexBytes `json:"digests"`
+		// Optional allows that nothing in the log matches the name,
+		// otherwise at least one must match.
+		Optional bool `json:"optional,omitempty"`
+		// Match is how to match the name, RefMatchExact or RefMatchGlob.
+		Match string `json:"match,omitempty"`
+		// Template is the ima template which measures the file, only for
+		// information.
+		Template string `json:"template,omitempty"`
+	}
+)
+
+// ParseRefValues decodes and validates the JSON reference values.
+func ParseRefValues(data []byte) (*RefValues, error) {
+	rv := &RefValues{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(rv)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRefValueWrong, err)
+	}
+	err = rv.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+// Validate checks the version and all entries of reference values.
+func (rv *RefValues) Validate() error {
+	if rv.Version != RefValueVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrRefValueWrong, rv.Version)
+	}
+	for i := range rv.Pcr {
+		n, err := strconv.Atoi(rv.Pcr[i].Name)
+		if err != nil || n < 0 || n >= PcrMaxNum || rv.Pcr[i].Match == RefMatchGlob {
+			return fmt.Errorf("%w: pcr entry %d has wrong name %q", ErrRefValueWrong, i, rv.Pcr[i].Name)
+		}
+		if err = rv.Pcr[i].validate(); err != nil {
+			return fmt.Errorf("%w: pcr entry %d %v", ErrRefValueWrong, i, err)
+		}
+	}
+	for i := range rv.Bios {
+		if err := rv.Bios[i].validate(); err != nil {
+			return fmt.Errorf("%w: bios entry %d %v", ErrRefValueWrong, i, err)
+		}
+	}
+	for i := range rv.Ima {
+		if err := rv.Ima[i].validate(); err != nil {
+			return fmt.Errorf("%w: ima entry %d %v", ErrRefValueWrong, i, err)
+		}
+	}
+	for i := range rv.Rootfs {
+		if err := rv.Rootfs[i].validate(); err != nil {
+			return fmt.Errorf("%w: rootfs entry %d %v", ErrRefValueWrong, i, err)
+		}
+	}
+	for i := range rv.Firmware {
+		if err := rv.Firmware[i].validate(); err != nil {
+			return fmt.Errorf("%w: firmware entry %d %v", ErrRefValueWrong, i, err)
+		}
+	}
+	return nil
+}
+
+func (e *RefValue) validate() error {
+	if e.Name == "" {
+		return errors.New("has empty name")
+	}
+	switch e.Match {
+	case "", RefMatchExact:
+	case RefMatchGlob:
+		if _, err := path.Match(e.Name, ""); err != nil {
+			return fmt.Errorf("has wrong pattern %q", e.Name)
+		}
+	default:
+		return fmt.Errorf("has wrong match %q", e.Match)
+	}
+	n, ok := SupportAlgAndLenMap[e.Alg]
+	if !ok {
+		return fmt.Errorf("has wrong alg %q", e.Alg)
+	}
+	if len(e.Digests) == 0 {
+		return errors.New("has no digest")
+	}
+	for _, d := range e.Digests {
+		if len(d) != n {
+			return fmt.Errorf("has wrong %s digest %s", e.Alg, hex.EncodeToString(d))
+		}
+	}
+	return nil
+}
+
+// MatchName checks whether the name matches the entry. For the exact match
+// of bios entries, use BiosEvent.MatchName which also accepts the former
+// event name.
+func (e *RefValue) MatchName(name string) bool {
+	if e.Match == RefMatchGlob {
+		ok, _ := path.Match(e.Name, name)
+		return ok
+	}
+	return e.Name == name
+}
+
+// MatchDigest checks whether the digest d of algorithm alg is accepted.
+func (e *RefValue) MatchDigest(alg string, d []byte) bool {
+	if alg != e.Alg {
+		return false
+	}
+	for _, v := range e.Digests {
+		if bytes.Equal(v, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertLegacyRefValues converts the legacy text pcr, bios and ima base
+// values into reference values. The legacy pcr line is "index:value", its
+// algorithm is pcrAlg if the length fits, otherwise it is guessed by the
+// length. The "value alg index" line of pcr log is also accepted. The ima
+// entries are optional because the legacy ima base value only checks the
+// files which are measured.
+func ConvertLegacyRefValues(pcr, bios, ima, pcrAlg string) (*RefValues, error) {
+	rv := &RefValues{Version: RefValueVersion}
+	var err error
+	if rv.Pcr, err = convertLegacyPcr(pcr, pcrAlg); err != nil {
+		return nil, err
+	}
+	if rv.Bios, err = convertLegacyBios(bios); err != nil {
+		return nil, err
+	}
+	if rv.Ima, err = convertLegacyIma(ima); err != nil {
+		return nil, err
+	}
+	return rv, rv.Validate()
+}
+
+func convertLegacyPcr(text, pcrAlg string) ([]RefValue, error) {
+	var refs []RefValue
+	for _, ln := range strings.Split(text, "\n") {
+		ln = strings.TrimSpace(ln)
+		if ln == "" {
+			continue
+		}
+		var idx, alg, value string
+		if words := strings.Fields(ln); len(words) == legacyItemNum {
+			value, alg, idx = words[0], words[1], words[2]
+		} else if i := strings.Index(ln, ":"); i > 0 {
+			idx, value = ln[:i], ln[i+1:]
+		} else {
+			return nil, fmt.Errorf("%w: pcr line %q", ErrRefValueWrong, ln)
+		}
+		d, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: pcr line %q", ErrRefValueWrong, ln)
+		}
+		n, err := strconv.Atoi(idx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: pcr line %q", ErrRefValueWrong, ln)
+		}
+		if alg == "" {
+			alg = guessAlg(d, pcrAlg)
+		}
+		refs = append(refs, RefValue{Name: strconv.Itoa(n), Alg: alg, Digests: []HexBytes{d}})
+	}
+	return refs, nil
+}
+
+// guessAlg returns alg if the digest length fits it, otherwise the first
+// algorithm of the same length, sha1 or sha256.
+func guessAlg(d []byte, alg string) string {
+	if n, ok := SupportAlgAndLenMap[alg]; ok && n == len(d) {
+		return alg
+	}
+	if len(d) == Sha1DigestLen {
+		return Sha1AlgStr
+	}
+	return Sha256AlgStr
+}
+
+// convertLegacyBios converts the "name sha1 sha256:hash [sm3:hash]" lines,
+// each hash which isn't "N/A" becomes one entry.
+func convertLegacyBios(text string) ([]RefValue, error) {
+	var refs []RefValue
+	for _, ln := range strings.Split(text, "\n") {
+		words := strings.Fields(ln)
+		if len(words) == 0 {
+			continue
+		}
+		if len(words) < legacyItemNum {
+			return nil, fmt.Errorf("%w: bios line %q", ErrRefValueWrong, ln)
+		}
+		hashes := map[string]string{Sha1AlgStr: words[1]}
+		for _, w := range words[2:] {
+			if i := strings.Index(w, ":"); i > 0 {
+				hashes[w[:i]] = w[i+1:]
+			}
+		}
+		algs := make([]string, 0, len(hashes))
+		for alg, h := range hashes {
+			if h != naStr {
+				algs = append(algs, alg)
+			}
+		}
+		if len(algs) == 0 {
+			return nil, fmt.Errorf("%w: bios line %q has no hash", ErrRefValueWrong, ln)
+		}
+		sort.Strings(algs)
+		for _, alg := range algs {
+			d, err := hex.DecodeString(hashes[alg])
+			if err != nil {
+				return nil, fmt.Errorf("%w: bios line %q", ErrRefValueWrong, ln)
+			}
+			refs = append(refs, RefValue{Name: words[0], Alg: alg, Digests: []HexBytes{d}})
+		}
+	}
+	return refs, nil
+}
+
+// convertLegacyIma converts the "template hash name" lines, the hash is
+// "alg:hex" or sha1 hex of ima template, and the name may have spaces.
+func convertLegacyIma(text string) ([]RefValue, error) {
+	var refs []RefValue
+	for _, ln := range strings.Split(text, "\n") {
+		if strings.TrimSpace(ln) == "" {
+			continue
+		}
+		words := strings.SplitN(ln, " ", legacyItemNum)
+		if len(words) != legacyItemNum {
+			return nil, fmt.Errorf("%w: ima line %q", ErrRefValueWrong, ln)
+		}
+		alg, h := Sha1AlgStr, words[1]
+		if i := strings.Index(h, ":"); i > 0 {
+			alg, h = h[:i], h[i+1:]
+		}
+		d, err := hex.DecodeString(h)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ima line %q", ErrRefValueWrong, ln)
+		}
+		refs = append(refs, RefValue{Name: words[2], Alg: alg, Digests: []HexBytes{d},
+			Optional: true, Template: words[0]})
+	}
+	return refs, nil
+}
+
+// LegacyText returns the legacy text pcr, bios and ima base values of the
+// reference values, which are used as the extract templates. The glob
+// entries can't be expressed and are skipped, and only the first digest
+// of each entry is kept.
+func (rv *RefValues) LegacyText() (string, string, string) {
+	var pcr, bios, ima strings.Builder
+	for _, e := range rv.Pcr {
+		pcr.WriteString(fmt.Sprintf("%s:%s\n", e.Name, hex.EncodeToString(e.Digests[0])))
+	}
+	var names []string
+	hashes := map[string]map[string]string{}
+	for _, e := range rv.Bios {
+		if e.Match == RefMatchGlob {
+			continue
+		}
+		if hashes[e.Name] == nil {
+			hashes[e.Name] = map[string]string{}
+			names = append(names, e.Name)
+		}
+		hashes[e.Name][e.Alg] = hex.EncodeToString(e.Digests[0])
+	}
+	for _, n := range names {
+		h := hashes[n]
+		bios.WriteString(n + " " + legacyHash(h, Sha1AlgStr))
+		bios.WriteString(" " + Sha256AlgStr + ":" + legacyHash(h, Sha256AlgStr))
+		if _, ok := h[Sm3AlgStr]; ok {
+			bios.WriteString(" " + Sm3AlgStr + ":" + h[Sm3AlgStr])
+		}
+		bios.WriteString("\n")
+	}
+	for _, e := range rv.Ima {
+		if e.Match == RefMatchGlob {
+			continue
+		}
+		tmpl := e.Template
+		if tmpl == "" {
+			tmpl = StrImaNg
+		}
+		h := e.Alg + ":" + hex.EncodeToString(e.Digests[0])
+		if tmpl == StrIma {
+			h = hex.EncodeToString(e.Digests[0])
+		}
+		ima.WriteString(tmpl + " " + h + " " + e.Name + "\n")
+	}
+	return pcr.String(), bios.String(), ima.String()
+}
+
+func legacyHash(h map[string]string, alg string) string {
+	if v, ok := h[alg]; ok {
+		return v
+	}
+	return naStr
+}
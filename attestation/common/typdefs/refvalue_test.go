@@ -0,0 +1,113 @@
+package typdefs
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	testSha1Hex   = "5cfcc7bff38ffd1389cc36f97b72b2af37aeadd4"
+	testSha256Hex = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+)
+
+func TestParseRefValues(t *testing.T) {
+	testCases := []struct {
+		input  string
+		result bool
+	}{
+		{`{"version":1}`, true},
+		{`{"version":1,"pcr":[{"name":"7","alg":"sha256","digests":["` + testSha256Hex + `"]}]}`, true},
+		{`{"version":1,"ima":[{"name":"/usr/bin/*","alg":"sha256","match":"glob","optional":true,` +
+			`"digests":["` + testSha256Hex + `"]}]}`, true},
+		{`{"version":1,"rootfs":[{"name":"/","alg":"sha256","digests":["` + testSha256Hex + `"]}]}`, true},
+		{`{"version":1,"firmware":[{"name":"bmc","alg":"sha256","digests":["` + testSha256Hex + `"]}]}`, true},
+		{`{"version":2}`, false},
+		{`{}`, false},
+		{`{"version":1,"unknown":1}`, false},
+		{`{"version":1,"pcr":[{"name":"24","alg":"sha256","digests":["` + testSha256Hex + `"]}]}`, false},
+		{`{"version":1,"pcr":[{"name":"pcr0","alg":"sha256","digests":["` + testSha256Hex + `"]}]}`, false},
+		{`{"version":1,"bios":[{"name":"0:EV_S_CRTM_VERSION","alg":"sha1","digests":["` + testSha256Hex + `"]}]}`, false},
+		{`{"version":1,"bios":[{"name":"0:EV_S_CRTM_VERSION","alg":"md5","digests":["00"]}]}`, false},
+		{`{"version":1,"bios":[{"name":"0:EV_S_CRTM_VERSION","alg":"sha1","digests":[]}]}`, false},
+		{`{"version":1,"ima":[{"name":"","alg":"sha1","digests":["` + testSha1Hex + `"]}]}`, false},
+		{`{"version":1,"ima":[{"name":"[","alg":"sha1","match":"glob","digests":["` + testSha1Hex + `"]}]}`, false},
+		{`{"version":1,"ima":[{"name":"a","alg":"sha1","match":"regex","digests":["` + testSha1Hex + `"]}]}`, false},
+		{`{"version":1,"rootfs":[{"name":"/","alg":"sha1","digests":["` + testSha256Hex + `"]}]}`, false},
+		{`{"version":1,"firmware":[{"name":"","alg":"sha256","digests":["` + testSha256Hex + `"]}]}`, false},
+	}
+	for i, tc := range testCases {
+		_, err := ParseRefValues([]byte(tc.input))
+		if (err == nil) != tc.result || (err != nil && !errors.Is(err, ErrRefValueWrong)) {
+			t.Errorf("test ParseRefValues error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func TestRefValueMatch(t *testing.T) {
+	rv, err := ParseRefValues([]byte(`{"version":1,"ima":[{"name":"/usr/lib64/*.so","alg":"sha256",` +
+		`"match":"glob","digests":["` + testSha256Hex + `","` + strings.Repeat("00", Sha256DigestLen) + `"]}]}`))
+	if err != nil {
+		t.Fatalf("parse ref values error, %v", err)
+	}
+	e := &rv.Ima[0]
+	if !e.MatchName("/usr/lib64/libc.so") || e.MatchName("/usr/lib64/sub/libc.so") || e.MatchName("/usr/bin/ls") {
+		t.Errorf("test RefValue MatchName error")
+	}
+	if !e.MatchDigest(Sha256AlgStr, make([]byte, Sha256DigestLen)) ||
+		e.MatchDigest(Sm3AlgStr, make([]byte, Sha256DigestLen)) ||
+		e.MatchDigest(Sha256AlgStr, make([]byte, Sha1DigestLen)) {
+		t.Errorf("test RefValue MatchDigest error")
+	}
+}
+
+func TestConvertLegacyRefValues(t *testing.T) {
+	pcr := "0:" + testSha256Hex + "\n10:" + testSha1Hex + "\n" + testSha256Hex + " sm3 7\n"
+	bios := "0:EV_S_CRTM_VERSION " + testSha1Hex + " sha256:" + testSha256Hex + "\n" +
+		"80000008-1 N/A sha256:" + testSha256Hex + "\n"
+	ima := "ima " + testSha1Hex + " /usr/bin/ls\nima-ng sha256:" + testSha256Hex + " /usr/bin/a b\n"
+	rv, err := ConvertLegacyRefValues(pcr, bios, ima, Sm3AlgStr)
+	if err != nil {
+		t.Fatalf("test ConvertLegacyRefValues error, %v", err)
+	}
+	if len(rv.Pcr) != 3 || rv.Pcr[0].Alg != Sm3AlgStr || rv.Pcr[1].Alg != Sha1AlgStr ||
+		rv.Pcr[1].Name != "10" || rv.Pcr[2].Name != "7" || rv.Pcr[2].Alg != Sm3AlgStr {
+		t.Errorf("test ConvertLegacyRefValues pcr error, %+v", rv.Pcr)
+	}
+	if len(rv.Bios) != 3 || rv.Bios[0].Alg != Sha1AlgStr || rv.Bios[1].Alg != Sha256AlgStr ||
+		rv.Bios[2].Name != "80000008-1" || rv.Bios[2].Alg != Sha256AlgStr {
+		t.Errorf("test ConvertLegacyRefValues bios error, %+v", rv.Bios)
+	}
+	if len(rv.Ima) != 2 || rv.Ima[0].Alg != Sha1AlgStr || rv.Ima[1].Name != "/usr/bin/a b" ||
+		rv.Ima[1].Template != StrImaNg || !rv.Ima[1].Optional {
+		t.Errorf("test ConvertLegacyRefValues ima error, %+v", rv.Ima)
+	}
+
+	// the json form can be parsed again.
+	buf, _ := json.Marshal(rv)
+	if _, err = ParseRefValues(buf); err != nil {
+		t.Errorf("test ParseRefValues of converted error, %v", err)
+	}
+	// the legacy text of reference values is the same as input.
+	p, b, i := rv.LegacyText()
+	if !strings.HasPrefix(p, "0:"+testSha256Hex+"\n10:") || b != bios || i != ima {
+		t.Errorf("test LegacyText error, %q %q %q", p, b, i)
+	}
+
+	wrongs := [][]string{
+		{"0", "", ""},
+		{"x:" + testSha1Hex, "", ""},
+		{"0:zz", "", ""},
+		{"", "0:EV_S_CRTM_VERSION " + testSha1Hex, ""},
+		{"", "0:EV_S_CRTM_VERSION N/A sha256:N/A", ""},
+		{"", "0:EV_S_CRTM_VERSION 00 sha256:" + testSha256Hex, ""},
+		{"", "", "ima-ng /usr/bin/ls"},
+		{"", "", "ima-ng sha256:zz /usr/bin/ls"},
+	}
+	for n, w := range wrongs {
+		if _, err = ConvertLegacyRefValues(w[0], w[1], w[2], ""); !errors.Is(err, ErrRefValueWrong) {
+			t.Errorf("test ConvertLegacyRefValues wrong input error at case %d, %v\n", n, err)
+		}
+	}
+}
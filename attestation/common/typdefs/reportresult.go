@@ -0,0 +1,103 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the result of each check done when validating a trust report,
+	with the pcrs, bios events and ima files which don't match base value.
+*/
+
+package typdefs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// the checks of a trust report.
+	CheckQuote      = "quote"
+	CheckPcrLog     = "pcrlog"
+	CheckLogs       = "bioslog/imalog"
+	CheckBiosPcr    = "biospcr"
+	CheckSecureBoot = "secureboot"
+	CheckPolicy     = "policy"
+	CheckBaseValue  = "basevalue"
+	CheckContainer  = "container"
+	CheckDevice     = "device"
+
+	// the reasons of mismatch.
+	MismatchNotFound = "not found"
+	MismatchNotEqual = "not equal"
+	MismatchNoAlg    = "no the same type of hash"
+)
+
+type (
+	// ReportResult stores the result of one check of a trust report in
+	// database table `report_result`.
+	ReportResult struct {
+		Check string `json:"check"`
+		// BaseID is the id of base value used by the check, 0 if none.
+		BaseID     int64      `json:"baseid,omitempty"`
+		Passed     bool       `json:"passed"`
+		Detail     string     `json:"detail,omitempty"`
+		Mismatches []Mismatch `json:"mismatches,omitempty"`
+	}
+
+	// Mismatch is one pcr, bios event or ima file in report which doesn't
+	// match the reference values of base value.
+	Mismatch struct {
+		// Type is StrPcr, StrBios, StrIma, StrRootfs or StrFirmware.
+		Type string `json:"type"`
+		// Name is the pcr index, bios event id, ima file path, the
+		// container directory or the device firmware name.
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+		Alg    string `json:"alg,omitempty"`
+		// Expected are the accepted digests, Actual is the digest in
+		// report, both are empty if the name is not found.
+		Expected []HexBytes `json:"expected,omitempty"`
+		Actual   HexBytes   `json:"actual,omitempty"`
+	}
+)
+
+// String returns the mismatch as "type name [alg] reason".
+func (m *Mismatch) String() string {
+	if m.Alg == "" {
+		return fmt.Sprintf("%s %s %s", m.Type, m.Name, m.Reason)
+	}
+	return fmt.Sprintf("%s %s %s %s", m.Type, m.Name, m.Alg, m.Reason)
+}
+
+// MismatchError lists all mismatches of a report to the base value.
+type MismatchError struct {
+	Mismatches []Mismatch
+}
+
+func (e *MismatchError) Error() string {
+	parts := make([]string, len(e.Mismatches))
+	for i := range e.Mismatches {
+		parts[i] = e.Mismatches[i].String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// NewReportResult returns the result of check, the mismatches of err are
+// also recorded if it is a *MismatchError.
+func NewReportResult(check string, baseID int64, err error) ReportResult {
+	res := ReportResult{Check: check, BaseID: baseID, Passed: err == nil}
+	if err != nil {
+		res.Detail = err.Error()
+		var me *MismatchError
+		if errors.As(err, &me) {
+			res.Mismatches = me.Mismatches
+		}
+	}
+	return res
+}
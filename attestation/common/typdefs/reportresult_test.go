@@ -0,0 +1,33 @@
+package typdefs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNewReportResult(t *testing.T) {
+	misErr := &MismatchError{Mismatches: []Mismatch{
+		{Type: StrPcr, Name: "0", Reason: MismatchNotEqual, Alg: Sha256AlgStr},
+		{Type: StrIma, Name: "/usr/bin/vi", Reason: MismatchNotFound},
+	}}
+	testCases := []struct {
+		err        error
+		passed     bool
+		detail     string
+		mismatches int
+	}{
+		{nil, true, "", 0},
+		{errors.New("quote fail"), false, "quote fail", 0},
+		{misErr, false, "pcr 0 sha256 not equal; ima /usr/bin/vi not found", 2},
+		{fmt.Errorf("verify fail: %w", misErr), false,
+			"verify fail: pcr 0 sha256 not equal; ima /usr/bin/vi not found", 2},
+	}
+	for i, tc := range testCases {
+		res := NewReportResult(CheckBaseValue, 1, tc.err)
+		if res.Check != CheckBaseValue || res.BaseID != 1 || res.Passed != tc.passed ||
+			res.Detail != tc.detail || len(res.Mismatches) != tc.mismatches {
+			t.Errorf("test NewReportResult error at case %d, %+v\n", i, res)
+		}
+	}
+}
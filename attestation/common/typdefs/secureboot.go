@@ -0,0 +1,237 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: replay the secure boot state from pcr 7 events of bios log.
+*/
+
+package typdefs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+)
+
+// the uefi variables and signature types used by secure boot.
+const (
+	// SecureBootPcr is the pcr which secure boot policy is measured into.
+	SecureBootPcr = 7
+
+	EfiGlobalVariableGUID        = "8be4df61-93ca-11d2-aa0d-00e098032b8c"
+	EfiImageSecurityDatabaseGUID = "d719b2cb-3d3a-4596-a3bc-dad00e67656f"
+	EfiVarSecureBoot             = "SecureBoot"
+	EfiVarPK                     = "PK"
+	EfiVarKEK                    = "KEK"
+	EfiVarDb                     = "db"
+	EfiVarDbx                    = "dbx"
+	EfiSigTypeX509               = "x509"
+
+	efiCertSha1GUID       = "826ca512-cf10-4ac9-b187-be01496631bd"
+	efiCertSha256GUID     = "c1c41626-504c-4092-aca9-41f936934328"
+	efiCertSha384GUID     = "ff3e5307-9fd0-48c9-85f1-8ad56c701e01"
+	efiCertSha512GUID     = "093e0fae-a6c4-4f50-9f1b-d41e2b89c19a"
+	efiCertX509GUID       = "a5c059a1-94e4-4aa7-87b5-ab155c2bf072"
+	efiCertX509Sha256GUID = "3bd2a492-96c0-4079-b420-fcf98ef103ed"
+	efiCertRsa2048GUID    = "3c5766e8-269c-4e34-aa14-ed776e85b3b6"
+	efiSigListHeaderLen   = 28
+)
+
+var (
+	efiSigTypes = map[string]string{
+		efiCertSha1GUID:       Sha1AlgStr,
+		efiCertSha256GUID:     Sha256AlgStr,
+		efiCertSha384GUID:     "sha384",
+		efiCertSha512GUID:     "sha512",
+		efiCertX509GUID:       EfiSigTypeX509,
+		efiCertX509Sha256GUID: "x509-sha256",
+		efiCertRsa2048GUID:    "rsa2048",
+	}
+)
+
+type (
+	// EfiSignature is one EFI_SIGNATURE_DATA in signature database.
+	EfiSignature struct {
+		// Type is the signature type, like sha256, x509, or the type guid
+		// if unknown.
+		Type  string `json:"type"`
+		Owner string `json:"owner"`
+		// Digest is the hash of hash types, the sha256 fingerprint of x509
+		// certificate, or the raw data of other types.
+		Digest     HexBytes `json:"digest"`
+		Subject    string   `json:"subject,omitempty"`
+		CommonName string   `json:"commonName,omitempty"`
+		// Variable is the name of variable where the authority comes from,
+		// only used by the authorities.
+		Variable string `json:"variable,omitempty"`
+	}
+
+	// SecureBootState is the secure boot configuration replayed from the
+	// pcr 7 events of bios log.
+	SecureBootState struct {
+		Enabled bool           `json:"enabled"`
+		PK      []EfiSignature `json:"pk"`
+		KEK     []EfiSignature `json:"kek"`
+		Db      []EfiSignature `json:"db"`
+		Dbx     []EfiSignature `json:"dbx"`
+		// Authorities are the db entries used to authorize boot images.
+		Authorities []EfiSignature `json:"authorities"`
+		// Pcr7 is the replayed pcr 7 value of each hash algorithm.
+		Pcr7 map[string]HexBytes `json:"pcr7"`
+	}
+
+	// SecureBootPolicy corresponds to rasconfig.secureboot in config, the
+	// empty policy doesn't check anything.
+	SecureBootPolicy struct {
+		// Required requires secure boot is enabled.
+		Required bool `mapstructure:"required"`
+		// PKOwners/KEKOwners are the allowed owner guids, certificate
+		// subjects or common names of PK/KEK entries.
+		PKOwners  []string `mapstructure:"pkowners"`
+		KEKOwners []string `mapstructure:"kekowners"`
+		// DbxHashes are the hashes which must be revoked in dbx.
+		DbxHashes []string `mapstructure:"dbxhashes"`
+	}
+)
+
+// IsEmpty checks whether the policy has no requirements.
+func (p *SecureBootPolicy) IsEmpty() bool {
+	return p == nil || (!p.Required && len(p.PKOwners) == 0 &&
+		len(p.KEKOwners) == 0 && len(p.DbxHashes) == 0)
+}
+
+// ParseEfiSignatureList decodes the EFI_SIGNATURE_LIST array stored in
+// PK/KEK/db/dbx variables.
+func ParseEfiSignatureList(data []byte) ([]EfiSignature, error) {
+	var sigs []EfiSignature
+	for len(data) > 0 {
+		if len(data) < efiSigListHeaderLen {
+			return nil, ErrBiosLogFormatWrong
+		}
+		typ := EfiGUID(data)
+		listSize := binary.LittleEndian.Uint32(data[16:])
+		hdrSize := binary.LittleEndian.Uint32(data[20:])
+		sigSize := binary.LittleEndian.Uint32(data[24:])
+		if listSize < efiSigListHeaderLen || uint64(listSize) > uint64(len(data)) ||
+			sigSize <= guidLen || uint64(hdrSize) > uint64(listSize-efiSigListHeaderLen) ||
+			(listSize-efiSigListHeaderLen-hdrSize)%sigSize != 0 {
+			return nil, ErrBiosLogFormatWrong
+		}
+		list := data[efiSigListHeaderLen+hdrSize : listSize]
+		for ; len(list) > 0; list = list[sigSize:] {
+			sigs = append(sigs, newEfiSignature(typ, list[:sigSize]))
+		}
+		data = data[listSize:]
+	}
+	return sigs, nil
+}
+
+// newEfiSignature creates the signature of type from EFI_SIGNATURE_DATA.
+func newEfiSignature(typ string, data []byte) EfiSignature {
+	sig := EfiSignature{Type: typ, Owner: EfiGUID(data), Digest: data[guidLen:]}
+	if t, ok := efiSigTypes[typ]; ok {
+		sig.Type = t
+	}
+	if sig.Type == EfiSigTypeX509 {
+		sum := sha256.Sum256(data[guidLen:])
+		sig.Digest = sum[:]
+		if cert, err := x509.ParseCertificate(data[guidLen:]); err == nil {
+			sig.Subject = cert.Subject.String()
+			sig.CommonName = cert.Subject.CommonName
+		}
+	}
+	return sig
+}
+
+// ReplaySecureBoot replays the pcr 7 events and gets the secure boot state.
+// The digest of every variable event must be the hash of its data,
+// otherwise the variable content can't be trusted.
+func ReplaySecureBoot(events []*BiosEvent) (*SecureBootState, error) {
+	st := &SecureBootState{Pcr7: map[string]HexBytes{}}
+	for _, e := range events {
+		if e.Pcr != SecureBootPcr || e.Type == EvNoAction {
+			continue
+		}
+		for alg, d := range e.Digests {
+			h, err := GetHFromAlg(alg)
+			if err != nil {
+				continue
+			}
+			if e.Type == EvEFIVariableDriverConfig || e.Type == EvEFIVariableAuthority {
+				h.Write(e.Data)
+				if !bytes.Equal(h.Sum(nil), d) {
+					return nil, ErrBiosLogFormatWrong
+				}
+				h.Reset()
+			}
+			if st.Pcr7[alg] == nil {
+				st.Pcr7[alg] = make([]byte, h.Size())
+			}
+			h.Write(st.Pcr7[alg])
+			h.Write(d)
+			st.Pcr7[alg] = h.Sum(nil)
+		}
+		v, ok := e.Details.(*EfiVariable)
+		if !ok {
+			continue
+		}
+		if err := st.addVariable(e.Type, v); err != nil {
+			return nil, err
+		}
+	}
+	return st, nil
+}
+
+// addVariable records the secure boot variable into state.
+func (st *SecureBootState) addVariable(typ uint32, v *EfiVariable) error {
+	if typ == EvEFIVariableAuthority {
+		if len(v.Data) <= guidLen {
+			return ErrBiosLogFormatWrong
+		}
+		sig := newEfiSignature(efiCertX509GUID, v.Data)
+		if sig.Subject == "" {
+			// not a certificate, the image hash in db authorizes it.
+			sig = EfiSignature{Owner: EfiGUID(v.Data), Digest: v.Data[guidLen:]}
+			for alg, n := range SupportAlgAndLenMap {
+				if n == len(sig.Digest) && alg != Sm3AlgStr {
+					sig.Type = alg
+				}
+			}
+		}
+		sig.Variable = v.Name
+		st.Authorities = append(st.Authorities, sig)
+		return nil
+	}
+	if typ != EvEFIVariableDriverConfig {
+		return nil
+	}
+	var list *[]EfiSignature
+	switch {
+	case v.GUID == EfiGlobalVariableGUID && v.Name == EfiVarSecureBoot:
+		st.Enabled = len(v.Data) == 1 && v.Data[0] == 1
+		return nil
+	case v.GUID == EfiGlobalVariableGUID && v.Name == EfiVarPK:
+		list = &st.PK
+	case v.GUID == EfiGlobalVariableGUID && v.Name == EfiVarKEK:
+		list = &st.KEK
+	case v.GUID == EfiImageSecurityDatabaseGUID && v.Name == EfiVarDb:
+		list = &st.Db
+	case v.GUID == EfiImageSecurityDatabaseGUID && v.Name == EfiVarDbx:
+		list = &st.Dbx
+	default:
+		return nil
+	}
+	sigs, err := ParseEfiSignatureList(v.Data)
+	if err != nil {
+		return err
+	}
+	*list = sigs
+	return nil
+}
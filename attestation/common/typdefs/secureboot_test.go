@@ -0,0 +1,120 @@
+package typdefs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+)
+
+const (
+	testSbOwner = "77fa9abd-0359-4d32-bd60-28f4e78f784b"
+)
+
+var (
+	testSbOwnerBytes = []byte{0xbd, 0x9a, 0xfa, 0x77, 0x59, 0x03, 0x32, 0x4d,
+		0xbd, 0x60, 0x28, 0xf4, 0xe7, 0x8f, 0x78, 0x4b}
+	testGlobalGUID = []byte{0x61, 0xdf, 0xe4, 0x8b, 0xca, 0x93, 0xd2, 0x11,
+		0xaa, 0x0d, 0x00, 0xe0, 0x98, 0x03, 0x2b, 0x8c}
+	testDbGUID = []byte{0xcb, 0xb2, 0x19, 0xd7, 0x3a, 0x3d, 0x96, 0x45,
+		0xa3, 0xbc, 0xda, 0xd0, 0x0e, 0x67, 0x65, 0x6f}
+	testX509GUID = []byte{0xa1, 0x59, 0xc0, 0xa5, 0xe4, 0x94, 0xa7, 0x4a,
+		0x87, 0xb5, 0xab, 0x15, 0x5c, 0x2b, 0xf0, 0x72}
+	testSha256GUID = []byte{0x26, 0x16, 0xc4, 0xc1, 0x4c, 0x50, 0x92, 0x40,
+		0xac, 0xa9, 0x41, 0xf9, 0x36, 0x93, 0x43, 0x28}
+)
+
+func createTestSbCert(t *testing.T, cn string) []byte {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate error, %v", err)
+	}
+	return der
+}
+
+// sigList makes an EFI_SIGNATURE_LIST of the same size signatures.
+func sigList(typ []byte, sigs ...[]byte) []byte {
+	size := guidLen + len(sigs[0])
+	hdr := make([]byte, efiSigListHeaderLen)
+	copy(hdr, typ)
+	binary.LittleEndian.PutUint32(hdr[16:], uint32(efiSigListHeaderLen+len(sigs)*size))
+	binary.LittleEndian.PutUint32(hdr[24:], uint32(size))
+	for _, s := range sigs {
+		hdr = append(hdr, testSbOwnerBytes...)
+		hdr = append(hdr, s...)
+	}
+	return hdr
+}
+
+// varEvent makes a pcr 7 variable event whose digest is the hash of data.
+func varEvent(typ uint32, guid []byte, name string, value []byte) *BiosEvent {
+	nm := ucs2(name)
+	data := make([]byte, efiVarHeaderLen)
+	copy(data, guid)
+	binary.LittleEndian.PutUint64(data[16:], uint64(len(nm)/2))
+	binary.LittleEndian.PutUint64(data[24:], uint64(len(value)))
+	data = append(append(data, nm...), value...)
+	sum := sha256.Sum256(data)
+	item := &BIOSManifestItem{Pcr: SecureBootPcr, BType: typ, Digest: DigestValues{Count: 1,
+		Item: []DigestItem{{AlgID: sha256AlgID, Item: hex.EncodeToString(sum[:])}}}}
+	return newBiosEvent(0, item, data)
+}
+
+func TestReplaySecureBoot(t *testing.T) {
+	pk := createTestSbCert(t, "Test PK")
+	db := createTestSbCert(t, "Test DB")
+	revoked := make([]byte, Sha256DigestLen)
+	revoked[0] = 0xaa
+	events := []*BiosEvent{
+		varEvent(EvEFIVariableDriverConfig, testGlobalGUID, EfiVarSecureBoot, []byte{1}),
+		varEvent(EvEFIVariableDriverConfig, testGlobalGUID, EfiVarPK, sigList(testX509GUID, pk)),
+		varEvent(EvEFIVariableDriverConfig, testGlobalGUID, EfiVarKEK, nil),
+		varEvent(EvEFIVariableDriverConfig, testDbGUID, EfiVarDb, sigList(testX509GUID, db)),
+		varEvent(EvEFIVariableDriverConfig, testDbGUID, EfiVarDbx,
+			append(sigList(testSha256GUID, revoked, revoked), sigList(testSha256GUID, revoked)...)),
+		varEvent(EvEFIVariableAuthority, testDbGUID, EfiVarDb, append(append([]byte{}, testSbOwnerBytes...), db...)),
+	}
+	st, err := ReplaySecureBoot(events)
+	if err != nil {
+		t.Fatalf("test ReplaySecureBoot error, %v", err)
+	}
+	if !st.Enabled || len(st.PK) != 1 || st.PK[0].CommonName != "Test PK" || st.PK[0].Owner != testSbOwner ||
+		len(st.KEK) != 0 || len(st.Db) != 1 || len(st.Dbx) != 3 || st.Dbx[2].Type != Sha256AlgStr ||
+		hex.EncodeToString(st.Dbx[0].Digest) != hex.EncodeToString(revoked) {
+		t.Errorf("test ReplaySecureBoot state error, %+v", st)
+	}
+	if len(st.Authorities) != 1 || st.Authorities[0].CommonName != "Test DB" ||
+		st.Authorities[0].Variable != EfiVarDb {
+		t.Errorf("test ReplaySecureBoot authorities error, %+v", st.Authorities)
+	}
+	want := NewPcrGroups()
+	for _, e := range events {
+		want.ExtendSha256(SecureBootPcr, e.Digests[Sha256AlgStr])
+	}
+	if hex.EncodeToString(st.Pcr7[Sha256AlgStr]) != hex.EncodeToString(want.Pcr(SecureBootPcr, Sha256AlgStr)) {
+		t.Errorf("test ReplaySecureBoot pcr 7 error")
+	}
+
+	// the variable data doesn't match the digest.
+	events[1].Data[len(events[1].Data)-1] ^= 1
+	if _, err = ReplaySecureBoot(events); err != ErrBiosLogFormatWrong {
+		t.Errorf("test ReplaySecureBoot wrong digest error, %v", err)
+	}
+	if _, err = ParseEfiSignatureList(sigList(testSha256GUID, revoked)[:40]); err != ErrBiosLogFormatWrong {
+		t.Errorf("test ParseEfiSignatureList truncated error, %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the trust states of clients, containers and devices.
+	unknown -> pending -> trusted/untrusted, trusted -> expired, and any
+	known state -> offline -> pending when the client heart beats again.
+*/
+
+package typdefs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// TrustStateUnknown is the state of an entity which isn't attested
+	// yet, like a client after ras restarts.
+	TrustStateUnknown = "unknown"
+	// TrustStatePending means the client is alive and ras is waiting for
+	// its trust report.
+	TrustStatePending = "pending"
+	// TrustStateTrusted means the latest trust report is verified.
+	TrustStateTrusted = "trusted"
+	// TrustStateUntrusted means the latest trust report fails, the reason
+	// tells which check.
+	TrustStateUntrusted = "untrusted"
+	// TrustStateExpired means no trust report is received in the trust
+	// duration after the latest trusted one.
+	TrustStateExpired = "expired"
+	// TrustStateOffline means no heart beat or trust report is received
+	// in the online duration.
+	TrustStateOffline = "offline"
+
+	// the reason codes of trust state transitions.
+	TrustReasonRegistered     = "registered"         // the client is registered
+	TrustReasonHeartbeat      = "heartbeat"          // the client heart beats again
+	TrustReasonReportVerified = "report_verified"    // the trust report passes
+	TrustReasonReportInvalid  = "report_invalid"     // quote, pcr or logs check fails
+	TrustReasonSecureBoot     = "secure_boot_failed" // secure boot policy check fails
+	TrustReasonPolicy         = "policy_failed"      // a fail rule of policies fails
+	TrustReasonBaseMismatch   = "base_mismatch"      // host/container/device base value check fails
+	TrustReasonBaseNotFound   = "base_not_found"     // container/device has no base value
+	TrustReasonReportExpired  = "report_expired"     // the trust duration is passed
+	TrustReasonHeartbeatLost  = "heartbeat_lost"     // the online duration is passed
+)
+
+var (
+	// ErrTrustStateWrong means the trust state transition is not allowed.
+	ErrTrustStateWrong = errors.New("trust state transition is not allowed")
+
+	// the allowed trust state transitions, trusted and untrusted may also
+	// change to themselves with a new reason.
+	trustTransitions = map[string][]string{
+		TrustStateUnknown:   {TrustStatePending, TrustStateTrusted, TrustStateUntrusted, TrustStateOffline},
+		TrustStatePending:   {TrustStateTrusted, TrustStateUntrusted, TrustStateOffline},
+		TrustStateTrusted:   {TrustStateTrusted, TrustStateUntrusted, TrustStateExpired, TrustStateOffline},
+		TrustStateUntrusted: {TrustStateTrusted, TrustStateUntrusted, TrustStateOffline},
+		TrustStateExpired:   {TrustStateTrusted, TrustStateUntrusted, TrustStateOffline},
+		TrustStateOffline:   {TrustStatePending, TrustStateTrusted, TrustStateUntrusted},
+	}
+)
+
+type (
+	// TrustStatus is the current trust state of a client, container or
+	// device and why it enters the state.
+	TrustStatus struct {
+		State      string    `json:"state"`
+		Reason     string    `json:"reason,omitempty"`
+		Detail     string    `json:"detail,omitempty"`
+		UpdateTime time.Time `json:"updatetime"`
+	}
+
+	// TrustHistoryRow stores a trust state transition in database table
+	// `trust_history`.
+	TrustHistoryRow struct {
+		ID         int64     `json:"id"`
+		ClientID   int64     `json:"clientid"`
+		EntityType string    `json:"entitytype"` // host, container or device
+		Uuid       string    `json:"uuid,omitempty"`
+		FromState  string    `json:"fromstate"`
+		ToState    string    `json:"tostate"`
+		Reason     string    `json:"reason"`
+		Detail     string    `json:"detail,omitempty"`
+		CreateTime time.Time `json:"createtime"`
+	}
+
+	// TrustInfo is the current trust state of an entity with its history
+	// in time order, it is returned by rest api.
+	TrustInfo struct {
+		EntityType string `json:"entitytype"`
+		Uuid       string `json:"uuid,omitempty"`
+		TrustStatus
+		History []TrustHistoryRow `json:"history"`
+	}
+)
+
+// NewTrustStatus returns the unknown trust status.
+func NewTrustStatus() TrustStatus {
+	return TrustStatus{State: TrustStateUnknown}
+}
+
+// CheckTrustTransition checks the trust state may change from one to
+// the other.
+func CheckTrustTransition(from, to string) error {
+	for _, s := range trustTransitions[from] {
+		if s == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: from %s to %s", ErrTrustStateWrong, from, to)
+}
+
+// Transit changes the trust status to state by reason at now, and returns
+// the transition which has no entity set. Nothing changes if the status
+// has the same state and reason already, a nil transition is returned.
+func (s *TrustStatus) Transit(state, reason, detail string, now time.Time) (*TrustHistoryRow, error) {
+	if s.State == state && s.Reason == reason {
+		return nil, nil
+	}
+	err := CheckTrustTransition(s.State, state)
+	if err != nil {
+		return nil, err
+	}
+	h := &TrustHistoryRow{
+		FromState:  s.State,
+		ToState:    state,
+		Reason:     reason,
+		Detail:     detail,
+		CreateTime: now,
+	}
+	s.State, s.Reason, s.Detail, s.UpdateTime = state, reason, detail, now
+	return h, nil
+}
@@ -0,0 +1,50 @@
+package typdefs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrustStateTransit(t *testing.T) {
+	now := time.Now()
+	s := NewTrustStatus()
+	testCases := []struct {
+		state   string
+		reason  string
+		changed bool
+		result  bool
+	}{
+		{TrustStateExpired, TrustReasonReportExpired, false, false},
+		{TrustStatePending, TrustReasonRegistered, true, true},
+		{TrustStatePending, TrustReasonRegistered, false, true},
+		{TrustStateExpired, TrustReasonReportExpired, false, false},
+		{TrustStateTrusted, TrustReasonReportVerified, true, true},
+		{TrustStateUntrusted, TrustReasonPolicy, true, true},
+		{TrustStateUntrusted, TrustReasonSecureBoot, true, true},
+		{TrustStateExpired, TrustReasonReportExpired, false, false},
+		{TrustStateTrusted, TrustReasonReportVerified, true, true},
+		{TrustStateExpired, TrustReasonReportExpired, true, true},
+		{TrustStatePending, TrustReasonHeartbeat, false, false},
+		{TrustStateOffline, TrustReasonHeartbeatLost, true, true},
+		{TrustStateExpired, TrustReasonReportExpired, false, false},
+		{TrustStatePending, TrustReasonHeartbeat, true, true},
+		{TrustStateUnknown, "", false, false},
+	}
+	for i, tc := range testCases {
+		from := s.State
+		h, err := s.Transit(tc.state, tc.reason, "detail", now)
+		if (err == nil) != tc.result || (err != nil && !errors.Is(err, ErrTrustStateWrong)) ||
+			(h != nil) != tc.changed {
+			t.Errorf("test Transit error at case %d, %v %v\n", i, h, err)
+			continue
+		}
+		if !tc.result && s.State != from {
+			t.Errorf("test Transit state changed by error at case %d, %v\n", i, s)
+		}
+		if tc.changed && (h.FromState != from || h.ToState != tc.state || h.Reason != tc.reason ||
+			s.State != tc.state || s.Reason != tc.reason || !s.UpdateTime.Equal(now)) {
+			t.Errorf("test Transit result error at case %d, %v %v\n", i, h, s)
+		}
+	}
+}
@@ -48,6 +48,8 @@ const (
 	StrBios         = "bios"
 	StrIma          = "ima"
 	StrImaNg        = "ima-ng"
+	StrRootfs       = "rootfs"
+	StrFirmware     = "firmware"
 	Sha1DigestLen   = 20
 	Sha256DigestLen = 32
 	SM3DigestLen    = 32
@@ -59,6 +61,20 @@ const (
 	DigestAlgStr    = "digestAlg"
 )
 
+// the ima verification modes of base value, the empty mode means hash.
+const (
+	// ImaModeHash trusts the files whose hash is in base value ima list.
+	ImaModeHash = "hash"
+	// ImaModeSignature trusts the files which have a valid signature by
+	// a certificate in ima keyring.
+	ImaModeSignature = "signature"
+	// ImaModeSignatureOrHash trusts the files which have a valid signature
+	// or whose hash is in base value ima list.
+	ImaModeSignatureOrHash = "signature-or-hash"
+	// ImaModeBoth needs the files pass both hash list and signature checks.
+	ImaModeBoth = "both"
+)
+
 // definitions for BIOS/IMA log parse used only in this package.
 const (
 	uint32Len         = 4
@@ -76,7 +92,6 @@ const (
 	algAndSizeStart   = 60
 	algIDLen          = 2
 	algDigestSizeLen  = 2
-	ImaLogItemNum     = 5
 	BiosLogItemNum    = 6
 	SM3BiosLogItemNum = 7
 	naStr             = "N/A"
@@ -93,6 +108,27 @@ type (
 		Quoted     []byte
 		Signature  []byte
 		Manifests  []Manifest
+		// quotes of the other pcr banks, the first one is in Quoted/Signature.
+		Quotes []Quote
+		// ImaOffset is the number of ima log entries acknowledged before,
+		// the ima manifest only has the entries after them if it isn't 0.
+		ImaOffset uint64
+		// Containers are the measurements of the running containers.
+		Containers []UuidManifest
+		// Devices are the firmware measurements of the devices.
+		Devices []UuidManifest
+	}
+
+	// Quote stores the quote of one pcr bank and its signature.
+	Quote struct {
+		Quoted    []byte
+		Signature []byte
+	}
+
+	// PcrSelection selects the pcrs of one pcr bank to be quoted.
+	PcrSelection struct {
+		HashAlg string // sha1/sha256/sm3
+		PCRs    []int
 	}
 
 	// Manifest stores the pcr/bios/ima log part of trust report.
@@ -101,6 +137,17 @@ type (
 		Value []byte // log file content
 	}
 
+	// UuidManifest stores the measurements of a container or device in
+	// trust report, which is verified by the base value of the same uuid.
+	// The ima manifest has the host ima log entries of the container, the
+	// rootfs manifest has the "alg:hex path" digest lines of its files.
+	// The firmware manifest of device has the "alg:hex name [version]"
+	// digest lines of its firmwares.
+	UuidManifest struct {
+		Uuid      string
+		Manifests []Manifest
+	}
+
 	// ClientRow stores one record of client basic information
 	// in database table `client`.
 	ClientRow struct {
@@ -124,14 +171,24 @@ type (
 		PcrLog     string // text format of pcr log
 		BiosLog    string // store the text format of bios log
 		ImaLog     string // original text format of ima log
+		SecureBoot string // json format of secure boot check result
+		Verdict    string // json format of policy verdict, see PolicyVerdict
+		// Results are the results of all checks, saved in table `report_result`.
+		Results []ReportResult
 	}
 
 	// BaseRow stores one record of the base information in database
 	// table `base`, which is specified by customer and will be used
 	// to verify trust report.
 	BaseRow struct {
-		ID         int64
-		ClientID   int64
+		ID       int64
+		ClientID int64 // 0 for the shared base value of a node group
+		// GroupID is the node group of a shared base value, 0 for the
+		// base value of a client.
+		GroupID int64
+		// WindowID is the maintenance window in which the base value is
+		// updated automatically, 0 for the others.
+		WindowID   int64
 		BaseType   string
 		Uuid       string
 		CreateTime time.Time
@@ -140,8 +197,49 @@ type (
 		Pcr        string
 		Bios       string
 		Ima        string
+		ImaMode    string
+		RefValue   string // json of typed reference values, see RefValues
 		Verified   bool
 		Trusted    bool
+		// State is the lifecycle state, see BaseStateDraft, only the
+		// active one is used, Enabled is true only for it.
+		State      string
+		Version    int // increases for each new base value of the client
+		Author     string
+		UpdateTime time.Time // the time of last state change
+	}
+
+	// PolicyRow stores one version of a verification policy in database
+	// table `policy`, a new version is inserted for each change.
+	PolicyRow struct {
+		ID         int64
+		Name       string
+		Version    int
+		CreateTime time.Time
+		Enabled    bool
+		Selector   string // json of client info subset, see Policy
+		Rules      string // json of policy rules, see PolicyRule
+	}
+
+	// GroupRow stores a node group in database table `node_group`.
+	GroupRow struct {
+		ID         int64
+		Name       string
+		CreateTime time.Time
+		Priority   int
+		Selector   string // json of client info subset, see Group
+	}
+
+	// ImaStateRow stores the ima log replay state of a client in database
+	// table `ima_state`, the next report may only carry the ima log entries
+	// after the Count ones.
+	ImaStateRow struct {
+		ClientID   int64
+		UpdateTime time.Time
+		Count      uint64 // number of the replayed ima log entries
+		Alg        string // pcr bank of Pcr
+		Pcr        string // hex of pcr 10 after the Count entries are extended
+		ResetCount uint32 // tpm reset count of the quote which Pcr matches
 	}
 )
 
@@ -178,6 +276,7 @@ var (
 	ErrBiosLogFormatWrong = errors.New("bios log format wrong")
 	ErrBiosAggregateFail  = errors.New("bios aggregate not match")
 	ErrValidateIMAFail    = errors.New("validate ima log fail")
+	ErrImaTemplateWrong   = errors.New("ima template is not supported")
 
 	// client database handle errors
 	ErrParameterWrong    = errors.New("parameter is wrong")
@@ -186,9 +285,24 @@ var (
 	ErrDoesnotRegistered = errors.New("client does not registered")
 	ErrAlreadyRegistered = errors.New("client already registered")
 	ErrIKCertNull        = errors.New("client ik cert null")
+	ErrIKNameNull        = errors.New("client ik cert has no ik name")
 	ErrNonceNotMatch     = errors.New("report nonce not match")
 	ErrPCRNotMatch       = errors.New("report pcr not match")
+	ErrPcrSelectionWrong = errors.New("pcr selection format wrong")
+	ErrPcrBankNotQuoted  = errors.New("report doesn't quote the selected pcr bank")
 	ErrNotSupportAlg     = errors.New("algorithm is not supported")
+	ErrSecureBootFail    = errors.New("secure boot check fail")
+	ErrBiosPcrNotMatch   = errors.New("replayed bios log pcr not match")
+	ErrPolicyFail        = errors.New("report doesn't satisfy the policy")
+	ErrBaseValueFail     = errors.New("report doesn't match the host base value")
+	ErrImaStateMismatch  = errors.New("ima log doesn't match the replay state")
+
+	// trust report quote freshness errors
+	ErrQuoteMagicWrong         = errors.New("quote magic is not TPM_GENERATED_VALUE")
+	ErrQuoteTypeWrong          = errors.New("quote type is not TPM_ST_ATTEST_QUOTE")
+	ErrQuoteFormatWrong        = errors.New("quote attestation data format wrong")
+	ErrQuoteExtraDataNotMatch  = errors.New("quote extra data not match report input")
+	ErrQualifiedSignerNotMatch = errors.New("quote qualified signer not match ik")
 
 	SupportAlgAndLenMap = map[string]int{
 		Sha1AlgStr:   Sha1DigestLen,
@@ -226,6 +340,11 @@ type (
 		IPAddress	 string `json:"ipaddress" form:"ipaddress"`
 		Trusted      bool   `json:"trusted" form:"trusted"`
 		IsAutoUpdate bool   `json:"isautoupdate" form:"isautoupdate"`
+		PcrSelection string `json:"pcrselection,omitempty" form:"pcrselection"`
+
+		// the trust state of client and why it enters the state.
+		State  string `json:"state,omitempty" form:"state"`
+		Reason string `json:"reason,omitempty" form:"reason"`
 	}
 
 	ArrNodeInfo []NodeInfo
@@ -318,72 +437,32 @@ func (pcrs *PcrGroups) ExtendSM3(index int, value []byte) {
 	h.Reset()
 }
 
-func (pcrs *PcrGroups) ExtendIMALog(index int, value, name []byte, algStr string) {
-	if index < 0 || index >= PcrMaxNum {
-		return
-	}
-	var h hash.Hash
+// Extend extends value into the pcr index of algStr bank.
+func (pcrs *PcrGroups) Extend(index int, value []byte, algStr string) {
 	switch algStr {
 	case Sha1AlgStr:
-		h = pcrs.Sha1Hash[index]
+		pcrs.ExtendSha1(index, value)
 	case Sha256AlgStr:
-		h = pcrs.Sha256Hash[index]
+		pcrs.ExtendSha256(index, value)
 	case Sm3AlgStr:
-		h = pcrs.SM3Hash[index]
-	}
-	h.Write(value)
-	h.Write(name)
-	if len(name) < imaItemNameLenMax+1 {
-		h.Write(make([]byte, imaItemNameLenMax+1-len(name)))
+		pcrs.ExtendSM3(index, value)
 	}
-	switch algStr {
-	case Sha1AlgStr:
-		pcrs.Sha1Pcrs[index] = h.Sum(nil)
-	case Sha256AlgStr:
-		pcrs.Sha256Pcrs[index] = h.Sum(nil)
-	case Sm3AlgStr:
-		pcrs.SM3Pcrs[index] = h.Sum(nil)
-	}
-	h.Reset()
 }
 
-// ima-ng doesn't support sha1 alg
-func (pcrs *PcrGroups) ExtendIMANGLog(index int, value, name []byte, algStr string) {
+// Pcr returns the value of pcr index in algStr bank, nil if not exists.
+func (pcrs *PcrGroups) Pcr(index int, algStr string) []byte {
 	if index < 0 || index >= PcrMaxNum {
-		return
-	}
-	var h hash.Hash
-	switch algStr {
-	case Sha256AlgStr:
-		h = pcrs.Sha256Hash[index]
-	case Sm3AlgStr:
-		h = pcrs.SM3Hash[index]
-	}
-	i := bytes.Index(value, Colon)
-	b := bytes.Buffer{}
-	b.Write(value[:i+1]) // "sha256:"
-	b.WriteByte(0)
-	s := make([]byte, hex.DecodedLen(len(value[i+1:])))
-	_, err := hex.Decode(s, value[i+1:])
-	if err != nil {
-		return
+		return nil
 	}
-	b.Write(s) // binary hash
-	sLen := make([]byte, uint32Len)
-	binary.LittleEndian.PutUint32(sLen, uint32(b.Len()))
-	h.Write(sLen)
-	h.Write(b.Bytes())
-	binary.LittleEndian.PutUint32(sLen, uint32(len(name))+1)
-	h.Write(sLen)
-	h.Write(name)
-	h.Write([]byte{0})
 	switch algStr {
+	case Sha1AlgStr:
+		return pcrs.Sha1Pcrs[index]
 	case Sha256AlgStr:
-		pcrs.Sha256Pcrs[index] = h.Sum(nil)
+		return pcrs.Sha256Pcrs[index]
 	case Sm3AlgStr:
-		pcrs.SM3Pcrs[index] = h.Sum(nil)
+		return pcrs.SM3Pcrs[index]
 	}
-	h.Reset()
+	return nil
 }
 
 func GetHFromAlg(algStr string) (hash.Hash, error) {
@@ -757,102 +836,21 @@ func ExtendPCRWithBIOSTxtLog(pcrs *PcrGroups, biosTxtLog []byte) {
 	}
 }
 
-func parseIMALine(line []byte) (int, [][]byte, error) {
-	ln := bytes.Trim(line, string(Space))
-	if len(ln) == 0 {
-		return 0, nil, nil
-	}
-	words := bytes.Split(ln, Space)
-	if len(words) < ImaLogItemNum {
-		return 0, nil, ErrImaLogFormatWrong
-	}
-	if len(words) > ImaLogItemNum {
-		words[4] = bytes.Join(words[4:], Space)
-	}
-	index, err := strconv.Atoi(string(words[0])) // PcrIndex
+// ExtendPCRWithIMALog first verifies the bios aggregate, then verifies the
+// template hash of ima logs one by one and extends them into pcr.
+func ExtendPCRWithIMALog(pcrs *PcrGroups, imaLog []byte, algStr string) (bool, error) {
+	lines := bytes.SplitN(imaLog, NewLine, 2)
+	first, err := ParseImaEntry(lines[0])
 	if err != nil {
-		return 0, nil, err
-	}
-	if index < 0 || index >= PcrMaxNum {
-		return 0, nil, ErrPcrIndexWrong
-	}
-	return index, words, nil
-}
-
-func handleIMAWordsByTag(words [][]byte, t1 []byte, s1 []byte, pcrs *PcrGroups, algStr string, index int) error {
-	switch string(words[2]) {
-	case StrIma:
-		_, err := hex.Decode(s1, words[3]) // FiledataHash
-		if err != nil {
-			return ErrImaLogFormatWrong
-		}
-		pcrs.ExtendIMALog(index, s1, words[4], algStr)
-		if !bytes.Equal(t1, pcrs.Sha1Pcrs[index]) {
-			return ErrValidateIMAFail
-		}
-	case StrImaNg:
-		pcrs.ExtendIMANGLog(index, words[3], words[4], algStr)
-		if !bytes.Equal(t1, pcrs.Sha256Pcrs[index]) {
-			return ErrValidateIMAFail
-		}
-	}
-	return nil
-}
-
-func handleIMALine(pcrs *PcrGroups, line []byte, algStr string) (bool, error) {
-	var t1 []byte
-	var s1 []byte
-	if dLen, ok := SupportAlgAndLenMap[algStr]; ok {
-		t1 = make([]byte, dLen)
-		s1 = make([]byte, dLen)
-	} else {
-		return false, ErrNotSupportAlg
+		return false, &ImaLogError{Line: 1, Err: err}
 	}
-
-	index, words, err := parseIMALine(line)
+	err = CheckBootAggregate(pcrs, first)
 	if err != nil {
 		return false, err
 	}
-	if len(words) == ImaLogItemNum {
-		_, err = hex.Decode(t1, words[1]) // TemplateHash to verify
-		if err != nil {
-			return false, ErrImaLogFormatWrong
-		}
-		err = handleIMAWordsByTag(words, t1, s1, pcrs, algStr, index)
-	}
-	//我觉得这里可能写错了，等待老师反馈
-	return true, nil
-}
-
-// ExtendPCRWithIMALog first verifies the bios aggregate, then extends ima
-// logs into pcr and verifies them one by one.
-// TODO: needs to test sha1/sha256/ima/ima-ng all cases, now just test ima/sha1.
-func ExtendPCRWithIMALog(pcrs *PcrGroups, imaLog []byte, algStr string) (bool, error) {
-	var aggr string
-	switch algStr {
-	case Sha1AlgStr:
-		aggr = pcrs.AggregateSha1(0, 8)
-	case Sha256AlgStr:
-		aggr = pcrs.AggregateSha256(0, 8)
-	case Sm3AlgStr:
-		aggr = pcrs.AggregateSM3(0, 8)
-	default:
-		return false, ErrNotSupportAlg
-	}
-
-	lines := bytes.Split(imaLog, NewLine)
-	ws := bytes.Split(lines[0], Space)
-	if len(ws) != ImaLogItemNum {
-		return false, ErrImaLogFormatWrong
-	}
-	if !bytes.Equal(ws[3], []byte(aggr)) {
-		return false, ErrBiosAggregateFail
-	}
-	for _, line := range lines {
-		ret, err := handleIMALine(pcrs, line, algStr)
-		if !ret {
-			return ret, err
-		}
+	_, err = ReplayIMALog(pcrs, imaLog, algStr)
+	if err != nil {
+		return false, err
 	}
 	return true, nil
 }
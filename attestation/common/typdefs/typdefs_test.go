@@ -973,3 +973,50 @@ func TestPcrGroups(t *testing.T) {
 	}
 	fmt.Printf("sha256=%s\n", p.AggregateSha256(0, 8))
 }
+
+func TestParsePcrSelections(t *testing.T) {
+	testCases := []struct {
+		input  string
+		result string
+		err    error
+	}{
+		{"", "", nil},
+		{"sha256:0-23", "sha256:0-23", nil},
+		{"SHA256:7,0-3, 3 ;sm3:10", "sha256:0-3,7;sm3:10", nil},
+		{"sha1:0;sha256:0-1;sm3:23", "sha1:0;sha256:0-1;sm3:23", nil},
+		{"sha256", "", ErrPcrSelectionWrong},
+		{"md5:0", "", ErrPcrSelectionWrong},
+		{"sha256:0;sha256:1", "", ErrPcrSelectionWrong},
+		{"sha256:24", "", ErrPcrSelectionWrong},
+		{"sha256:7-3", "", ErrPcrSelectionWrong},
+		{"sha256:a", "", ErrPcrSelectionWrong},
+	}
+	for i, tc := range testCases {
+		sels, err := ParsePcrSelections(tc.input)
+		if err != tc.err {
+			t.Errorf("test ParsePcrSelections error at case %d, %v\n", i, err)
+			continue
+		}
+		if FormatPcrSelections(sels) != tc.result {
+			t.Errorf("test FormatPcrSelections error at case %d, %s\n", i, FormatPcrSelections(sels))
+		}
+	}
+}
+
+func TestPcrSelectionContains(t *testing.T) {
+	all := PcrSelection{HashAlg: Sha256AlgStr, PCRs: []int{0, 1, 2, 3, 7}}
+	testCases := []struct {
+		other  PcrSelection
+		result bool
+	}{
+		{PcrSelection{HashAlg: Sha256AlgStr, PCRs: []int{0, 7}}, true},
+		{PcrSelection{HashAlg: Sha256AlgStr, PCRs: []int{}}, true},
+		{PcrSelection{HashAlg: Sha256AlgStr, PCRs: []int{4}}, false},
+		{PcrSelection{HashAlg: Sm3AlgStr, PCRs: []int{0}}, false},
+	}
+	for i, tc := range testCases {
+		if all.Contains(&tc.other) != tc.result {
+			t.Errorf("test PcrSelection Contains error at case %d\n", i)
+		}
+	}
+}
@@ -1,12 +1,16 @@
 #/bin/sh
 
+# sqlite/memory database of ras is created automatically, nothing to clear
+if [ "${DBTYPE:-postgres}" != "postgres" ]; then
+	echo "skip clear ${DBTYPE} database"
+	exit 0
+fi
+
+# only drop the tables, ras creates them again when it starts
 sqldropfile=./dropTable.sql
-sqlcreate=$(cat ./createTable.sql)
 
 while read line
 do
 	echo $line
 	sudo su - postgres -c "psql -d kunpengsecl -U postgres -c '$line'"
 done < $sqldropfile
-
-sudo su - postgres -c "psql -d kunpengsecl -U postgres -c '$sqlcreate'"
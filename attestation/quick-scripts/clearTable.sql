@@ -1,3 +1,9 @@
-TRUNCATE TABLE client CASCADE;
+TRUNCATE TABLE report_result CASCADE;
+TRUNCATE TABLE trust_history CASCADE;
+TRUNCATE TABLE ima_state CASCADE;
+TRUNCATE TABLE policy CASCADE;
 TRUNCATE TABLE report CASCADE;
-TRUNCATE TABLE base CASCADE;
\ No newline at end of file
+TRUNCATE TABLE base CASCADE;
+TRUNCATE TABLE maint_window CASCADE;
+TRUNCATE TABLE node_group CASCADE;
+TRUNCATE TABLE client CASCADE;
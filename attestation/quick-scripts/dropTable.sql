@@ -1,3 +1,10 @@
-DROP TABLE client;
-DROP TABLE report;
-DROP TABLE base;
+DROP TABLE IF EXISTS report_result CASCADE;
+DROP TABLE IF EXISTS trust_history CASCADE;
+DROP TABLE IF EXISTS ima_state CASCADE;
+DROP TABLE IF EXISTS policy CASCADE;
+DROP TABLE IF EXISTS report CASCADE;
+DROP TABLE IF EXISTS base CASCADE;
+DROP TABLE IF EXISTS maint_window CASCADE;
+DROP TABLE IF EXISTS node_group CASCADE;
+DROP TABLE IF EXISTS client CASCADE;
+DROP TABLE IF EXISTS schema_version;
@@ -23,11 +23,10 @@ EOF
         ;;
 esac
 
-sql=$(cat ./createTable.sql)
+# the tables are created by ras when it starts, or by "ras migrate up"
 sudo su - postgres <<EOF
 psql -U postgres -c "alter user postgres with password 'postgres';";
 psql -U postgres -c "create database kunpengsecl owner postgres;";
 psql -U postgres -c "grant all privileges on database kunpengsecl to postgres;";
-psql -d kunpengsecl -U postgres -c "$sql";
 EOF
 
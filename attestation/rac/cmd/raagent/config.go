@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -45,6 +46,10 @@ const (
 	confEKeyCertTest    = "racconfig.ectestfile"
 	confIKeyCertTest    = "racconfig.ictestfile"
 	confDigestAlgorithm = "racconfig.digestalgorithm"
+	confPcrSelection    = "racconfig.pcrselection"
+	confContainerMode   = "racconfig.containermode"
+	confDeviceCollector = "racconfig.devicecollectors"
+	confIKAlgorithm     = "racconfig.ikalgorithm"
 	confSeed            = "racconfig.seed"
 	// raagent config default value
 	nullString         = ""
@@ -89,9 +94,15 @@ type (
 		trustDuration time.Duration // trust state duration
 		logPath       string
 		digest        string
+		pcrSelection  string // pcr banks to quote, empty for the digest bank
+		containerMode string // ima/rootfs separated by comma, empty for none
+		imaCount      uint64 // ima log entries acknowledged by ras, not saved
 		testMode      bool
 		eKeyCert      []byte
 		iKeyCert      []byte
+		// device firmware collectors like "file:./devices.json"
+		devices []string
+		ikAlg   string // ik key algorithm, rsa or ecc
 
 		// for TPM chip
 		password string
@@ -267,6 +278,10 @@ func loadConfigs() {
 	racCfg.clientId = viper.GetInt64(confClientID)
 	racCfg.password = viper.GetString(confPassword)
 	racCfg.digest = viper.GetString(confDigestAlgorithm)
+	racCfg.pcrSelection = viper.GetString(confPcrSelection)
+	racCfg.containerMode = viper.GetString(confContainerMode)
+	racCfg.devices = viper.GetStringSlice(confDeviceCollector)
+	racCfg.ikAlg = viper.GetString(confIKAlgorithm)
 	racCfg.seed = viper.GetInt64(confSeed)
 }
 
@@ -282,6 +297,10 @@ func saveConfigs() {
 	viper.Set(confHbDuration, racCfg.hbDuration)
 	viper.Set(confTrustDuration, racCfg.trustDuration)
 	viper.Set(confDigestAlgorithm, racCfg.digest)
+	viper.Set(confPcrSelection, racCfg.pcrSelection)
+	viper.Set(confContainerMode, racCfg.containerMode)
+	viper.Set(confDeviceCollector, racCfg.devices)
+	viper.Set(confIKAlgorithm, racCfg.ikAlg)
 	viper.Set(confSeed, racCfg.seed)
 	if racCfg.testMode {
 		viper.Set(confEKeyCertTest, racCfg.ecTestFile)
@@ -452,6 +471,90 @@ func SetDigestAlgorithm(algorithm string) {
 	racCfg.digest = algorithm
 }
 
+// GetPcrSelection returns the pcr selection configuration.
+func GetPcrSelection() string {
+	if racCfg == nil {
+		return ""
+	}
+	return racCfg.pcrSelection
+}
+
+// SetPcrSelection sets the pcr selection configuration.
+func SetPcrSelection(sel string) {
+	if racCfg == nil {
+		return
+	}
+	racCfg.pcrSelection = sel
+}
+
+// GetContainerModes returns the ways to measure the running containers,
+// none is measured if it is empty.
+func GetContainerModes() []string {
+	if racCfg == nil || racCfg.containerMode == "" {
+		return nil
+	}
+	return strings.Split(racCfg.containerMode, ",")
+}
+
+// SetContainerMode sets the ways to measure the running containers, they
+// are ima and rootfs separated by comma.
+func SetContainerMode(mode string) {
+	if racCfg == nil {
+		return
+	}
+	racCfg.containerMode = mode
+}
+
+// GetDeviceCollectors returns the specs of device firmware collectors,
+// each is "name:argument", see ractools.NewDeviceCollector.
+func GetDeviceCollectors() []string {
+	if racCfg == nil {
+		return nil
+	}
+	return racCfg.devices
+}
+
+// SetDeviceCollectors sets the specs of device firmware collectors.
+func SetDeviceCollectors(specs []string) {
+	if racCfg == nil {
+		return
+	}
+	racCfg.devices = specs
+}
+
+// GetIKAlgorithm returns the ik key algorithm configuration, rsa or ecc,
+// empty is rsa.
+func GetIKAlgorithm() string {
+	if racCfg == nil {
+		return ""
+	}
+	return racCfg.ikAlg
+}
+
+// SetIKAlgorithm sets the ik key algorithm configuration.
+func SetIKAlgorithm(alg string) {
+	if racCfg == nil {
+		return
+	}
+	racCfg.ikAlg = alg
+}
+
+// GetImaCount returns the number of ima log entries acknowledged by ras.
+func GetImaCount() uint64 {
+	if racCfg == nil {
+		return 0
+	}
+	return racCfg.imaCount
+}
+
+// SetImaCount sets the number of ima log entries acknowledged by ras.
+func SetImaCount(n uint64) {
+	if racCfg == nil {
+		return
+	}
+	racCfg.imaCount = n
+}
+
 // GetSeed returns the tpm-simulator seed configuration.
 func GetSeed() int64 {
 	return racCfg.seed
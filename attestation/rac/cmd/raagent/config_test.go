@@ -28,6 +28,7 @@ racconfig:
   clientId: -1
   password: ""
   digestalgorithm: sha256
+  ikalgorithm: ecc
   seed: 1
 `
 )
@@ -116,6 +117,13 @@ func TestConfigNotInTestMode(t *testing.T) {
 			t.Errorf("test Server error at case %d\n", i)
 		}
 	}
+	if GetIKAlgorithm() != "ecc" {
+		t.Errorf("test load IKAlgorithm error, %s\n", GetIKAlgorithm())
+	}
+	SetIKAlgorithm("rsa")
+	if GetIKAlgorithm() != "rsa" {
+		t.Errorf("test IKAlgorithm error\n")
+	}
 	saveConfigs()
 }
 func TestDurations(t *testing.T) {
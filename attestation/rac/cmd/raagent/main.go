@@ -19,9 +19,9 @@ Description: raagent main package entry.
 package main
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/x509"
-	"encoding/json"
 	"log"
 	"math"
 	"math/big"
@@ -100,10 +100,22 @@ func prepare() {
 	}
 
 	logger.L.Debug("load IK certificate...")
-	err = ractools.GenerateIKey()
+	ikParams, err := ractools.GetIKParams(GetIKAlgorithm())
+	if err != nil {
+		logger.L.Sugar().Errorf("get IK %s params failed, %s", GetIKAlgorithm(), err)
+		os.Exit(1)
+	}
+	err = ractools.GenerateIKeyWithParams(ikParams)
 	if err != nil {
 		logger.L.Sugar().Errorf("generate IK  failed, %s", err)
 	}
+	if !isIKeyCertOfIK() {
+		// the ik algorithm is changed, the client registers again with
+		// the new ik certificate.
+		logger.L.Debug("IK certificate doesn't match IK, generate it again")
+		SetIKeyCert(nil)
+		SetClientId(-1)
+	}
 	if GetIKeyCert() == nil {
 		generateIKeyCert(ras)
 	}
@@ -166,6 +178,8 @@ func createTPMConfig(testMode bool) *ractools.TPMConfig {
 		tpmConf.BIOSLogPath = ractools.BiosLogPath
 		tpmConf.ReportHashAlg = typdefs.Sha1AlgStr
 	}
+	tpmConf.ProcPath = ractools.ProcPath
+	tpmConf.ContainerModes = GetContainerModes()
 	return &tpmConf
 }
 
@@ -207,6 +221,22 @@ func LoadEKeyCert() error {
 	return nil
 }
 
+// isIKeyCertOfIK returns whether the saved IK certificate is empty or
+// certifies the current IK public key.
+func isIKeyCertOfIK() bool {
+	icDer := GetIKeyCert()
+	if len(icDer) == 0 || ractools.GetIKPub() == nil {
+		return true
+	}
+	cert, err := x509.ParseCertificate(icDer)
+	if err != nil {
+		return false
+	}
+	certPub, err1 := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	ikPub, err2 := x509.MarshalPKIXPublicKey(ractools.GetIKPub())
+	return err1 == nil && err2 == nil && bytes.Equal(certPub, ikPub)
+}
+
 // generateIKeyCert gets the IK public from tpm simulator and sends to PCA
 // to sign it, after that saves it into config.
 func generateIKeyCert(ras *clientapi.RasConn) {
@@ -262,6 +292,7 @@ func registerClientID(ras *clientapi.RasConn) int64 {
 	cc := bk.GetClientConfig()
 	SetClientId(cid)
 	SetDigestAlgorithm(cc.GetDigestAlgorithm())
+	SetPcrSelection(cc.GetPcrSelection())
 	SetHBDuration(time.Duration(cc.GetHbDurationSeconds() * int64(time.Second)))
 	SetTrustDuration(time.Duration(cc.GetTrustDurationSeconds() * int64(time.Second)))
 	return cid
@@ -285,39 +316,58 @@ func setNewConf(rpy *clientapi.SendHeartbeatReply) {
 	conf := rpy.GetClientConfig()
 	SetHBDuration(time.Duration(conf.GetHbDurationSeconds() * int64(time.Second)))
 	SetTrustDuration(time.Duration(conf.GetTrustDurationSeconds() * int64(time.Second)))
+	SetPcrSelection(conf.GetPcrSelection())
 	saveConfigs()
 }
 
-// sendTrustReport sneds a new trust report to RAS.
+// sendTrustReport sneds a new trust report to RAS, the ima log only has
+// the entries after the ones acknowledged by RAS, and the full ima log is
+// sent again if RAS asks for it because the incremental one doesn't match
+// its ima replay state.
 func sendTrustReport(ras *clientapi.RasConn, rpy *clientapi.SendHeartbeatReply) {
-	tRep, err := ractools.GetTrustReport(GetClientId(),
-		rpy.GetClientConfig().GetNonce(), GetDigestAlgorithm())
+	err := ractools.SetPcrSelection(GetPcrSelection())
 	if err != nil {
-		logger.L.Sugar().Errorf("prepare trust report failed, %v", err)
+		logger.L.Sugar().Errorf("set pcr selection failed, %v", err)
 		return
 	}
-	// handle clientInfo
-	ci := tRep.ClientInfo
-	ciMap := map[string]string{}
-	err = json.Unmarshal([]byte(ci), &ciMap)
-	if err != nil {
-		logger.L.Sugar().Errorf("unmarshal client info failed, %v", err)
-		return
-	}
-	ciMap[typdefs.DigestAlgStr] = GetDigestAlgorithm()
-	newCi, err := json.Marshal(ciMap)
-	if err != nil {
-		logger.L.Sugar().Errorf("marshal client info failed, %v", err)
-		return
+	imaCount := GetImaCount()
+	devices := collectDevices()
+	for {
+		tRep, err := ractools.GetTrustReportFrom(GetClientId(),
+			rpy.GetClientConfig().GetNonce(), GetDigestAlgorithm(), imaCount)
+		if err != nil {
+			logger.L.Sugar().Errorf("prepare trust report failed, %v", err)
+			return
+		}
+		tRep.Devices = devices
+		bk, err := doSendTrustReport(ras, tRep)
+		if err != nil {
+			logger.L.Sugar().Errorf("send trust report failed, %v", err)
+			return
+		}
+		SetImaCount(bk.GetImaCount())
+		if !bk.GetImaResend() || tRep.ImaOffset == 0 {
+			break
+		}
+		logger.L.Debug("resend trust report with full ima log")
+		imaCount = 0
 	}
-	tRep.ClientInfo = string(newCi)
+	logger.L.Debug("send trust report ok")
+}
 
+// doSendTrustReport converts the trust report to request and sends it.
+func doSendTrustReport(ras *clientapi.RasConn, tRep *typdefs.TrustReport) (*clientapi.SendReportReply, error) {
 	var manifests []*clientapi.Manifest
 	for _, m := range tRep.Manifests {
 		manifests = append(manifests,
 			&clientapi.Manifest{Key: m.Key, Value: m.Value})
 	}
-	clientapi.DoSendReportWithConn(ras,
+	var quotes []*clientapi.Quote
+	for _, q := range tRep.Quotes {
+		quotes = append(quotes,
+			&clientapi.Quote{Quoted: q.Quoted, Signature: q.Signature})
+	}
+	return clientapi.DoSendReportWithConn(ras,
 		&clientapi.SendReportRequest{
 			ClientId:   tRep.ClientID,
 			Nonce:      tRep.Nonce,
@@ -325,6 +375,42 @@ func sendTrustReport(ras *clientapi.RasConn, rpy *clientapi.SendHeartbeatReply)
 			Quoted:     tRep.Quoted,
 			Signature:  tRep.Signature,
 			Manifests:  manifests,
+			Quotes:     quotes,
+			ImaOffset:  tRep.ImaOffset,
+			Containers: toUuidManifests(tRep.Containers),
+			Devices:    toUuidManifests(tRep.Devices),
 		})
-	logger.L.Debug("send trust report ok")
+}
+
+// toUuidManifests converts the container/device manifests to request.
+func toUuidManifests(ms []typdefs.UuidManifest) []*clientapi.UuidManifest {
+	var res []*clientapi.UuidManifest
+	for _, um := range ms {
+		r := &clientapi.UuidManifest{Uuid: um.Uuid}
+		for _, m := range um.Manifests {
+			r.Manifests = append(r.Manifests,
+				&clientapi.Manifest{Key: m.Key, Value: m.Value})
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+// collectDevices collects the device firmware measurements by the device
+// collectors in config, the wrong or failed collectors are skipped.
+func collectDevices() []typdefs.UuidManifest {
+	var collectors []ractools.DeviceCollector
+	for _, spec := range GetDeviceCollectors() {
+		c, err := ractools.NewDeviceCollector(spec)
+		if err != nil {
+			logger.L.Sugar().Errorf("create device collector %s failed, %v", spec, err)
+			continue
+		}
+		collectors = append(collectors, c)
+	}
+	devices, err := ractools.CollectDevices(collectors, GetDigestAlgorithm())
+	if err != nil {
+		logger.L.Sugar().Errorf("collect device firmware failed, %v", err)
+	}
+	return devices
 }
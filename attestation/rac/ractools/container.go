@@ -0,0 +1,238 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: collect the measurements of the running containers, which
+	are found by the cgroups of processes in proc file system.
+*/
+
+package ractools
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+const (
+	// ProcPath is where the proc file system is mounted.
+	ProcPath = "/proc"
+	// ContainerModeIma attributes the host ima log entries to containers.
+	ContainerModeIma = "ima"
+	// ContainerModeRootfs hashes the whole root file system of containers.
+	ContainerModeRootfs = "rootfs"
+)
+
+var (
+	// the container id is the last 64 hex digits of cgroup path, like
+	// "/docker/<id>", "/system.slice/docker-<id>.scope",
+	// "/kubepods/.../cri-containerd-<id>.scope" or "/kubepods/.../<id>".
+	cgroupContainerID = regexp.MustCompile(`(?:^|[/-])([0-9a-f]{64})(?:\.scope)?$`)
+	// the virtual file systems skipped when hashing the rootfs.
+	rootfsSkipDirs = map[string]bool{"/proc": true, "/sys": true, "/dev": true}
+)
+
+// FindContainers returns the running containers found by the cgroups of
+// processes in procPath, which are the container ids mapped to the least
+// pid of their processes.
+func FindContainers(procPath string) (map[string]int, error) {
+	entries, err := ioutil.ReadDir(procPath)
+	if err != nil {
+		return nil, err
+	}
+	containers := map[string]int{}
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		id := readContainerID(filepath.Join(procPath, e.Name(), "cgroup"))
+		if id == "" {
+			continue
+		}
+		if old, ok := containers[id]; !ok || pid < old {
+			containers[id] = pid
+		}
+	}
+	return containers, nil
+}
+
+// readContainerID returns the container id in the cgroup file of a
+// process, empty if the process doesn't run in a container.
+func readContainerID(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		words := strings.SplitN(s.Text(), ":", 3)
+		if len(words) != 3 {
+			continue
+		}
+		if m := cgroupContainerID.FindStringSubmatch(words[2]); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// CollectContainers measures the running containers found in procPath by
+// the modes and returns their manifests sorted by container id. In ima
+// mode, the entries of imaLog are attributed to a container by the
+// heuristic of matchContainerImaByFile. In rootfs mode, the files of
+// container root are hashed by alg into one "alg:hex /" line, which is
+// asserted by the agent and not bound to the quote. A container whose
+// rootfs can't be read has no rootfs manifest.
+func CollectContainers(procPath string, imaLog []byte, modes []string, alg string) ([]typdefs.UuidManifest, error) {
+	if len(modes) == 0 {
+		return nil, nil
+	}
+	containers, err := FindContainers(procPath)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(containers))
+	for id := range containers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	res := make([]typdefs.UuidManifest, 0, len(ids))
+	for _, id := range ids {
+		root := filepath.Join(procPath, strconv.Itoa(containers[id]), "root")
+		m := typdefs.UuidManifest{Uuid: id}
+		for _, mode := range modes {
+			switch mode {
+			case ContainerModeIma:
+				m.Manifests = append(m.Manifests, typdefs.Manifest{Key: typdefs.StrIma,
+					Value: matchContainerImaByFile(root, imaLog)})
+			case ContainerModeRootfs:
+				d, err := hashRootfs(root, alg)
+				if err != nil {
+					continue
+				}
+				m.Manifests = append(m.Manifests, typdefs.Manifest{Key: typdefs.StrRootfs,
+					Value: []byte(fmt.Sprintf("%s:%s /\n", alg, hex.EncodeToString(d)))})
+			default:
+				return nil, fmt.Errorf("unknown container mode %s", mode)
+			}
+		}
+		res = append(res, m)
+	}
+	return res, nil
+}
+
+// matchContainerImaByFile returns the ima log entries whose files exist in
+// the container root with the same digest. It is a heuristic: the host ima
+// log records neither the cgroup nor the ima namespace of the measuring
+// process, so an entry is matched by the file content only. The entries of
+// files shared by the host and containers, or by containers of the same
+// image, are matched by all of them, and a container file changed after
+// it was measured is missed.
+func matchContainerImaByFile(root string, imaLog []byte) []byte {
+	var buf bytes.Buffer
+	for _, ln := range bytes.Split(imaLog, typdefs.NewLine) {
+		e, err := typdefs.ParseImaEntry(ln)
+		if err != nil || e.IsViolation() || e.Template == typdefs.StrImaBuf ||
+			e.FileName == typdefs.StrBootAggr {
+			continue
+		}
+		// the cleaned absolute name can't escape from the root.
+		d, err := hashFile(filepath.Join(root, filepath.Clean("/"+e.FileName)), e.FileHashAlg)
+		if err != nil || !bytes.Equal(d, e.FileHash) {
+			continue
+		}
+		buf.Write(ln)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// hashRootfs hashes the file tree of container root except the virtual
+// file systems. Each directory, regular file and symbolic link adds one
+// line "d path", "f hex path" or "l target path" in lexical order.
+func hashRootfs(root, alg string) ([]byte, error) {
+	h, err := typdefs.GetHFromAlg(alg)
+	if err != nil {
+		return nil, err
+	}
+	// the trailing separator follows the root symbolic link of proc.
+	err = filepath.Walk(root+string(filepath.Separator), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.Clean("/" + rel)
+		switch mode := info.Mode(); {
+		case mode.IsDir():
+			if rootfsSkipDirs[name] {
+				return filepath.SkipDir
+			}
+			fmt.Fprintf(h, "d %s\n", name)
+		case mode.IsRegular():
+			d, err := hashFile(path, alg)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "f %s %s\n", hex.EncodeToString(d), name)
+		case mode&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "l %s %s\n", target, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// hashFile returns the digest of regular file content by alg.
+func hashFile(path, alg string) ([]byte, error) {
+	h, err := typdefs.GetHFromAlg(alg)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, os.ErrInvalid
+	}
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,125 @@
+package ractools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+// createTestProc creates a proc file system of processes keyed by pid,
+// each has its cgroup content and root file system with files.
+func createTestProc(t *testing.T, procs map[string]string, roots map[string]map[string]string) string {
+	dir := t.TempDir()
+	proc := filepath.Join(dir, "proc")
+	for pid, cgroup := range procs {
+		err := os.MkdirAll(filepath.Join(proc, pid), 0755)
+		if err != nil {
+			t.Fatalf("create test proc error, %v", err)
+		}
+		err = ioutil.WriteFile(filepath.Join(proc, pid, "cgroup"), []byte(cgroup), 0644)
+		if err != nil {
+			t.Fatalf("create test cgroup error, %v", err)
+		}
+		root := filepath.Join(dir, "root"+pid)
+		for name, content := range roots[pid] {
+			path := filepath.Join(root, name)
+			if err = os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+				err = ioutil.WriteFile(path, []byte(content), 0644)
+			}
+			if err != nil {
+				t.Fatalf("create test root file error, %v", err)
+			}
+		}
+		os.MkdirAll(root, 0755)
+		err = os.Symlink(root, filepath.Join(proc, pid, "root"))
+		if err != nil {
+			t.Fatalf("create test root error, %v", err)
+		}
+	}
+	return proc
+}
+
+func TestCollectContainers(t *testing.T) {
+	id1 := strings.Repeat("1a", 32)
+	id2 := strings.Repeat("2b", 32)
+	proc := createTestProc(t, map[string]string{
+		"1":  "0::/init.scope\n",
+		"21": "0::/system.slice/docker-" + id1 + ".scope\n",
+		"20": "12:pids:/docker/" + id1 + "\n0::/\n",
+		"30": "0::/kubepods/besteffort/pod1/cri-containerd-" + id2 + ".scope\n",
+	}, map[string]map[string]string{
+		"1":  {"usr/bin/app": "host"},
+		"20": {"usr/bin/app": "app1", "proc/1/status": "skipped"},
+		"30": {"usr/bin/app": "app2", "etc/conf": "conf"},
+	})
+	os.MkdirAll(filepath.Join(proc, "self"), 0755)
+
+	containers, err := FindContainers(proc)
+	if err != nil || len(containers) != 2 || containers[id1] != 20 || containers[id2] != 30 {
+		t.Errorf("test FindContainers error, %v %v\n", containers, err)
+	}
+
+	imaLine := func(content string) string {
+		d := sha256.Sum256([]byte(content))
+		return fmt.Sprintf("10 %s ima-ng sha256:%s /usr/bin/app", strings.Repeat("1", 40),
+			hex.EncodeToString(d[:]))
+	}
+	imaLog := []byte(imaLine("host") + "\n" + imaLine("app1") + "\n" + imaLine("app2") + "\n")
+	testCases := []struct {
+		modes []string
+		num   int
+		err   bool
+	}{
+		{nil, 0, false},
+		{[]string{ContainerModeIma}, 2, false},
+		{[]string{ContainerModeIma, ContainerModeRootfs}, 2, false},
+		{[]string{"image"}, 0, true},
+	}
+	for i, tc := range testCases {
+		ms, err := CollectContainers(proc, imaLog, tc.modes, typdefs.Sha256AlgStr)
+		if (err != nil) != tc.err || len(ms) != tc.num {
+			t.Errorf("test CollectContainers error at case %d, %v %v\n", i, ms, err)
+			continue
+		}
+		for j, m := range ms {
+			if len(m.Manifests) != len(tc.modes) {
+				t.Errorf("test CollectContainers manifests error at case %d-%d, %v\n", i, j, m)
+			}
+		}
+	}
+
+	ms, _ := CollectContainers(proc, imaLog, []string{ContainerModeIma, ContainerModeRootfs}, typdefs.Sha256AlgStr)
+	if len(ms) != 2 || ms[0].Uuid != id1 || ms[1].Uuid != id2 {
+		t.Fatalf("test CollectContainers uuid error, %v\n", ms)
+	}
+	for i, content := range []string{"app1", "app2"} {
+		if ima := string(ms[i].Manifests[0].Value); ima != imaLine(content)+"\n" {
+			t.Errorf("test CollectContainers ima error at case %d, %q\n", i, ima)
+		}
+		rootfs := string(ms[i].Manifests[1].Value)
+		if !strings.HasPrefix(rootfs, typdefs.Sha256AlgStr+":") || !strings.HasSuffix(rootfs, " /\n") {
+			t.Errorf("test CollectContainers rootfs error at case %d, %q\n", i, rootfs)
+		}
+	}
+	if string(ms[0].Manifests[1].Value) == string(ms[1].Manifests[1].Value) {
+		t.Errorf("test CollectContainers rootfs of different containers error\n")
+	}
+
+	// the virtual file systems aren't hashed.
+	root := filepath.Join(proc, "20", "root")
+	d1, err1 := hashRootfs(root, typdefs.Sha256AlgStr)
+	ioutil.WriteFile(filepath.Join(root, "proc", "1", "status"), []byte("changed"), 0644)
+	d2, err2 := hashRootfs(root, typdefs.Sha256AlgStr)
+	ioutil.WriteFile(filepath.Join(root, "usr", "bin", "app"), []byte("changed"), 0644)
+	d3, err3 := hashRootfs(root, typdefs.Sha256AlgStr)
+	if err1 != nil || err2 != nil || err3 != nil || string(d1) != string(d2) || string(d1) == string(d3) {
+		t.Errorf("test hashRootfs error, %v %v %v\n", err1, err2, err3)
+	}
+}
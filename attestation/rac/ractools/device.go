@@ -0,0 +1,223 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: collect the firmware measurements of devices like NIC, BMC
+	and accelerators by the pluggable device collectors.
+*/
+
+package ractools
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+const (
+	// DeviceCollectorFile reads the device firmwares from a json file, it
+	// is mostly used as a fake device for test.
+	DeviceCollectorFile = "file"
+	// DeviceCollectorExec runs a vendor tool which prints the device
+	// firmwares as json.
+	DeviceCollectorExec = "exec"
+)
+
+var (
+	// ErrDeviceCollectorWrong means the device collector spec is wrong.
+	ErrDeviceCollectorWrong = errors.New("device collector spec wrong")
+	// ErrDeviceFirmwareWrong means the device firmware can't be measured.
+	ErrDeviceFirmwareWrong = errors.New("device firmware wrong")
+
+	deviceMu         sync.Mutex
+	deviceCollectors = map[string]DeviceCollectorFactory{
+		DeviceCollectorFile: newFileCollector,
+		DeviceCollectorExec: newExecCollector,
+	}
+)
+
+type (
+	// DeviceFirmware is one firmware of a device, the json form is like:
+	//
+	//	{"uuid": "3f1c...", "name": "nic", "version": "22.31.1014",
+	//	 "alg": "sha256", "digest": "60e2..."}
+	//
+	// The firmware image in path is hashed if the digest isn't given.
+	DeviceFirmware struct {
+		Uuid    string           `json:"uuid"`
+		Name    string           `json:"name"`
+		Version string           `json:"version,omitempty"`
+		Alg     string           `json:"alg,omitempty"`
+		Digest  typdefs.HexBytes `json:"digest,omitempty"`
+		Path    string           `json:"path,omitempty"`
+	}
+
+	// DeviceCollector collects the firmwares of devices.
+	DeviceCollector interface {
+		Collect() ([]DeviceFirmware, error)
+	}
+
+	// DeviceCollectorFactory creates a device collector by the argument
+	// of its spec.
+	DeviceCollectorFactory func(arg string) (DeviceCollector, error)
+
+	fileCollector struct {
+		path string
+	}
+
+	execCollector struct {
+		args []string
+	}
+)
+
+// RegisterDeviceCollector adds a kind of device collector, which is
+// created by the spec "name:argument".
+func RegisterDeviceCollector(name string, f DeviceCollectorFactory) {
+	deviceMu.Lock()
+	defer deviceMu.Unlock()
+	deviceCollectors[name] = f
+}
+
+// NewDeviceCollector creates the device collector by spec "name:argument",
+// like "file:/etc/attestation/devices.json" or "exec:/usr/bin/fwtool -j".
+func NewDeviceCollector(spec string) (DeviceCollector, error) {
+	words := strings.SplitN(spec, ":", 2)
+	if len(words) != 2 {
+		return nil, fmt.Errorf("%w: %s", ErrDeviceCollectorWrong, spec)
+	}
+	deviceMu.Lock()
+	f, ok := deviceCollectors[words[0]]
+	deviceMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown collector %s", ErrDeviceCollectorWrong, words[0])
+	}
+	return f(words[1])
+}
+
+func newFileCollector(arg string) (DeviceCollector, error) {
+	if arg == "" {
+		return nil, fmt.Errorf("%w: empty file", ErrDeviceCollectorWrong)
+	}
+	return &fileCollector{path: arg}, nil
+}
+
+// Collect reads the json array of device firmwares from file.
+func (c *fileCollector) Collect() ([]DeviceFirmware, error) {
+	data, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	return parseDeviceFirmwares(data)
+}
+
+func newExecCollector(arg string) (DeviceCollector, error) {
+	args := strings.Fields(arg)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: empty command", ErrDeviceCollectorWrong)
+	}
+	return &execCollector{args: args}, nil
+}
+
+// Collect runs the command and reads the json array of device firmwares
+// from its output.
+func (c *execCollector) Collect() ([]DeviceFirmware, error) {
+	var out bytes.Buffer
+	cmd := exec.Command(c.args[0], c.args[1:]...)
+	cmd.Stdout = &out
+	err := cmd.Run()
+	if err != nil {
+		return nil, err
+	}
+	return parseDeviceFirmwares(out.Bytes())
+}
+
+func parseDeviceFirmwares(data []byte) ([]DeviceFirmware, error) {
+	var fws []DeviceFirmware
+	err := json.Unmarshal(data, &fws)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDeviceFirmwareWrong, err)
+	}
+	return fws, nil
+}
+
+// CollectDevices collects the device firmwares by all collectors and
+// returns the firmware manifests of devices in the order they are found,
+// each firmware is a "alg:hex name [version]" line. The firmware image is
+// hashed by alg if the collector doesn't give the digest. The firmwares
+// of the failed collectors are skipped, and the first error is returned.
+func CollectDevices(collectors []DeviceCollector, alg string) ([]typdefs.UuidManifest, error) {
+	var firstErr error
+	var res []typdefs.UuidManifest
+	index := map[string]int{}
+	for _, c := range collectors {
+		fws, err := c.Collect()
+		if err == nil {
+			err = measureFirmwares(fws, alg)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, fw := range fws {
+			i, ok := index[fw.Uuid]
+			if !ok {
+				i = len(res)
+				index[fw.Uuid] = i
+				res = append(res, typdefs.UuidManifest{Uuid: fw.Uuid,
+					Manifests: []typdefs.Manifest{{Key: typdefs.StrFirmware}}})
+			}
+			ln := fmt.Sprintf("%s:%s %s", fw.Alg, hex.EncodeToString(fw.Digest), fw.Name)
+			if fw.Version != "" {
+				ln += " " + fw.Version
+			}
+			m := &res[i].Manifests[0]
+			m.Value = append(m.Value, ln+"\n"...)
+		}
+	}
+	return res, firstErr
+}
+
+// measureFirmwares checks the firmwares and hashes the firmware images
+// which have no digest by alg.
+func measureFirmwares(fws []DeviceFirmware, alg string) error {
+	for i := range fws {
+		fw := &fws[i]
+		if fw.Uuid == "" || fw.Name == "" || strings.ContainsAny(fw.Name+fw.Version, " \t\n") {
+			return fmt.Errorf("%w: %s of device %s has empty or spaced name", ErrDeviceFirmwareWrong,
+				fw.Name, fw.Uuid)
+		}
+		if len(fw.Digest) > 0 {
+			if n, ok := typdefs.SupportAlgAndLenMap[fw.Alg]; !ok || n != len(fw.Digest) {
+				return fmt.Errorf("%w: %s of device %s has wrong digest", ErrDeviceFirmwareWrong,
+					fw.Name, fw.Uuid)
+			}
+			continue
+		}
+		if fw.Path == "" {
+			return fmt.Errorf("%w: %s of device %s has no digest", ErrDeviceFirmwareWrong,
+				fw.Name, fw.Uuid)
+		}
+		d, err := hashFile(fw.Path, alg)
+		if err != nil {
+			return err
+		}
+		fw.Alg, fw.Digest = alg, d
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package ractools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+type testDeviceCollector struct {
+	fws []DeviceFirmware
+	err error
+}
+
+func (c *testDeviceCollector) Collect() ([]DeviceFirmware, error) {
+	return c.fws, c.err
+}
+
+func TestNewDeviceCollector(t *testing.T) {
+	RegisterDeviceCollector("test", func(arg string) (DeviceCollector, error) {
+		return &testDeviceCollector{}, nil
+	})
+	testCases := []struct {
+		spec   string
+		result bool
+	}{
+		{"file:./devices.json", true},
+		{"exec:/usr/bin/fwtool -j", true},
+		{"test:", true},
+		{"file:", false},
+		{"exec: ", false},
+		{"file", false},
+		{"sysfs:/sys", false},
+	}
+	for i, tc := range testCases {
+		_, err := NewDeviceCollector(tc.spec)
+		if (err == nil) != tc.result || (err != nil && !errors.Is(err, ErrDeviceCollectorWrong)) {
+			t.Errorf("test NewDeviceCollector error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func TestCollectDevices(t *testing.T) {
+	dir := t.TempDir()
+	image := filepath.Join(dir, "bmc.bin")
+	ioutil.WriteFile(image, []byte("bmc firmware"), 0644)
+	d := sha256.Sum256([]byte("bmc firmware"))
+	bmc := hex.EncodeToString(d[:])
+	nic := strings.Repeat("ab", 32)
+	file := filepath.Join(dir, "devices.json")
+	ioutil.WriteFile(file, []byte(`[{"uuid":"d1","name":"nic","version":"22.31","alg":"sha256","digest":"`+nic+`"},
+		{"uuid":"d2","name":"bmc","path":"`+image+`"},
+		{"uuid":"d1","name":"nic-rom","alg":"sha256","digest":"`+bmc+`"}]`), 0644)
+	fc, err := NewDeviceCollector("file:" + file)
+	if err != nil {
+		t.Fatalf("test NewDeviceCollector error, %v", err)
+	}
+	ec, err := NewDeviceCollector("exec:cat " + file)
+	if err != nil {
+		t.Fatalf("test NewDeviceCollector error, %v", err)
+	}
+	bad, _ := NewDeviceCollector("file:" + filepath.Join(dir, "none.json"))
+	testCases := []struct {
+		collectors []DeviceCollector
+		num        int
+		err        bool
+	}{
+		{nil, 0, false},
+		{[]DeviceCollector{fc}, 2, false},
+		{[]DeviceCollector{ec}, 2, false},
+		{[]DeviceCollector{bad, fc}, 2, true},
+		{[]DeviceCollector{&testDeviceCollector{fws: []DeviceFirmware{{Uuid: "d3", Name: "gpu"}}}}, 0, true},
+		{[]DeviceCollector{&testDeviceCollector{fws: []DeviceFirmware{{Uuid: "d3", Name: "gpu fw",
+			Alg: "sha1", Digest: make([]byte, typdefs.Sha1DigestLen)}}}}, 0, true},
+		{[]DeviceCollector{&testDeviceCollector{fws: []DeviceFirmware{{Uuid: "d3", Name: "gpu",
+			Alg: "sha256", Digest: make([]byte, typdefs.Sha1DigestLen)}}}}, 0, true},
+		{[]DeviceCollector{&testDeviceCollector{err: errors.New("tool error")}}, 0, true},
+	}
+	for i, tc := range testCases {
+		ms, err := CollectDevices(tc.collectors, typdefs.Sha256AlgStr)
+		if (err != nil) != tc.err || len(ms) != tc.num {
+			t.Errorf("test CollectDevices error at case %d, %v %v\n", i, ms, err)
+		}
+	}
+
+	ms, _ := CollectDevices([]DeviceCollector{fc}, typdefs.Sha256AlgStr)
+	results := []struct {
+		uuid  string
+		value string
+	}{
+		{"d1", "sha256:" + nic + " nic 22.31\nsha256:" + bmc + " nic-rom\n"},
+		{"d2", "sha256:" + bmc + " bmc\n"},
+	}
+	for i, r := range results {
+		if ms[i].Uuid != r.uuid || len(ms[i].Manifests) != 1 || ms[i].Manifests[0].Key != typdefs.StrFirmware ||
+			string(ms[i].Manifests[0].Value) != r.value {
+			t.Errorf("test CollectDevices manifest error at case %d, %v\n", i, ms[i])
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"os/exec"
 	"strings"
 
@@ -117,12 +118,17 @@ System Information
 	TestSeedPath    = "./simulator_seed"
 	ImaLogPath      = "/sys/kernel/security/ima/ascii_runtime_measurements"
 	BiosLogPath     = "/sys/kernel/security/tpm0/binary_bios_measurements"
+	osReleasePath   = "/etc/os-release"
 	AlgSM3          = 0x0012
 	algSHA1Str      = "sha1"
 	algSHA256Str    = "sha256"
 	algSHA384Str    = "sha384"
 	algSHA512Str    = "sha512"
 	algSM3Str       = "sm3"
+
+	// the ik key algorithms
+	IKAlgRSA = "rsa"
+	IKAlgECC = "ecc"
 )
 
 type (
@@ -132,6 +138,18 @@ type (
 		dev    io.ReadWriteCloser
 		ek     endorsementKey
 		ik     attestationKey
+		// pcr banks and pcrs to be quoted, nil for all pcrs of digest bank.
+		pcrSelections []tpm2.PCRSelection
+		// the positions of ima log entries read last time.
+		imaPos imaLogPos
+	}
+
+	// imaLogPos remembers the byte positions in ima log of the entries
+	// read last time, so that the acknowledged entries needn't be read
+	// again, starts[i] is the position of entry offset+i.
+	imaLogPos struct {
+		offset uint64
+		starts []int64
 	}
 
 	TPMConfig struct {
@@ -139,6 +157,10 @@ type (
 		BIOSLogPath   string
 		ReportHashAlg string
 		SeedPath      string
+		// the running containers in ProcPath are measured by each of
+		// ContainerModes, none is measured if it is empty.
+		ProcPath       string
+		ContainerModes []string
 	}
 
 	endorsementKey struct {
@@ -172,6 +194,8 @@ var (
 	ErrFailTPMInit         = errors.New("couldn't start tpm or init key/certificate")
 	ErrReadPCRFail         = errors.New("failed to read all PCRs")
 	ErrNotSupportedHashAlg = errors.New("the set hash algorithm  is not supported")
+	ErrNoPcrBankQuoted     = errors.New("none of the selected pcr banks can be quoted")
+	ErrNotSupportedIKAlg   = errors.New("the ik key algorithm is not supported")
 
 	algStrMap = map[tpm2.Algorithm]string{
 		tpm2.AlgSHA1:   "SHA1",
@@ -257,6 +281,23 @@ var (
 		},
 	}
 
+	// according to TCG specification, 7.3.4.4 Template H-3: ECC NIST P256
+	// https://trustedcomputinggroup.org/wp-content/uploads/TPM-2p0-Keys-for-Device-Identity-and-Attestation_v1_r12_pub10082021.pdf
+	IKParamsECC = tpm2.Public{
+		Type:    tpm2.AlgECC,
+		NameAlg: tpm2.AlgSHA256,
+		Attributes: tpm2.FlagFixedTPM | tpm2.FlagFixedParent | tpm2.FlagSensitiveDataOrigin |
+			tpm2.FlagUserWithAuth | tpm2.FlagSign | tpm2.FlagRestricted,
+
+		ECCParameters: &tpm2.ECCParams{
+			Sign: &tpm2.SigScheme{
+				Alg:  tpm2.AlgECDSA,
+				Hash: tpm2.AlgSHA256,
+			},
+			CurveID: tpm2.CurveNISTP256,
+		},
+	}
+
 	tpmRef *tpm = nil
 )
 
@@ -306,6 +347,36 @@ func SetDigestAlg(alg string) error {
 
 }
 
+// SetPcrSelection sets the pcr banks and pcrs to be quoted in trust report,
+// like "sha256:0-23;sm3:0-23", empty means all pcrs of digest algorithm bank.
+func SetPcrSelection(s string) error {
+	if tpmRef == nil {
+		return ErrFailTPMInit
+	}
+	sels, err := typdefs.ParsePcrSelections(s)
+	if err != nil {
+		return err
+	}
+	var pcrSels []tpm2.PCRSelection
+	for _, sel := range sels {
+		algID, ok := algIdMap[sel.HashAlg]
+		if !ok {
+			return ErrNotSupportedHashAlg
+		}
+		pcrSels = append(pcrSels, tpm2.PCRSelection{Hash: algID, PCRs: sel.PCRs})
+	}
+	tpmRef.pcrSelections = pcrSels
+	return nil
+}
+
+// getPcrSelections returns the pcr selections to be quoted.
+func getPcrSelections() []tpm2.PCRSelection {
+	if len(tpmRef.pcrSelections) == 0 {
+		return []tpm2.PCRSelection{pcrSelectionAll}
+	}
+	return tpmRef.pcrSelections
+}
+
 // OpenTPM uses either a physical TPM device(default/useHW=true) or a
 // simulator(-t/useHW=false), returns a global TPM object variable.
 func OpenTPM(useHW bool, conf *TPMConfig, seed int64) error {
@@ -448,13 +519,31 @@ func GenerateEKey() error {
 // GenerateIKey generates the ik key as a primary key by tpm2, gets the handle, public
 // and name fields to use later
 func GenerateIKey() error {
+	return GenerateIKeyWithParams(IKParams)
+}
+
+// GetIKParams returns the ik key template of the algorithm alg, IKAlgRSA
+// or IKAlgECC, the empty alg is IKAlgRSA.
+func GetIKParams(alg string) (tpm2.Public, error) {
+	switch alg {
+	case "", IKAlgRSA:
+		return IKParams, nil
+	case IKAlgECC:
+		return IKParamsECC, nil
+	}
+	return tpm2.Public{}, ErrNotSupportedIKAlg
+}
+
+// GenerateIKeyWithParams generates the ik key by the template params, such as
+// IKParams and IKParamsECC.
+func GenerateIKeyWithParams(params tpm2.Public) error {
 	var err error
 	if tpmRef == nil {
 		return ErrFailTPMInit
 	}
 	tpmRef.ik.handle, tpmRef.ik.pub, err = tpm2.CreatePrimary(tpmRef.dev,
 		tpm2.HandleEndorsement, pcrSelectionNil,
-		emptyPassword, emptyPassword, IKParams)
+		emptyPassword, emptyPassword, params)
 	if err != nil {
 		tpmRef.ik.handle = tpmutil.Handle(0)
 		tpmRef.ik.pub = nil
@@ -549,25 +638,60 @@ func GetClientInfo() (string, error) {
 	clientInfo["os"] = out2.String()
 	clientInfo["ip"] = typdefs.GetIP()
 	clientInfo["version"] = "1.0.0"
+	// the hardware/OS profile which ras selects the node group by.
+	clientInfo[typdefs.ClientInfoProduct] = getDMIValue(clientInfo["system"], "Product Name")
+	clientInfo[typdefs.ClientInfoBiosVersion] = getDMIValue(clientInfo["bios"], "Version")
+	clientInfo[typdefs.ClientInfoOsRelease] = getOsRelease(osReleasePath)
 	strCI, err := json.Marshal(clientInfo)
 	return string(strCI), err
 }
 
+// getDMIValue returns the value of key in the dmidecode output section,
+// like "Product Name" of "System Information".
+func getDMIValue(section, key string) string {
+	for _, ln := range strings.Split(section, "\n") {
+		ln = strings.TrimSpace(ln)
+		if strings.HasPrefix(ln, key+":") {
+			return strings.TrimSpace(ln[len(key)+1:])
+		}
+	}
+	return ""
+}
+
+// getOsRelease returns the PRETTY_NAME of os-release file, like
+// "openEuler 22.03 LTS", or empty if not found.
+func getOsRelease(path string) string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, ln := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(ln, "PRETTY_NAME=") {
+			return strings.Trim(strings.TrimPrefix(ln, "PRETTY_NAME="), `"'`)
+		}
+	}
+	return ""
+}
+
 func readPcrLog(pcrSelection tpm2.PCRSelection) ([]byte, error) {
 	var buf bytes.Buffer
 	var digBuf []byte
+	var algStr string
 	switch pcrSelection.Hash {
 	case tpm2.AlgSHA1:
 		digBuf = make([]byte, typdefs.Sha1DigestLen*2)
+		algStr = algSHA1Str
 	case tpm2.AlgSHA256:
 		digBuf = make([]byte, typdefs.Sha256DigestLen*2)
-	// TODO: need rewrite
+		algStr = algSHA256Str
 	case AlgSM3:
 		digBuf = make([]byte, typdefs.SM3DigestLen*2)
+		algStr = algSM3Str
+	default:
+		return nil, ErrNotSupportedHashAlg
 	}
-	numPCRs := len(pcrSelection.PCRs)
 	// read pcr one by one by ordering
-	for i := 0; i < numPCRs; i++ {
+	for _, i := range pcrSelection.PCRs {
 		pcrSel := tpm2.PCRSelection{
 			Hash: pcrSelection.Hash,
 			PCRs: []int{i},
@@ -581,19 +705,54 @@ func readPcrLog(pcrSelection tpm2.PCRSelection) ([]byte, error) {
 		for pcr, digest := range ret {
 			hex.Encode(digBuf, digest)
 			buf.Write(digBuf)
-			switch pcrSelection.Hash {
-			case tpm2.AlgSHA1:
-				buf.WriteString(fmt.Sprintf(" sha1 %02d\n", pcr))
-			case tpm2.AlgSHA256:
-				buf.WriteString(fmt.Sprintf(" sha256 %02d\n", pcr))
-			}
+			buf.WriteString(fmt.Sprintf(" %s %02d\n", algStr, pcr))
 		}
 	}
 	return buf.Bytes(), nil
 }
 
+// quotePcrBanks quotes each selected pcr bank and reads its pcr log, the
+// bank which TPM doesn't support is skipped.
+func quotePcrBanks(repHash []byte) ([]typdefs.Quote, []byte, error) {
+	var quotes []typdefs.Quote
+	var pcrLog []byte
+	err := ErrNoPcrBankQuoted
+	for _, sel := range getPcrSelections() {
+		quoted, signature, err2 := tpm2.Quote(tpmRef.dev,
+			tpmRef.ik.handle, tpmRef.ik.password, emptyPassword,
+			repHash, sel, tpm2.AlgNull)
+		if err2 != nil {
+			err = err2
+			continue
+		}
+		jsonSignature, err2 := json.Marshal(signature)
+		if err2 != nil {
+			return nil, nil, err2
+		}
+		log, err2 := readPcrLog(sel)
+		if err2 != nil {
+			return nil, nil, err2
+		}
+		quotes = append(quotes, typdefs.Quote{Quoted: quoted, Signature: jsonSignature})
+		pcrLog = append(pcrLog, log...)
+	}
+	if len(quotes) == 0 {
+		return nil, nil, err
+	}
+	return quotes, pcrLog, nil
+}
+
 // GetTrustReport takes a nonce input, generates the current trust report
+// with the full ima log.
 func GetTrustReport(clientID int64, nonce uint64, algStr string) (*typdefs.TrustReport, error) {
+	return GetTrustReportFrom(clientID, nonce, algStr, 0)
+}
+
+// GetTrustReportFrom generates the current trust report like GetTrustReport,
+// but the ima log only has the entries after the first imaOffset ones which
+// were acknowledged by ras. The full ima log is used if it has less entries
+// than imaOffset, and the ImaOffset of report is 0 then.
+func GetTrustReportFrom(clientID int64, nonce uint64, algStr string, imaOffset uint64) (*typdefs.TrustReport, error) {
 	if tpmRef == nil {
 		return nil, ErrFailTPMInit
 	}
@@ -601,6 +760,19 @@ func GetTrustReport(clientID int64, nonce uint64, algStr string) (*typdefs.Trust
 	if err != nil {
 		return nil, err
 	}
+	// the digest algorithm must be in client info before hashing it into
+	// quote, so that RAS could calculate the same quote extra data.
+	ciMap := map[string]string{}
+	err = json.Unmarshal([]byte(clientInfo), &ciMap)
+	if err != nil {
+		return nil, err
+	}
+	ciMap[typdefs.DigestAlgStr] = algStr
+	ci, err := json.Marshal(ciMap)
+	if err != nil {
+		return nil, err
+	}
+	clientInfo = string(ci)
 	tRepIn := typdefs.TrustReportInput{
 		ClientID:   clientID,
 		Nonce:      nonce,
@@ -611,25 +783,20 @@ func GetTrustReport(clientID int64, nonce uint64, algStr string) (*typdefs.Trust
 	if err != nil {
 		return nil, err
 	}
-	quoted, signature, err := tpm2.Quote(tpmRef.dev,
-		tpmRef.ik.handle, tpmRef.ik.password, emptyPassword,
-		repHash, pcrSelectionAll, tpm2.AlgNull)
-	if err != nil {
-		return nil, err
-	}
-	jsonSignature, err := json.Marshal(signature)
+	quotes, pcrLog, err := quotePcrBanks(repHash)
 	if err != nil {
 		return nil, err
 	}
-	pcrLog, err := readPcrLog(pcrSelectionAll)
+	biosLog, err := ioutil.ReadFile(tpmRef.config.BIOSLogPath)
 	if err != nil {
 		return nil, err
 	}
-	biosLog, err := ioutil.ReadFile(tpmRef.config.BIOSLogPath)
+	imaLog, imaOffset, err := tpmRef.imaPos.read(tpmRef.config.IMALogPath, imaOffset)
 	if err != nil {
 		return nil, err
 	}
-	imaLog, err := ioutil.ReadFile(tpmRef.config.IMALogPath)
+	containers, err := CollectContainers(tpmRef.config.ProcPath, imaLog,
+		tpmRef.config.ContainerModes, algStr)
 	if err != nil {
 		return nil, err
 	}
@@ -637,13 +804,61 @@ func GetTrustReport(clientID int64, nonce uint64, algStr string) (*typdefs.Trust
 		ClientID:   tRepIn.ClientID,
 		Nonce:      tRepIn.Nonce,
 		ClientInfo: tRepIn.ClientInfo,
-		Quoted:     quoted,
-		Signature:  jsonSignature,
+		Quoted:     quotes[0].Quoted,
+		Signature:  quotes[0].Signature,
 		Manifests: []typdefs.Manifest{
 			{Key: typdefs.StrPcr, Value: pcrLog},
 			{Key: typdefs.StrBios, Value: biosLog},
 			{Key: typdefs.StrIma, Value: imaLog},
 		},
+		Quotes:     quotes[1:],
+		ImaOffset:  imaOffset,
+		Containers: containers,
 	}
 	return &report, nil
 }
+
+// read reads the ima log entries after the first offset ones and records
+// their positions, it returns the ima log and the actual offset, which is
+// 0 if the log has less than offset entries.
+func (p *imaLogPos) read(path string, offset uint64) ([]byte, uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	start, skip := int64(0), offset
+	if offset >= p.offset && offset-p.offset < uint64(len(p.starts)) {
+		start, skip = p.starts[offset-p.offset], 0
+		_, err = f.Seek(start, io.SeekStart)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, 0, err
+	}
+	// skip the acknowledged entries whose positions are unknown.
+	for ; skip > 0; skip-- {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			p.offset, p.starts = 0, nil
+			return p.read(path, 0)
+		}
+		start += int64(i + 1)
+		data = data[i+1:]
+	}
+	p.offset, p.starts = offset, []int64{start}
+	pos := start
+	for rest := data; ; {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			break
+		}
+		pos += int64(i + 1)
+		p.starts = append(p.starts, pos)
+		rest = rest[i+1:]
+	}
+	return data, offset, nil
+}
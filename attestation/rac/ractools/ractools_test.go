@@ -7,6 +7,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"io/ioutil"
 	"math"
 	"math/big"
@@ -234,6 +235,60 @@ func TestCreateTrustReport(t *testing.T) {
 	}
 }
 
+func TestMultiBankTrustReport(t *testing.T) {
+	tpmConf := createTPMConfig(testMode)
+	tpmConf.IMALogPath = testImaLogPath
+	tpmConf.BIOSLogPath = testBiosLogPath
+
+	random, _ := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	err := OpenTPM(!testMode, tpmConf, random.Int64())
+	if err != nil {
+		t.Errorf(openTPMFailStr, err)
+		os.Exit(1)
+	}
+	defer CloseTPM()
+	defer SetPcrSelection("")
+
+	err = GenerateEKey()
+	if err != nil {
+		t.Errorf(str1, strCreateEkFailed, err)
+	}
+	err = GenerateIKey()
+	if err != nil {
+		t.Errorf(str1, strCreateIkFailed, err)
+	}
+	if err = SetPcrSelection("md5:0-9"); err == nil {
+		t.Errorf("test SetPcrSelection with wrong alg error")
+	}
+	err = SetPcrSelection("sha1:0-7;sha256:0-7,10")
+	if err != nil {
+		t.Errorf("test SetPcrSelection error %v", err)
+	}
+	got, err := GetTrustReport(clientId, nonce, algSHA1Str)
+	if err != nil {
+		t.Errorf(str1, strCreateTrustReportFailed, err)
+	}
+	if len(got.Quotes) != 1 {
+		t.Errorf("test multi bank quotes error, got %d extra quotes", len(got.Quotes))
+	}
+	quotes := append([]typdefs.Quote{{Quoted: got.Quoted}}, got.Quotes...)
+	for i, q := range quotes {
+		att, err := tpm2.DecodeAttestationData(q.Quoted)
+		if err != nil {
+			t.Errorf("DecodeAttestationData failed at quote %d: %s", i, err)
+			continue
+		}
+		if att.AttestedQuoteInfo.PCRSelection.Hash != tpmRef.pcrSelections[i].Hash {
+			t.Errorf("test multi bank quote %d alg error", i)
+		}
+	}
+	for _, line := range []string{" sha1 07\n", " sha256 10\n"} {
+		if !bytes.Contains(got.Manifests[0].Value, []byte(line)) {
+			t.Errorf("test multi bank pcr log error, %s not found", line)
+		}
+	}
+}
+
 func TestNVRAM(t *testing.T) {
 	tpmConf := createTPMConfig(testMode)
 	random, _ := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
@@ -347,6 +402,69 @@ func TestActivateIKCert(t *testing.T) {
 	}
 }
 
+func TestQuoteSignatureByKeyType(t *testing.T) {
+	tpmConf := createTPMConfig(testMode)
+	tpmConf.IMALogPath = testImaLogPath
+	tpmConf.BIOSLogPath = testBiosLogPath
+
+	random, _ := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	err := OpenTPM(!testMode, tpmConf, random.Int64())
+	if err != nil {
+		t.Errorf(openTPMFailStr, err)
+		os.Exit(1)
+	}
+	defer CloseTPM()
+
+	rsaPSSParams := IKParams
+	rsaPSSParams.RSAParameters = &tpm2.RSAParams{
+		Sign: &tpm2.SigScheme{
+			Alg:  tpm2.AlgRSAPSS,
+			Hash: tpm2.AlgSHA256,
+		},
+		KeyBits: 2048,
+	}
+	testCases := []struct {
+		params tpm2.Public
+		sigAlg tpm2.Algorithm
+	}{
+		{IKParams, tpm2.AlgRSASSA},
+		{rsaPSSParams, tpm2.AlgRSAPSS},
+		{IKParamsECC, tpm2.AlgECDSA},
+	}
+	if _, err = GetIKParams("sm2"); err != ErrNotSupportedIKAlg {
+		t.Errorf("get unsupported ik params error, %v", err)
+	}
+	for i, tc := range testCases {
+		err = GenerateIKeyWithParams(tc.params)
+		if err != nil {
+			t.Fatalf("%s at case %d, %v", strCreateIkFailed, i, err)
+		}
+		got, err := GetTrustReport(clientId, nonce, algSHA256Str)
+		if err != nil {
+			t.Fatalf("%s at case %d, %v", strCreateTrustReportFailed, i, err)
+		}
+		sig := new(tpm2.Signature)
+		err = json.Unmarshal(got.Signature, sig)
+		if err != nil {
+			t.Fatalf("unmarshal signature failed at case %d, %v", i, err)
+		}
+		if sig.Alg != tc.sigAlg {
+			t.Errorf("signature algorithm error at case %d, got %v want %v", i, sig.Alg, tc.sigAlg)
+		}
+		err = cryptotools.VerifySignature(GetIKPub(), got.Quoted, sig)
+		if err != nil {
+			t.Errorf("verify quote signature failed at case %d, %v", i, err)
+		}
+		got.Quoted[len(got.Quoted)-1] ^= 0xff
+		err = cryptotools.VerifySignature(GetIKPub(), got.Quoted, sig)
+		if err == nil {
+			t.Errorf("verify tampered quote signature succeeded at case %d", i)
+		}
+		tpm2.FlushContext(tpmRef.dev, tpmRef.ik.handle)
+		tpmRef.ik.handle = tpmutil.Handle(0)
+	}
+}
+
 /*
 func prepareManifestFiles(imaFile, biosFile string, imaManifest, biosManifest []byte) {
 	_ = ioutil.WriteFile(imaFile, imaManifest, 0600)
@@ -609,3 +727,78 @@ func TestOpenTPM(t *testing.T) {
 		file.Close()
 	}
 }*/
+
+func TestReadImaLog(t *testing.T) {
+	f, err := ioutil.TempFile("", "ima")
+	if err != nil {
+		t.Fatalf("create ima log error, %v", err)
+	}
+	defer os.Remove(f.Name())
+	lines := []string{"10 a ima-ng sha1:1 boot_aggregate\n", "10 b ima-ng sha1:2 /usr/bin/a\n",
+		"10 c ima-ng sha1:3 /usr/bin/bc\n"}
+	f.WriteString(lines[0] + lines[1])
+	f.Close()
+
+	p := &imaLogPos{}
+	testCases := []struct {
+		more   string
+		offset uint64
+		result string
+		actual uint64
+	}{
+		{"", 0, lines[0] + lines[1], 0},
+		{"", 2, "", 2},
+		{lines[2], 1, lines[1] + lines[2], 1},
+		{"", 3, "", 3},
+		// the log has less entries than offset.
+		{"", 5, lines[0] + lines[1] + lines[2], 0},
+	}
+	for i, tc := range testCases {
+		if tc.more != "" {
+			f, _ = os.OpenFile(f.Name(), os.O_APPEND|os.O_WRONLY, 0600)
+			f.WriteString(tc.more)
+			f.Close()
+		}
+		data, offset, err := p.read(f.Name(), tc.offset)
+		if err != nil || string(data) != tc.result || offset != tc.actual {
+			t.Errorf("test read ima log error at case %d, %q %d %v\n", i, data, offset, err)
+		}
+	}
+	// the positions are unknown.
+	p.offset, p.starts = 0, nil
+	data, _, _ := p.read(f.Name(), 2)
+	if string(data) != lines[2] || p.offset != 2 || len(p.starts) != 2 {
+		t.Errorf("test read ima log by skipping entries error, %q %+v\n", data, p)
+	}
+}
+
+func TestClientInfoProfile(t *testing.T) {
+	testCases := []struct {
+		section string
+		key     string
+		value   string
+	}{
+		{constDMISYSTEM, "Product Name", "CW35S"},
+		{constDMIBIOS, "Version", "4.6.5"},
+		{constDMIBIOS, "BIOS Revision", "4.6"},
+		{constDMIBIOS, "Product Name", ""},
+	}
+	for i, tc := range testCases {
+		if v := getDMIValue(tc.section, tc.key); v != tc.value {
+			t.Errorf("test getDMIValue error at case %d, %q\n", i, v)
+		}
+	}
+	f, err := ioutil.TempFile("", "os-release")
+	if err != nil {
+		t.Fatalf("create os-release error, %v", err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("NAME=\"openEuler\"\nVERSION_ID=\"22.03\"\nPRETTY_NAME=\"openEuler 22.03 LTS\"\n")
+	f.Close()
+	if v := getOsRelease(f.Name()); v != "openEuler 22.03 LTS" {
+		t.Errorf("test getOsRelease error, %q\n", v)
+	}
+	if v := getOsRelease(f.Name() + ".none"); v != "" {
+		t.Errorf("test getOsRelease of missing file error, %q\n", v)
+	}
+}
@@ -24,6 +24,8 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/binary"
+	"sort"
+	"sync"
 	"time"
 
 	"gitee.com/openeuler/kunpengsecl/attestation/common/cryptotools"
@@ -36,11 +38,15 @@ const (
 
 type (
 	// Cache stores the latest status of one RAC client and commands.
+	// It is safe for concurrent use, all fields are guarded by mu.
 	Cache struct {
-		regtime      string
-		online       bool
-		hostTrusted  bool
-		isAutoUpdate bool //true表示信任下一次的可信报告，不验证直接抽取更新基准值；false则正常对下一次报告进行验证
+		mu      sync.Mutex
+		regtime string
+		online  bool
+		// the end time of the maintenance window opened by auto-update
+		// strategy, the host base value is updated from the trust reports
+		// automatically until then.
+		autoUpdateEnd time.Time
 		// current commands for RAC.
 		commands uint64
 		// heartbeat expiration, used for judging whether RAC heartbeat is expired.
@@ -51,10 +57,30 @@ type (
 		// for remote attestation
 		nonce  uint64
 		ikCert *x509.Certificate
-		// for verify process
-		HostBase       []*typdefs.BaseRow
-		ContainerBases []*typdefs.BaseRow
-		DeviceBases    []*typdefs.BaseRow
+		// pcr banks and pcrs quoted by this client, empty for default.
+		pcrSelection string
+		// the registered client info and the id of node group which the
+		// client belongs to by it, 0 if none.
+		clientInfo string
+		groupID    int64
+		// ima log replay state, nil if the next report must carry the full
+		// ima log.
+		imaState *typdefs.ImaStateRow
+		// for verify process, the rows are never changed after they are
+		// put into cache, updating a row replaces it with a new one.
+		hostBases      []*typdefs.BaseRow
+		containerBases []*typdefs.BaseRow
+		deviceBases    []*typdefs.BaseRow
+		// trust states of the client and its containers/devices by uuid,
+		// and the transitions which are not saved yet.
+		hostState    typdefs.TrustStatus
+		uuidStates   map[string]*uuidState
+		trustHistory []*typdefs.TrustHistoryRow
+	}
+
+	uuidState struct {
+		baseType string
+		status   typdefs.TrustStatus
 	}
 )
 
@@ -63,75 +89,222 @@ func NewCache() *Cache {
 	c := &Cache{
 		regtime:         "",
 		online:          false,
-		hostTrusted:     false,
-		isAutoUpdate:    false,
 		commands:        typdefs.CmdNone,
 		trustExpiration: time.Now(),
 		nonce:           0,
 		ikCert:          nil,
-		HostBase:        make([]*typdefs.BaseRow, 0, defaultBaseRows),
-		ContainerBases:  make([]*typdefs.BaseRow, 0, defaultBaseRows),
-		DeviceBases:     make([]*typdefs.BaseRow, 0, defaultBaseRows),
+		hostBases:       make([]*typdefs.BaseRow, 0, defaultBaseRows),
+		containerBases:  make([]*typdefs.BaseRow, 0, defaultBaseRows),
+		deviceBases:     make([]*typdefs.BaseRow, 0, defaultBaseRows),
+		hostState:       typdefs.NewTrustStatus(),
+		uuidStates:      map[string]*uuidState{},
 	}
 	return c
 }
 
 // UpdateHeartBeat is called when receives heart beat message from RAC.
 func (c *Cache) UpdateHeartBeat(hb time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	// Once get a heart beat message then extends the expiration.
 	c.online = true
 	c.hbExpiration = time.Now().Add(hb)
-	c.GetTrusted()
+	// the client is alive again and its next report is waited for.
+	if c.hostState.State == typdefs.TrustStateUnknown || c.hostState.State == typdefs.TrustStateOffline {
+		c.transitAll(typdefs.TrustStatePending, typdefs.TrustReasonHeartbeat, "")
+	}
+	c.refreshTrust()
 }
 
 // UpdateTrustReport is called when receives trust report message from RAC.
 func (c *Cache) UpdateTrustReport(trust time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.trustExpiration = time.Now().Add(trust)
 }
 
 func (c *Cache) UpdateOnline(t time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.onlineExpiration = time.Now().Add(t)
 }
 
 // IsHeartBeatExpired checks if the client is expired.
 func (c *Cache) IsHeartBeatExpired() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return time.Now().After(c.hbExpiration)
 }
 
 // HasCommands checks if the client has some commands.
 func (c *Cache) HasCommands() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.commands != typdefs.CmdNone
 }
 
 // ClearCommands clears the client commands.
 func (c *Cache) ClearCommands() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.commands = typdefs.CmdNone
 }
 
 // SetCommands saves the new commands for waiting.
 func (c *Cache) SetCommands(cmds uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.commands |= cmds
 }
 
 // GetCommands gets the pending commands of client.
 func (c *Cache) GetCommands() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.commands
 }
 
-// SetVerified sets the trusted field.
+// TakeCommands gets the pending commands of client and clears them, no
+// command set at the same time is lost.
+func (c *Cache) TakeCommands() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cmds := c.commands
+	c.commands = typdefs.CmdNone
+	return cmds
+}
+
+// SetTrusted sets the host trust state by the trust report verification
+// result v.
 func (c *Cache) SetTrusted(v bool) {
-	c.hostTrusted = v
+	if v {
+		c.SetTrustState(typdefs.TrustStateTrusted, typdefs.TrustReasonReportVerified, "")
+	} else {
+		c.SetTrustState(typdefs.TrustStateUntrusted, typdefs.TrustReasonReportInvalid, "")
+	}
 }
 
 // GetTrusted checks where the RAC trust report is valid or not.
 func (c *Cache) GetTrusted() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshTrust()
+	return c.hostState.State == typdefs.TrustStateTrusted
+}
+
+// SetTrustState changes the host trust state by reason, the transition is
+// kept until TakeTrustHistory.
+func (c *Cache) SetTrustState(state, reason, detail string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.transit(typdefs.BaseTypeHost, "", &c.hostState, state, reason, detail)
+}
+
+// GetTrustState returns the current host trust state.
+func (c *Cache) GetTrustState() typdefs.TrustStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshTrust()
+	return c.hostState
+}
+
+// SetUuidTrustState changes the trust state of the container/device of
+// uuid by reason like SetTrustState.
+func (c *Cache) SetUuidTrustState(baseType, uuid, state, reason, detail string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st, ok := c.uuidStates[uuid]
+	if !ok {
+		st = &uuidState{baseType: baseType, status: typdefs.NewTrustStatus()}
+		c.uuidStates[uuid] = st
+	}
+	return c.transit(st.baseType, uuid, &st.status, state, reason, detail)
+}
+
+// GetUuidTrustState returns the current trust state of the container/device
+// of uuid, it is unknown if the uuid isn't attested yet.
+func (c *Cache) GetUuidTrustState(uuid string) typdefs.TrustStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshTrust()
+	st, ok := c.uuidStates[uuid]
+	if !ok {
+		return typdefs.NewTrustStatus()
+	}
+	return st.status
+}
+
+// GetUuidTrustStates returns the current trust states of the attested
+// containers or devices by uuid, baseType chooses which kind.
+func (c *Cache) GetUuidTrustStates(baseType string) map[string]typdefs.TrustStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshTrust()
+	res := map[string]typdefs.TrustStatus{}
+	for uuid, st := range c.uuidStates {
+		if st.baseType == baseType {
+			res[uuid] = st.status
+		}
+	}
+	return res
+}
+
+// TakeTrustHistory returns the trust state transitions of the client and
+// its containers/devices in time order and clears them.
+func (c *Cache) TakeTrustHistory() []*typdefs.TrustHistoryRow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshTrust()
+	rows := c.trustHistory
+	c.trustHistory = nil
+	return rows
+}
+
+// transit changes st of the entity and saves the transition if it changes.
+func (c *Cache) transit(entityType, uuid string, st *typdefs.TrustStatus, state, reason, detail string) error {
+	h, err := st.Transit(state, reason, detail, time.Now())
+	if err != nil || h == nil {
+		return err
+	}
+	h.EntityType, h.Uuid = entityType, uuid
+	c.trustHistory = append(c.trustHistory, h)
+	return nil
+}
+
+// transitAll changes the trust states of the client and its attested
+// containers/devices which are allowed to, the others are kept.
+func (c *Cache) transitAll(state, reason, detail string) {
+	c.transit(typdefs.BaseTypeHost, "", &c.hostState, state, reason, detail)
+	uuids := make([]string, 0, len(c.uuidStates))
+	for uuid := range c.uuidStates {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	for _, uuid := range uuids {
+		st := c.uuidStates[uuid]
+		if st.status.State != typdefs.TrustStateUnknown {
+			c.transit(st.baseType, uuid, &st.status, state, reason, detail)
+		}
+	}
+}
+
+// refreshTrust makes the trust states offline if the client isn't online
+// any more, or expired if no trust report is received in time.
+func (c *Cache) refreshTrust() {
+	now := time.Now()
+	if !c.onlineExpiration.IsZero() && now.After(c.onlineExpiration) {
+		c.transitAll(typdefs.TrustStateOffline, typdefs.TrustReasonHeartbeatLost, "")
+		return
+	}
 	// After trust report expiration there is no one report received,
 	// the RAC can't be trusted any more and needs to get a new trust report.
-	if time.Now().After(c.trustExpiration) {
-		c.SetCommands(typdefs.CmdGetReport)
-		c.hostTrusted = false
+	// Only the trusted states expire, the untrusted ones keep their reasons.
+	if now.After(c.trustExpiration) {
+		c.commands |= typdefs.CmdGetReport
+		if c.hostState.State == typdefs.TrustStateTrusted {
+			c.transitAll(typdefs.TrustStateExpired, typdefs.TrustReasonReportExpired, "")
+		}
 	}
-	return c.hostTrusted
 }
 
 // GetNonce returns a nonce value for remote attestation trust report.
@@ -141,36 +314,51 @@ func (c *Cache) GetNonce() uint64 {
 	if err != nil {
 		return 0
 	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.nonce = binary.LittleEndian.Uint64(a[:])
 	return c.nonce
 }
 
 // CompareNonce checks the returned nonce match or not.
 func (c *Cache) CompareNonce(n uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.nonce == n
 }
 
 // GetIKeyCert returns the client IK certificate for validate the trust report.
 func (c *Cache) GetIKeyCert() *x509.Certificate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.ikCert
 }
 
 // SetIKeyCert saves the client IK certificate in cache to enhance performance.
 func (c *Cache) SetIKeyCert(pemCert string) {
-	c.ikCert, _, _ = cryptotools.DecodeKeyCertFromPEM([]byte(pemCert))
+	cert, _, _ := cryptotools.DecodeKeyCertFromPEM([]byte(pemCert))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ikCert = cert
 }
 
 // GetRegTime returns the client register time.
 func (c *Cache) GetRegTime() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.regtime
 }
 
 // SetRegTime saves the client register time.
 func (c *Cache) SetRegTime(v string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.regtime = v
 }
 
 func (c *Cache) GetOnline() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if time.Now().After(c.onlineExpiration) {
 		c.online = false
 	} else {
@@ -179,16 +367,229 @@ func (c *Cache) GetOnline() bool {
 	return c.online
 }
 
-// GetIsAutoUpdate returns the client autoupdate strategy.
+// GetIsAutoUpdate returns whether the auto-update maintenance window of
+// the client is still open.
 func (c *Cache) GetIsAutoUpdate() bool {
-	return c.isAutoUpdate
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Now().Before(c.autoUpdateEnd)
+}
+
+// SetAutoUpdateEnd saves the end time of the auto-update maintenance
+// window of the client, a passed or zero time means no window.
+func (c *Cache) SetAutoUpdateEnd(end time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.autoUpdateEnd = end
+}
+
+// GetPcrSelection returns the pcr selection quoted by this client.
+func (c *Cache) GetPcrSelection() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pcrSelection
 }
 
-// SetIsAutoUpdate saves the client autoppdate strategy.
-func (c *Cache) SetIsAutoUpdate(v bool) {
-	c.isAutoUpdate = v
+// SetPcrSelection saves the pcr selection quoted by this client.
+func (c *Cache) SetPcrSelection(v string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pcrSelection = v
+}
+
+// GetClientInfo returns the registered client info.
+func (c *Cache) GetClientInfo() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.clientInfo
+}
+
+// SetClientInfo saves the registered client info.
+func (c *Cache) SetClientInfo(v string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clientInfo = v
+}
+
+// GetGroupID returns the id of node group which the client belongs to.
+func (c *Cache) GetGroupID() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.groupID
+}
+
+// SetGroupID saves the id of node group which the client belongs to.
+func (c *Cache) SetGroupID(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.groupID = id
+}
+
+// GetImaState returns a copy of the ima log replay state, nil if none.
+func (c *Cache) GetImaState() *typdefs.ImaStateRow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.imaState == nil {
+		return nil
+	}
+	st := *c.imaState
+	return &st
+}
+
+// SetImaState saves a copy of the ima log replay state, nil clears it.
+func (c *Cache) SetImaState(v *typdefs.ImaStateRow) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v == nil {
+		c.imaState = nil
+		return
+	}
+	st := *v
+	c.imaState = &st
 }
 
 func (c *Cache) GetTrustExpiration() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.trustExpiration
 }
+
+// GetHostBases returns copies of the client host base values.
+func (c *Cache) GetHostBases() []*typdefs.BaseRow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return copyBases(c.hostBases)
+}
+
+// GetContainerBases returns copies of the client container base values.
+func (c *Cache) GetContainerBases() []*typdefs.BaseRow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return copyBases(c.containerBases)
+}
+
+// GetDeviceBases returns copies of the client device base values.
+func (c *Cache) GetDeviceBases() []*typdefs.BaseRow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return copyBases(c.deviceBases)
+}
+
+// UpdateBase saves a copy of the new base value into cache by its type.
+// A new host base value replaces all the old ones, a container/device
+// base value replaces the one which has the same uuid or is appended.
+func (c *Cache) UpdateBase(v *typdefs.BaseRow) {
+	row := *v
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch v.BaseType {
+	case "host":
+		c.hostBases = append(c.hostBases[:0:0], &row)
+	case "container":
+		c.containerBases = replaceBase(c.containerBases, &row)
+	case "device":
+		c.deviceBases = replaceBase(c.deviceBases, &row)
+	}
+}
+
+// RemoveHostBase removes the host base value of id from cache.
+func (c *Cache) RemoveHostBase(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, base := range c.hostBases {
+		if base.ID == id {
+			c.hostBases = append(c.hostBases[:i:i], c.hostBases[i+1:]...)
+			return
+		}
+	}
+}
+
+// VerifyHostBases calls verify for each host base value and saves the
+// results into cache, the base value is trusted if verify returns nil.
+// verify is called without holding the cache lock.
+func (c *Cache) VerifyHostBases(verify func(base *typdefs.BaseRow) error) {
+	c.mu.Lock()
+	olds := append([]*typdefs.BaseRow(nil), c.hostBases...)
+	c.mu.Unlock()
+	news := make([]*typdefs.BaseRow, len(olds))
+	for i, base := range olds {
+		news[i] = verifyBase(base, verify)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range olds {
+		saveVerifiedBase(c.hostBases, olds[i], news[i])
+	}
+}
+
+// VerifyUuidBase calls verify for the enabled container/device base value
+// of uuid and saves the result into cache like VerifyHostBases, it returns
+// false if no such base value.
+func (c *Cache) VerifyUuidBase(uuid string, verify func(base *typdefs.BaseRow) error) bool {
+	c.mu.Lock()
+	var old *typdefs.BaseRow
+	for _, rows := range [][]*typdefs.BaseRow{c.containerBases, c.deviceBases} {
+		for _, base := range rows {
+			if old == nil && base.Uuid == uuid && base.Enabled {
+				old = base
+			}
+		}
+	}
+	c.mu.Unlock()
+	if old == nil {
+		return false
+	}
+	row := verifyBase(old, verify)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !saveVerifiedBase(c.containerBases, old, row) {
+		saveVerifiedBase(c.deviceBases, old, row)
+	}
+	return true
+}
+
+// verifyBase returns a copy of base with the result of verify.
+func verifyBase(base *typdefs.BaseRow, verify func(base *typdefs.BaseRow) error) *typdefs.BaseRow {
+	row := *base
+	err := verify(&row)
+	row.Verified = true
+	row.Trusted = err == nil
+	return &row
+}
+
+// saveVerifiedBase replaces old in rows with the verified row. The base
+// values are never changed in place, so old isn't in rows if it is updated
+// or removed while verifying, and its result is dropped.
+func saveVerifiedBase(rows []*typdefs.BaseRow, old, row *typdefs.BaseRow) bool {
+	for i, base := range rows {
+		if base == old {
+			rows[i] = row
+			return true
+		}
+	}
+	return false
+}
+
+// copyBases returns deep copies of rows, so callers can't change cache.
+func copyBases(rows []*typdefs.BaseRow) []*typdefs.BaseRow {
+	res := make([]*typdefs.BaseRow, len(rows))
+	for i, v := range rows {
+		row := *v
+		res[i] = &row
+	}
+	return res
+}
+
+// replaceBase replaces the row which has the same uuid with v, or appends
+// v if not found, the old slice is never changed.
+func replaceBase(rows []*typdefs.BaseRow, v *typdefs.BaseRow) []*typdefs.BaseRow {
+	res := make([]*typdefs.BaseRow, len(rows), len(rows)+1)
+	copy(res, rows)
+	for i, base := range res {
+		if base.Uuid == v.Uuid {
+			res[i] = v
+			return res
+		}
+	}
+	return append(res, v)
+}
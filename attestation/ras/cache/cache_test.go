@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -119,12 +121,302 @@ func TestRegTime(t *testing.T) {
 }
 
 func TestIsAutoUpdate(t *testing.T) {
-	testCases2 := []bool{true, false}
+	testCases2 := []struct {
+		end  time.Time
+		want bool
+	}{
+		{time.Now().Add(time.Hour), true},
+		{time.Now().Add(-time.Second), false},
+		{time.Time{}, false},
+	}
 	for i := 0; i < len(testCases2); i++ {
 		c := NewCache()
-		c.SetIsAutoUpdate(testCases2[i])
-		if c.GetIsAutoUpdate() != testCases2[i] {
+		c.SetAutoUpdateEnd(testCases2[i].end)
+		if c.GetIsAutoUpdate() != testCases2[i].want {
 			t.Errorf("test IsAutoUpdate error at case %d\n", i)
 		}
 	}
+	// the window is closed when its end time passes.
+	c := NewCache()
+	c.SetAutoUpdateEnd(time.Now().Add(50 * time.Millisecond))
+	time.Sleep(100 * time.Millisecond)
+	if c.GetIsAutoUpdate() {
+		t.Errorf("test IsAutoUpdate after window end error\n")
+	}
+}
+
+func TestPcrSelection(t *testing.T) {
+	testCases2 := []string{"sha256:0-23", "sm3:0-7;sha256:0-7", ""}
+	for i := 0; i < len(testCases2); i++ {
+		c := NewCache()
+		c.SetPcrSelection(testCases2[i])
+		if c.GetPcrSelection() != testCases2[i] {
+			t.Errorf("test PcrSelection error at case %d\n", i)
+		}
+	}
+}
+
+func TestClientGroup(t *testing.T) {
+	testCases2 := []struct {
+		info    string
+		groupID int64
+	}{
+		{`{"productname":"P1"}`, 1},
+		{"", 0},
+	}
+	for i := 0; i < len(testCases2); i++ {
+		c := NewCache()
+		c.SetClientInfo(testCases2[i].info)
+		c.SetGroupID(testCases2[i].groupID)
+		if c.GetClientInfo() != testCases2[i].info || c.GetGroupID() != testCases2[i].groupID {
+			t.Errorf("test ClientGroup error at case %d\n", i)
+		}
+	}
+}
+
+func TestImaState(t *testing.T) {
+	c := NewCache()
+	if c.GetImaState() != nil {
+		t.Errorf("test GetImaState of new cache error")
+	}
+	st := &typdefs.ImaStateRow{ClientID: 1, Count: 10, Alg: typdefs.Sha256AlgStr, Pcr: "00"}
+	c.SetImaState(st)
+	st.Count = 20
+	st2 := c.GetImaState()
+	if st2 == nil || st2.Count != 10 {
+		t.Errorf("test SetImaState copy error, %v", st2)
+	}
+	st2.Count = 30
+	if c.GetImaState().Count != 10 {
+		t.Errorf("test GetImaState copy error")
+	}
+	c.SetImaState(nil)
+	if c.GetImaState() != nil {
+		t.Errorf("test SetImaState nil error")
+	}
+}
+
+func TestTakeCommands(t *testing.T) {
+	c := NewCache()
+	c.SetCommands(typdefs.CmdGetReport)
+	c.SetCommands(typdefs.CmdSendConfig)
+	if c.TakeCommands() != typdefs.CmdGetReport|typdefs.CmdSendConfig {
+		t.Errorf("test TakeCommands error")
+	}
+	if c.HasCommands() {
+		t.Errorf("test TakeCommands doesn't clear commands")
+	}
+}
+
+func TestUpdateBase(t *testing.T) {
+	testCases2 := []struct {
+		row       typdefs.BaseRow
+		host      int
+		container int
+		device    int
+	}{
+		{typdefs.BaseRow{BaseType: "host", Name: "h1"}, 1, 0, 0},
+		{typdefs.BaseRow{BaseType: "host", Name: "h2"}, 1, 0, 0},
+		{typdefs.BaseRow{BaseType: "container", Uuid: "c1"}, 1, 1, 0},
+		{typdefs.BaseRow{BaseType: "container", Uuid: "c2"}, 1, 2, 0},
+		{typdefs.BaseRow{BaseType: "container", Uuid: "c1", Name: "new"}, 1, 2, 0},
+		{typdefs.BaseRow{BaseType: "device", Uuid: "d1"}, 1, 2, 1},
+		{typdefs.BaseRow{BaseType: "unknown", Uuid: "u1"}, 1, 2, 1},
+	}
+	c := NewCache()
+	for i := 0; i < len(testCases2); i++ {
+		c.UpdateBase(&testCases2[i].row)
+		if len(c.GetHostBases()) != testCases2[i].host ||
+			len(c.GetContainerBases()) != testCases2[i].container ||
+			len(c.GetDeviceBases()) != testCases2[i].device {
+			t.Errorf("test UpdateBase error at case %d\n", i)
+		}
+	}
+	if c.GetHostBases()[0].Name != "h2" || c.GetContainerBases()[0].Name != "new" {
+		t.Errorf("test UpdateBase replace error")
+	}
+	// the returned rows are copies.
+	c.GetHostBases()[0].Name = "changed"
+	testCases2[1].row.Name = "changed"
+	if c.GetHostBases()[0].Name != "h2" {
+		t.Errorf("test GetHostBases copy error")
+	}
+}
+
+func TestRemoveHostBase(t *testing.T) {
+	c := NewCache()
+	c.UpdateBase(&typdefs.BaseRow{ID: 1, BaseType: "host"})
+	c.RemoveHostBase(2)
+	if len(c.GetHostBases()) != 1 {
+		t.Errorf("test RemoveHostBase other id error")
+	}
+	c.RemoveHostBase(1)
+	if len(c.GetHostBases()) != 0 {
+		t.Errorf("test RemoveHostBase error")
+	}
+}
+
+func TestVerifyHostBases(t *testing.T) {
+	c := NewCache()
+	c.UpdateBase(&typdefs.BaseRow{BaseType: "host", Name: "h1"})
+	old := c.GetHostBases()
+	testCases2 := []error{nil, errors.New("verify error")}
+	for i := 0; i < len(testCases2); i++ {
+		c.VerifyHostBases(func(base *typdefs.BaseRow) error {
+			return testCases2[i]
+		})
+		b := c.GetHostBases()[0]
+		if !b.Verified || b.Trusted != (testCases2[i] == nil) {
+			t.Errorf("test VerifyHostBases error at case %d\n", i)
+		}
+	}
+	if old[0].Verified {
+		t.Errorf("test VerifyHostBases changes the old copy")
+	}
+	// verify may use the cache, the base value updated meanwhile is kept.
+	c.VerifyHostBases(func(base *typdefs.BaseRow) error {
+		c.GetHostBases()
+		c.UpdateBase(&typdefs.BaseRow{BaseType: "host", Name: "h2"})
+		return nil
+	})
+	if b := c.GetHostBases()[0]; b.Name != "h2" || b.Verified {
+		t.Errorf("test VerifyHostBases with update error, %v\n", b)
+	}
+}
+
+func TestVerifyUuidBase(t *testing.T) {
+	c := NewCache()
+	c.UpdateBase(&typdefs.BaseRow{BaseType: "container", Uuid: "c1", Enabled: true})
+	c.UpdateBase(&typdefs.BaseRow{BaseType: "container", Uuid: "c2"})
+	c.UpdateBase(&typdefs.BaseRow{BaseType: "device", Uuid: "d1", Enabled: true})
+	testCases := []struct {
+		uuid   string
+		err    error
+		result bool
+	}{
+		{"c1", nil, true},
+		{"c1", errors.New("verify error"), true},
+		{"c2", nil, false},
+		{"d1", nil, true},
+		{"x", nil, false},
+	}
+	for i, tc := range testCases {
+		found := c.VerifyUuidBase(tc.uuid, func(base *typdefs.BaseRow) error {
+			c.GetContainerBases()
+			return tc.err
+		})
+		if found != tc.result {
+			t.Errorf("test VerifyUuidBase error at case %d\n", i)
+			continue
+		}
+		for _, b := range append(c.GetContainerBases(), c.GetDeviceBases()...) {
+			if b.Uuid == tc.uuid && b.Verified != found || found && b.Uuid == tc.uuid && b.Trusted != (tc.err == nil) {
+				t.Errorf("test VerifyUuidBase result error at case %d, %v\n", i, b)
+			}
+		}
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	const workers = 50
+	c := NewCache()
+	c.UpdateBase(&typdefs.BaseRow{BaseType: "host"})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.UpdateHeartBeat(time.Second)
+				c.SetCommands(typdefs.CmdGetReport)
+				c.TakeCommands()
+				c.CompareNonce(c.GetNonce())
+				c.SetTrusted(j%2 == 0)
+				c.GetTrusted()
+				c.GetOnline()
+				c.UpdateBase(&typdefs.BaseRow{BaseType: "container", Uuid: string(rune('a' + i))})
+				c.VerifyHostBases(func(base *typdefs.BaseRow) error { return nil })
+				for _, b := range c.GetContainerBases() {
+					b.Trusted = true
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if len(c.GetContainerBases()) != workers {
+		t.Errorf("test concurrent UpdateBase error, %d bases", len(c.GetContainerBases()))
+	}
+}
+
+func TestTrustState(t *testing.T) {
+	c := NewCache()
+	if st := c.GetTrustState(); st.State != typdefs.TrustStateUnknown || c.GetTrusted() {
+		t.Fatalf("test initial trust state error, %v\n", st)
+	}
+	c.UpdateOnline(time.Hour)
+	c.UpdateHeartBeat(time.Hour)
+	if c.GetCommands()&typdefs.CmdGetReport == 0 {
+		t.Errorf("test report command of pending client error\n")
+	}
+	c.UpdateTrustReport(time.Hour)
+	c.SetTrustState(typdefs.TrustStateTrusted, typdefs.TrustReasonReportVerified, "")
+	c.SetUuidTrustState(typdefs.BaseTypeContainer, "c1", typdefs.TrustStateTrusted, typdefs.TrustReasonReportVerified, "")
+	c.SetUuidTrustState(typdefs.BaseTypeDevice, "d1", typdefs.TrustStateUntrusted, typdefs.TrustReasonBaseNotFound, "d1")
+	if !c.GetTrusted() || c.GetUuidTrustState("c1").State != typdefs.TrustStateTrusted ||
+		c.GetUuidTrustState("d1").Reason != typdefs.TrustReasonBaseNotFound ||
+		c.GetUuidTrustState("x").State != typdefs.TrustStateUnknown {
+		t.Errorf("test SetTrustState error, %v\n", c.GetTrustState())
+	}
+	err := c.SetTrustState(typdefs.TrustStatePending, typdefs.TrustReasonHeartbeat, "")
+	if !errors.Is(err, typdefs.ErrTrustStateWrong) || !c.GetTrusted() {
+		t.Errorf("test SetTrustState wrong transition error, %v\n", err)
+	}
+	// the report expires, then the client goes offline and comes back.
+	c.mu.Lock()
+	c.trustExpiration = time.Now().Add(-time.Second)
+	c.mu.Unlock()
+	if st := c.GetTrustState(); st.State != typdefs.TrustStateExpired || st.Reason != typdefs.TrustReasonReportExpired ||
+		c.GetUuidTrustState("c1").State != typdefs.TrustStateExpired ||
+		c.GetUuidTrustState("d1").State != typdefs.TrustStateUntrusted {
+		t.Errorf("test expired trust state error, %v\n", st)
+	}
+	c.UpdateOnline(-time.Second)
+	if st := c.GetTrustState(); st.State != typdefs.TrustStateOffline || c.GetUuidTrustState("c1").State != typdefs.TrustStateOffline {
+		t.Errorf("test offline trust state error, %v\n", st)
+	}
+	c.UpdateOnline(time.Hour)
+	c.UpdateHeartBeat(time.Hour)
+	if st := c.GetTrustState(); st.State != typdefs.TrustStatePending || st.Reason != typdefs.TrustReasonHeartbeat {
+		t.Errorf("test pending trust state error, %v\n", st)
+	}
+
+	results := []struct {
+		entityType string
+		uuid       string
+		from       string
+		to         string
+	}{
+		{typdefs.BaseTypeHost, "", typdefs.TrustStateUnknown, typdefs.TrustStatePending},
+		{typdefs.BaseTypeHost, "", typdefs.TrustStatePending, typdefs.TrustStateTrusted},
+		{typdefs.BaseTypeContainer, "c1", typdefs.TrustStateUnknown, typdefs.TrustStateTrusted},
+		{typdefs.BaseTypeDevice, "d1", typdefs.TrustStateUnknown, typdefs.TrustStateUntrusted},
+		{typdefs.BaseTypeHost, "", typdefs.TrustStateTrusted, typdefs.TrustStateExpired},
+		{typdefs.BaseTypeContainer, "c1", typdefs.TrustStateTrusted, typdefs.TrustStateExpired},
+		{typdefs.BaseTypeHost, "", typdefs.TrustStateExpired, typdefs.TrustStateOffline},
+		{typdefs.BaseTypeContainer, "c1", typdefs.TrustStateExpired, typdefs.TrustStateOffline},
+		{typdefs.BaseTypeDevice, "d1", typdefs.TrustStateUntrusted, typdefs.TrustStateOffline},
+		{typdefs.BaseTypeHost, "", typdefs.TrustStateOffline, typdefs.TrustStatePending},
+		{typdefs.BaseTypeContainer, "c1", typdefs.TrustStateOffline, typdefs.TrustStatePending},
+		{typdefs.BaseTypeDevice, "d1", typdefs.TrustStateOffline, typdefs.TrustStatePending},
+	}
+	rows := c.TakeTrustHistory()
+	if len(rows) != len(results) || len(c.TakeTrustHistory()) != 0 {
+		t.Fatalf("test TakeTrustHistory error, %d rows\n", len(rows))
+	}
+	for i, r := range results {
+		if rows[i].EntityType != r.entityType || rows[i].Uuid != r.uuid || rows[i].FromState != r.from ||
+			rows[i].ToState != r.to || rows[i].CreateTime.IsZero() {
+			t.Errorf("test TakeTrustHistory error at case %d, %+v\n", i, rows[i])
+		}
+	}
 }
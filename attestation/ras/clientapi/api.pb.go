@@ -375,6 +375,8 @@ type ClientConfig struct {
 	TrustDurationSeconds int64  `protobuf:"varint,2,opt,name=trustDurationSeconds,proto3" json:"trustDurationSeconds,omitempty"`
 	Nonce                uint64 `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	DigestAlgorithm      string `protobuf:"bytes,4,opt,name=digestAlgorithm,proto3" json:"digestAlgorithm,omitempty"`
+	// pcr banks and pcrs to be quoted, like "sha256:0-23;sm3:0-23".
+	PcrSelection string `protobuf:"bytes,5,opt,name=pcrSelection,proto3" json:"pcrSelection,omitempty"`
 }
 
 func (x *ClientConfig) Reset() {
@@ -437,6 +439,13 @@ func (x *ClientConfig) GetDigestAlgorithm() string {
 	return ""
 }
 
+func (x *ClientConfig) GetPcrSelection() string {
+	if x != nil {
+		return x.PcrSelection
+	}
+	return ""
+}
+
 type UnregisterClientRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -644,6 +653,15 @@ type SendReportRequest struct {
 	Quoted     []byte      `protobuf:"bytes,4,opt,name=quoted,proto3" json:"quoted,omitempty"`
 	Signature  []byte      `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
 	Manifests  []*Manifest `protobuf:"bytes,6,rep,name=manifests,proto3" json:"manifests,omitempty"`
+	// quotes of the other pcr banks.
+	Quotes []*Quote `protobuf:"bytes,7,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	// the number of ima log entries acknowledged by ras before, which are
+	// not in the ima manifest, 0 for the full ima log.
+	ImaOffset uint64 `protobuf:"varint,8,opt,name=imaOffset,proto3" json:"imaOffset,omitempty"`
+	// the measurements of the running containers.
+	Containers []*UuidManifest `protobuf:"bytes,9,rep,name=containers,proto3" json:"containers,omitempty"`
+	// the firmware measurements of the devices.
+	Devices []*UuidManifest `protobuf:"bytes,10,rep,name=devices,proto3" json:"devices,omitempty"`
 }
 
 func (x *SendReportRequest) Reset() {
@@ -720,6 +738,34 @@ func (x *SendReportRequest) GetManifests() []*Manifest {
 	return nil
 }
 
+func (x *SendReportRequest) GetQuotes() []*Quote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *SendReportRequest) GetImaOffset() uint64 {
+	if x != nil {
+		return x.ImaOffset
+	}
+	return 0
+}
+
+func (x *SendReportRequest) GetContainers() []*UuidManifest {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *SendReportRequest) GetDevices() []*UuidManifest {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
 type Manifest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -775,18 +821,135 @@ func (x *Manifest) GetValue() []byte {
 	return nil
 }
 
+// the measurements of a container or device keyed by its uuid.
+type UuidManifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid      string      `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Manifests []*Manifest `protobuf:"bytes,2,rep,name=manifests,proto3" json:"manifests,omitempty"`
+}
+
+func (x *UuidManifest) Reset() {
+	*x = UuidManifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clientapi_api_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UuidManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UuidManifest) ProtoMessage() {}
+
+func (x *UuidManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_clientapi_api_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UuidManifest.ProtoReflect.Descriptor instead.
+func (*UuidManifest) Descriptor() ([]byte, []int) {
+	return file_clientapi_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UuidManifest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UuidManifest) GetManifests() []*Manifest {
+	if x != nil {
+		return x.Manifests
+	}
+	return nil
+}
+
+type Quote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quoted    []byte `protobuf:"bytes,1,opt,name=quoted,proto3" json:"quoted,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clientapi_api_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_clientapi_api_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_clientapi_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Quote) GetQuoted() []byte {
+	if x != nil {
+		return x.Quoted
+	}
+	return nil
+}
+
+func (x *Quote) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type SendReportReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	// the number of ima log entries acknowledged by ras, the next report
+	// only needs the entries after them, 0 asks for the full ima log.
+	ImaCount uint64 `protobuf:"varint,2,opt,name=imaCount,proto3" json:"imaCount,omitempty"`
+	// the incremental ima log doesn't match the ima replay state of ras,
+	// the report must be sent again with the full ima log.
+	ImaResend bool `protobuf:"varint,3,opt,name=imaResend,proto3" json:"imaResend,omitempty"`
 }
 
 func (x *SendReportReply) Reset() {
 	*x = SendReportReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_clientapi_api_proto_msgTypes[13]
+		mi := &file_clientapi_api_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -799,7 +962,7 @@ func (x *SendReportReply) String() string {
 func (*SendReportReply) ProtoMessage() {}
 
 func (x *SendReportReply) ProtoReflect() protoreflect.Message {
-	mi := &file_clientapi_api_proto_msgTypes[13]
+	mi := &file_clientapi_api_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,7 +975,7 @@ func (x *SendReportReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendReportReply.ProtoReflect.Descriptor instead.
 func (*SendReportReply) Descriptor() ([]byte, []int) {
-	return file_clientapi_api_proto_rawDescGZIP(), []int{13}
+	return file_clientapi_api_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *SendReportReply) GetResult() bool {
@@ -822,6 +985,20 @@ func (x *SendReportReply) GetResult() bool {
 	return false
 }
 
+func (x *SendReportReply) GetImaCount() uint64 {
+	if x != nil {
+		return x.ImaCount
+	}
+	return 0
+}
+
+func (x *SendReportReply) GetImaResend() bool {
+	if x != nil {
+		return x.ImaResend
+	}
+	return false
+}
+
 var File_clientapi_api_proto protoreflect.FileDescriptor
 
 var file_clientapi_api_proto_rawDesc = []byte{
@@ -861,7 +1038,7 @@ var file_clientapi_api_proto_rawDesc = []byte{
 	0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
 	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43,
 	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xb0, 0x01, 0x0a, 0x0c, 0x43, 0x6c,
+	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xd4, 0x01, 0x0a, 0x0c, 0x43, 0x6c,
 	0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2c, 0x0a, 0x11, 0x68, 0x62,
 	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x68, 0x62, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
@@ -872,42 +1049,66 @@ var file_clientapi_api_proto_rawDesc = []byte{
 	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e,
 	0x63, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x67, 0x6f,
 	0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0x35, 0x0a, 0x17,
-	0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x49, 0x64, 0x22, 0x2f, 0x0a, 0x15, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x22, 0x32, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x48, 0x65, 0x61, 0x72,
-	0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
-	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x67, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64,
-	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e,
-	0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31,
-	0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x22, 0xc4, 0x01, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75, 0x6f,
-	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x65,
-	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
-	0x27, 0x0a, 0x09, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x09, 0x6d,
-	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x22, 0x32, 0x0a, 0x08, 0x4d, 0x61, 0x6e, 0x69,
-	0x66, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x29, 0x0a, 0x0f,
-	0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0x88, 0x03, 0x0a, 0x03, 0x52, 0x61, 0x73, 0x12,
+	0x65, 0x73, 0x74, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x22, 0x0a, 0x0c,
+	0x70, 0x63, 0x72, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x70, 0x63, 0x72, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x35, 0x0a, 0x17, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x2f, 0x0a, 0x15, 0x55, 0x6e, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x32, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64,
+	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x67, 0x0a, 0x12,
+	0x53, 0x65, 0x6e, 0x64, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xda, 0x02, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a,
+	0x06, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x71,
+	0x75, 0x6f, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x27, 0x0a, 0x09, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x52, 0x09, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x06,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x69, 0x6d, 0x61, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x69, 0x6d, 0x61, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x55, 0x75, 0x69, 0x64, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x0a, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x55, 0x75, 0x69,
+	0x64, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x22, 0x32, 0x0a, 0x08, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4b, 0x0a, 0x0c, 0x55, 0x75, 0x69, 0x64, 0x4d, 0x61,
+	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x09, 0x6d, 0x61,
+	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x09, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x73, 0x22, 0x3d, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x63, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6d, 0x61, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x69, 0x6d, 0x61, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6d, 0x61,
+	0x52, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x6d,
+	0x61, 0x52, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x32, 0x88, 0x03, 0x0a, 0x03, 0x52, 0x61, 0x73, 0x12,
 	0x40, 0x0a, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x45, 0x4b, 0x43, 0x65, 0x72,
 	0x74, 0x12, 0x16, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x45, 0x4b, 0x43, 0x65,
 	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x47, 0x65, 0x6e, 0x65,
@@ -951,7 +1152,7 @@ func file_clientapi_api_proto_rawDescGZIP() []byte {
 	return file_clientapi_api_proto_rawDescData
 }
 
-var file_clientapi_api_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_clientapi_api_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_clientapi_api_proto_goTypes = []interface{}{
 	(*GenerateEKCertRequest)(nil),   // 0: GenerateEKCertRequest
 	(*GenerateEKCertReply)(nil),     // 1: GenerateEKCertReply
@@ -966,29 +1167,35 @@ var file_clientapi_api_proto_goTypes = []interface{}{
 	(*SendHeartbeatReply)(nil),      // 10: SendHeartbeatReply
 	(*SendReportRequest)(nil),       // 11: SendReportRequest
 	(*Manifest)(nil),                // 12: Manifest
-	(*SendReportReply)(nil),         // 13: SendReportReply
+	(*UuidManifest)(nil),            // 13: UuidManifest
+	(*Quote)(nil),                   // 14: Quote
+	(*SendReportReply)(nil),         // 15: SendReportReply
 }
 var file_clientapi_api_proto_depIdxs = []int32{
 	6,  // 0: RegisterClientReply.clientConfig:type_name -> ClientConfig
 	6,  // 1: SendHeartbeatReply.clientConfig:type_name -> ClientConfig
 	12, // 2: SendReportRequest.manifests:type_name -> Manifest
-	0,  // 3: Ras.GenerateEKCert:input_type -> GenerateEKCertRequest
-	2,  // 4: Ras.GenerateIKCert:input_type -> GenerateIKCertRequest
-	4,  // 5: Ras.RegisterClient:input_type -> RegisterClientRequest
-	7,  // 6: Ras.UnregisterClient:input_type -> UnregisterClientRequest
-	9,  // 7: Ras.SendHeartbeat:input_type -> SendHeartbeatRequest
-	11, // 8: Ras.SendReport:input_type -> SendReportRequest
-	1,  // 9: Ras.GenerateEKCert:output_type -> GenerateEKCertReply
-	3,  // 10: Ras.GenerateIKCert:output_type -> GenerateIKCertReply
-	5,  // 11: Ras.RegisterClient:output_type -> RegisterClientReply
-	8,  // 12: Ras.UnregisterClient:output_type -> UnregisterClientReply
-	10, // 13: Ras.SendHeartbeat:output_type -> SendHeartbeatReply
-	13, // 14: Ras.SendReport:output_type -> SendReportReply
-	9,  // [9:15] is the sub-list for method output_type
-	3,  // [3:9] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	14, // 3: SendReportRequest.quotes:type_name -> Quote
+	13, // 4: SendReportRequest.containers:type_name -> UuidManifest
+	13, // 5: SendReportRequest.devices:type_name -> UuidManifest
+	12, // 6: UuidManifest.manifests:type_name -> Manifest
+	0,  // 7: Ras.GenerateEKCert:input_type -> GenerateEKCertRequest
+	2,  // 8: Ras.GenerateIKCert:input_type -> GenerateIKCertRequest
+	4,  // 9: Ras.RegisterClient:input_type -> RegisterClientRequest
+	7,  // 10: Ras.UnregisterClient:input_type -> UnregisterClientRequest
+	9,  // 11: Ras.SendHeartbeat:input_type -> SendHeartbeatRequest
+	11, // 12: Ras.SendReport:input_type -> SendReportRequest
+	1,  // 13: Ras.GenerateEKCert:output_type -> GenerateEKCertReply
+	3,  // 14: Ras.GenerateIKCert:output_type -> GenerateIKCertReply
+	5,  // 15: Ras.RegisterClient:output_type -> RegisterClientReply
+	8,  // 16: Ras.UnregisterClient:output_type -> UnregisterClientReply
+	10, // 17: Ras.SendHeartbeat:output_type -> SendHeartbeatReply
+	15, // 18: Ras.SendReport:output_type -> SendReportReply
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_clientapi_api_proto_init() }
@@ -1154,6 +1361,30 @@ func file_clientapi_api_proto_init() {
 			}
 		}
 		file_clientapi_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UuidManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clientapi_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clientapi_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SendReportReply); i {
 			case 0:
 				return &v.state
@@ -1172,7 +1403,7 @@ func file_clientapi_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_clientapi_api_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -32,6 +32,7 @@ package clientapi
 import (
 	"context"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -50,6 +51,8 @@ import (
 	"gitee.com/openeuler/kunpengsecl/attestation/ras/trustmgr"
 	"golang.org/x/net/netutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -63,10 +66,12 @@ cat $1 | awk '/open files/ { print $4 }'`
 
 type rasService struct {
 	UnimplementedRasServer
+	mgr *trustmgr.TrustManager
 }
 
 var (
 	ErrClientApiParameterWrong = errors.New("client api parameter wrong")
+	ErrNoEKTrustStore          = errors.New("no TPM vendor certificate in EK trust store")
 
 	srv *grpc.Server = nil
 )
@@ -87,12 +92,48 @@ func getSockNum() int {
 }
 
 // newRasServer creates a new rasService to support clientapi interface.
-func newRasService() *rasService {
-	return &rasService{}
+func newRasService(mgr *trustmgr.TrustManager) *rasService {
+	return &rasService{mgr: mgr}
 }
 
-// StartServer starts a server to provide ras rpc services.
-func StartServer(addr string) {
+// CreateStore creates the store of trust manager by database configuration.
+func CreateStore() (trustmgr.Store, error) {
+	switch config.GetDBType() {
+	case config.DBTypeSqlite:
+		return trustmgr.NewSqliteStore(config.GetDBName())
+	case config.DBTypeMemory:
+		return trustmgr.NewMemoryStore(), nil
+	}
+	dsn := trustmgr.GetPostgresDSN(config.GetDBHost(), config.GetDBPort(),
+		config.GetDBUser(), config.GetDBPassword(), config.GetDBName())
+	return trustmgr.NewPostgresStore(dsn)
+}
+
+// CreateTrustManager creates a trust manager saving data into the
+// database defined by configuration.
+func CreateTrustManager() (*trustmgr.TrustManager, error) {
+	store, err := CreateStore()
+	if err != nil {
+		return nil, err
+	}
+	mgr, err := trustmgr.New(store, trustmgr.Options{
+		SkipMigrate:       !config.GetDBAutoMigrate(),
+		StoreWorkers:      config.GetDBStoreWorkers(),
+		StoreQueueDepth:   config.GetDBStoreQueueDepth(),
+		StoreBatchSize:    config.GetDBStoreBatchSize(),
+		StoreRetries:      config.GetDBStoreRetries(),
+		StoreRetryBackoff: config.GetDBStoreRetryBackoff(),
+		EnqueueTimeout:    config.GetDBEnqueueTimeout(),
+	})
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	return mgr, nil
+}
+
+// StartServer starts a server to provide ras rpc services by trust manager mgr.
+func StartServer(addr string, mgr *trustmgr.TrustManager) {
 	var err error
 	if srv != nil {
 		return
@@ -101,15 +142,17 @@ func StartServer(addr string) {
 		logger.L.Sugar().Errorf("listen ip:port can not be empty")
 		return
 	}
+	if mgr == nil {
+		logger.L.Sugar().Errorf("trust manager can not be nil")
+		return
+	}
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		logger.L.Sugar().Errorf("fail to listen at %s, %v", addr, err)
 		return
 	}
-	trustmgr.CreateTrustManager("postgres",
-		"user=postgres password=postgres dbname=kunpengsecl host=localhost port=5432 sslmode=disable")
-	srv := grpc.NewServer()
-	RegisterRasServer(srv, newRasService())
+	srv = grpc.NewServer()
+	RegisterRasServer(srv, newRasService(mgr))
 	//logger.L.Sugar().Debugf("listen at %s", addr)
 	lis = netutil.LimitListener(lis, getSockNum())
 	err = srv.Serve(lis)
@@ -118,14 +161,13 @@ func StartServer(addr string) {
 	}
 }
 
-// StopServer stops the server and trust manager, release all resources.
+// StopServer stops the server, the trust manager is released by its creator.
 func StopServer() {
 	if srv == nil {
 		return
 	}
-	srv.Stop()
+	srv.GracefulStop()
 	srv = nil
-	trustmgr.ReleaseTrustManager()
 }
 
 // GenerateEKCert handles the generation of the EK certificate for client.
@@ -155,16 +197,36 @@ func (s *rasService) GenerateEKCert(ctx context.Context, in *GenerateEKCertReque
 // GenerateIKCert handles the generation of the IK certificate for client.
 func (s *rasService) GenerateIKCert(ctx context.Context, in *GenerateIKCertRequest) (*GenerateIKCertReply, error) {
 	//logger.L.Debug("get GenerateIKCert request")
+	ekCert, err := x509.ParseCertificate(in.GetEkCert())
+	if err != nil {
+		logger.L.Sugar().Errorf("parse client EK Cert fail, %v", err)
+		return nil, err
+	}
+	// only issue IK certificate for the trusted TPM.
+	err = checkEKCert(ekCert)
+	if err != nil {
+		logger.L.Sugar().Errorf("untrusted client EK Cert, %v", err)
+		return nil, status.Errorf(codes.PermissionDenied,
+			"untrusted EK certificate: %v", err)
+	}
 	t := time.Now()
+	// save the IK name into certificate, then the quote signer could be
+	// checked when verifying trust report.
+	ikNameExt, err := cryptotools.NewIKNameExtension(in.GetIkName())
+	if err != nil {
+		logger.L.Sugar().Errorf("create IK name extension fail, %v", err)
+		return nil, err
+	}
 	template := x509.Certificate{
 		SerialNumber: big.NewInt(cryptotools.GetSerialNumber()),
 		NotBefore:    t,
 		NotAfter:     t.AddDate(1, 0, 0),
 		KeyUsage: x509.KeyUsageDigitalSignature |
 			x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
-		IsCA:           false,
-		MaxPathLenZero: true,
-		IPAddresses:    []net.IP{net.ParseIP(config.GetIP())},
+		IsCA:            false,
+		MaxPathLenZero:  true,
+		IPAddresses:     []net.IP{net.ParseIP(config.GetIP())},
+		ExtraExtensions: []pkix.Extension{ikNameExt},
 	}
 	ikCertDer, err := cryptotools.GenerateCertificate(&template,
 		config.GetPcaKeyCert(), in.GetIkPub(), config.GetPcaPrivateKey())
@@ -172,11 +234,6 @@ func (s *rasService) GenerateIKCert(ctx context.Context, in *GenerateIKCertReque
 		logger.L.Sugar().Errorf("generate IK Cert fail, %v", err)
 		return nil, err
 	}
-	ekCert, err := x509.ParseCertificate(in.GetEkCert())
-	if err != nil {
-		logger.L.Sugar().Errorf("parse client EK Cert fail, %v", err)
-		return nil, err
-	}
 	encIkCert, err := cryptotools.EncryptIKCert(ekCert.PublicKey,
 		ikCertDer, in.GetIkName())
 	if err != nil {
@@ -193,6 +250,27 @@ func (s *rasService) GenerateIKCert(ctx context.Context, in *GenerateIKCertReque
 	}, nil
 }
 
+// checkEKCert checks whether the EK certificate is trusted according to the
+// EK policy configuration.
+func checkEKCert(ekCert *x509.Certificate) error {
+	switch config.GetEKPolicy() {
+	case config.EKPolicyOff:
+		return nil
+	case config.EKPolicyAllowTest:
+		// test EK certificate is issued by GenerateEKCert for simulator.
+		pcaCert := config.GetPcaKeyCert()
+		if pcaCert != nil && ekCert.CheckSignatureFrom(pcaCert) == nil {
+			return nil
+		}
+	}
+	store := config.GetEKTrustStore()
+	if store == nil || store.Len() == 0 {
+		return ErrNoEKTrustStore
+	}
+	_, err := store.VerifyEKCert(ekCert)
+	return err
+}
+
 // RegisterClient registers a new client by IK certificate and its client information string.
 func (s *rasService) RegisterClient(ctx context.Context, in *RegisterClientRequest) (*RegisterClientReply, error) {
 	//logger.L.Debug("get RegisterClient request")
@@ -202,7 +280,7 @@ func (s *rasService) RegisterClient(ctx context.Context, in *RegisterClientReque
 		logger.L.Sugar().Errorf("encode IK Cert to PEM fail, %v", err)
 		return &RegisterClientReply{ClientId: -1}, err
 	}
-	client, err := trustmgr.RegisterClientByIK(string(ikPem), in.GetClientInfo())
+	client, err := s.mgr.RegisterClientByIK(string(ikPem), in.GetClientInfo())
 	if err != nil {
 		logger.L.Sugar().Errorf("register client fail, %v", err)
 		return &RegisterClientReply{ClientId: -1}, err
@@ -215,6 +293,7 @@ func (s *rasService) RegisterClient(ctx context.Context, in *RegisterClientReque
 			TrustDurationSeconds: int64(config.GetTrustDuration().Seconds()),
 			Nonce:                0,
 			DigestAlgorithm:      config.GetDigestAlgorithm(),
+			PcrSelection:         s.mgr.GetPcrSelection(client.ID),
 		},
 	}, nil
 }
@@ -223,7 +302,7 @@ func (s *rasService) RegisterClient(ctx context.Context, in *RegisterClientReque
 func (s *rasService) UnregisterClient(ctx context.Context, in *UnregisterClientRequest) (*UnregisterClientReply, error) {
 	cid := in.GetClientId()
 	//logger.L.Sugar().Debugf("get UnregisterClient %d request", cid)
-	trustmgr.UnRegisterClientByID(cid)
+	s.mgr.UnRegisterClientByID(cid)
 	//logger.L.Sugar().Debugf("send UnregisterClient reply")
 	return &UnregisterClientReply{Result: true}, nil
 }
@@ -233,7 +312,7 @@ func (s *rasService) SendHeartbeat(ctx context.Context, in *SendHeartbeatRequest
 	var out SendHeartbeatReply
 	cid := in.GetClientId()
 	//logger.L.Sugar().Debugf("get hb from %d", cid)
-	cmds, nonce, err := trustmgr.HandleHeartbeat(cid)
+	cmds, nonce, err := s.mgr.HandleHeartbeat(cid)
 	if err != nil {
 		logger.L.Sugar().Errorf("client(%d) heart beat fail, %v", cid, err)
 		return nil, err
@@ -251,6 +330,7 @@ func (s *rasService) SendHeartbeat(ctx context.Context, in *SendHeartbeatRequest
 				TrustDurationSeconds: int64(config.GetTrustDuration().Seconds()),
 				Nonce:                nonce,
 				DigestAlgorithm:      config.GetDigestAlgorithm(),
+				PcrSelection:         s.mgr.GetPcrSelection(cid),
 			},
 		}
 		//logger.L.Sugar().Debugf("send reply to %d, NextActions=%d ClientConfig=%+v", cid, cmds, out.ClientConfig)
@@ -272,6 +352,35 @@ func (s *rasService) SendReport(ctx context.Context, in *SendReportRequest) (*Se
 		}
 		ms = append(ms, m)
 	}
+	var qs []typdefs.Quote
+	for _, iq := range in.GetQuotes() {
+		qs = append(qs, typdefs.Quote{
+			Quoted:    iq.GetQuoted(),
+			Signature: iq.GetSignature(),
+		})
+	}
+	var cs []typdefs.UuidManifest
+	for _, ic := range in.GetContainers() {
+		c := typdefs.UuidManifest{Uuid: ic.GetUuid()}
+		for _, im := range ic.GetManifests() {
+			c.Manifests = append(c.Manifests, typdefs.Manifest{
+				Key:   im.GetKey(),
+				Value: im.GetValue(),
+			})
+		}
+		cs = append(cs, c)
+	}
+	var ds []typdefs.UuidManifest
+	for _, id := range in.GetDevices() {
+		d := typdefs.UuidManifest{Uuid: id.GetUuid()}
+		for _, im := range id.GetManifests() {
+			d.Manifests = append(d.Manifests, typdefs.Manifest{
+				Key:   im.GetKey(),
+				Value: im.GetValue(),
+			})
+		}
+		ds = append(ds, d)
+	}
 	trustReport := typdefs.TrustReport{
 		ClientID:   in.ClientId,
 		Nonce:      in.GetNonce(),
@@ -279,21 +388,26 @@ func (s *rasService) SendReport(ctx context.Context, in *SendReportRequest) (*Se
 		Quoted:     in.GetQuoted(),
 		Signature:  in.GetSignature(),
 		Manifests:  ms,
+		Quotes:     qs,
+		ImaOffset:  in.GetImaOffset(),
+		Containers: cs,
+		Devices:    ds,
 	}
 	//logger.L.Debug("validate report and save...")
-	_, err := trustmgr.ValidateReport(&trustReport)
+	_, err := s.mgr.ValidateReport(&trustReport)
 	if err != nil {
 		logger.L.Sugar().Errorf("validate client(%d) report error, %v", cid, err)
-		return &SendReportReply{Result: false}, nil
+		return &SendReportReply{Result: false, ImaCount: s.mgr.GetImaCount(cid),
+			ImaResend: errors.Is(err, typdefs.ErrImaStateMismatch)}, nil
 	}
 
-	err = trustmgr.HandleBaseValue(&trustReport)
+	err = s.mgr.HandleBaseValue(&trustReport)
 	if err != nil {
 		logger.L.Sugar().Errorf("handle client(%d) basevalue error, %v", cid, err)
-		return &SendReportReply{Result: false}, nil
+		return &SendReportReply{Result: false, ImaCount: s.mgr.GetImaCount(cid)}, nil
 	}
 	//logger.L.Sugar().Debugf("validate success and send reply to %d", cid)
-	return &SendReportReply{Result: true}, nil
+	return &SendReportReply{Result: true, ImaCount: s.mgr.GetImaCount(cid)}, nil
 }
 
 type RasConn struct {
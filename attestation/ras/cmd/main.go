@@ -28,17 +28,17 @@ import (
 	"gitee.com/openeuler/kunpengsecl/attestation/ras/clientapi"
 	"gitee.com/openeuler/kunpengsecl/attestation/ras/config"
 	"gitee.com/openeuler/kunpengsecl/attestation/ras/restapi"
+	"github.com/spf13/pflag"
 )
 
-// signalHandler handles the singal and save configurations.
+// signalHandler handles the singal and stops the server gracefully, main
+// releases trust manager and saves configurations after that.
 func signalHandler() {
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-ch
 		clientapi.StopServer()
-		config.SaveConfigs()
-		os.Exit(0)
 	}()
 }
 
@@ -70,9 +70,20 @@ func main() {
 	config.LoadConfigs()
 	config.HandleFlags()
 	handleGlobalFlags()
+	if args := pflag.Args(); len(args) > 0 && args[0] == cmdMigrate {
+		os.Exit(handleMigrate(args[1:]))
+	}
+	mgr, err := clientapi.CreateTrustManager()
+	if err != nil {
+		fmt.Printf("create trust manager failed: %v\n", err)
+		os.Exit(1)
+	}
 	signalHandler()
 
 	logger.L.Debug("start server")
-	go restapi.StartServer(config.GetHttpsSwitch())
-	clientapi.StartServer(config.GetServerPort())
+	go restapi.StartServer(config.GetHttpsSwitch(), mgr)
+	clientapi.StartServer(config.GetServerPort(), mgr)
+	// save all queued reports before exit.
+	mgr.Close()
+	config.SaveConfigs()
 }
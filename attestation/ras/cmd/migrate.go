@@ -0,0 +1,94 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: ras migrate sub command to manage the database schema.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/clientapi"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/trustmgr"
+)
+
+const (
+	cmdMigrate       = "migrate"
+	cmdMigrateUp     = "up"
+	cmdMigrateDown   = "down"
+	cmdMigrateStatus = "status"
+	strMigrateUsage  = "usage: ras migrate up|down|status"
+	strNotApplied    = "pending"
+)
+
+// handleMigrate handles "ras migrate up|down|status" sub command and
+// returns the process exit code.
+//
+//	up:     applies all migrations which are not applied yet.
+//	down:   reverts the last applied migration.
+//	status: shows all migrations and the current schema version.
+func handleMigrate(args []string) int {
+	if len(args) != 1 {
+		fmt.Println(strMigrateUsage)
+		return 1
+	}
+	store, err := clientapi.CreateStore()
+	if err != nil {
+		fmt.Printf("open database failed: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+	m, ok := store.(trustmgr.Migrator)
+	if !ok {
+		fmt.Println("this database type doesn't have a versioned schema")
+		return 1
+	}
+	switch args[0] {
+	case cmdMigrateUp:
+		err = m.MigrateUp()
+	case cmdMigrateDown:
+		err = m.MigrateDown()
+	case cmdMigrateStatus:
+		err = showMigrationStatus(m)
+	default:
+		fmt.Println(strMigrateUsage)
+		return 1
+	}
+	if err != nil {
+		fmt.Printf("migrate %s failed: %v\n", args[0], err)
+		return 1
+	}
+	v, err := m.SchemaVersion()
+	if err != nil {
+		fmt.Printf("read schema version failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("current schema version: %d, latest: %d\n", v, m.LatestSchemaVersion())
+	return 0
+}
+
+// showMigrationStatus prints all migrations and whether they are applied.
+func showMigrationStatus(m trustmgr.Migrator) error {
+	ms, err := m.MigrationStatus()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%-8s %-30s %s\n", "version", "applied", "name")
+	for _, s := range ms {
+		applied := strNotApplied
+		if s.Applied {
+			applied = s.AppliedTime.Format(typdefs.StrTimeFormat)
+		}
+		fmt.Printf("%-8d %-30s %s\n", s.Version, applied, s.Name)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: ras-refgen generates the ima reference values of base value
+	from rpm packages or unpacked root filesystems.
+*/
+
+// ras-refgen main package.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"github.com/spf13/pflag"
+)
+
+const (
+	refgenVersion = "version 1.0.0"
+	// output formats
+	formatJSON = "json"
+	formatText = "text"
+	// hash algorithms
+	lflagAlg = "alg"
+	sflagAlg = "a"
+	helpAlg  = "hash algorithms of file digests, sha1/sha256/sm3"
+	// ima template
+	lflagTemplate = "template"
+	sflagTemplate = "t"
+	helpTemplate  = "ima template which measures the files, ima/ima-ng/ima-sig"
+	// output format
+	lflagFormat = "format"
+	sflagFormat = "f"
+	helpFormat  = "output format, json reference values or text ima base value"
+	// output file
+	lflagOutput = "output"
+	sflagOutput = "o"
+	helpOutput  = "output file, default is stdout"
+	// version output
+	lflagVersion = "version"
+	sflagVersion = "V"
+	helpVersion  = "show version number and quit"
+
+	usage = `Usage: ras-refgen [OPTIONS] RPM|ROOTFS...
+Generate the ima reference values of executables, libraries and kernel
+modules in the rpm packages and unpacked root filesystem directories.
+
+Options:
+`
+)
+
+var (
+	algs        *[]string = nil
+	template    *string   = nil
+	format      *string   = nil
+	output      *string   = nil
+	versionFlag *bool     = nil
+)
+
+// initFlags inits the ras-refgen whole command flags.
+func initFlags() {
+	algs = pflag.StringSliceP(lflagAlg, sflagAlg, []string{typdefs.Sha256AlgStr}, helpAlg)
+	template = pflag.StringP(lflagTemplate, sflagTemplate, typdefs.StrImaNg, helpTemplate)
+	format = pflag.StringP(lflagFormat, sflagFormat, formatJSON, helpFormat)
+	output = pflag.StringP(lflagOutput, sflagOutput, "", helpOutput)
+	versionFlag = pflag.BoolP(lflagVersion, sflagVersion, false, helpVersion)
+	pflag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		pflag.PrintDefaults()
+	}
+	pflag.Parse()
+}
+
+// generate adds all the rpm packages and root filesystems in args, and
+// writes the reference values.
+func generate(args []string, w io.Writer) error {
+	g, err := newGenerator(*algs, *template)
+	if err != nil {
+		return err
+	}
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			err = g.addRootfs(arg)
+		case strings.HasSuffix(arg, ".rpm"):
+			err = g.addRpm(arg)
+		default:
+			err = fmt.Errorf("%s is neither a rpm package nor a directory", arg)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return writeRefValues(w, g.refValues(), *format)
+}
+
+func main() {
+	initFlags()
+	if versionFlag != nil && *versionFlag {
+		fmt.Printf("ras-refgen: %s\n", refgenVersion)
+		os.Exit(0)
+	}
+	if pflag.NArg() == 0 {
+		pflag.Usage()
+		os.Exit(1)
+	}
+	w := io.Writer(os.Stdout)
+	if output != nil && *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ras-refgen: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	err := generate(pflag.Args(), w)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ras-refgen: %v\n", err)
+		os.Exit(1)
+	}
+}
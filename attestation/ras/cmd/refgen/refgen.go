@@ -0,0 +1,200 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: generate the ima reference values of the measured files.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+const (
+	modeExec = 0111
+)
+
+var (
+	// the suffixes of kernel modules, which may be compressed.
+	moduleSuffixes = []string{".ko", ".ko.xz", ".ko.gz", ".ko.zst"}
+)
+
+type (
+	// generator collects the digests of measured files.
+	generator struct {
+		algs     []string
+		template string
+		// file path -> alg -> digests
+		digests map[string]map[string][]typdefs.HexBytes
+	}
+)
+
+func newGenerator(algs []string, template string) (*generator, error) {
+	if len(algs) == 0 {
+		return nil, fmt.Errorf("no hash algorithm")
+	}
+	for _, alg := range algs {
+		if _, err := typdefs.GetHFromAlg(alg); err != nil {
+			return nil, fmt.Errorf("%s: %w", alg, err)
+		}
+		// the ima template only has the sha1 digest of file.
+		if template == typdefs.StrIma && alg != typdefs.Sha1AlgStr {
+			return nil, fmt.Errorf("template %s only supports %s", template, typdefs.Sha1AlgStr)
+		}
+	}
+	return &generator{
+		algs:     algs,
+		template: template,
+		digests:  map[string]map[string][]typdefs.HexBytes{},
+	}, nil
+}
+
+// isMeasured returns whether the file is an executable, a library or a
+// kernel module, which are measured by ima when executed or mapped.
+func isMeasured(name string, mode os.FileMode) bool {
+	if mode&modeExec != 0 {
+		return true
+	}
+	base := filepath.Base(name)
+	if strings.HasSuffix(base, ".so") || strings.Contains(base, ".so.") {
+		return true
+	}
+	for _, s := range moduleSuffixes {
+		if strings.HasSuffix(base, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// addFile hashes the file content by all algorithms, the different
+// contents of the same path are all kept.
+func (g *generator) addFile(name string, mode os.FileMode, r io.Reader) error {
+	if !isMeasured(name, mode) {
+		return nil
+	}
+	hs := make([]hash.Hash, len(g.algs))
+	ws := make([]io.Writer, len(g.algs))
+	for i, alg := range g.algs {
+		hs[i], _ = typdefs.GetHFromAlg(alg)
+		ws[i] = hs[i]
+	}
+	_, err := io.Copy(io.MultiWriter(ws...), r)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	if g.digests[name] == nil {
+		g.digests[name] = map[string][]typdefs.HexBytes{}
+	}
+	for i, alg := range g.algs {
+		d := hs[i].Sum(nil)
+		if !containsDigest(g.digests[name][alg], d) {
+			g.digests[name][alg] = append(g.digests[name][alg], d)
+		}
+	}
+	return nil
+}
+
+func containsDigest(ds []typdefs.HexBytes, d []byte) bool {
+	for _, v := range ds {
+		if bytes.Equal(v, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// addRootfs adds all the measured regular files under the unpacked root
+// filesystem, the file names are the absolute paths in it.
+func (g *generator) addRootfs(root string) error {
+	return filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || !isMeasured(p, info.Mode()) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return g.addFile("/"+filepath.ToSlash(rel), info.Mode(), f)
+	})
+}
+
+// addRpm adds all the measured files in the rpm package.
+func (g *generator) addRpm(name string) error {
+	return readRpmFiles(name, g.addFile)
+}
+
+// refValues returns the optional ima reference values of all files, since a
+// node only measures the files it runs, sorted by file path and algorithm.
+func (g *generator) refValues() *typdefs.RefValues {
+	names := make([]string, 0, len(g.digests))
+	for n := range g.digests {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	rv := &typdefs.RefValues{Version: typdefs.RefValueVersion}
+	for _, n := range names {
+		for _, alg := range g.algs {
+			rv.Ima = append(rv.Ima, typdefs.RefValue{
+				Name:     n,
+				Alg:      alg,
+				Digests:  g.digests[n][alg],
+				Optional: true,
+				Template: g.template,
+			})
+		}
+	}
+	return rv
+}
+
+// writeRefValues writes the reference values as JSON, or as the legacy ima
+// base value text which has one line for each digest.
+func writeRefValues(w io.Writer, rv *typdefs.RefValues, format string) error {
+	switch format {
+	case formatJSON:
+		buf, err := json.MarshalIndent(rv, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(buf, '\n'))
+		return err
+	case formatText:
+		lines := &typdefs.RefValues{Version: rv.Version}
+		for _, e := range rv.Ima {
+			for _, d := range e.Digests {
+				e1 := e
+				e1.Digests = []typdefs.HexBytes{d}
+				lines.Ima = append(lines.Ima, e1)
+			}
+		}
+		_, _, ima := lines.LegacyText()
+		_, err := io.WriteString(w, ima)
+		return err
+	}
+	return fmt.Errorf("output format %s not supported", format)
+}
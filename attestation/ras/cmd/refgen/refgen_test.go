@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+type testFile struct {
+	name  string
+	mode  uint64
+	ino   uint64
+	nlink uint64
+	data  string
+}
+
+var testFiles = []testFile{
+	{"./usr", 040755, 1, 2, ""},
+	{"./usr/bin/ls", 0100755, 2, 1, "ls binary"},
+	{"./usr/lib64/libc.so.6", 0100644, 3, 1, "libc"},
+	{"./usr/lib/modules/5.10/a.ko.xz", 0100644, 4, 1, "module"},
+	{"./usr/share/doc/readme", 0100644, 5, 1, "readme"},
+	{"./usr/bin/ll", 0120777, 6, 1, "ls"},
+	{"./usr/bin/vi", 0100755, 7, 2, ""},
+	{"./usr/bin/vim", 0100755, 7, 2, "vim binary"},
+}
+
+func testDigest(alg, data string) typdefs.HexBytes {
+	h, _ := typdefs.GetHFromAlg(alg)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func writeCpio(buf *bytes.Buffer, f testFile) {
+	name := f.name + "\x00"
+	buf.WriteString(fmt.Sprintf("%s%08x%08x%08x%08x%08x%08x%08x%08x%08x%08x%08x%08x%08x",
+		cpioMagic, f.ino, f.mode, 0, 0, f.nlink, 0, len(f.data), 0, 0, 0, 0, len(name), 0))
+	buf.WriteString(name)
+	for buf.Len()%cpioAlign != 0 {
+		buf.WriteByte(0)
+	}
+	buf.WriteString(f.data)
+	for buf.Len()%cpioAlign != 0 {
+		buf.WriteByte(0)
+	}
+}
+
+func writeRpmHeader(buf *bytes.Buffer, tags map[int32]string) {
+	var index, data bytes.Buffer
+	for tag, v := range tags {
+		binary.Write(&index, binary.BigEndian, []uint32{uint32(tag), rpmTypeString, uint32(data.Len()), 1})
+		data.WriteString(v + "\x00")
+	}
+	buf.Write(rpmHeaderMagic)
+	binary.Write(buf, binary.BigEndian, []uint32{0, uint32(len(tags)), uint32(data.Len())})
+	buf.Write(index.Bytes())
+	buf.Write(data.Bytes())
+}
+
+func createTestRpm(t *testing.T, name string) {
+	var cpio bytes.Buffer
+	for _, f := range testFiles {
+		writeCpio(&cpio, f)
+	}
+	writeCpio(&cpio, testFile{name: cpioTrailer, nlink: 1})
+
+	var rpm bytes.Buffer
+	rpm.Write(rpmLeadMagic)
+	rpm.Write(make([]byte, rpmLeadLen-len(rpmLeadMagic)))
+	writeRpmHeader(&rpm, map[int32]string{1000: "sig"})
+	for rpm.Len()%rpmSigAlign != 0 {
+		rpm.WriteByte(0)
+	}
+	writeRpmHeader(&rpm, map[int32]string{rpmTagPayloadFormat: "cpio", rpmTagPayloadCompressor: "gzip"})
+	zw := gzip.NewWriter(&rpm)
+	zw.Write(cpio.Bytes())
+	zw.Close()
+	if err := ioutil.WriteFile(name, rpm.Bytes(), 0644); err != nil {
+		t.Fatalf("create test rpm error, %v", err)
+	}
+}
+
+func createTestRootfs(t *testing.T, root string) {
+	for _, f := range testFiles {
+		p := filepath.Join(root, f.name)
+		var err error
+		switch {
+		case f.mode&cpioModeType == 040000:
+			err = os.MkdirAll(p, 0755)
+		case f.mode&cpioModeType == 0120000:
+			err = os.Symlink(f.data, p)
+		case f.data != "":
+			os.MkdirAll(filepath.Dir(p), 0755)
+			err = ioutil.WriteFile(p, []byte(f.data), os.FileMode(f.mode&cpioModePerm))
+		}
+		if err != nil {
+			t.Fatalf("create test rootfs error, %v", err)
+		}
+	}
+	// vi is the hard link of vim.
+	err := os.Link(filepath.Join(root, "usr/bin/vim"), filepath.Join(root, "usr/bin/vi"))
+	if err != nil {
+		t.Fatalf("create test rootfs error, %v", err)
+	}
+}
+
+func TestIsMeasured(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mode   os.FileMode
+		result bool
+	}{
+		{"/usr/bin/ls", 0755, true},
+		{"/usr/libexec/a.sh", 0700, true},
+		{"/usr/lib64/libc.so", 0644, true},
+		{"/usr/lib64/libc.so.6", 0644, true},
+		{"/lib/modules/5.10/kernel/a.ko", 0644, true},
+		{"/lib/modules/5.10/kernel/a.ko.zst", 0644, true},
+		{"/usr/share/doc/readme", 0644, false},
+		{"/usr/share/doc/a.sop", 0644, false},
+	}
+	for i, tc := range testCases {
+		if isMeasured(tc.name, tc.mode) != tc.result {
+			t.Errorf("test isMeasured error at case %d\n", i)
+		}
+	}
+}
+
+func checkRefValues(t *testing.T, rv *typdefs.RefValues, algs []string) {
+	want := map[string]string{
+		"/usr/bin/ls":                   "ls binary",
+		"/usr/lib64/libc.so.6":          "libc",
+		"/usr/lib/modules/5.10/a.ko.xz": "module",
+		"/usr/bin/vi":                   "vim binary",
+		"/usr/bin/vim":                  "vim binary",
+	}
+	if len(rv.Ima) != len(want)*len(algs) {
+		t.Fatalf("test refValues error, %+v", rv.Ima)
+	}
+	for i, e := range rv.Ima {
+		data, ok := want[e.Name]
+		if !ok || e.Alg != algs[i%len(algs)] || !e.Optional || len(e.Digests) != 1 ||
+			!bytes.Equal(e.Digests[0], testDigest(e.Alg, data)) {
+			t.Errorf("test refValues error at entry %d, %+v\n", i, e)
+		}
+	}
+	if err := rv.Validate(); err != nil {
+		t.Errorf("test refValues validate error, %v", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "refgen")
+	if err != nil {
+		t.Fatalf("create temp dir error, %v", err)
+	}
+	defer os.RemoveAll(dir)
+	rpm := filepath.Join(dir, "test.rpm")
+	createTestRpm(t, rpm)
+	root := filepath.Join(dir, "rootfs")
+	createTestRootfs(t, root)
+
+	algs := []string{typdefs.Sha1AlgStr, typdefs.Sha256AlgStr, typdefs.Sm3AlgStr}
+	for _, src := range []string{rpm, root} {
+		g, _ := newGenerator(algs, typdefs.StrImaNg)
+		if strings.HasSuffix(src, ".rpm") {
+			err = g.addRpm(src)
+		} else {
+			err = g.addRootfs(src)
+		}
+		if err != nil {
+			t.Fatalf("test generate %s error, %v", src, err)
+		}
+		checkRefValues(t, g.refValues(), algs)
+	}
+
+	// the same file of different versions has all digests.
+	g, _ := newGenerator([]string{typdefs.Sha256AlgStr}, typdefs.StrImaNg)
+	g.addFile("/usr/bin/ls", 0755, strings.NewReader("v1"))
+	g.addFile("/usr/bin/ls", 0755, strings.NewReader("v2"))
+	g.addFile("/usr/bin/ls", 0755, strings.NewReader("v1"))
+	rv := g.refValues()
+	if len(rv.Ima) != 1 || len(rv.Ima[0].Digests) != 2 {
+		t.Errorf("test generate versions error, %+v", rv.Ima)
+	}
+	var buf bytes.Buffer
+	if err = writeRefValues(&buf, rv, formatText); err != nil ||
+		strings.Count(buf.String(), " /usr/bin/ls\n") != 2 {
+		t.Errorf("test write text error, %v %q", err, buf.String())
+	}
+	buf.Reset()
+	if err = writeRefValues(&buf, rv, formatJSON); err != nil {
+		t.Errorf("test write json error, %v", err)
+	}
+	if _, err = typdefs.ParseRefValues(buf.Bytes()); err != nil {
+		t.Errorf("test parse written json error, %v", err)
+	}
+
+	if _, err = newGenerator([]string{typdefs.Sha256AlgStr}, typdefs.StrIma); err == nil {
+		t.Errorf("test newGenerator with ima template error")
+	}
+	if _, err = newGenerator([]string{"md5"}, typdefs.StrImaNg); err == nil {
+		t.Errorf("test newGenerator with wrong alg error")
+	}
+	if err = g.addRpm(filepath.Join(root, "usr/bin/ls")); err == nil {
+		t.Errorf("test addRpm with wrong file error")
+	}
+}
@@ -0,0 +1,322 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: read the files in the cpio payload of rpm package.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const (
+	// rpm lead and header, see rpm doc/manual/format.
+	rpmLeadLen     = 96
+	rpmHeaderLen   = 16
+	rpmIndexLen    = 16
+	rpmSigAlign    = 8
+	rpmMaxIndexNum = 0x10000
+	rpmMaxDataLen  = 0x10000000
+	rpmTypeString  = 6
+	// rpm header tags of payload.
+	rpmTagPayloadFormat     = 1124
+	rpmTagPayloadCompressor = 1125
+	// cpio "newc" format, see cpio(5).
+	cpioMagic     = "070701"
+	cpioMagicCrc  = "070702"
+	cpioHeaderLen = 110
+	cpioFieldLen  = 8
+	cpioAlign     = 4
+	cpioTrailer   = "TRAILER!!!"
+	cpioModeType  = 0170000
+	cpioModeReg   = 0100000
+	cpioModePerm  = 07777
+)
+
+var (
+	rpmLeadMagic   = []byte{0xed, 0xab, 0xee, 0xdb}
+	rpmHeaderMagic = []byte{0x8e, 0xad, 0xe8, 0x01}
+
+	errRpmFormat  = errors.New("rpm format wrong")
+	errCpioFormat = errors.New("cpio format wrong")
+)
+
+type (
+	// cpioHeader is the part of cpio entry header used here.
+	cpioHeader struct {
+		Name  string
+		Ino   uint64
+		Mode  uint64
+		Nlink uint64
+		Size  int64
+	}
+
+	// cpioReader reads the entries of cpio newc archive in sequence.
+	cpioReader struct {
+		r      *bufio.Reader
+		offset int64
+		remain int64
+	}
+)
+
+// readRpmFiles calls fn with every regular file in the rpm package, the
+// hard links share the data of their last link.
+func readRpmFiles(name string, fn func(name string, mode os.FileMode, r io.Reader) error) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	payload, err := openRpmPayload(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	defer payload.Close()
+
+	cr := &cpioReader{r: bufio.NewReader(payload)}
+	links := map[uint64][]string{}
+	for {
+		hdr, err := cr.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if hdr.Mode&cpioModeType != cpioModeReg {
+			continue
+		}
+		fname := "/" + strings.TrimPrefix(hdr.Name, "./")
+		if hdr.Nlink > 1 && hdr.Size == 0 {
+			links[hdr.Ino] = append(links[hdr.Ino], fname)
+			continue
+		}
+		names := append(links[hdr.Ino], fname)
+		delete(links, hdr.Ino)
+		data, err := ioutil.ReadAll(cr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		for _, n := range names {
+			err = fn(n, os.FileMode(hdr.Mode&cpioModePerm), bytes.NewReader(data))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// openRpmPayload skips the lead and headers of rpm package, and returns the
+// decompressed cpio payload.
+func openRpmPayload(f io.Reader) (io.ReadCloser, error) {
+	lead := make([]byte, rpmLeadLen)
+	_, err := io.ReadFull(f, lead)
+	if err != nil || !bytes.Equal(lead[:len(rpmLeadMagic)], rpmLeadMagic) {
+		return nil, fmt.Errorf("%w: bad lead", errRpmFormat)
+	}
+	// the signature header is padded to 8 bytes.
+	sigLen, _, err := readRpmHeader(f)
+	if err != nil {
+		return nil, err
+	}
+	if pad := (rpmSigAlign - sigLen%rpmSigAlign) % rpmSigAlign; pad > 0 {
+		_, err = io.CopyN(ioutil.Discard, f, int64(pad))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errRpmFormat, err)
+		}
+	}
+	_, tags, err := readRpmHeader(f)
+	if err != nil {
+		return nil, err
+	}
+	if format, ok := tags[rpmTagPayloadFormat]; ok && format != "cpio" {
+		return nil, fmt.Errorf("%w: payload format %s not supported", errRpmFormat, format)
+	}
+	return decompress(f, tags[rpmTagPayloadCompressor])
+}
+
+// readRpmHeader reads one header structure, and returns its length and the
+// string tags in it.
+func readRpmHeader(f io.Reader) (int, map[int32]string, error) {
+	head := make([]byte, rpmHeaderLen)
+	_, err := io.ReadFull(f, head)
+	if err != nil || !bytes.Equal(head[:len(rpmHeaderMagic)], rpmHeaderMagic) {
+		return 0, nil, fmt.Errorf("%w: bad header", errRpmFormat)
+	}
+	num := binary.BigEndian.Uint32(head[8:12])
+	size := binary.BigEndian.Uint32(head[12:16])
+	if num > rpmMaxIndexNum || size > rpmMaxDataLen {
+		return 0, nil, fmt.Errorf("%w: header too large", errRpmFormat)
+	}
+	buf := make([]byte, int(num)*rpmIndexLen+int(size))
+	_, err = io.ReadFull(f, buf)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", errRpmFormat, err)
+	}
+	data := buf[int(num)*rpmIndexLen:]
+	tags := map[int32]string{}
+	for i := 0; i < int(num); i++ {
+		idx := buf[i*rpmIndexLen : (i+1)*rpmIndexLen]
+		tag := int32(binary.BigEndian.Uint32(idx[0:4]))
+		typ := binary.BigEndian.Uint32(idx[4:8])
+		off := binary.BigEndian.Uint32(idx[8:12])
+		if typ != rpmTypeString || off >= size {
+			continue
+		}
+		if end := bytes.IndexByte(data[off:], 0); end >= 0 {
+			tags[tag] = string(data[off : int(off)+end])
+		}
+	}
+	return rpmHeaderLen + len(buf), tags, nil
+}
+
+// decompress returns the decompressed payload, the compressors which aren't
+// in go library are run as external commands.
+func decompress(r io.Reader, compressor string) (io.ReadCloser, error) {
+	switch compressor {
+	case "", "gzip":
+		return gzip.NewReader(r)
+	case "bzip2":
+		return ioutil.NopCloser(bzip2.NewReader(r)), nil
+	case "xz", "lzma":
+		return execDecompress(r, "xz", "-dc")
+	case "zstd":
+		return execDecompress(r, "zstd", "-dcq")
+	}
+	return nil, fmt.Errorf("%w: payload compressor %s not supported", errRpmFormat, compressor)
+}
+
+type cmdReader struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (c *cmdReader) Close() error {
+	c.ReadCloser.Close()
+	return c.cmd.Wait()
+}
+
+func execDecompress(r io.Reader, name string, args ...string) (io.ReadCloser, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = r
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	err = cmd.Start()
+	if err != nil {
+		return nil, fmt.Errorf("run %s: %v", name, err)
+	}
+	return &cmdReader{ReadCloser: out, cmd: cmd}, nil
+}
+
+// next skips the rest of current entry and returns the next entry header,
+// io.EOF means the trailer is reached.
+func (cr *cpioReader) next() (*cpioHeader, error) {
+	err := cr.skip(cr.remain + cr.pad())
+	if err != nil {
+		return nil, err
+	}
+	cr.remain = 0
+	buf := make([]byte, cpioHeaderLen)
+	err = cr.readFull(buf)
+	if err != nil {
+		return nil, err
+	}
+	magic := string(buf[:len(cpioMagic)])
+	if magic != cpioMagic && magic != cpioMagicCrc {
+		return nil, fmt.Errorf("%w: bad magic %q", errCpioFormat, magic)
+	}
+	// ino mode uid gid nlink mtime filesize devmajor devminor rdevmajor
+	// rdevminor namesize check.
+	var fields [13]uint64
+	for i := range fields {
+		s := len(cpioMagic) + i*cpioFieldLen
+		fields[i], err = strconv.ParseUint(string(buf[s:s+cpioFieldLen]), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errCpioFormat, err)
+		}
+	}
+	name := make([]byte, fields[11])
+	err = cr.readFull(name)
+	if err != nil {
+		return nil, err
+	}
+	err = cr.skip(cr.pad())
+	if err != nil {
+		return nil, err
+	}
+	hdr := &cpioHeader{
+		Name:  string(bytes.TrimRight(name, "\x00")),
+		Ino:   fields[0],
+		Mode:  fields[1],
+		Nlink: fields[4],
+		Size:  int64(fields[6]),
+	}
+	if hdr.Name == cpioTrailer {
+		return nil, io.EOF
+	}
+	cr.remain = hdr.Size
+	return hdr, nil
+}
+
+// Read reads the data of current entry.
+func (cr *cpioReader) Read(p []byte) (int, error) {
+	if cr.remain <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > cr.remain {
+		p = p[:cr.remain]
+	}
+	n, err := cr.r.Read(p)
+	cr.offset += int64(n)
+	cr.remain -= int64(n)
+	if err == io.EOF && cr.remain > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (cr *cpioReader) readFull(p []byte) error {
+	n, err := io.ReadFull(cr.r, p)
+	cr.offset += int64(n)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errCpioFormat, err)
+	}
+	return nil
+}
+
+func (cr *cpioReader) skip(n int64) error {
+	m, err := io.CopyN(ioutil.Discard, cr.r, n)
+	cr.offset += m
+	if err != nil {
+		return fmt.Errorf("%w: %v", errCpioFormat, err)
+	}
+	return nil
+}
+
+// pad returns the padding length after the data of current entry, or
+// after the header and name.
+func (cr *cpioReader) pad() int64 {
+	return (cpioAlign - (cr.offset+cr.remain)%cpioAlign) % cpioAlign
+}
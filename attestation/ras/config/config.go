@@ -50,15 +50,23 @@ const (
 	confName = "config"
 	confExt  = "yaml"
 	// database
-	dbHost        = "database.host"
-	dbName        = "database.name"
-	dbPort        = "database.port"
-	dbUser        = "database.user"
-	dbPassword    = "database.password"
-	dbHostDefault = "localhost"
-	dbNameDefault = "kunpengsecl"
-	dbUserDefault = "postgres"
-	dbPortDefault = 5432
+	dbHost            = "database.host"
+	dbName            = "database.name"
+	dbPort            = "database.port"
+	dbUser            = "database.user"
+	dbPassword        = "database.password"
+	dbType            = "database.type"
+	dbAutoMigrate     = "database.automigrate"
+	dbStoreWorkers    = "database.storeworkers"
+	dbStoreQueueDepth = "database.storequeuedepth"
+	dbStoreBatchSize  = "database.storebatchsize"
+	dbStoreRetries    = "database.storeretries"
+	dbStoreBackoff    = "database.storeretrybackoff"
+	dbEnqueueTimeout  = "database.enqueuetimeout"
+	dbHostDefault     = "localhost"
+	dbNameDefault     = "kunpengsecl"
+	dbUserDefault     = "postgres"
+	dbPortDefault     = 5432
 	// logger
 	logFile = "log.file"
 	// RAS config key
@@ -76,6 +84,14 @@ const (
 	confHbDuration      = "racconfig.hbduration"
 	confTrustDuration   = "racconfig.trustduration"
 	confDigestAlgorithm = "racconfig.digestalgorithm"
+	confPcrSelection    = "racconfig.pcrselection"
+	confEKTrustStore    = "rasconfig.ektruststore"
+	confEKPolicy        = "rasconfig.ekpolicy"
+	confImaKeyring      = "rasconfig.imakeyring"
+	confSecureBoot      = "rasconfig.secureboot"
+	confStrictPcr       = "rasconfig.strictpcr"
+	confAutoUpdateWin   = "rasconfig.autoupdatewindow"
+	confAllowLegacyIK   = "rasconfig.allowlegacyikcert"
 	// RAS config default value
 	nullString      = ""
 	rasLogFile      = "./logs/ras-log.txt"
@@ -91,6 +107,11 @@ const (
 	strCompany      = "Company"
 	strRootCA       = "Root CA"
 	strPrivacyCA    = "Privacy CA"
+
+	// the maintenance window opened by setting a client to update
+	// automatically lasts one hour by default.
+	autoUpdateWindow = time.Hour
+
 	// server listen port
 	lflagServerPort = "port"
 	sflagServerPort = "p"
@@ -125,6 +146,21 @@ const (
 	AutoUpdateStrategy = "auto-update"
 	changeTime         = "rasconfig.changetime"
 	extRules           = "rasconfig.basevalue-extract-rules"
+	// EK certificate policy
+	// strict: EK certificate must be issued by the TPM vendor in trust store.
+	// allow-pca-issued-test: also accept the test EK certificate issued by
+	// PCA of ras itself, for the simulator.
+	// off: don't check EK certificate.
+	EKPolicyStrict    = "strict"
+	EKPolicyAllowTest = "allow-pca-issued-test"
+	EKPolicyOff       = "off"
+	// database type
+	// postgres: save data into postgres database server.
+	// sqlite: save data into the sqlite database file named by database.name.
+	// memory: save data in memory, all data is lost after ras exits.
+	DBTypePostgres = "postgres"
+	DBTypeSqlite   = "sqlite"
+	DBTypeMemory   = "memory"
 )
 
 type (
@@ -139,6 +175,16 @@ type (
 		dbUser     string
 		dbPassword string
 		dbPort     int
+		dbType     string
+		// migrate database schema up when ras starts
+		dbAutoMigrate bool
+		// store pipe which saves reports and base values, 0 means default
+		dbStoreWorkers    int
+		dbStoreQueueDepth int
+		dbStoreBatchSize  int
+		dbStoreRetries    int
+		dbStoreBackoff    time.Duration
+		dbEnqueueTimeout  time.Duration
 
 		// ras configuration
 		rootPrivKeyFile string
@@ -158,10 +204,23 @@ type (
 		mgrStrategy     string
 		extractRules    typdefs.ExtractRules
 		onlineDuration  time.Duration
+		ekTrustStoreDir string
+		ekTrustStore    *cryptotools.EKTrustStore
+		ekPolicy        string
+		imaKeyringDir   string
+		imaKeyring      *cryptotools.ImaKeyring
+		secureBoot      typdefs.SecureBootPolicy
+		strictPcr       bool
+		// the length of maintenance window opened by isautoupdate
+		autoUpdateWindow time.Duration
+		// accept the ik certs without ik name issued by old pca
+		allowLegacyIKCert bool
 		// rac configuration
 		hbDuration      time.Duration // heartbeat duration
 		trustDuration   time.Duration // trust state duration
 		digestAlgorithm string
+		// default pcr banks and pcrs quoted by rac, like "sha256:0-23"
+		pcrSelection string
 	}
 )
 
@@ -239,6 +298,16 @@ func getConfigs() {
 	rasCfg.dbPort = viper.GetInt(dbPort)
 	rasCfg.dbUser = viper.GetString(dbUser)
 	rasCfg.dbPassword = viper.GetString(dbPassword)
+	SetDBType(viper.GetString(dbType))
+	if viper.IsSet(dbAutoMigrate) {
+		rasCfg.dbAutoMigrate = viper.GetBool(dbAutoMigrate)
+	}
+	rasCfg.dbStoreWorkers = viper.GetInt(dbStoreWorkers)
+	rasCfg.dbStoreQueueDepth = viper.GetInt(dbStoreQueueDepth)
+	rasCfg.dbStoreBatchSize = viper.GetInt(dbStoreBatchSize)
+	rasCfg.dbStoreRetries = viper.GetInt(dbStoreRetries)
+	rasCfg.dbStoreBackoff = viper.GetDuration(dbStoreBackoff)
+	rasCfg.dbEnqueueTimeout = viper.GetDuration(dbEnqueueTimeout)
 	rasCfg.servPort = viper.GetString(confServerPort)
 	rasCfg.httpsSwitch = viper.GetBool(confhttpsSwitch)
 	rasCfg.restPort = viper.GetString(confRestPort)
@@ -248,7 +317,21 @@ func getConfigs() {
 	rasCfg.onlineDuration = viper.GetDuration(confOnlineDuration)
 	rasCfg.trustDuration = viper.GetDuration(confTrustDuration)
 	rasCfg.digestAlgorithm = viper.GetString(confDigestAlgorithm)
-	rasCfg.mgrStrategy = viper.GetString(mgrStrategy)
+	SetPcrSelection(viper.GetString(confPcrSelection))
+	SetMgrStrategy(viper.GetString(mgrStrategy))
+	rasCfg.changeTime = viper.GetTime(changeTime)
+	rasCfg.ekTrustStoreDir = viper.GetString(confEKTrustStore)
+	SetEKPolicy(viper.GetString(confEKPolicy))
+	rasCfg.imaKeyringDir = viper.GetString(confImaKeyring)
+	rasCfg.strictPcr = viper.GetBool(confStrictPcr)
+	rasCfg.allowLegacyIKCert = viper.GetBool(confAllowLegacyIK)
+	if viper.IsSet(confAutoUpdateWin) {
+		SetAutoUpdateWindow(viper.GetDuration(confAutoUpdateWin))
+	}
+	var sbp typdefs.SecureBootPolicy
+	if viper.UnmarshalKey(confSecureBoot, &sbp) == nil {
+		rasCfg.secureBoot = sbp
+	}
 	var ers typdefs.ExtractRules
 	if viper.UnmarshalKey(extRules, &ers) == nil {
 		rasCfg.extractRules = ers
@@ -410,6 +493,37 @@ func getPcaKeyCert() {
 	}
 }
 
+// getEKTrustStore loads the TPM vendor certificates for verifying EK.
+func getEKTrustStore() {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.ekTrustStore = cryptotools.NewEKTrustStore()
+	if rasCfg.ekTrustStoreDir == nullString {
+		return
+	}
+	err := rasCfg.ekTrustStore.LoadDir(rasCfg.ekTrustStoreDir)
+	if err != nil {
+		fmt.Printf("load EK trust store '%s' error: %v\n", rasCfg.ekTrustStoreDir, err)
+	}
+}
+
+// getImaKeyring loads the vendor file signing certificates for verifying
+// ima signatures.
+func getImaKeyring() {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.imaKeyring = cryptotools.NewImaKeyring()
+	if rasCfg.imaKeyringDir == nullString {
+		return
+	}
+	err := rasCfg.imaKeyring.LoadDir(rasCfg.imaKeyringDir)
+	if err != nil {
+		fmt.Printf("load ima keyring '%s' error: %v\n", rasCfg.imaKeyringDir, err)
+	}
+}
+
 // LoadConfigs searches and loads config from config.yaml file.
 func LoadConfigs() {
 	if rasCfg != nil {
@@ -419,15 +533,21 @@ func LoadConfigs() {
 	// set default values
 	rasCfg = &rasConfig{
 		// default database
-		dbHost:     dbHostDefault,
-		dbName:     dbNameDefault,
-		dbUser:     dbUserDefault,
-		dbPassword: dbUserDefault,
-		dbPort:     dbPortDefault,
+		dbHost:        dbHostDefault,
+		dbName:        dbNameDefault,
+		dbUser:        dbUserDefault,
+		dbPassword:    dbUserDefault,
+		dbPort:        dbPortDefault,
+		dbType:        DBTypePostgres,
+		dbAutoMigrate: true,
 		// default rac configure
 		hbDuration:      hbDuration,
 		trustDuration:   trustDuration,
 		digestAlgorithm: digestAlgorithm,
+		ekPolicy:        EKPolicyStrict,
+		mgrStrategy:     AutoStrategy,
+		// default auto-update window
+		autoUpdateWindow: autoUpdateWindow,
 	}
 	// set config.yaml loading name and path
 	viper.SetConfigName(confName)
@@ -443,6 +563,8 @@ func LoadConfigs() {
 	getConfigs()
 	getRootKeyCert()
 	getPcaKeyCert()
+	getEKTrustStore()
+	getImaKeyring()
 }
 
 // SaveConfigs saves all config variables to the config.yaml file.
@@ -456,6 +578,14 @@ func SaveConfigs() {
 	viper.Set(dbPort, rasCfg.dbPort)
 	viper.Set(dbUser, rasCfg.dbUser)
 	viper.Set(dbPassword, rasCfg.dbPassword)
+	viper.Set(dbType, rasCfg.dbType)
+	viper.Set(dbAutoMigrate, rasCfg.dbAutoMigrate)
+	viper.Set(dbStoreWorkers, rasCfg.dbStoreWorkers)
+	viper.Set(dbStoreQueueDepth, rasCfg.dbStoreQueueDepth)
+	viper.Set(dbStoreBatchSize, rasCfg.dbStoreBatchSize)
+	viper.Set(dbStoreRetries, rasCfg.dbStoreRetries)
+	viper.Set(dbStoreBackoff, rasCfg.dbStoreBackoff)
+	viper.Set(dbEnqueueTimeout, rasCfg.dbEnqueueTimeout)
 	viper.Set(confRootPrivKeyFile, rasCfg.rootPrivKeyFile)
 	viper.Set(confRootKeyCertFile, rasCfg.rootKeyCertFile)
 	viper.Set(confPcaPrivKeyFile, rasCfg.pcaPrivKeyFile)
@@ -468,8 +598,17 @@ func SaveConfigs() {
 	viper.Set(confSerialNumber, cryptotools.GetSerialNumber())
 	viper.Set(confHbDuration, rasCfg.hbDuration)
 	viper.Set(confOnlineDuration, rasCfg.onlineDuration)
+	viper.Set(confAutoUpdateWin, rasCfg.autoUpdateWindow)
 	viper.Set(confTrustDuration, rasCfg.trustDuration)
 	viper.Set(confDigestAlgorithm, rasCfg.digestAlgorithm)
+	viper.Set(confPcrSelection, rasCfg.pcrSelection)
+	viper.Set(confEKTrustStore, rasCfg.ekTrustStoreDir)
+	viper.Set(confEKPolicy, rasCfg.ekPolicy)
+	viper.Set(confImaKeyring, rasCfg.imaKeyringDir)
+	viper.Set(confStrictPcr, rasCfg.strictPcr)
+	viper.Set(confAllowLegacyIK, rasCfg.allowLegacyIKCert)
+	viper.Set(mgrStrategy, rasCfg.mgrStrategy)
+	viper.Set(changeTime, rasCfg.changeTime)
 	err := viper.WriteConfig()
 	if err != nil {
 		_ = viper.SafeWriteConfig()
@@ -572,7 +711,146 @@ func SetDBPassword(password string) {
 	rasCfg.dbPassword = password
 }
 
+// GetDBType returns the database type configuration.
+func GetDBType() string {
+	if rasCfg == nil {
+		return DBTypePostgres
+	}
+	return rasCfg.dbType
+}
+
+// SetDBType sets the database type configuration, unknown type is ignored.
+func SetDBType(t string) {
+	if rasCfg == nil {
+		return
+	}
+	switch t {
+	case DBTypePostgres, DBTypeSqlite, DBTypeMemory:
+		rasCfg.dbType = t
+	}
+}
+
+// GetDBAutoMigrate returns whether to migrate database schema when ras starts.
+func GetDBAutoMigrate() bool {
+	if rasCfg == nil {
+		return true
+	}
+	return rasCfg.dbAutoMigrate
+}
+
+// SetDBAutoMigrate sets whether to migrate database schema when ras starts.
+func SetDBAutoMigrate(b bool) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.dbAutoMigrate = b
+}
+
+// GetDBStoreWorkers returns the number of workers saving reports into database.
+func GetDBStoreWorkers() int {
+	if rasCfg == nil {
+		return 0
+	}
+	return rasCfg.dbStoreWorkers
+}
+
+// SetDBStoreWorkers sets the number of workers saving reports into database.
+func SetDBStoreWorkers(n int) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.dbStoreWorkers = n
+}
+
+// GetDBStoreQueueDepth returns the max number of reports waiting to be saved.
+func GetDBStoreQueueDepth() int {
+	if rasCfg == nil {
+		return 0
+	}
+	return rasCfg.dbStoreQueueDepth
+}
+
+// SetDBStoreQueueDepth sets the max number of reports waiting to be saved.
+func SetDBStoreQueueDepth(n int) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.dbStoreQueueDepth = n
+}
+
+// GetDBStoreBatchSize returns the max number of reports saved in one insert.
+func GetDBStoreBatchSize() int {
+	if rasCfg == nil {
+		return 0
+	}
+	return rasCfg.dbStoreBatchSize
+}
+
+// SetDBStoreBatchSize sets the max number of reports saved in one insert.
+func SetDBStoreBatchSize(n int) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.dbStoreBatchSize = n
+}
+
+// GetDBStoreRetries returns the retry times when saving reports fails.
+func GetDBStoreRetries() int {
+	if rasCfg == nil {
+		return 0
+	}
+	return rasCfg.dbStoreRetries
+}
+
+// SetDBStoreRetries sets the retry times when saving reports fails.
+func SetDBStoreRetries(n int) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.dbStoreRetries = n
+}
+
+// GetDBStoreRetryBackoff returns the first wait time before retrying to
+// save reports, it doubles after each retry.
+func GetDBStoreRetryBackoff() time.Duration {
+	if rasCfg == nil {
+		return 0
+	}
+	return rasCfg.dbStoreBackoff
+}
+
+// SetDBStoreRetryBackoff sets the first wait time before retrying to save
+// reports.
+func SetDBStoreRetryBackoff(d time.Duration) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.dbStoreBackoff = d
+}
+
+// GetDBEnqueueTimeout returns the max wait time to put a report into the
+// full store queue before dropping it.
+func GetDBEnqueueTimeout() time.Duration {
+	if rasCfg == nil {
+		return 0
+	}
+	return rasCfg.dbEnqueueTimeout
+}
+
+// SetDBEnqueueTimeout sets the max wait time to put a report into the full
+// store queue before dropping it.
+func SetDBEnqueueTimeout(d time.Duration) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.dbEnqueueTimeout = d
+}
+
+// GetExtractRules returns the rules to extract base values from reports.
 func GetExtractRules() typdefs.ExtractRules {
+	if rasCfg == nil {
+		return typdefs.ExtractRules{}
+	}
 	return rasCfg.extractRules
 }
 
@@ -730,6 +1008,24 @@ func SetOnlineDuration(v time.Duration) {
 	rasCfg.onlineDuration = v
 }
 
+// GetAutoUpdateWindow returns the length of maintenance window opened by
+// setting a client to update its base value automatically.
+func GetAutoUpdateWindow() time.Duration {
+	if rasCfg == nil {
+		return autoUpdateWindow
+	}
+	return rasCfg.autoUpdateWindow
+}
+
+// SetAutoUpdateWindow sets the length of auto-update maintenance window,
+// the non-positive value is ignored.
+func SetAutoUpdateWindow(v time.Duration) {
+	if rasCfg == nil || v <= 0 {
+		return
+	}
+	rasCfg.autoUpdateWindow = v
+}
+
 // GetTrustDuration returns trust report expire duration configuration.
 func GetTrustDuration() time.Duration {
 	if rasCfg == nil {
@@ -753,3 +1049,166 @@ func GetDigestAlgorithm() string {
 	}
 	return rasCfg.digestAlgorithm
 }
+
+// GetPcrSelection returns the default pcr selection quoted by rac, empty
+// means rac quotes all pcrs of the digest algorithm bank.
+func GetPcrSelection() string {
+	if rasCfg == nil {
+		return ""
+	}
+	return rasCfg.pcrSelection
+}
+
+// SetPcrSelection sets the default pcr selection quoted by rac, the old
+// one is kept if s is wrong.
+func SetPcrSelection(s string) error {
+	if rasCfg == nil {
+		return nil
+	}
+	sels, err := typdefs.ParsePcrSelections(s)
+	if err != nil {
+		return err
+	}
+	rasCfg.pcrSelection = typdefs.FormatPcrSelections(sels)
+	return nil
+}
+
+// GetEKTrustStoreDir returns the directory of TPM vendor certificates.
+func GetEKTrustStoreDir() string {
+	if rasCfg == nil {
+		return ""
+	}
+	return rasCfg.ekTrustStoreDir
+}
+
+// GetEKTrustStore returns the trust store which keeps TPM vendor certificates.
+func GetEKTrustStore() *cryptotools.EKTrustStore {
+	if rasCfg == nil {
+		return nil
+	}
+	return rasCfg.ekTrustStore
+}
+
+// GetImaKeyringDir returns the directory of vendor file signing certificates.
+func GetImaKeyringDir() string {
+	if rasCfg == nil {
+		return ""
+	}
+	return rasCfg.imaKeyringDir
+}
+
+// GetImaKeyring returns the keyring which keeps vendor file signing
+// certificates for verifying ima signatures.
+func GetImaKeyring() *cryptotools.ImaKeyring {
+	if rasCfg == nil {
+		return nil
+	}
+	return rasCfg.imaKeyring
+}
+
+// GetEKPolicy returns the EK certificate policy configuration.
+func GetEKPolicy() string {
+	if rasCfg == nil {
+		return EKPolicyStrict
+	}
+	return rasCfg.ekPolicy
+}
+
+// SetEKPolicy sets the EK certificate policy configuration, unknown policy
+// is ignored.
+func SetEKPolicy(p string) {
+	if rasCfg == nil {
+		return
+	}
+	switch p {
+	case EKPolicyStrict, EKPolicyAllowTest, EKPolicyOff:
+		rasCfg.ekPolicy = p
+	}
+}
+
+// GetSecureBootPolicy returns the secure boot policy checked for all reports.
+func GetSecureBootPolicy() *typdefs.SecureBootPolicy {
+	if rasCfg == nil {
+		return nil
+	}
+	p := rasCfg.secureBoot
+	return &p
+}
+
+// SetSecureBootPolicy sets the secure boot policy checked for all reports.
+func SetSecureBootPolicy(p typdefs.SecureBootPolicy) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.secureBoot = p
+}
+
+// GetStrictPcr returns whether the pcrs replayed from bios log must equal
+// the quoted pcrs.
+func GetStrictPcr() bool {
+	if rasCfg == nil {
+		return false
+	}
+	return rasCfg.strictPcr
+}
+
+// SetStrictPcr sets whether the pcrs replayed from bios log must equal the
+// quoted pcrs.
+func SetStrictPcr(v bool) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.strictPcr = v
+}
+
+// GetAllowLegacyIKCert returns whether the ik certs without ik name, which
+// are issued by old pca, are accepted without checking the quote signer.
+func GetAllowLegacyIKCert() bool {
+	if rasCfg == nil {
+		return false
+	}
+	return rasCfg.allowLegacyIKCert
+}
+
+// SetAllowLegacyIKCert sets whether the ik certs without ik name are
+// accepted without checking the quote signer.
+func SetAllowLegacyIKCert(v bool) {
+	if rasCfg == nil {
+		return
+	}
+	rasCfg.allowLegacyIKCert = v
+}
+
+// GetMgrStrategy returns the base value management strategy, only the
+// auto-update strategy allows a client to update its host base value
+// automatically without a maintenance window.
+func GetMgrStrategy() string {
+	if rasCfg == nil {
+		return AutoStrategy
+	}
+	return rasCfg.mgrStrategy
+}
+
+// SetMgrStrategy sets the base value management strategy and records the
+// change time, unknown strategy is ignored.
+func SetMgrStrategy(s string) {
+	if rasCfg == nil {
+		return
+	}
+	switch s {
+	case AutoStrategy, AutoUpdateStrategy:
+		if rasCfg.mgrStrategy != s {
+			rasCfg.mgrStrategy = s
+			rasCfg.changeTime = time.Now()
+		}
+	}
+}
+
+// GetChangeTime returns the last change time of the base value management
+// strategy.
+func GetChangeTime() time.Time {
+	if rasCfg == nil {
+		return time.Time{}
+	}
+	return rasCfg.changeTime
+}
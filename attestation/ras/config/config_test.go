@@ -64,14 +64,27 @@ database:
   password: postgres
   port: 5432
   user: postgres
+  type: sqlite
+  automigrate: false
+  storeworkers: 4
+  storequeuedepth: 200
+  storeretrybackoff: 200ms
 log:
   file: ./logs/ras-log.txt
 racconfig:
   digestalgorithm: sha1
+  pcrselection: sha256:0-7;sm3:0-7,10
   hbduration: 10s
   trustduration: 2m0s
 rasconfig:
   authkeyfile: ./ecdsakey.pub
+  ekpolicy: "off"
+  ektruststore: ../../common/cryptotools/certificates
+  imakeyring: ""
+  secureboot:
+    required: true
+    kekowners:
+    - Microsoft Corporation KEK CA 2011
   pcakeycertfile: ""
   pcaprivkeyfile: ""
   restport: 127.0.0.1:40002
@@ -79,7 +92,10 @@ rasconfig:
   rootprivkeyfile: ""
   serialnumber: 0
   serverport: 127.0.0.1:40001
+  strictpcr: true
+  allowlegacyikcert: true
   onlineduration: 30s
+  autoupdatewindow: 2h
   basevalue-extract-rules:
     manifest:
     - name:
@@ -229,6 +245,110 @@ func TestRASConfig2(t *testing.T) {
 	os.Remove(rasCfg.pcaPrivKeyFile)
 }
 
+func TestEKConfig(t *testing.T) {
+	CreateServerConfigFile()
+	defer RemoveConfigFile()
+
+	LoadConfigs()
+	HandleFlags()
+
+	if GetEKPolicy() != EKPolicyOff {
+		t.Errorf("test load EK policy error")
+	}
+	if GetEKTrustStoreDir() == "" || GetEKTrustStore() == nil ||
+		GetEKTrustStore().Len() == 0 {
+		t.Errorf("test load EK trust store error")
+	}
+	if GetImaKeyringDir() != "" || GetImaKeyring() == nil || GetImaKeyring().Len() != 0 {
+		t.Errorf("test load ima keyring error")
+	}
+	sbp := GetSecureBootPolicy()
+	if !sbp.Required || len(sbp.KEKOwners) != 1 || len(sbp.PKOwners) != 0 || sbp.IsEmpty() {
+		t.Errorf("test load secure boot policy error, %+v", sbp)
+	}
+	if !GetStrictPcr() {
+		t.Errorf("test load strict pcr error")
+	}
+	if !GetAllowLegacyIKCert() {
+		t.Errorf("test load allow legacy ik cert error")
+	}
+	if GetAutoUpdateWindow() != 2*time.Hour {
+		t.Errorf("test load auto update window error, %v", GetAutoUpdateWindow())
+	}
+	SetAutoUpdateWindow(0)
+	if GetAutoUpdateWindow() != 2*time.Hour {
+		t.Errorf("test SetAutoUpdateWindow ignore error, %v", GetAutoUpdateWindow())
+	}
+	testCases := []struct {
+		input  string
+		result string
+	}{
+		{EKPolicyStrict, EKPolicyStrict},
+		{EKPolicyAllowTest, EKPolicyAllowTest},
+		{"unknown", EKPolicyAllowTest},
+		{EKPolicyOff, EKPolicyOff},
+	}
+	for i := 0; i < len(testCases); i++ {
+		SetEKPolicy(testCases[i].input)
+		if GetEKPolicy() != testCases[i].result {
+			t.Errorf("test EK policy error at case %d\n", i)
+		}
+	}
+}
+
+func TestDBType(t *testing.T) {
+	CreateServerConfigFile()
+	defer RemoveConfigFile()
+
+	LoadConfigs()
+	HandleFlags()
+
+	if GetDBType() != DBTypeSqlite {
+		t.Errorf("test load database type error")
+	}
+	if GetDBAutoMigrate() {
+		t.Errorf("test load database auto migrate error")
+	}
+	SetDBAutoMigrate(true)
+	if !GetDBAutoMigrate() {
+		t.Errorf("test set database auto migrate error")
+	}
+	if GetDBStoreWorkers() != 4 || GetDBStoreQueueDepth() != 200 {
+		t.Errorf("test load database store pipe error")
+	}
+	if GetDBStoreRetryBackoff() != 200*time.Millisecond {
+		t.Errorf("test load database store retry backoff error")
+	}
+	if GetDBStoreBatchSize() != 0 || GetDBStoreRetries() != 0 || GetDBEnqueueTimeout() != 0 {
+		t.Errorf("test load database store pipe default error")
+	}
+	SetDBStoreWorkers(8)
+	SetDBStoreQueueDepth(400)
+	SetDBStoreBatchSize(50)
+	SetDBStoreRetries(5)
+	SetDBStoreRetryBackoff(time.Second)
+	SetDBEnqueueTimeout(2 * time.Second)
+	if GetDBStoreWorkers() != 8 || GetDBStoreQueueDepth() != 400 ||
+		GetDBStoreBatchSize() != 50 || GetDBStoreRetries() != 5 ||
+		GetDBStoreRetryBackoff() != time.Second || GetDBEnqueueTimeout() != 2*time.Second {
+		t.Errorf("test set database store pipe error")
+	}
+	testCases := []struct {
+		input  string
+		result string
+	}{
+		{DBTypeMemory, DBTypeMemory},
+		{"unknown", DBTypeMemory},
+		{DBTypePostgres, DBTypePostgres},
+	}
+	for i := 0; i < len(testCases); i++ {
+		SetDBType(testCases[i].input)
+		if GetDBType() != testCases[i].result {
+			t.Errorf("test database type error at case %d\n", i)
+		}
+	}
+}
+
 func testHBDuration(t *testing.T) {
 	LoadConfigs()
 
@@ -264,6 +384,32 @@ func testTrustDuration(t *testing.T) {
 	}
 }
 
+func TestPcrSelection(t *testing.T) {
+	CreateServerConfigFile()
+	defer RemoveConfigFile()
+
+	LoadConfigs()
+	HandleFlags()
+
+	if GetPcrSelection() != "sha256:0-7;sm3:0-7,10" {
+		t.Errorf("test load pcr selection error, %s", GetPcrSelection())
+	}
+	testCases := []struct {
+		input  string
+		result string
+	}{
+		{"sm3:0-23", "sm3:0-23"},
+		{"md5:0", "sm3:0-23"},
+		{"", ""},
+	}
+	for i := 0; i < len(testCases); i++ {
+		SetPcrSelection(testCases[i].input)
+		if GetPcrSelection() != testCases[i].result {
+			t.Errorf("test pcr selection error at case %d\n", i)
+		}
+	}
+}
+
 func TestRACConfig(t *testing.T) {
 	CreateClientConfigFile()
 	defer RemoveConfigFile()
@@ -281,3 +427,30 @@ func TestRACConfig(t *testing.T) {
 	os.Remove(rasCfg.pcaKeyCertFile)
 	os.Remove(rasCfg.pcaPrivKeyFile)
 }
+
+func TestMgrStrategy(t *testing.T) {
+	CreateServerConfigFile()
+	defer RemoveConfigFile()
+
+	LoadConfigs()
+	HandleFlags()
+
+	SetMgrStrategy(AutoStrategy)
+	testCases := []struct {
+		input   string
+		result  string
+		changed bool
+	}{
+		{"unknown", AutoStrategy, false},
+		{AutoUpdateStrategy, AutoUpdateStrategy, true},
+		{AutoUpdateStrategy, AutoUpdateStrategy, false},
+		{AutoStrategy, AutoStrategy, true},
+	}
+	for i := 0; i < len(testCases); i++ {
+		last := GetChangeTime()
+		SetMgrStrategy(testCases[i].input)
+		if GetMgrStrategy() != testCases[i].result || GetChangeTime().Equal(last) == testCases[i].changed {
+			t.Errorf("test mgr strategy error at case %d\n", i)
+		}
+	}
+}
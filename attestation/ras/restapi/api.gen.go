@@ -10,52 +10,597 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
 const (
 	Servermgt_oauth2Scopes = "servermgt_oauth2.Scopes"
 )
 
+// Defines values for BaseValueInfoImamode.
+const (
+	BaseValueInfoImamodeBoth BaseValueInfoImamode = "both"
+
+	BaseValueInfoImamodeHash BaseValueInfoImamode = "hash"
+
+	BaseValueInfoImamodeSignature BaseValueInfoImamode = "signature"
+
+	BaseValueInfoImamodeSignatureOrHash BaseValueInfoImamode = "signature-or-hash"
+)
+
+// Defines values for BaseValueInfoState.
+const (
+	BaseValueInfoStateActive BaseValueInfoState = "active"
+
+	BaseValueInfoStateApproved BaseValueInfoState = "approved"
+
+	BaseValueInfoStateDraft BaseValueInfoState = "draft"
+
+	BaseValueInfoStateRetired BaseValueInfoState = "retired"
+)
+
+// Defines values for MaintWindowParts.
+const (
+	MaintWindowPartsBios MaintWindowParts = "bios"
+
+	MaintWindowPartsIma MaintWindowParts = "ima"
+
+	MaintWindowPartsPcr MaintWindowParts = "pcr"
+)
+
+// Defines values for MaintWindowInfoState.
+const (
+	MaintWindowInfoStateClosed MaintWindowInfoState = "closed"
+
+	MaintWindowInfoStateOpen MaintWindowInfoState = "open"
+
+	MaintWindowInfoStateScheduled MaintWindowInfoState = "scheduled"
+)
+
+// Defines values for MismatchType.
+const (
+	MismatchTypeBios MismatchType = "bios"
+
+	MismatchTypeIma MismatchType = "ima"
+
+	MismatchTypePcr MismatchType = "pcr"
+)
+
+// Defines values for PolicyRuleSeverity.
+const (
+	PolicyRuleSeverityFail PolicyRuleSeverity = "fail"
+
+	PolicyRuleSeverityInfo PolicyRuleSeverity = "info"
+
+	PolicyRuleSeverityWarn PolicyRuleSeverity = "warn"
+)
+
+// Defines values for PolicyRuleType.
+const (
+	PolicyRuleTypeBios PolicyRuleType = "bios"
+
+	PolicyRuleTypeBiospcr PolicyRuleType = "biospcr"
+
+	PolicyRuleTypeClientinfo PolicyRuleType = "clientinfo"
+
+	PolicyRuleTypeIma PolicyRuleType = "ima"
+
+	PolicyRuleTypeImaunknown PolicyRuleType = "imaunknown"
+
+	PolicyRuleTypePcr PolicyRuleType = "pcr"
+
+	PolicyRuleTypeSecureboot PolicyRuleType = "secureboot"
+)
+
+// Defines values for RefValueAlg.
+const (
+	RefValueAlgSha1 RefValueAlg = "sha1"
+
+	RefValueAlgSha256 RefValueAlg = "sha256"
+
+	RefValueAlgSm3 RefValueAlg = "sm3"
+)
+
+// Defines values for RefValueMatch.
+const (
+	RefValueMatchExact RefValueMatch = "exact"
+
+	RefValueMatchGlob RefValueMatch = "glob"
+)
+
+// Defines values for RefValuesVersion.
+const (
+	RefValuesVersionN1 RefValuesVersion = 1
+)
+
+// Defines values for ReportResultCheck.
+const (
+	ReportResultCheckBasevalue ReportResultCheck = "basevalue"
+
+	ReportResultCheckBioslogimalog ReportResultCheck = "bioslog/imalog"
+
+	ReportResultCheckBiospcr ReportResultCheck = "biospcr"
+
+	ReportResultCheckPcrlog ReportResultCheck = "pcrlog"
+
+	ReportResultCheckPolicy ReportResultCheck = "policy"
+
+	ReportResultCheckQuote ReportResultCheck = "quote"
+
+	ReportResultCheckSecureboot ReportResultCheck = "secureboot"
+)
+
+// Defines values for TrustHistoryEntitytype.
+const (
+	TrustHistoryEntitytypeContainer TrustHistoryEntitytype = "container"
+
+	TrustHistoryEntitytypeDevice TrustHistoryEntitytype = "device"
+
+	TrustHistoryEntitytypeHost TrustHistoryEntitytype = "host"
+)
+
+// Defines values for TrustInfoEntitytype.
+const (
+	TrustInfoEntitytypeContainer TrustInfoEntitytype = "container"
+
+	TrustInfoEntitytypeDevice TrustInfoEntitytype = "device"
+
+	TrustInfoEntitytypeHost TrustInfoEntitytype = "host"
+)
+
+// Defines values for TrustState.
+const (
+	TrustStateExpired TrustState = "expired"
+
+	TrustStateOffline TrustState = "offline"
+
+	TrustStatePending TrustState = "pending"
+
+	TrustStateTrusted TrustState = "trusted"
+
+	TrustStateUnknown TrustState = "unknown"
+
+	TrustStateUntrusted TrustState = "untrusted"
+)
+
 // BaseValueInfo defines model for BaseValueInfo.
 type BaseValueInfo struct {
-	Bios       string `json:"bios"`
+	Author     *string `json:"author,omitempty"`
+	Basetype   string  `json:"basetype"`
+	Bios       string  `json:"bios"`
+	Clientid   int64   `json:"clientid"`
+	Createtime string  `json:"createtime"`
+	Enabled    bool    `json:"enabled"`
+
+	// the node group of a shared base value
+	Groupid    *int64                `json:"groupid,omitempty"`
+	Id         int64                 `json:"id"`
+	Ima        string                `json:"ima"`
+	Imamode    *BaseValueInfoImamode `json:"imamode,omitempty"`
+	Name       string                `json:"name"`
+	Pcr        string                `json:"pcr"`
+	Refvalue   *string               `json:"refvalue,omitempty"`
+	State      *BaseValueInfoState   `json:"state,omitempty"`
+	Updatetime *string               `json:"updatetime,omitempty"`
+	Uuid       string                `json:"uuid"`
+	Version    *int                  `json:"version,omitempty"`
+
+	// the maintenance window in which the base value is updated automatically
+	Windowid *int64 `json:"windowid,omitempty"`
+}
+
+// BaseValueInfoImamode defines model for BaseValueInfo.Imamode.
+type BaseValueInfoImamode string
+
+// BaseValueInfoState defines model for BaseValueInfo.State.
+type BaseValueInfoState string
+
+// BiosEvent defines model for BiosEvent.
+type BiosEvent struct {
+	Data     *string                 `json:"data,omitempty"`
+	Details  *map[string]interface{} `json:"details,omitempty"`
+	Digests  BiosEvent_Digests       `json:"digests"`
+	Id       string                  `json:"id"`
+	Index    int                     `json:"index"`
+	Pcr      int                     `json:"pcr"`
+	Type     int                     `json:"type"`
+	TypeName string                  `json:"typeName"`
+}
+
+// BiosEvent_Digests defines model for BiosEvent.Digests.
+type BiosEvent_Digests struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// Group defines model for Group.
+type Group struct {
+	Name string `json:"name"`
+
+	// the group with the highest priority is chosen if several groups select a server
+	Priority *int `json:"priority,omitempty"`
+
+	// the subset of the registered server info, like productname, biosversion and osrelease
+	Selector map[string]interface{} `json:"selector"`
+}
+
+// GroupInfo defines model for GroupInfo.
+type GroupInfo struct {
+	// Embedded struct due to allOf(#/components/schemas/Group)
+	Group `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	Basevalues *[]BaseValueInfo `json:"basevalues,omitempty"`
+	Clients    *[]int64         `json:"clients,omitempty"`
+	Createtime string           `json:"createtime"`
+	Id         int64            `json:"id"`
+}
+
+// ImaKeyInfo defines model for ImaKeyInfo.
+type ImaKeyInfo struct {
+	File     *string `json:"file,omitempty"`
+	Keyid    string  `json:"keyid"`
+	Notafter string  `json:"notafter"`
+	Subject  string  `json:"subject"`
+}
+
+// MaintWindow defines model for MaintWindow.
+type MaintWindow struct {
+
+	// the server of window, either clientid or groupid is set
+	Clientid *int64    `json:"clientid,omitempty"`
+	Endtime  time.Time `json:"endtime"`
+
+	// the node group of window
+	Groupid *int64 `json:"groupid,omitempty"`
+
+	// the parts of host base value allowed to change
+	Parts []MaintWindowParts `json:"parts"`
+
+	// the window starts now if not set
+	Starttime *time.Time `json:"starttime,omitempty"`
+}
+
+// MaintWindowParts defines model for MaintWindow.Parts.
+type MaintWindowParts string
+
+// MaintWindowInfo defines model for MaintWindowInfo.
+type MaintWindowInfo struct {
+	// Embedded struct due to allOf(#/components/schemas/MaintWindow)
+	MaintWindow `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	Author     string               `json:"author"`
+	Basevalues *[]BaseValueInfo     `json:"basevalues,omitempty"`
+	Closedby   *string              `json:"closedby,omitempty"`
+	Createtime string               `json:"createtime"`
+	Id         int64                `json:"id"`
+	State      MaintWindowInfoState `json:"state"`
+}
+
+// MaintWindowInfoState defines model for MaintWindowInfo.State.
+type MaintWindowInfoState string
+
+// Mismatch defines model for Mismatch.
+type Mismatch struct {
+
+	// the hex digest in report
+	Actual *string `json:"actual,omitempty"`
+	Alg    *string `json:"alg,omitempty"`
+
+	// the accepted hex digests
+	Expected *[]string `json:"expected,omitempty"`
+
+	// pcr index, bios event id or ima file path
+	Name   string       `json:"name"`
+	Reason string       `json:"reason"`
+	Type   MismatchType `json:"type"`
+}
+
+// MismatchType defines model for Mismatch.Type.
+type MismatchType string
+
+// Policy defines model for Policy.
+type Policy struct {
+	Enabled  *bool                   `json:"enabled,omitempty"`
+	Name     string                  `json:"name"`
+	Rules    []PolicyRule            `json:"rules"`
+	Selector *map[string]interface{} `json:"selector,omitempty"`
+}
+
+// PolicyInfo defines model for PolicyInfo.
+type PolicyInfo struct {
+	// Embedded struct due to allOf(#/components/schemas/Policy)
+	Policy `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
 	Createtime string `json:"createtime"`
-	Enabled    bool   `json:"enabled"`
-	Id         int64  `json:"id"`
-	Ima        string `json:"ima"`
-	Name       string `json:"name"`
-	Pcr        string `json:"pcr"`
+	Version    int    `json:"version"`
+}
+
+// PolicyRule defines model for PolicyRule.
+type PolicyRule struct {
+	Field    *string            `json:"field,omitempty"`
+	Name     string             `json:"name"`
+	Paths    *[]string          `json:"paths,omitempty"`
+	Pattern  *string            `json:"pattern,omitempty"`
+	Pcrs     *[]int             `json:"pcrs,omitempty"`
+	Severity PolicyRuleSeverity `json:"severity"`
+	Type     PolicyRuleType     `json:"type"`
+}
+
+// PolicyRuleSeverity defines model for PolicyRule.Severity.
+type PolicyRuleSeverity string
+
+// PolicyRuleType defines model for PolicyRule.Type.
+type PolicyRuleType string
+
+// RefValue defines model for RefValue.
+type RefValue struct {
+	Alg RefValueAlg `json:"alg"`
+
+	// the accepted hex digests
+	Digests []string       `json:"digests"`
+	Match   *RefValueMatch `json:"match,omitempty"`
+
+	// pcr index, bios event id or ima file path
+	Name string `json:"name"`
+
+	// nothing in the log has to match the name
+	Optional *bool   `json:"optional,omitempty"`
+	Template *string `json:"template,omitempty"`
+}
+
+// RefValueAlg defines model for RefValue.Alg.
+type RefValueAlg string
+
+// RefValueMatch defines model for RefValue.Match.
+type RefValueMatch string
+
+// RefValues defines model for RefValues.
+type RefValues struct {
+	Bios    *[]RefValue      `json:"bios,omitempty"`
+	Ima     *[]RefValue      `json:"ima,omitempty"`
+	Pcr     *[]RefValue      `json:"pcr,omitempty"`
+	Version RefValuesVersion `json:"version"`
 }
 
+// RefValuesVersion defines model for RefValues.Version.
+type RefValuesVersion int
+
 // ReportInfo defines model for ReportInfo.
 type ReportInfo struct {
-	Bioslog    string `json:"bioslog"`
-	Createtime string `json:"createtime"`
-	Id         int64  `json:"id"`
-	Imalog     string `json:"imalog"`
-	Pcrlog     string `json:"pcrlog"`
-	Quoted     string `json:"quoted"`
-	Signature  string `json:"signature"`
-	Trusted    bool   `json:"trusted"`
-	Validated  bool   `json:"validated"`
-}
+	Bioslog    string          `json:"bioslog"`
+	Createtime string          `json:"createtime"`
+	Id         int64           `json:"id"`
+	Imalog     string          `json:"imalog"`
+	Pcrlog     string          `json:"pcrlog"`
+	Quoted     string          `json:"quoted"`
+	Results    *[]ReportResult `json:"results,omitempty"`
+	Secureboot *string         `json:"secureboot,omitempty"`
+	Signature  string          `json:"signature"`
+	Trusted    bool            `json:"trusted"`
+	Validated  bool            `json:"validated"`
+	Verdict    *string         `json:"verdict,omitempty"`
+}
+
+// ReportResult defines model for ReportResult.
+type ReportResult struct {
+
+	// the base value used by the check
+	Baseid     *int64            `json:"baseid,omitempty"`
+	Check      ReportResultCheck `json:"check"`
+	Detail     *string           `json:"detail,omitempty"`
+	Mismatches *[]Mismatch       `json:"mismatches,omitempty"`
+	Passed     bool              `json:"passed"`
+}
+
+// ReportResultCheck defines model for ReportResult.Check.
+type ReportResultCheck string
 
 // ServerInfo defines model for ServerInfo.
 type ServerInfo struct {
-	Id      int64  `json:"id"`
-	Info    string `json:"info"`
-	Online  bool   `json:"online"`
-	Regtime string `json:"regtime"`
-	Trusted bool   `json:"trusted"`
+	Id           int64       `json:"id"`
+	Info         string      `json:"info"`
+	Isautoupdate bool        `json:"isautoupdate"`
+	Online       bool        `json:"online"`
+	Reason       *string     `json:"reason,omitempty"`
+	Regtime      string      `json:"regtime"`
+	State        *TrustState `json:"state,omitempty"`
+	Trusted      bool        `json:"trusted"`
+}
+
+// StoreStats defines model for StoreStats.
+type StoreStats struct {
+	Dropped     uint64 `json:"dropped"`
+	Queued      uint64 `json:"queued"`
+	Queuelength int    `json:"queuelength"`
+	Retries     uint64 `json:"retries"`
+	Saved       uint64 `json:"saved"`
+}
+
+// TrustHistory defines model for TrustHistory.
+type TrustHistory struct {
+	Clientid   int64                  `json:"clientid"`
+	Createtime string                 `json:"createtime"`
+	Detail     *string                `json:"detail,omitempty"`
+	Entitytype TrustHistoryEntitytype `json:"entitytype"`
+	Fromstate  TrustState             `json:"fromstate"`
+	Id         int64                  `json:"id"`
+	Reason     string                 `json:"reason"`
+	Tostate    TrustState             `json:"tostate"`
+	Uuid       *string                `json:"uuid,omitempty"`
+}
+
+// TrustHistoryEntitytype defines model for TrustHistory.Entitytype.
+type TrustHistoryEntitytype string
+
+// TrustInfo defines model for TrustInfo.
+type TrustInfo struct {
+	Detail     *string             `json:"detail,omitempty"`
+	Entitytype TrustInfoEntitytype `json:"entitytype"`
+	History    []TrustHistory      `json:"history"`
+	Reason     *string             `json:"reason,omitempty"`
+	State      TrustState          `json:"state"`
+	Updatetime string              `json:"updatetime"`
+	Uuid       *string             `json:"uuid,omitempty"`
+}
+
+// TrustInfoEntitytype defines model for TrustInfo.Entitytype.
+type TrustInfoEntitytype string
+
+// TrustState defines model for TrustState.
+type TrustState string
+
+// PostGroupsJSONBody defines parameters for PostGroups.
+type PostGroupsJSONBody Group
+
+// PostGroupsGroupidBasevaluesBasevalueidPromoteParams defines parameters for PostGroupsGroupidBasevaluesBasevalueidPromote.
+type PostGroupsGroupidBasevaluesBasevalueidPromoteParams struct {
+	Author *string `json:"author,omitempty"`
+}
+
+// PostGroupsGroupidBasevaluesBasevalueidRetireParams defines parameters for PostGroupsGroupidBasevaluesBasevalueidRetire.
+type PostGroupsGroupidBasevaluesBasevalueidRetireParams struct {
+	Author *string `json:"author,omitempty"`
+}
+
+// PostGroupsGroupidRefvaluesJSONBody defines parameters for PostGroupsGroupidRefvalues.
+type PostGroupsGroupidRefvaluesJSONBody RefValues
+
+// PostGroupsGroupidRefvaluesParams defines parameters for PostGroupsGroupidRefvalues.
+type PostGroupsGroupidRefvaluesParams struct {
+	Name    *string                                  `json:"name,omitempty"`
+	Enabled *bool                                    `json:"enabled,omitempty"`
+	Imamode *PostGroupsGroupidRefvaluesParamsImamode `json:"imamode,omitempty"`
+}
+
+// PostGroupsGroupidRefvaluesParamsImamode defines parameters for PostGroupsGroupidRefvalues.
+type PostGroupsGroupidRefvaluesParamsImamode string
+
+// PostMaintwindowsJSONBody defines parameters for PostMaintwindows.
+type PostMaintwindowsJSONBody MaintWindow
+
+// PostMaintwindowsParams defines parameters for PostMaintwindows.
+type PostMaintwindowsParams struct {
+	Author *string `json:"author,omitempty"`
+}
+
+// PostMaintwindowsWindowidCloseParams defines parameters for PostMaintwindowsWindowidClose.
+type PostMaintwindowsWindowidCloseParams struct {
+	Author *string `json:"author,omitempty"`
+}
+
+// PostPoliciesJSONBody defines parameters for PostPolicies.
+type PostPoliciesJSONBody Policy
+
+// PostIdBasevaluesRollbackParams defines parameters for PostIdBasevaluesRollback.
+type PostIdBasevaluesRollbackParams struct {
+	Author *string `json:"author,omitempty"`
+}
+
+// PostIdBasevaluesBasevalueidPromoteParams defines parameters for PostIdBasevaluesBasevalueidPromote.
+type PostIdBasevaluesBasevalueidPromoteParams struct {
+	Author *string `json:"author,omitempty"`
+}
+
+// PostIdBasevaluesBasevalueidRetireParams defines parameters for PostIdBasevaluesBasevalueidRetire.
+type PostIdBasevaluesBasevalueidRetireParams struct {
+	Author *string `json:"author,omitempty"`
+}
+
+// PostIdRefvaluesJSONBody defines parameters for PostIdRefvalues.
+type PostIdRefvaluesJSONBody RefValues
+
+// PostIdRefvaluesParams defines parameters for PostIdRefvalues.
+type PostIdRefvaluesParams struct {
+	Name    *string                       `json:"name,omitempty"`
+	Enabled *bool                         `json:"enabled,omitempty"`
+	Imamode *PostIdRefvaluesParamsImamode `json:"imamode,omitempty"`
+}
+
+// PostIdRefvaluesParamsImamode defines parameters for PostIdRefvalues.
+type PostIdRefvaluesParamsImamode string
+
+// PostUuidBasevalueJSONBody defines parameters for PostUuidBasevalue.
+type PostUuidBasevalueJSONBody BaseValueInfo
+
+// PostGroupsJSONRequestBody defines body for PostGroups for application/json ContentType.
+type PostGroupsJSONRequestBody PostGroupsJSONBody
+
+// PostGroupsGroupidRefvaluesJSONRequestBody defines body for PostGroupsGroupidRefvalues for application/json ContentType.
+type PostGroupsGroupidRefvaluesJSONRequestBody PostGroupsGroupidRefvaluesJSONBody
+
+// PostMaintwindowsJSONRequestBody defines body for PostMaintwindows for application/json ContentType.
+type PostMaintwindowsJSONRequestBody PostMaintwindowsJSONBody
+
+// PostPoliciesJSONRequestBody defines body for PostPolicies for application/json ContentType.
+type PostPoliciesJSONRequestBody PostPoliciesJSONBody
+
+// PostIdRefvaluesJSONRequestBody defines body for PostIdRefvalues for application/json ContentType.
+type PostIdRefvaluesJSONRequestBody PostIdRefvaluesJSONBody
+
+// PostUuidBasevalueJSONRequestBody defines body for PostUuidBasevalue for application/json ContentType.
+type PostUuidBasevalueJSONRequestBody PostUuidBasevalueJSONBody
+
+// Getter for additional properties for BiosEvent_Digests. Returns the specified
+// element and whether it was found
+func (a BiosEvent_Digests) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for BiosEvent_Digests
+func (a *BiosEvent_Digests) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for BiosEvent_Digests to handle AdditionalProperties
+func (a *BiosEvent_Digests) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for BiosEvent_Digests to handle AdditionalProperties
+func (a BiosEvent_Digests) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
 }
 
 // RequestEditorFn  is the function signature for the RequestEditor callback function
@@ -140,9 +685,74 @@ type ClientInterface interface {
 	// PostConfig request
 	PostConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetGroups request
+	GetGroups(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostGroups request  with any body
+	PostGroupsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostGroups(ctx context.Context, body PostGroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteGroupsGroupid request
+	DeleteGroupsGroupid(ctx context.Context, groupid int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetGroupsGroupid request
+	GetGroupsGroupid(ctx context.Context, groupid int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostGroupsGroupidBasevaluesBasevalueidPromote request
+	PostGroupsGroupidBasevaluesBasevalueidPromote(ctx context.Context, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidPromoteParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostGroupsGroupidBasevaluesBasevalueidRetire request
+	PostGroupsGroupidBasevaluesBasevalueidRetire(ctx context.Context, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidRetireParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostGroupsGroupidRefvalues request  with any body
+	PostGroupsGroupidRefvaluesWithBody(ctx context.Context, groupid int64, params *PostGroupsGroupidRefvaluesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostGroupsGroupidRefvalues(ctx context.Context, groupid int64, params *PostGroupsGroupidRefvaluesParams, body PostGroupsGroupidRefvaluesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetImaKeys request
+	GetImaKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostImaKeys request
+	PostImaKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteImaKeysKeyid request
+	DeleteImaKeysKeyid(ctx context.Context, keyid string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostLogin request
 	PostLogin(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetMaintwindows request
+	GetMaintwindows(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostMaintwindows request  with any body
+	PostMaintwindowsWithBody(ctx context.Context, params *PostMaintwindowsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostMaintwindows(ctx context.Context, params *PostMaintwindowsParams, body PostMaintwindowsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMaintwindowsWindowid request
+	GetMaintwindowsWindowid(ctx context.Context, windowid int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostMaintwindowsWindowidClose request
+	PostMaintwindowsWindowidClose(ctx context.Context, windowid int64, params *PostMaintwindowsWindowidCloseParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPolicies request
+	GetPolicies(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPolicies request  with any body
+	PostPoliciesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPolicies(ctx context.Context, body PostPoliciesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeletePoliciesName request
+	DeletePoliciesName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPoliciesName request
+	GetPoliciesName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStorestats request
+	GetStorestats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetVersion request
 	GetVersion(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -161,6 +771,9 @@ type ClientInterface interface {
 	// GetIdBasevalues request
 	GetIdBasevalues(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostIdBasevaluesRollback request
+	PostIdBasevaluesRollback(ctx context.Context, id int64, params *PostIdBasevaluesRollbackParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteIdBasevaluesBasevalueid request
 	DeleteIdBasevaluesBasevalueid(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -170,12 +783,32 @@ type ClientInterface interface {
 	// PostIdBasevaluesBasevalueid request
 	PostIdBasevaluesBasevalueid(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostIdBasevaluesBasevalueidPromote request
+	PostIdBasevaluesBasevalueidPromote(ctx context.Context, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidPromoteParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIdBasevaluesBasevalueidRefvalue request
+	GetIdBasevaluesBasevalueidRefvalue(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostIdBasevaluesBasevalueidRetire request
+	PostIdBasevaluesBasevalueidRetire(ctx context.Context, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidRetireParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIdContainerStatus request
+	GetIdContainerStatus(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIdDeviceStatus request
+	GetIdDeviceStatus(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetIdNewbasevalue request
 	GetIdNewbasevalue(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// PostIdNewbasevalue request
 	PostIdNewbasevalue(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostIdRefvalues request  with any body
+	PostIdRefvaluesWithBody(ctx context.Context, id int64, params *PostIdRefvaluesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostIdRefvalues(ctx context.Context, id int64, params *PostIdRefvaluesParams, body PostIdRefvaluesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetIdReports request
 	GetIdReports(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -184,6 +817,23 @@ type ClientInterface interface {
 
 	// GetIdReportsReportid request
 	GetIdReportsReportid(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIdReportsReportidBioslog request
+	GetIdReportsReportidBioslog(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIdTrust request
+	GetIdTrust(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUuidBasevalue request
+	GetUuidBasevalue(ctx context.Context, uuid string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUuidBasevalue request  with any body
+	PostUuidBasevalueWithBody(ctx context.Context, uuid string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUuidBasevalue(ctx context.Context, uuid string, body PostUuidBasevalueJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUuidStatus request
+	GetUuidStatus(ctx context.Context, uuid string, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) Get(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -222,8 +872,8 @@ func (c *Client) PostConfig(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostLogin(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostLoginRequest(c.Server)
+func (c *Client) GetGroups(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetGroupsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -234,8 +884,8 @@ func (c *Client) PostLogin(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetVersion(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetVersionRequest(c.Server)
+func (c *Client) PostGroupsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostGroupsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -246,8 +896,8 @@ func (c *Client) GetVersion(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetFromTo(ctx context.Context, from int64, to int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetFromToRequest(c.Server, from, to)
+func (c *Client) PostGroups(ctx context.Context, body PostGroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostGroupsRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -258,8 +908,8 @@ func (c *Client) GetFromTo(ctx context.Context, from int64, to int64, reqEditors
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteIdRequest(c.Server, id)
+func (c *Client) DeleteGroupsGroupid(ctx context.Context, groupid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteGroupsGroupidRequest(c.Server, groupid)
 	if err != nil {
 		return nil, err
 	}
@@ -270,8 +920,8 @@ func (c *Client) DeleteId(ctx context.Context, id int64, reqEditors ...RequestEd
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetIdRequest(c.Server, id)
+func (c *Client) GetGroupsGroupid(ctx context.Context, groupid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetGroupsGroupidRequest(c.Server, groupid)
 	if err != nil {
 		return nil, err
 	}
@@ -282,8 +932,8 @@ func (c *Client) GetId(ctx context.Context, id int64, reqEditors ...RequestEdito
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostIdRequest(c.Server, id)
+func (c *Client) PostGroupsGroupidBasevaluesBasevalueidPromote(ctx context.Context, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidPromoteParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostGroupsGroupidBasevaluesBasevalueidPromoteRequest(c.Server, groupid, basevalueid, params)
 	if err != nil {
 		return nil, err
 	}
@@ -294,8 +944,8 @@ func (c *Client) PostId(ctx context.Context, id int64, reqEditors ...RequestEdit
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetIdBasevalues(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetIdBasevaluesRequest(c.Server, id)
+func (c *Client) PostGroupsGroupidBasevaluesBasevalueidRetire(ctx context.Context, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidRetireParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostGroupsGroupidBasevaluesBasevalueidRetireRequest(c.Server, groupid, basevalueid, params)
 	if err != nil {
 		return nil, err
 	}
@@ -306,8 +956,8 @@ func (c *Client) GetIdBasevalues(ctx context.Context, id int64, reqEditors ...Re
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteIdBasevaluesBasevalueid(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteIdBasevaluesBasevalueidRequest(c.Server, id, basevalueid)
+func (c *Client) PostGroupsGroupidRefvaluesWithBody(ctx context.Context, groupid int64, params *PostGroupsGroupidRefvaluesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostGroupsGroupidRefvaluesRequestWithBody(c.Server, groupid, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -318,8 +968,8 @@ func (c *Client) DeleteIdBasevaluesBasevalueid(ctx context.Context, id int64, ba
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetIdBasevaluesBasevalueid(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetIdBasevaluesBasevalueidRequest(c.Server, id, basevalueid)
+func (c *Client) PostGroupsGroupidRefvalues(ctx context.Context, groupid int64, params *PostGroupsGroupidRefvaluesParams, body PostGroupsGroupidRefvaluesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostGroupsGroupidRefvaluesRequest(c.Server, groupid, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -330,8 +980,8 @@ func (c *Client) GetIdBasevaluesBasevalueid(ctx context.Context, id int64, basev
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostIdBasevaluesBasevalueid(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostIdBasevaluesBasevalueidRequest(c.Server, id, basevalueid)
+func (c *Client) GetImaKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetImaKeysRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -342,8 +992,8 @@ func (c *Client) PostIdBasevaluesBasevalueid(ctx context.Context, id int64, base
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetIdNewbasevalue(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetIdNewbasevalueRequest(c.Server, id)
+func (c *Client) PostImaKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostImaKeysRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -354,8 +1004,8 @@ func (c *Client) GetIdNewbasevalue(ctx context.Context, id int64, reqEditors ...
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostIdNewbasevalue(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostIdNewbasevalueRequest(c.Server, id)
+func (c *Client) DeleteImaKeysKeyid(ctx context.Context, keyid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteImaKeysKeyidRequest(c.Server, keyid)
 	if err != nil {
 		return nil, err
 	}
@@ -366,8 +1016,8 @@ func (c *Client) PostIdNewbasevalue(ctx context.Context, id int64, reqEditors ..
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetIdReports(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetIdReportsRequest(c.Server, id)
+func (c *Client) PostLogin(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostLoginRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -378,8 +1028,8 @@ func (c *Client) GetIdReports(ctx context.Context, id int64, reqEditors ...Reque
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteIdReportsReportid(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteIdReportsReportidRequest(c.Server, id, reportid)
+func (c *Client) GetMaintwindows(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMaintwindowsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -390,8 +1040,8 @@ func (c *Client) DeleteIdReportsReportid(ctx context.Context, id int64, reportid
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetIdReportsReportid(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetIdReportsReportidRequest(c.Server, id, reportid)
+func (c *Client) PostMaintwindowsWithBody(ctx context.Context, params *PostMaintwindowsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostMaintwindowsRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -402,383 +1052,460 @@ func (c *Client) GetIdReportsReportid(ctx context.Context, id int64, reportid in
 	return c.Client.Do(req)
 }
 
-// NewGetRequest generates requests for Get
-func NewGetRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostMaintwindows(ctx context.Context, params *PostMaintwindowsParams, body PostMaintwindowsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostMaintwindowsRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/")
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
-	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) GetMaintwindowsWindowid(ctx context.Context, windowid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMaintwindowsWindowidRequest(c.Server, windowid)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetConfigRequest generates requests for GetConfig
-func NewGetConfigRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostMaintwindowsWindowidClose(ctx context.Context, windowid int64, params *PostMaintwindowsWindowidCloseParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostMaintwindowsWindowidCloseRequest(c.Server, windowid, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/config")
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
-	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) GetPolicies(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPoliciesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostConfigRequest generates requests for PostConfig
-func NewPostConfigRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostPoliciesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPoliciesRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/config")
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
-	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+func (c *Client) PostPolicies(ctx context.Context, body PostPoliciesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPoliciesRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostLoginRequest generates requests for PostLogin
-func NewPostLoginRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DeletePoliciesName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeletePoliciesNameRequest(c.Server, name)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/login")
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
-	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+func (c *Client) GetPoliciesName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPoliciesNameRequest(c.Server, name)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetVersionRequest generates requests for GetVersion
-func NewGetVersionRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetStorestats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStorestatsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/version")
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
-	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) GetVersion(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetVersionRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetFromToRequest generates requests for GetFromTo
-func NewGetFromToRequest(server string, from int64, to int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "from", runtime.ParamLocationPath, from)
+func (c *Client) GetFromTo(ctx context.Context, from int64, to int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFromToRequest(c.Server, from, to)
 	if err != nil {
 		return nil, err
 	}
-
-	var pathParam1 string
-
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "to", runtime.ParamLocationPath, to)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) DeleteId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/%s/%s", pathParam0, pathParam1)
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
-	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) GetId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewDeleteIdRequest generates requests for DeleteId
-func NewDeleteIdRequest(server string, id int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) PostId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetIdBasevalues(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdBasevaluesRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
+func (c *Client) PostIdBasevaluesRollback(ctx context.Context, id int64, params *PostIdBasevaluesRollbackParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIdBasevaluesRollbackRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
 	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+func (c *Client) DeleteIdBasevaluesBasevalueid(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteIdBasevaluesBasevalueidRequest(c.Server, id, basevalueid)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetIdRequest generates requests for GetId
-func NewGetIdRequest(server string, id int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) GetIdBasevaluesBasevalueid(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdBasevaluesBasevalueidRequest(c.Server, id, basevalueid)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostIdBasevaluesBasevalueid(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIdBasevaluesBasevalueidRequest(c.Server, id, basevalueid)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
-	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PostIdBasevaluesBasevalueidPromote(ctx context.Context, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidPromoteParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIdBasevaluesBasevalueidPromoteRequest(c.Server, id, basevalueid, params)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostIdRequest generates requests for PostId
-func NewPostIdRequest(server string, id int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) GetIdBasevaluesBasevalueidRefvalue(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdBasevaluesBasevalueidRefvalueRequest(c.Server, id, basevalueid)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostIdBasevaluesBasevalueidRetire(ctx context.Context, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidRetireParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIdBasevaluesBasevalueidRetireRequest(c.Server, id, basevalueid, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
+func (c *Client) GetIdContainerStatus(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdContainerStatusRequest(c.Server, id)
+	if err != nil {
+		return nil, err
 	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+func (c *Client) GetIdDeviceStatus(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdDeviceStatusRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetIdBasevaluesRequest generates requests for GetIdBasevalues
-func NewGetIdBasevaluesRequest(server string, id int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) GetIdNewbasevalue(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdNewbasevalueRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostIdNewbasevalue(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIdNewbasevalueRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/%s/basevalues", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
+func (c *Client) PostIdRefvaluesWithBody(ctx context.Context, id int64, params *PostIdRefvaluesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIdRefvaluesRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PostIdRefvalues(ctx context.Context, id int64, params *PostIdRefvaluesParams, body PostIdRefvaluesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIdRefvaluesRequest(c.Server, id, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewDeleteIdBasevaluesBasevalueidRequest generates requests for DeleteIdBasevaluesBasevalueid
-func NewDeleteIdBasevaluesBasevalueidRequest(server string, id int64, basevalueid int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) GetIdReports(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdReportsRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam1 string
-
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+func (c *Client) DeleteIdReportsReportid(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteIdReportsReportidRequest(c.Server, id, reportid)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetIdReportsReportid(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdReportsReportidRequest(c.Server, id, reportid)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/%s/basevalues/%s", pathParam0, pathParam1)
-	if operationPath[0] == '/' {
-		operationPath = operationPath[1:]
+func (c *Client) GetIdReportsReportidBioslog(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdReportsReportidBioslogRequest(c.Server, id, reportid)
+	if err != nil {
+		return nil, err
 	}
-	operationURL := url.URL{
-		Path: operationPath,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL := serverURL.ResolveReference(&operationURL)
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+func (c *Client) GetIdTrust(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdTrustRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetIdBasevaluesBasevalueidRequest generates requests for GetIdBasevaluesBasevalueid
-func NewGetIdBasevaluesBasevalueidRequest(server string, id int64, basevalueid int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
+func (c *Client) GetUuidBasevalue(ctx context.Context, uuid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUuidBasevalueRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) PostUuidBasevalueWithBody(ctx context.Context, uuid string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUuidBasevalueRequestWithBody(c.Server, uuid, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam1 string
+func (c *Client) PostUuidBasevalue(ctx context.Context, uuid string, body PostUuidBasevalueJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUuidBasevalueRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+func (c *Client) GetUuidStatus(ctx context.Context, uuid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUuidStatusRequest(c.Server, uuid)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetRequest generates requests for Get
+func NewGetRequest(server string) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/%s/basevalues/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/")
 	if operationPath[0] == '/' {
 		operationPath = operationPath[1:]
 	}
@@ -796,30 +1523,43 @@ func NewGetIdBasevaluesBasevalueidRequest(server string, id int64, basevalueid i
 	return req, nil
 }
 
-// NewPostIdBasevaluesBasevalueidRequest generates requests for PostIdBasevaluesBasevalueid
-func NewPostIdBasevaluesBasevalueidRequest(server string, id int64, basevalueid int64) (*http.Request, error) {
+// NewGetConfigRequest generates requests for GetConfig
+func NewGetConfigRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	var pathParam1 string
+	operationPath := fmt.Sprintf("/config")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	return req, nil
+}
+
+// NewPostConfigRequest generates requests for PostConfig
+func NewPostConfigRequest(server string) (*http.Request, error) {
+	var err error
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/%s/basevalues/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/config")
 	if operationPath[0] == '/' {
 		operationPath = operationPath[1:]
 	}
@@ -837,23 +1577,16 @@ func NewPostIdBasevaluesBasevalueidRequest(server string, id int64, basevalueid
 	return req, nil
 }
 
-// NewGetIdNewbasevalueRequest generates requests for GetIdNewbasevalue
-func NewGetIdNewbasevalueRequest(server string, id int64) (*http.Request, error) {
+// NewGetGroupsRequest generates requests for GetGroups
+func NewGetGroupsRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/%s/newbasevalue", pathParam0)
+	operationPath := fmt.Sprintf("/groups")
 	if operationPath[0] == '/' {
 		operationPath = operationPath[1:]
 	}
@@ -871,23 +1604,27 @@ func NewGetIdNewbasevalueRequest(server string, id int64) (*http.Request, error)
 	return req, nil
 }
 
-// NewPostIdNewbasevalueRequest generates requests for PostIdNewbasevalue
-func NewPostIdNewbasevalueRequest(server string, id int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+// NewPostGroupsRequest calls the generic PostGroups builder with application/json body
+func NewPostGroupsRequest(server string, body PostGroupsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostGroupsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostGroupsRequestWithBody generates requests for PostGroups with any type of body
+func NewPostGroupsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/%s/newbasevalue", pathParam0)
+	operationPath := fmt.Sprintf("/groups")
 	if operationPath[0] == '/' {
 		operationPath = operationPath[1:]
 	}
@@ -897,21 +1634,23 @@ func NewPostIdNewbasevalueRequest(server string, id int64) (*http.Request, error
 
 	queryURL := serverURL.ResolveReference(&operationURL)
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetIdReportsRequest generates requests for GetIdReports
-func NewGetIdReportsRequest(server string, id int64) (*http.Request, error) {
+// NewDeleteGroupsGroupidRequest generates requests for DeleteGroupsGroupid
+func NewDeleteGroupsGroupidRequest(server string, groupid int64) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupid", runtime.ParamLocationPath, groupid)
 	if err != nil {
 		return nil, err
 	}
@@ -921,7 +1660,41 @@ func NewGetIdReportsRequest(server string, id int64) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/%s/reports", pathParam0)
+	operationPath := fmt.Sprintf("/groups/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetGroupsGroupidRequest generates requests for GetGroupsGroupid
+func NewGetGroupsGroupidRequest(server string, groupid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupid", runtime.ParamLocationPath, groupid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/groups/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = operationPath[1:]
 	}
@@ -939,20 +1712,20 @@ func NewGetIdReportsRequest(server string, id int64) (*http.Request, error) {
 	return req, nil
 }
 
-// NewDeleteIdReportsReportidRequest generates requests for DeleteIdReportsReportid
-func NewDeleteIdReportsReportidRequest(server string, id int64, reportid int64) (*http.Request, error) {
+// NewPostGroupsGroupidBasevaluesBasevalueidPromoteRequest generates requests for PostGroupsGroupidBasevaluesBasevalueidPromote
+func NewPostGroupsGroupidBasevaluesBasevalueidPromoteRequest(server string, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidPromoteParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupid", runtime.ParamLocationPath, groupid)
 	if err != nil {
 		return nil, err
 	}
 
 	var pathParam1 string
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "reportid", runtime.ParamLocationPath, reportid)
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
 	if err != nil {
 		return nil, err
 	}
@@ -962,7 +1735,7 @@ func NewDeleteIdReportsReportidRequest(server string, id int64, reportid int64)
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/%s/reports/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/groups/%s/basevalues/%s/promote", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = operationPath[1:]
 	}
@@ -972,7 +1745,27 @@ func NewDeleteIdReportsReportidRequest(server string, id int64, reportid int64)
 
 	queryURL := serverURL.ResolveReference(&operationURL)
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	queryValues := queryURL.Query()
+
+	if params.Author != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -980,20 +1773,20 @@ func NewDeleteIdReportsReportidRequest(server string, id int64, reportid int64)
 	return req, nil
 }
 
-// NewGetIdReportsReportidRequest generates requests for GetIdReportsReportid
-func NewGetIdReportsReportidRequest(server string, id int64, reportid int64) (*http.Request, error) {
+// NewPostGroupsGroupidBasevaluesBasevalueidRetireRequest generates requests for PostGroupsGroupidBasevaluesBasevalueidRetire
+func NewPostGroupsGroupidBasevaluesBasevalueidRetireRequest(server string, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidRetireParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupid", runtime.ParamLocationPath, groupid)
 	if err != nil {
 		return nil, err
 	}
 
 	var pathParam1 string
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "reportid", runtime.ParamLocationPath, reportid)
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
 	if err != nil {
 		return nil, err
 	}
@@ -1003,7 +1796,7 @@ func NewGetIdReportsReportidRequest(server string, id int64, reportid int64) (*h
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/%s/reports/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/groups/%s/basevalues/%s/retire", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = operationPath[1:]
 	}
@@ -1013,7 +1806,27 @@ func NewGetIdReportsReportidRequest(server string, id int64, reportid int64) (*h
 
 	queryURL := serverURL.ResolveReference(&operationURL)
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	queryValues := queryURL.Query()
+
+	if params.Author != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1021,670 +1834,4092 @@ func NewGetIdReportsReportidRequest(server string, id int64, reportid int64) (*h
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewPostGroupsGroupidRefvaluesRequest calls the generic PostGroupsGroupidRefvalues builder with application/json body
+func NewPostGroupsGroupidRefvaluesRequest(server string, groupid int64, params *PostGroupsGroupidRefvaluesParams, body PostGroupsGroupidRefvaluesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	bodyReader = bytes.NewReader(buf)
+	return NewPostGroupsGroupidRefvaluesRequestWithBody(server, groupid, params, "application/json", bodyReader)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewPostGroupsGroupidRefvaluesRequestWithBody generates requests for PostGroupsGroupidRefvalues with any type of body
+func NewPostGroupsGroupidRefvaluesRequestWithBody(server string, groupid int64, params *PostGroupsGroupidRefvaluesParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupid", runtime.ParamLocationPath, groupid)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
-
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// Get request
-	GetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetResponse, error)
-
-	// GetConfig request
-	GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error)
 
-	// PostConfig request
-	PostConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostConfigResponse, error)
+	operationPath := fmt.Sprintf("/groups/%s/refvalues", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
 
-	// PostLogin request
-	PostLoginWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostLoginResponse, error)
+	queryURL := serverURL.ResolveReference(&operationURL)
 
-	// GetVersion request
-	GetVersionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVersionResponse, error)
+	queryValues := queryURL.Query()
 
-	// GetFromTo request
-	GetFromToWithResponse(ctx context.Context, from int64, to int64, reqEditors ...RequestEditorFn) (*GetFromToResponse, error)
+	if params.Name != nil {
 
-	// DeleteId request
-	DeleteIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteIdResponse, error)
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "name", runtime.ParamLocationQuery, *params.Name); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
 
-	// GetId request
-	GetIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdResponse, error)
+	}
 
-	// PostId request
-	PostIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostIdResponse, error)
+	if params.Enabled != nil {
 
-	// GetIdBasevalues request
-	GetIdBasevaluesWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesResponse, error)
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "enabled", runtime.ParamLocationQuery, *params.Enabled); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
 
-	// DeleteIdBasevaluesBasevalueid request
-	DeleteIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*DeleteIdBasevaluesBasevalueidResponse, error)
+	}
 
-	// GetIdBasevaluesBasevalueid request
-	GetIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesBasevalueidResponse, error)
+	if params.Imamode != nil {
 
-	// PostIdBasevaluesBasevalueid request
-	PostIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*PostIdBasevaluesBasevalueidResponse, error)
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "imamode", runtime.ParamLocationQuery, *params.Imamode); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
 
-	// GetIdNewbasevalue request
-	GetIdNewbasevalueWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdNewbasevalueResponse, error)
+	}
 
-	// PostIdNewbasevalue request
-	PostIdNewbasevalueWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostIdNewbasevalueResponse, error)
+	queryURL.RawQuery = queryValues.Encode()
 
-	// GetIdReports request
-	GetIdReportsWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdReportsResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// DeleteIdReportsReportid request
-	DeleteIdReportsReportidWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*DeleteIdReportsReportidResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	// GetIdReportsReportid request
-	GetIdReportsReportidWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*GetIdReportsReportidResponse, error)
+	return req, nil
 }
 
-type GetResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]ServerInfo
-}
+// NewGetImaKeysRequest generates requests for GetImaKeys
+func NewGetImaKeysRequest(server string) (*http.Request, error) {
+	var err error
 
-// Status returns HTTPResponse.Status
-func (r GetResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	operationPath := fmt.Sprintf("/ima/keys")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
 	}
-	return 0
-}
 
-type GetConfigResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *string
-}
+	queryURL := serverURL.ResolveReference(&operationURL)
 
-// Status returns HTTPResponse.Status
-func (r GetConfigResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetConfigResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return req, nil
 }
 
-type PostConfigResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+// NewPostImaKeysRequest generates requests for PostImaKeys
+func NewPostImaKeysRequest(server string) (*http.Request, error) {
+	var err error
 
-// Status returns HTTPResponse.Status
-func (r PostConfigResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostConfigResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	operationPath := fmt.Sprintf("/ima/keys")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
 	}
-	return 0
-}
 
-type PostLoginResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+	queryURL := serverURL.ResolveReference(&operationURL)
 
-// Status returns HTTPResponse.Status
-func (r PostLoginResponse) Status() string {
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteImaKeysKeyidRequest generates requests for DeleteImaKeysKeyid
+func NewDeleteImaKeysKeyidRequest(server string, keyid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "keyid", runtime.ParamLocationPath, keyid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ima/keys/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostLoginRequest generates requests for PostLogin
+func NewPostLoginRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/login")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMaintwindowsRequest generates requests for GetMaintwindows
+func NewGetMaintwindowsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/maintwindows")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostMaintwindowsRequest calls the generic PostMaintwindows builder with application/json body
+func NewPostMaintwindowsRequest(server string, params *PostMaintwindowsParams, body PostMaintwindowsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostMaintwindowsRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostMaintwindowsRequestWithBody generates requests for PostMaintwindows with any type of body
+func NewPostMaintwindowsRequestWithBody(server string, params *PostMaintwindowsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/maintwindows")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	queryValues := queryURL.Query()
+
+	if params.Author != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetMaintwindowsWindowidRequest generates requests for GetMaintwindowsWindowid
+func NewGetMaintwindowsWindowidRequest(server string, windowid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "windowid", runtime.ParamLocationPath, windowid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/maintwindows/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostMaintwindowsWindowidCloseRequest generates requests for PostMaintwindowsWindowidClose
+func NewPostMaintwindowsWindowidCloseRequest(server string, windowid int64, params *PostMaintwindowsWindowidCloseParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "windowid", runtime.ParamLocationPath, windowid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/maintwindows/%s/close", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	queryValues := queryURL.Query()
+
+	if params.Author != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPoliciesRequest generates requests for GetPolicies
+func NewGetPoliciesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/policies")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostPoliciesRequest calls the generic PostPolicies builder with application/json body
+func NewPostPoliciesRequest(server string, body PostPoliciesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPoliciesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostPoliciesRequestWithBody generates requests for PostPolicies with any type of body
+func NewPostPoliciesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/policies")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeletePoliciesNameRequest generates requests for DeletePoliciesName
+func NewDeletePoliciesNameRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/policies/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPoliciesNameRequest generates requests for GetPoliciesName
+func NewGetPoliciesNameRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/policies/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetStorestatsRequest generates requests for GetStorestats
+func NewGetStorestatsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/storestats")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetVersionRequest generates requests for GetVersion
+func NewGetVersionRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/version")
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFromToRequest generates requests for GetFromTo
+func NewGetFromToRequest(server string, from int64, to int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "from", runtime.ParamLocationPath, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "to", runtime.ParamLocationPath, to)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteIdRequest generates requests for DeleteId
+func NewDeleteIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdRequest generates requests for GetId
+func NewGetIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIdRequest generates requests for PostId
+func NewPostIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdBasevaluesRequest generates requests for GetIdBasevalues
+func NewGetIdBasevaluesRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalues", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIdBasevaluesRollbackRequest generates requests for PostIdBasevaluesRollback
+func NewPostIdBasevaluesRollbackRequest(server string, id int64, params *PostIdBasevaluesRollbackParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalues/rollback", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	queryValues := queryURL.Query()
+
+	if params.Author != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteIdBasevaluesBasevalueidRequest generates requests for DeleteIdBasevaluesBasevalueid
+func NewDeleteIdBasevaluesBasevalueidRequest(server string, id int64, basevalueid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalues/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdBasevaluesBasevalueidRequest generates requests for GetIdBasevaluesBasevalueid
+func NewGetIdBasevaluesBasevalueidRequest(server string, id int64, basevalueid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalues/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIdBasevaluesBasevalueidRequest generates requests for PostIdBasevaluesBasevalueid
+func NewPostIdBasevaluesBasevalueidRequest(server string, id int64, basevalueid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalues/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIdBasevaluesBasevalueidPromoteRequest generates requests for PostIdBasevaluesBasevalueidPromote
+func NewPostIdBasevaluesBasevalueidPromoteRequest(server string, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidPromoteParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalues/%s/promote", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	queryValues := queryURL.Query()
+
+	if params.Author != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdBasevaluesBasevalueidRefvalueRequest generates requests for GetIdBasevaluesBasevalueidRefvalue
+func NewGetIdBasevaluesBasevalueidRefvalueRequest(server string, id int64, basevalueid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalues/%s/refvalue", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIdBasevaluesBasevalueidRetireRequest generates requests for PostIdBasevaluesBasevalueidRetire
+func NewPostIdBasevaluesBasevalueidRetireRequest(server string, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidRetireParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, basevalueid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalues/%s/retire", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	queryValues := queryURL.Query()
+
+	if params.Author != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author", runtime.ParamLocationQuery, *params.Author); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdContainerStatusRequest generates requests for GetIdContainerStatus
+func NewGetIdContainerStatusRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/container/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdDeviceStatusRequest generates requests for GetIdDeviceStatus
+func NewGetIdDeviceStatusRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/device/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdNewbasevalueRequest generates requests for GetIdNewbasevalue
+func NewGetIdNewbasevalueRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/newbasevalue", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIdNewbasevalueRequest generates requests for PostIdNewbasevalue
+func NewPostIdNewbasevalueRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/newbasevalue", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIdRefvaluesRequest calls the generic PostIdRefvalues builder with application/json body
+func NewPostIdRefvaluesRequest(server string, id int64, params *PostIdRefvaluesParams, body PostIdRefvaluesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostIdRefvaluesRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPostIdRefvaluesRequestWithBody generates requests for PostIdRefvalues with any type of body
+func NewPostIdRefvaluesRequestWithBody(server string, id int64, params *PostIdRefvaluesParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/refvalues", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	queryValues := queryURL.Query()
+
+	if params.Name != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "name", runtime.ParamLocationQuery, *params.Name); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Enabled != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "enabled", runtime.ParamLocationQuery, *params.Enabled); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Imamode != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "imamode", runtime.ParamLocationQuery, *params.Imamode); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetIdReportsRequest generates requests for GetIdReports
+func NewGetIdReportsRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/reports", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteIdReportsReportidRequest generates requests for DeleteIdReportsReportid
+func NewDeleteIdReportsReportidRequest(server string, id int64, reportid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "reportid", runtime.ParamLocationPath, reportid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/reports/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdReportsReportidRequest generates requests for GetIdReportsReportid
+func NewGetIdReportsReportidRequest(server string, id int64, reportid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "reportid", runtime.ParamLocationPath, reportid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/reports/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdReportsReportidBioslogRequest generates requests for GetIdReportsReportidBioslog
+func NewGetIdReportsReportidBioslogRequest(server string, id int64, reportid int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "reportid", runtime.ParamLocationPath, reportid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/reports/%s/bioslog", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdTrustRequest generates requests for GetIdTrust
+func NewGetIdTrustRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/trust", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUuidBasevalueRequest generates requests for GetUuidBasevalue
+func NewGetUuidBasevalueRequest(server string, uuid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalue", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostUuidBasevalueRequest calls the generic PostUuidBasevalue builder with application/json body
+func NewPostUuidBasevalueRequest(server string, uuid string, body PostUuidBasevalueJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUuidBasevalueRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewPostUuidBasevalueRequestWithBody generates requests for PostUuidBasevalue with any type of body
+func NewPostUuidBasevalueRequestWithBody(server string, uuid string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/basevalue", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetUuidStatusRequest generates requests for GetUuidStatus
+func NewGetUuidStatusRequest(server string, uuid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// Get request
+	GetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetResponse, error)
+
+	// GetConfig request
+	GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error)
+
+	// PostConfig request
+	PostConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostConfigResponse, error)
+
+	// GetGroups request
+	GetGroupsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetGroupsResponse, error)
+
+	// PostGroups request  with any body
+	PostGroupsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostGroupsResponse, error)
+
+	PostGroupsWithResponse(ctx context.Context, body PostGroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostGroupsResponse, error)
+
+	// DeleteGroupsGroupid request
+	DeleteGroupsGroupidWithResponse(ctx context.Context, groupid int64, reqEditors ...RequestEditorFn) (*DeleteGroupsGroupidResponse, error)
+
+	// GetGroupsGroupid request
+	GetGroupsGroupidWithResponse(ctx context.Context, groupid int64, reqEditors ...RequestEditorFn) (*GetGroupsGroupidResponse, error)
+
+	// PostGroupsGroupidBasevaluesBasevalueidPromote request
+	PostGroupsGroupidBasevaluesBasevalueidPromoteWithResponse(ctx context.Context, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidPromoteParams, reqEditors ...RequestEditorFn) (*PostGroupsGroupidBasevaluesBasevalueidPromoteResponse, error)
+
+	// PostGroupsGroupidBasevaluesBasevalueidRetire request
+	PostGroupsGroupidBasevaluesBasevalueidRetireWithResponse(ctx context.Context, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidRetireParams, reqEditors ...RequestEditorFn) (*PostGroupsGroupidBasevaluesBasevalueidRetireResponse, error)
+
+	// PostGroupsGroupidRefvalues request  with any body
+	PostGroupsGroupidRefvaluesWithBodyWithResponse(ctx context.Context, groupid int64, params *PostGroupsGroupidRefvaluesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostGroupsGroupidRefvaluesResponse, error)
+
+	PostGroupsGroupidRefvaluesWithResponse(ctx context.Context, groupid int64, params *PostGroupsGroupidRefvaluesParams, body PostGroupsGroupidRefvaluesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostGroupsGroupidRefvaluesResponse, error)
+
+	// GetImaKeys request
+	GetImaKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetImaKeysResponse, error)
+
+	// PostImaKeys request
+	PostImaKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostImaKeysResponse, error)
+
+	// DeleteImaKeysKeyid request
+	DeleteImaKeysKeyidWithResponse(ctx context.Context, keyid string, reqEditors ...RequestEditorFn) (*DeleteImaKeysKeyidResponse, error)
+
+	// PostLogin request
+	PostLoginWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostLoginResponse, error)
+
+	// GetMaintwindows request
+	GetMaintwindowsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMaintwindowsResponse, error)
+
+	// PostMaintwindows request  with any body
+	PostMaintwindowsWithBodyWithResponse(ctx context.Context, params *PostMaintwindowsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostMaintwindowsResponse, error)
+
+	PostMaintwindowsWithResponse(ctx context.Context, params *PostMaintwindowsParams, body PostMaintwindowsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostMaintwindowsResponse, error)
+
+	// GetMaintwindowsWindowid request
+	GetMaintwindowsWindowidWithResponse(ctx context.Context, windowid int64, reqEditors ...RequestEditorFn) (*GetMaintwindowsWindowidResponse, error)
+
+	// PostMaintwindowsWindowidClose request
+	PostMaintwindowsWindowidCloseWithResponse(ctx context.Context, windowid int64, params *PostMaintwindowsWindowidCloseParams, reqEditors ...RequestEditorFn) (*PostMaintwindowsWindowidCloseResponse, error)
+
+	// GetPolicies request
+	GetPoliciesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPoliciesResponse, error)
+
+	// PostPolicies request  with any body
+	PostPoliciesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPoliciesResponse, error)
+
+	PostPoliciesWithResponse(ctx context.Context, body PostPoliciesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPoliciesResponse, error)
+
+	// DeletePoliciesName request
+	DeletePoliciesNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeletePoliciesNameResponse, error)
+
+	// GetPoliciesName request
+	GetPoliciesNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetPoliciesNameResponse, error)
+
+	// GetStorestats request
+	GetStorestatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStorestatsResponse, error)
+
+	// GetVersion request
+	GetVersionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVersionResponse, error)
+
+	// GetFromTo request
+	GetFromToWithResponse(ctx context.Context, from int64, to int64, reqEditors ...RequestEditorFn) (*GetFromToResponse, error)
+
+	// DeleteId request
+	DeleteIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteIdResponse, error)
+
+	// GetId request
+	GetIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdResponse, error)
+
+	// PostId request
+	PostIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostIdResponse, error)
+
+	// GetIdBasevalues request
+	GetIdBasevaluesWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesResponse, error)
+
+	// PostIdBasevaluesRollback request
+	PostIdBasevaluesRollbackWithResponse(ctx context.Context, id int64, params *PostIdBasevaluesRollbackParams, reqEditors ...RequestEditorFn) (*PostIdBasevaluesRollbackResponse, error)
+
+	// DeleteIdBasevaluesBasevalueid request
+	DeleteIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*DeleteIdBasevaluesBasevalueidResponse, error)
+
+	// GetIdBasevaluesBasevalueid request
+	GetIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesBasevalueidResponse, error)
+
+	// PostIdBasevaluesBasevalueid request
+	PostIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*PostIdBasevaluesBasevalueidResponse, error)
+
+	// PostIdBasevaluesBasevalueidPromote request
+	PostIdBasevaluesBasevalueidPromoteWithResponse(ctx context.Context, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidPromoteParams, reqEditors ...RequestEditorFn) (*PostIdBasevaluesBasevalueidPromoteResponse, error)
+
+	// GetIdBasevaluesBasevalueidRefvalue request
+	GetIdBasevaluesBasevalueidRefvalueWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesBasevalueidRefvalueResponse, error)
+
+	// PostIdBasevaluesBasevalueidRetire request
+	PostIdBasevaluesBasevalueidRetireWithResponse(ctx context.Context, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidRetireParams, reqEditors ...RequestEditorFn) (*PostIdBasevaluesBasevalueidRetireResponse, error)
+
+	// GetIdContainerStatus request
+	GetIdContainerStatusWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdContainerStatusResponse, error)
+
+	// GetIdDeviceStatus request
+	GetIdDeviceStatusWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdDeviceStatusResponse, error)
+
+	// GetIdNewbasevalue request
+	GetIdNewbasevalueWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdNewbasevalueResponse, error)
+
+	// PostIdNewbasevalue request
+	PostIdNewbasevalueWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostIdNewbasevalueResponse, error)
+
+	// PostIdRefvalues request  with any body
+	PostIdRefvaluesWithBodyWithResponse(ctx context.Context, id int64, params *PostIdRefvaluesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostIdRefvaluesResponse, error)
+
+	PostIdRefvaluesWithResponse(ctx context.Context, id int64, params *PostIdRefvaluesParams, body PostIdRefvaluesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostIdRefvaluesResponse, error)
+
+	// GetIdReports request
+	GetIdReportsWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdReportsResponse, error)
+
+	// DeleteIdReportsReportid request
+	DeleteIdReportsReportidWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*DeleteIdReportsReportidResponse, error)
+
+	// GetIdReportsReportid request
+	GetIdReportsReportidWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*GetIdReportsReportidResponse, error)
+
+	// GetIdReportsReportidBioslog request
+	GetIdReportsReportidBioslogWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*GetIdReportsReportidBioslogResponse, error)
+
+	// GetIdTrust request
+	GetIdTrustWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdTrustResponse, error)
+
+	// GetUuidBasevalue request
+	GetUuidBasevalueWithResponse(ctx context.Context, uuid string, reqEditors ...RequestEditorFn) (*GetUuidBasevalueResponse, error)
+
+	// PostUuidBasevalue request  with any body
+	PostUuidBasevalueWithBodyWithResponse(ctx context.Context, uuid string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUuidBasevalueResponse, error)
+
+	PostUuidBasevalueWithResponse(ctx context.Context, uuid string, body PostUuidBasevalueJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUuidBasevalueResponse, error)
+
+	// GetUuidStatus request
+	GetUuidStatusWithResponse(ctx context.Context, uuid string, reqEditors ...RequestEditorFn) (*GetUuidStatusResponse, error)
+}
+
+type GetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ServerInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r GetConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetGroupsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]GroupInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetGroupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetGroupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostGroupsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GroupInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostGroupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostGroupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteGroupsGroupidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteGroupsGroupidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteGroupsGroupidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetGroupsGroupidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GroupInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetGroupsGroupidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetGroupsGroupidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostGroupsGroupidBasevaluesBasevalueidPromoteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BaseValueInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostGroupsGroupidBasevaluesBasevalueidPromoteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostGroupsGroupidBasevaluesBasevalueidPromoteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostGroupsGroupidBasevaluesBasevalueidRetireResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BaseValueInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostGroupsGroupidBasevaluesBasevalueidRetireResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostGroupsGroupidBasevaluesBasevalueidRetireResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostGroupsGroupidRefvaluesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostGroupsGroupidRefvaluesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostGroupsGroupidRefvaluesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetImaKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ImaKeyInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetImaKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetImaKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostImaKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostImaKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostImaKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteImaKeysKeyidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteImaKeysKeyidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteImaKeysKeyidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMaintwindowsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]MaintWindowInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMaintwindowsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMaintwindowsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostMaintwindowsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MaintWindowInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostMaintwindowsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostMaintwindowsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMaintwindowsWindowidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MaintWindowInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMaintwindowsWindowidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMaintwindowsWindowidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostMaintwindowsWindowidCloseResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MaintWindowInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostMaintwindowsWindowidCloseResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostMaintwindowsWindowidCloseResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPoliciesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PolicyInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPoliciesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPoliciesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPoliciesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PolicyInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPoliciesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPoliciesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeletePoliciesNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeletePoliciesNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeletePoliciesNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPoliciesNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PolicyInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPoliciesNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPoliciesNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStorestatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StoreStats
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStorestatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStorestatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetVersionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r GetVersionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetVersionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFromToResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ServerInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFromToResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFromToResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdBasevaluesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]BaseValueInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdBasevaluesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdBasevaluesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIdBasevaluesRollbackResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BaseValueInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIdBasevaluesRollbackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIdBasevaluesRollbackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteIdBasevaluesBasevalueidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteIdBasevaluesBasevalueidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteIdBasevaluesBasevalueidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdBasevaluesBasevalueidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdBasevaluesBasevalueidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdBasevaluesBasevalueidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIdBasevaluesBasevalueidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIdBasevaluesBasevalueidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIdBasevaluesBasevalueidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIdBasevaluesBasevalueidPromoteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BaseValueInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIdBasevaluesBasevalueidPromoteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIdBasevaluesBasevalueidPromoteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdBasevaluesBasevalueidRefvalueResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RefValues
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdBasevaluesBasevalueidRefvalueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdBasevaluesBasevalueidRefvalueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIdBasevaluesBasevalueidRetireResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BaseValueInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIdBasevaluesBasevalueidRetireResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIdBasevaluesBasevalueidRetireResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdContainerStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]TrustInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdContainerStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdContainerStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdDeviceStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]TrustInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdDeviceStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdDeviceStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdNewbasevalueResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]BaseValueInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdNewbasevalueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdNewbasevalueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIdNewbasevalueResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIdNewbasevalueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIdNewbasevalueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIdRefvaluesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIdRefvaluesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIdRefvaluesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdReportsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ReportInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdReportsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdReportsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteIdReportsReportidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteIdReportsReportidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteIdReportsReportidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdReportsReportidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdReportsReportidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdReportsReportidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdReportsReportidBioslogResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]BiosEvent
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdReportsReportidBioslogResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdReportsReportidBioslogResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdTrustResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrustInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdTrustResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdTrustResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUuidBasevalueResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUuidBasevalueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUuidBasevalueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUuidBasevalueResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUuidBasevalueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUuidBasevalueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUuidStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrustInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUuidStatusResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUuidStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetWithResponse request returning *GetResponse
+func (c *ClientWithResponses) GetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetResponse, error) {
+	rsp, err := c.Get(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetResponse(rsp)
+}
+
+// GetConfigWithResponse request returning *GetConfigResponse
+func (c *ClientWithResponses) GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error) {
+	rsp, err := c.GetConfig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetConfigResponse(rsp)
+}
+
+// PostConfigWithResponse request returning *PostConfigResponse
+func (c *ClientWithResponses) PostConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostConfigResponse, error) {
+	rsp, err := c.PostConfig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostConfigResponse(rsp)
+}
+
+// GetGroupsWithResponse request returning *GetGroupsResponse
+func (c *ClientWithResponses) GetGroupsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetGroupsResponse, error) {
+	rsp, err := c.GetGroups(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetGroupsResponse(rsp)
+}
+
+// PostGroupsWithBodyWithResponse request with arbitrary body returning *PostGroupsResponse
+func (c *ClientWithResponses) PostGroupsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostGroupsResponse, error) {
+	rsp, err := c.PostGroupsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostGroupsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostGroupsWithResponse(ctx context.Context, body PostGroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostGroupsResponse, error) {
+	rsp, err := c.PostGroups(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostGroupsResponse(rsp)
+}
+
+// DeleteGroupsGroupidWithResponse request returning *DeleteGroupsGroupidResponse
+func (c *ClientWithResponses) DeleteGroupsGroupidWithResponse(ctx context.Context, groupid int64, reqEditors ...RequestEditorFn) (*DeleteGroupsGroupidResponse, error) {
+	rsp, err := c.DeleteGroupsGroupid(ctx, groupid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteGroupsGroupidResponse(rsp)
+}
+
+// GetGroupsGroupidWithResponse request returning *GetGroupsGroupidResponse
+func (c *ClientWithResponses) GetGroupsGroupidWithResponse(ctx context.Context, groupid int64, reqEditors ...RequestEditorFn) (*GetGroupsGroupidResponse, error) {
+	rsp, err := c.GetGroupsGroupid(ctx, groupid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetGroupsGroupidResponse(rsp)
+}
+
+// PostGroupsGroupidBasevaluesBasevalueidPromoteWithResponse request returning *PostGroupsGroupidBasevaluesBasevalueidPromoteResponse
+func (c *ClientWithResponses) PostGroupsGroupidBasevaluesBasevalueidPromoteWithResponse(ctx context.Context, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidPromoteParams, reqEditors ...RequestEditorFn) (*PostGroupsGroupidBasevaluesBasevalueidPromoteResponse, error) {
+	rsp, err := c.PostGroupsGroupidBasevaluesBasevalueidPromote(ctx, groupid, basevalueid, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostGroupsGroupidBasevaluesBasevalueidPromoteResponse(rsp)
+}
+
+// PostGroupsGroupidBasevaluesBasevalueidRetireWithResponse request returning *PostGroupsGroupidBasevaluesBasevalueidRetireResponse
+func (c *ClientWithResponses) PostGroupsGroupidBasevaluesBasevalueidRetireWithResponse(ctx context.Context, groupid int64, basevalueid int64, params *PostGroupsGroupidBasevaluesBasevalueidRetireParams, reqEditors ...RequestEditorFn) (*PostGroupsGroupidBasevaluesBasevalueidRetireResponse, error) {
+	rsp, err := c.PostGroupsGroupidBasevaluesBasevalueidRetire(ctx, groupid, basevalueid, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostGroupsGroupidBasevaluesBasevalueidRetireResponse(rsp)
+}
+
+// PostGroupsGroupidRefvaluesWithBodyWithResponse request with arbitrary body returning *PostGroupsGroupidRefvaluesResponse
+func (c *ClientWithResponses) PostGroupsGroupidRefvaluesWithBodyWithResponse(ctx context.Context, groupid int64, params *PostGroupsGroupidRefvaluesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostGroupsGroupidRefvaluesResponse, error) {
+	rsp, err := c.PostGroupsGroupidRefvaluesWithBody(ctx, groupid, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostGroupsGroupidRefvaluesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostGroupsGroupidRefvaluesWithResponse(ctx context.Context, groupid int64, params *PostGroupsGroupidRefvaluesParams, body PostGroupsGroupidRefvaluesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostGroupsGroupidRefvaluesResponse, error) {
+	rsp, err := c.PostGroupsGroupidRefvalues(ctx, groupid, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostGroupsGroupidRefvaluesResponse(rsp)
+}
+
+// GetImaKeysWithResponse request returning *GetImaKeysResponse
+func (c *ClientWithResponses) GetImaKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetImaKeysResponse, error) {
+	rsp, err := c.GetImaKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetImaKeysResponse(rsp)
+}
+
+// PostImaKeysWithResponse request returning *PostImaKeysResponse
+func (c *ClientWithResponses) PostImaKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostImaKeysResponse, error) {
+	rsp, err := c.PostImaKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostImaKeysResponse(rsp)
+}
+
+// DeleteImaKeysKeyidWithResponse request returning *DeleteImaKeysKeyidResponse
+func (c *ClientWithResponses) DeleteImaKeysKeyidWithResponse(ctx context.Context, keyid string, reqEditors ...RequestEditorFn) (*DeleteImaKeysKeyidResponse, error) {
+	rsp, err := c.DeleteImaKeysKeyid(ctx, keyid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteImaKeysKeyidResponse(rsp)
+}
+
+// PostLoginWithResponse request returning *PostLoginResponse
+func (c *ClientWithResponses) PostLoginWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostLoginResponse, error) {
+	rsp, err := c.PostLogin(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLoginResponse(rsp)
+}
+
+// GetMaintwindowsWithResponse request returning *GetMaintwindowsResponse
+func (c *ClientWithResponses) GetMaintwindowsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMaintwindowsResponse, error) {
+	rsp, err := c.GetMaintwindows(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMaintwindowsResponse(rsp)
+}
+
+// PostMaintwindowsWithBodyWithResponse request with arbitrary body returning *PostMaintwindowsResponse
+func (c *ClientWithResponses) PostMaintwindowsWithBodyWithResponse(ctx context.Context, params *PostMaintwindowsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostMaintwindowsResponse, error) {
+	rsp, err := c.PostMaintwindowsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostMaintwindowsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostMaintwindowsWithResponse(ctx context.Context, params *PostMaintwindowsParams, body PostMaintwindowsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostMaintwindowsResponse, error) {
+	rsp, err := c.PostMaintwindows(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostMaintwindowsResponse(rsp)
+}
+
+// GetMaintwindowsWindowidWithResponse request returning *GetMaintwindowsWindowidResponse
+func (c *ClientWithResponses) GetMaintwindowsWindowidWithResponse(ctx context.Context, windowid int64, reqEditors ...RequestEditorFn) (*GetMaintwindowsWindowidResponse, error) {
+	rsp, err := c.GetMaintwindowsWindowid(ctx, windowid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMaintwindowsWindowidResponse(rsp)
+}
+
+// PostMaintwindowsWindowidCloseWithResponse request returning *PostMaintwindowsWindowidCloseResponse
+func (c *ClientWithResponses) PostMaintwindowsWindowidCloseWithResponse(ctx context.Context, windowid int64, params *PostMaintwindowsWindowidCloseParams, reqEditors ...RequestEditorFn) (*PostMaintwindowsWindowidCloseResponse, error) {
+	rsp, err := c.PostMaintwindowsWindowidClose(ctx, windowid, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostMaintwindowsWindowidCloseResponse(rsp)
+}
+
+// GetPoliciesWithResponse request returning *GetPoliciesResponse
+func (c *ClientWithResponses) GetPoliciesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPoliciesResponse, error) {
+	rsp, err := c.GetPolicies(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPoliciesResponse(rsp)
+}
+
+// PostPoliciesWithBodyWithResponse request with arbitrary body returning *PostPoliciesResponse
+func (c *ClientWithResponses) PostPoliciesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPoliciesResponse, error) {
+	rsp, err := c.PostPoliciesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPoliciesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPoliciesWithResponse(ctx context.Context, body PostPoliciesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPoliciesResponse, error) {
+	rsp, err := c.PostPolicies(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPoliciesResponse(rsp)
+}
+
+// DeletePoliciesNameWithResponse request returning *DeletePoliciesNameResponse
+func (c *ClientWithResponses) DeletePoliciesNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeletePoliciesNameResponse, error) {
+	rsp, err := c.DeletePoliciesName(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeletePoliciesNameResponse(rsp)
+}
+
+// GetPoliciesNameWithResponse request returning *GetPoliciesNameResponse
+func (c *ClientWithResponses) GetPoliciesNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetPoliciesNameResponse, error) {
+	rsp, err := c.GetPoliciesName(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPoliciesNameResponse(rsp)
+}
+
+// GetStorestatsWithResponse request returning *GetStorestatsResponse
+func (c *ClientWithResponses) GetStorestatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStorestatsResponse, error) {
+	rsp, err := c.GetStorestats(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStorestatsResponse(rsp)
+}
+
+// GetVersionWithResponse request returning *GetVersionResponse
+func (c *ClientWithResponses) GetVersionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVersionResponse, error) {
+	rsp, err := c.GetVersion(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetVersionResponse(rsp)
+}
+
+// GetFromToWithResponse request returning *GetFromToResponse
+func (c *ClientWithResponses) GetFromToWithResponse(ctx context.Context, from int64, to int64, reqEditors ...RequestEditorFn) (*GetFromToResponse, error) {
+	rsp, err := c.GetFromTo(ctx, from, to, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFromToResponse(rsp)
+}
+
+// DeleteIdWithResponse request returning *DeleteIdResponse
+func (c *ClientWithResponses) DeleteIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteIdResponse, error) {
+	rsp, err := c.DeleteId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteIdResponse(rsp)
+}
+
+// GetIdWithResponse request returning *GetIdResponse
+func (c *ClientWithResponses) GetIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdResponse, error) {
+	rsp, err := c.GetId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdResponse(rsp)
+}
+
+// PostIdWithResponse request returning *PostIdResponse
+func (c *ClientWithResponses) PostIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostIdResponse, error) {
+	rsp, err := c.PostId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIdResponse(rsp)
+}
+
+// GetIdBasevaluesWithResponse request returning *GetIdBasevaluesResponse
+func (c *ClientWithResponses) GetIdBasevaluesWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesResponse, error) {
+	rsp, err := c.GetIdBasevalues(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdBasevaluesResponse(rsp)
+}
+
+// PostIdBasevaluesRollbackWithResponse request returning *PostIdBasevaluesRollbackResponse
+func (c *ClientWithResponses) PostIdBasevaluesRollbackWithResponse(ctx context.Context, id int64, params *PostIdBasevaluesRollbackParams, reqEditors ...RequestEditorFn) (*PostIdBasevaluesRollbackResponse, error) {
+	rsp, err := c.PostIdBasevaluesRollback(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIdBasevaluesRollbackResponse(rsp)
+}
+
+// DeleteIdBasevaluesBasevalueidWithResponse request returning *DeleteIdBasevaluesBasevalueidResponse
+func (c *ClientWithResponses) DeleteIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*DeleteIdBasevaluesBasevalueidResponse, error) {
+	rsp, err := c.DeleteIdBasevaluesBasevalueid(ctx, id, basevalueid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteIdBasevaluesBasevalueidResponse(rsp)
+}
+
+// GetIdBasevaluesBasevalueidWithResponse request returning *GetIdBasevaluesBasevalueidResponse
+func (c *ClientWithResponses) GetIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesBasevalueidResponse, error) {
+	rsp, err := c.GetIdBasevaluesBasevalueid(ctx, id, basevalueid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdBasevaluesBasevalueidResponse(rsp)
+}
+
+// PostIdBasevaluesBasevalueidWithResponse request returning *PostIdBasevaluesBasevalueidResponse
+func (c *ClientWithResponses) PostIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*PostIdBasevaluesBasevalueidResponse, error) {
+	rsp, err := c.PostIdBasevaluesBasevalueid(ctx, id, basevalueid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIdBasevaluesBasevalueidResponse(rsp)
+}
+
+// PostIdBasevaluesBasevalueidPromoteWithResponse request returning *PostIdBasevaluesBasevalueidPromoteResponse
+func (c *ClientWithResponses) PostIdBasevaluesBasevalueidPromoteWithResponse(ctx context.Context, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidPromoteParams, reqEditors ...RequestEditorFn) (*PostIdBasevaluesBasevalueidPromoteResponse, error) {
+	rsp, err := c.PostIdBasevaluesBasevalueidPromote(ctx, id, basevalueid, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIdBasevaluesBasevalueidPromoteResponse(rsp)
+}
+
+// GetIdBasevaluesBasevalueidRefvalueWithResponse request returning *GetIdBasevaluesBasevalueidRefvalueResponse
+func (c *ClientWithResponses) GetIdBasevaluesBasevalueidRefvalueWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesBasevalueidRefvalueResponse, error) {
+	rsp, err := c.GetIdBasevaluesBasevalueidRefvalue(ctx, id, basevalueid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdBasevaluesBasevalueidRefvalueResponse(rsp)
+}
+
+// PostIdBasevaluesBasevalueidRetireWithResponse request returning *PostIdBasevaluesBasevalueidRetireResponse
+func (c *ClientWithResponses) PostIdBasevaluesBasevalueidRetireWithResponse(ctx context.Context, id int64, basevalueid int64, params *PostIdBasevaluesBasevalueidRetireParams, reqEditors ...RequestEditorFn) (*PostIdBasevaluesBasevalueidRetireResponse, error) {
+	rsp, err := c.PostIdBasevaluesBasevalueidRetire(ctx, id, basevalueid, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIdBasevaluesBasevalueidRetireResponse(rsp)
+}
+
+// GetIdContainerStatusWithResponse request returning *GetIdContainerStatusResponse
+func (c *ClientWithResponses) GetIdContainerStatusWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdContainerStatusResponse, error) {
+	rsp, err := c.GetIdContainerStatus(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdContainerStatusResponse(rsp)
+}
+
+// GetIdDeviceStatusWithResponse request returning *GetIdDeviceStatusResponse
+func (c *ClientWithResponses) GetIdDeviceStatusWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdDeviceStatusResponse, error) {
+	rsp, err := c.GetIdDeviceStatus(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdDeviceStatusResponse(rsp)
+}
+
+// GetIdNewbasevalueWithResponse request returning *GetIdNewbasevalueResponse
+func (c *ClientWithResponses) GetIdNewbasevalueWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdNewbasevalueResponse, error) {
+	rsp, err := c.GetIdNewbasevalue(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdNewbasevalueResponse(rsp)
+}
+
+// PostIdNewbasevalueWithResponse request returning *PostIdNewbasevalueResponse
+func (c *ClientWithResponses) PostIdNewbasevalueWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostIdNewbasevalueResponse, error) {
+	rsp, err := c.PostIdNewbasevalue(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIdNewbasevalueResponse(rsp)
+}
+
+// PostIdRefvaluesWithBodyWithResponse request with arbitrary body returning *PostIdRefvaluesResponse
+func (c *ClientWithResponses) PostIdRefvaluesWithBodyWithResponse(ctx context.Context, id int64, params *PostIdRefvaluesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostIdRefvaluesResponse, error) {
+	rsp, err := c.PostIdRefvaluesWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIdRefvaluesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostIdRefvaluesWithResponse(ctx context.Context, id int64, params *PostIdRefvaluesParams, body PostIdRefvaluesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostIdRefvaluesResponse, error) {
+	rsp, err := c.PostIdRefvalues(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIdRefvaluesResponse(rsp)
+}
+
+// GetIdReportsWithResponse request returning *GetIdReportsResponse
+func (c *ClientWithResponses) GetIdReportsWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdReportsResponse, error) {
+	rsp, err := c.GetIdReports(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdReportsResponse(rsp)
+}
+
+// DeleteIdReportsReportidWithResponse request returning *DeleteIdReportsReportidResponse
+func (c *ClientWithResponses) DeleteIdReportsReportidWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*DeleteIdReportsReportidResponse, error) {
+	rsp, err := c.DeleteIdReportsReportid(ctx, id, reportid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteIdReportsReportidResponse(rsp)
+}
+
+// GetIdReportsReportidWithResponse request returning *GetIdReportsReportidResponse
+func (c *ClientWithResponses) GetIdReportsReportidWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*GetIdReportsReportidResponse, error) {
+	rsp, err := c.GetIdReportsReportid(ctx, id, reportid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdReportsReportidResponse(rsp)
+}
+
+// GetIdReportsReportidBioslogWithResponse request returning *GetIdReportsReportidBioslogResponse
+func (c *ClientWithResponses) GetIdReportsReportidBioslogWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*GetIdReportsReportidBioslogResponse, error) {
+	rsp, err := c.GetIdReportsReportidBioslog(ctx, id, reportid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdReportsReportidBioslogResponse(rsp)
+}
+
+// GetIdTrustWithResponse request returning *GetIdTrustResponse
+func (c *ClientWithResponses) GetIdTrustWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdTrustResponse, error) {
+	rsp, err := c.GetIdTrust(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdTrustResponse(rsp)
+}
+
+// GetUuidBasevalueWithResponse request returning *GetUuidBasevalueResponse
+func (c *ClientWithResponses) GetUuidBasevalueWithResponse(ctx context.Context, uuid string, reqEditors ...RequestEditorFn) (*GetUuidBasevalueResponse, error) {
+	rsp, err := c.GetUuidBasevalue(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUuidBasevalueResponse(rsp)
+}
+
+// PostUuidBasevalueWithBodyWithResponse request with arbitrary body returning *PostUuidBasevalueResponse
+func (c *ClientWithResponses) PostUuidBasevalueWithBodyWithResponse(ctx context.Context, uuid string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUuidBasevalueResponse, error) {
+	rsp, err := c.PostUuidBasevalueWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUuidBasevalueResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUuidBasevalueWithResponse(ctx context.Context, uuid string, body PostUuidBasevalueJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUuidBasevalueResponse, error) {
+	rsp, err := c.PostUuidBasevalue(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUuidBasevalueResponse(rsp)
+}
+
+// GetUuidStatusWithResponse request returning *GetUuidStatusResponse
+func (c *ClientWithResponses) GetUuidStatusWithResponse(ctx context.Context, uuid string, reqEditors ...RequestEditorFn) (*GetUuidStatusResponse, error) {
+	rsp, err := c.GetUuidStatus(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUuidStatusResponse(rsp)
+}
+
+// ParseGetResponse parses an HTTP response from a GetWithResponse call
+func ParseGetResponse(rsp *http.Response) (*GetResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ServerInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/plain) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseGetConfigResponse parses an HTTP response from a GetConfigWithResponse call
+func ParseGetConfigResponse(rsp *http.Response) (*GetConfigResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/plain) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParsePostConfigResponse parses an HTTP response from a PostConfigWithResponse call
+func ParsePostConfigResponse(rsp *http.Response) (*PostConfigResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseGetGroupsResponse parses an HTTP response from a GetGroupsWithResponse call
+func ParseGetGroupsResponse(rsp *http.Response) (*GetGroupsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetGroupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []GroupInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostGroupsResponse parses an HTTP response from a PostGroupsWithResponse call
+func ParsePostGroupsResponse(rsp *http.Response) (*PostGroupsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostGroupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GroupInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteGroupsGroupidResponse parses an HTTP response from a DeleteGroupsGroupidWithResponse call
+func ParseDeleteGroupsGroupidResponse(rsp *http.Response) (*DeleteGroupsGroupidResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteGroupsGroupidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseGetGroupsGroupidResponse parses an HTTP response from a GetGroupsGroupidWithResponse call
+func ParseGetGroupsGroupidResponse(rsp *http.Response) (*GetGroupsGroupidResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetGroupsGroupidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GroupInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostGroupsGroupidBasevaluesBasevalueidPromoteResponse parses an HTTP response from a PostGroupsGroupidBasevaluesBasevalueidPromoteWithResponse call
+func ParsePostGroupsGroupidBasevaluesBasevalueidPromoteResponse(rsp *http.Response) (*PostGroupsGroupidBasevaluesBasevalueidPromoteResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostGroupsGroupidBasevaluesBasevalueidPromoteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BaseValueInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostLoginResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParsePostGroupsGroupidBasevaluesBasevalueidRetireResponse parses an HTTP response from a PostGroupsGroupidBasevaluesBasevalueidRetireWithResponse call
+func ParsePostGroupsGroupidBasevaluesBasevalueidRetireResponse(rsp *http.Response) (*PostGroupsGroupidBasevaluesBasevalueidRetireResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostGroupsGroupidBasevaluesBasevalueidRetireResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BaseValueInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return 0
-}
 
-type GetVersionResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *string
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetVersionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParsePostGroupsGroupidRefvaluesResponse parses an HTTP response from a PostGroupsGroupidRefvaluesWithResponse call
+func ParsePostGroupsGroupidRefvaluesResponse(rsp *http.Response) (*PostGroupsGroupidRefvaluesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetVersionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &PostGroupsGroupidRefvaluesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetFromToResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]ServerInfo
+	switch {
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetFromToResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetImaKeysResponse parses an HTTP response from a GetImaKeysWithResponse call
+func ParseGetImaKeysResponse(rsp *http.Response) (*GetImaKeysResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetFromToResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &GetImaKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type DeleteIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ImaKeyInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r DeleteIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-type GetIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *map[string]interface{}
-}
+// ParsePostImaKeysResponse parses an HTTP response from a PostImaKeysWithResponse call
+func ParsePostImaKeysResponse(rsp *http.Response) (*PostImaKeysResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &PostImaKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
 	}
-	return 0
-}
 
-type PostIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PostIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseDeleteImaKeysKeyidResponse parses an HTTP response from a DeleteImaKeysKeyidWithResponse call
+func ParseDeleteImaKeysKeyidResponse(rsp *http.Response) (*DeleteImaKeysKeyidResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &DeleteImaKeysKeyidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetIdBasevaluesResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]BaseValueInfo
+	switch {
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetIdBasevaluesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParsePostLoginResponse parses an HTTP response from a PostLoginWithResponse call
+func ParsePostLoginResponse(rsp *http.Response) (*PostLoginResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetIdBasevaluesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &PostLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type DeleteIdBasevaluesBasevalueidResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	switch {
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteIdBasevaluesBasevalueidResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetMaintwindowsResponse parses an HTTP response from a GetMaintwindowsWithResponse call
+func ParseGetMaintwindowsResponse(rsp *http.Response) (*GetMaintwindowsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteIdBasevaluesBasevalueidResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &GetMaintwindowsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetIdBasevaluesBasevalueidResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *map[string]interface{}
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []MaintWindowInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GetIdBasevaluesBasevalueidResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetIdBasevaluesBasevalueidResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParsePostMaintwindowsResponse parses an HTTP response from a PostMaintwindowsWithResponse call
+func ParsePostMaintwindowsResponse(rsp *http.Response) (*PostMaintwindowsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type PostIdBasevaluesBasevalueidResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+	response := &PostMaintwindowsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MaintWindowInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r PostIdBasevaluesBasevalueidResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostIdBasevaluesBasevalueidResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseGetMaintwindowsWindowidResponse parses an HTTP response from a GetMaintwindowsWindowidWithResponse call
+func ParseGetMaintwindowsWindowidResponse(rsp *http.Response) (*GetMaintwindowsWindowidResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetIdNewbasevalueResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]BaseValueInfo
+	response := &GetMaintwindowsWindowidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MaintWindowInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetIdNewbasevalueResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParsePostMaintwindowsWindowidCloseResponse parses an HTTP response from a PostMaintwindowsWindowidCloseWithResponse call
+func ParsePostMaintwindowsWindowidCloseResponse(rsp *http.Response) (*PostMaintwindowsWindowidCloseResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetIdNewbasevalueResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &PostMaintwindowsWindowidCloseResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type PostIdNewbasevalueResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MaintWindowInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r PostIdNewbasevalueResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostIdNewbasevalueResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-type GetIdReportsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]ReportInfo
-}
+// ParseGetPoliciesResponse parses an HTTP response from a GetPoliciesWithResponse call
+func ParseGetPoliciesResponse(rsp *http.Response) (*GetPoliciesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetIdReportsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &GetPoliciesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetIdReportsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PolicyInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return 0
-}
 
-type DeleteIdReportsReportidResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteIdReportsReportidResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParsePostPoliciesResponse parses an HTTP response from a PostPoliciesWithResponse call
+func ParsePostPoliciesResponse(rsp *http.Response) (*PostPoliciesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteIdReportsReportidResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &PostPoliciesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetIdReportsReportidResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *map[string]interface{}
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PolicyInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GetIdReportsReportidResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetIdReportsReportidResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-// GetWithResponse request returning *GetResponse
-func (c *ClientWithResponses) GetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetResponse, error) {
-	rsp, err := c.Get(ctx, reqEditors...)
+// ParseDeletePoliciesNameResponse parses an HTTP response from a DeletePoliciesNameWithResponse call
+func ParseDeletePoliciesNameResponse(rsp *http.Response) (*DeletePoliciesNameResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetResponse(rsp)
-}
 
-// GetConfigWithResponse request returning *GetConfigResponse
-func (c *ClientWithResponses) GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error) {
-	rsp, err := c.GetConfig(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeletePoliciesNameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetConfigResponse(rsp)
-}
 
-// PostConfigWithResponse request returning *PostConfigResponse
-func (c *ClientWithResponses) PostConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostConfigResponse, error) {
-	rsp, err := c.PostConfig(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
 	}
-	return ParsePostConfigResponse(rsp)
+
+	return response, nil
 }
 
-// PostLoginWithResponse request returning *PostLoginResponse
-func (c *ClientWithResponses) PostLoginWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostLoginResponse, error) {
-	rsp, err := c.PostLogin(ctx, reqEditors...)
+// ParseGetPoliciesNameResponse parses an HTTP response from a GetPoliciesNameWithResponse call
+func ParseGetPoliciesNameResponse(rsp *http.Response) (*GetPoliciesNameResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostLoginResponse(rsp)
-}
 
-// GetVersionWithResponse request returning *GetVersionResponse
-func (c *ClientWithResponses) GetVersionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVersionResponse, error) {
-	rsp, err := c.GetVersion(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetPoliciesNameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetVersionResponse(rsp)
-}
 
-// GetFromToWithResponse request returning *GetFromToResponse
-func (c *ClientWithResponses) GetFromToWithResponse(ctx context.Context, from int64, to int64, reqEditors ...RequestEditorFn) (*GetFromToResponse, error) {
-	rsp, err := c.GetFromTo(ctx, from, to, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PolicyInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return ParseGetFromToResponse(rsp)
+
+	return response, nil
 }
 
-// DeleteIdWithResponse request returning *DeleteIdResponse
-func (c *ClientWithResponses) DeleteIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteIdResponse, error) {
-	rsp, err := c.DeleteId(ctx, id, reqEditors...)
+// ParseGetStorestatsResponse parses an HTTP response from a GetStorestatsWithResponse call
+func ParseGetStorestatsResponse(rsp *http.Response) (*GetStorestatsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteIdResponse(rsp)
-}
 
-// GetIdWithResponse request returning *GetIdResponse
-func (c *ClientWithResponses) GetIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdResponse, error) {
-	rsp, err := c.GetId(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetStorestatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetIdResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StoreStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// PostIdWithResponse request returning *PostIdResponse
-func (c *ClientWithResponses) PostIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostIdResponse, error) {
-	rsp, err := c.PostId(ctx, id, reqEditors...)
+// ParseGetVersionResponse parses an HTTP response from a GetVersionWithResponse call
+func ParseGetVersionResponse(rsp *http.Response) (*GetVersionResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostIdResponse(rsp)
+
+	response := &GetVersionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/plain) unsupported
+
+	}
+
+	return response, nil
 }
 
-// GetIdBasevaluesWithResponse request returning *GetIdBasevaluesResponse
-func (c *ClientWithResponses) GetIdBasevaluesWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesResponse, error) {
-	rsp, err := c.GetIdBasevalues(ctx, id, reqEditors...)
+// ParseGetFromToResponse parses an HTTP response from a GetFromToWithResponse call
+func ParseGetFromToResponse(rsp *http.Response) (*GetFromToResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetIdBasevaluesResponse(rsp)
+
+	response := &GetFromToResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ServerInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/plain) unsupported
+
+	}
+
+	return response, nil
 }
 
-// DeleteIdBasevaluesBasevalueidWithResponse request returning *DeleteIdBasevaluesBasevalueidResponse
-func (c *ClientWithResponses) DeleteIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*DeleteIdBasevaluesBasevalueidResponse, error) {
-	rsp, err := c.DeleteIdBasevaluesBasevalueid(ctx, id, basevalueid, reqEditors...)
+// ParseDeleteIdResponse parses an HTTP response from a DeleteIdWithResponse call
+func ParseDeleteIdResponse(rsp *http.Response) (*DeleteIdResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteIdBasevaluesBasevalueidResponse(rsp)
-}
 
-// GetIdBasevaluesBasevalueidWithResponse request returning *GetIdBasevaluesBasevalueidResponse
-func (c *ClientWithResponses) GetIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*GetIdBasevaluesBasevalueidResponse, error) {
-	rsp, err := c.GetIdBasevaluesBasevalueid(ctx, id, basevalueid, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeleteIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetIdBasevaluesBasevalueidResponse(rsp)
-}
 
-// PostIdBasevaluesBasevalueidWithResponse request returning *PostIdBasevaluesBasevalueidResponse
-func (c *ClientWithResponses) PostIdBasevaluesBasevalueidWithResponse(ctx context.Context, id int64, basevalueid int64, reqEditors ...RequestEditorFn) (*PostIdBasevaluesBasevalueidResponse, error) {
-	rsp, err := c.PostIdBasevaluesBasevalueid(ctx, id, basevalueid, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
 	}
-	return ParsePostIdBasevaluesBasevalueidResponse(rsp)
+
+	return response, nil
 }
 
-// GetIdNewbasevalueWithResponse request returning *GetIdNewbasevalueResponse
-func (c *ClientWithResponses) GetIdNewbasevalueWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdNewbasevalueResponse, error) {
-	rsp, err := c.GetIdNewbasevalue(ctx, id, reqEditors...)
+// ParseGetIdResponse parses an HTTP response from a GetIdWithResponse call
+func ParseGetIdResponse(rsp *http.Response) (*GetIdResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetIdNewbasevalueResponse(rsp)
-}
 
-// PostIdNewbasevalueWithResponse request returning *PostIdNewbasevalueResponse
-func (c *ClientWithResponses) PostIdNewbasevalueWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostIdNewbasevalueResponse, error) {
-	rsp, err := c.PostIdNewbasevalue(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePostIdNewbasevalueResponse(rsp)
-}
 
-// GetIdReportsWithResponse request returning *GetIdReportsResponse
-func (c *ClientWithResponses) GetIdReportsWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetIdReportsResponse, error) {
-	rsp, err := c.GetIdReports(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/plain) unsupported
+
 	}
-	return ParseGetIdReportsResponse(rsp)
+
+	return response, nil
 }
 
-// DeleteIdReportsReportidWithResponse request returning *DeleteIdReportsReportidResponse
-func (c *ClientWithResponses) DeleteIdReportsReportidWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*DeleteIdReportsReportidResponse, error) {
-	rsp, err := c.DeleteIdReportsReportid(ctx, id, reportid, reqEditors...)
+// ParsePostIdResponse parses an HTTP response from a PostIdWithResponse call
+func ParsePostIdResponse(rsp *http.Response) (*PostIdResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteIdReportsReportidResponse(rsp)
-}
 
-// GetIdReportsReportidWithResponse request returning *GetIdReportsReportidResponse
-func (c *ClientWithResponses) GetIdReportsReportidWithResponse(ctx context.Context, id int64, reportid int64, reqEditors ...RequestEditorFn) (*GetIdReportsReportidResponse, error) {
-	rsp, err := c.GetIdReportsReportid(ctx, id, reportid, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PostIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetIdReportsReportidResponse(rsp)
+
+	switch {
+	}
+
+	return response, nil
 }
 
-// ParseGetResponse parses an HTTP response from a GetWithResponse call
-func ParseGetResponse(rsp *http.Response) (*GetResponse, error) {
+// ParseGetIdBasevaluesResponse parses an HTTP response from a GetIdBasevaluesWithResponse call
+func ParseGetIdBasevaluesResponse(rsp *http.Response) (*GetIdBasevaluesResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetResponse{
+	response := &GetIdBasevaluesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []ServerInfo
+		var dest []BaseValueInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1698,63 +5933,89 @@ func ParseGetResponse(rsp *http.Response) (*GetResponse, error) {
 	return response, nil
 }
 
-// ParseGetConfigResponse parses an HTTP response from a GetConfigWithResponse call
-func ParseGetConfigResponse(rsp *http.Response) (*GetConfigResponse, error) {
+// ParsePostIdBasevaluesRollbackResponse parses an HTTP response from a PostIdBasevaluesRollbackWithResponse call
+func ParsePostIdBasevaluesRollbackResponse(rsp *http.Response) (*PostIdBasevaluesRollbackResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetConfigResponse{
+	response := &PostIdBasevaluesRollbackResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest string
+		var dest BaseValueInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case rsp.StatusCode == 200:
-		// Content-type (text/plain) unsupported
+	}
+
+	return response, nil
+}
+
+// ParseDeleteIdBasevaluesBasevalueidResponse parses an HTTP response from a DeleteIdBasevaluesBasevalueidWithResponse call
+func ParseDeleteIdBasevaluesBasevalueidResponse(rsp *http.Response) (*DeleteIdBasevaluesBasevalueidResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteIdBasevaluesBasevalueidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
+	switch {
 	}
 
 	return response, nil
 }
 
-// ParsePostConfigResponse parses an HTTP response from a PostConfigWithResponse call
-func ParsePostConfigResponse(rsp *http.Response) (*PostConfigResponse, error) {
+// ParseGetIdBasevaluesBasevalueidResponse parses an HTTP response from a GetIdBasevaluesBasevalueidWithResponse call
+func ParseGetIdBasevaluesBasevalueidResponse(rsp *http.Response) (*GetIdBasevaluesBasevalueidResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostConfigResponse{
+	response := &GetIdBasevaluesBasevalueidResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/plain) unsupported
+
 	}
 
 	return response, nil
 }
 
-// ParsePostLoginResponse parses an HTTP response from a PostLoginWithResponse call
-func ParsePostLoginResponse(rsp *http.Response) (*PostLoginResponse, error) {
+// ParsePostIdBasevaluesBasevalueidResponse parses an HTTP response from a PostIdBasevaluesBasevalueidWithResponse call
+func ParsePostIdBasevaluesBasevalueidResponse(rsp *http.Response) (*PostIdBasevaluesBasevalueidResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostLoginResponse{
+	response := &PostIdBasevaluesBasevalueidResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1765,140 +6026,145 @@ func ParsePostLoginResponse(rsp *http.Response) (*PostLoginResponse, error) {
 	return response, nil
 }
 
-// ParseGetVersionResponse parses an HTTP response from a GetVersionWithResponse call
-func ParseGetVersionResponse(rsp *http.Response) (*GetVersionResponse, error) {
+// ParsePostIdBasevaluesBasevalueidPromoteResponse parses an HTTP response from a PostIdBasevaluesBasevalueidPromoteWithResponse call
+func ParsePostIdBasevaluesBasevalueidPromoteResponse(rsp *http.Response) (*PostIdBasevaluesBasevalueidPromoteResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetVersionResponse{
+	response := &PostIdBasevaluesBasevalueidPromoteResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest string
+		var dest BaseValueInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case rsp.StatusCode == 200:
-		// Content-type (text/plain) unsupported
-
 	}
 
 	return response, nil
 }
 
-// ParseGetFromToResponse parses an HTTP response from a GetFromToWithResponse call
-func ParseGetFromToResponse(rsp *http.Response) (*GetFromToResponse, error) {
+// ParseGetIdBasevaluesBasevalueidRefvalueResponse parses an HTTP response from a GetIdBasevaluesBasevalueidRefvalueWithResponse call
+func ParseGetIdBasevaluesBasevalueidRefvalueResponse(rsp *http.Response) (*GetIdBasevaluesBasevalueidRefvalueResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetFromToResponse{
+	response := &GetIdBasevaluesBasevalueidRefvalueResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []ServerInfo
+		var dest RefValues
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case rsp.StatusCode == 200:
-		// Content-type (text/plain) unsupported
-
 	}
 
 	return response, nil
 }
 
-// ParseDeleteIdResponse parses an HTTP response from a DeleteIdWithResponse call
-func ParseDeleteIdResponse(rsp *http.Response) (*DeleteIdResponse, error) {
+// ParsePostIdBasevaluesBasevalueidRetireResponse parses an HTTP response from a PostIdBasevaluesBasevalueidRetireWithResponse call
+func ParsePostIdBasevaluesBasevalueidRetireResponse(rsp *http.Response) (*PostIdBasevaluesBasevalueidRetireResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteIdResponse{
+	response := &PostIdBasevaluesBasevalueidRetireResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BaseValueInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetIdResponse parses an HTTP response from a GetIdWithResponse call
-func ParseGetIdResponse(rsp *http.Response) (*GetIdResponse, error) {
+// ParseGetIdContainerStatusResponse parses an HTTP response from a GetIdContainerStatusWithResponse call
+func ParseGetIdContainerStatusResponse(rsp *http.Response) (*GetIdContainerStatusResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetIdResponse{
+	response := &GetIdContainerStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
+		var dest []TrustInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case rsp.StatusCode == 200:
-		// Content-type (text/plain) unsupported
-
 	}
 
 	return response, nil
 }
 
-// ParsePostIdResponse parses an HTTP response from a PostIdWithResponse call
-func ParsePostIdResponse(rsp *http.Response) (*PostIdResponse, error) {
+// ParseGetIdDeviceStatusResponse parses an HTTP response from a GetIdDeviceStatusWithResponse call
+func ParseGetIdDeviceStatusResponse(rsp *http.Response) (*GetIdDeviceStatusResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostIdResponse{
+	response := &GetIdDeviceStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []TrustInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetIdBasevaluesResponse parses an HTTP response from a GetIdBasevaluesWithResponse call
-func ParseGetIdBasevaluesResponse(rsp *http.Response) (*GetIdBasevaluesResponse, error) {
+// ParseGetIdNewbasevalueResponse parses an HTTP response from a GetIdNewbasevalueWithResponse call
+func ParseGetIdNewbasevalueResponse(rsp *http.Response) (*GetIdNewbasevalueResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetIdBasevaluesResponse{
+	response := &GetIdNewbasevalueResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1919,15 +6185,15 @@ func ParseGetIdBasevaluesResponse(rsp *http.Response) (*GetIdBasevaluesResponse,
 	return response, nil
 }
 
-// ParseDeleteIdBasevaluesBasevalueidResponse parses an HTTP response from a DeleteIdBasevaluesBasevalueidWithResponse call
-func ParseDeleteIdBasevaluesBasevalueidResponse(rsp *http.Response) (*DeleteIdBasevaluesBasevalueidResponse, error) {
+// ParsePostIdNewbasevalueResponse parses an HTTP response from a PostIdNewbasevalueWithResponse call
+func ParsePostIdNewbasevalueResponse(rsp *http.Response) (*PostIdNewbasevalueResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteIdBasevaluesBasevalueidResponse{
+	response := &PostIdNewbasevalueResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1938,22 +6204,41 @@ func ParseDeleteIdBasevaluesBasevalueidResponse(rsp *http.Response) (*DeleteIdBa
 	return response, nil
 }
 
-// ParseGetIdBasevaluesBasevalueidResponse parses an HTTP response from a GetIdBasevaluesBasevalueidWithResponse call
-func ParseGetIdBasevaluesBasevalueidResponse(rsp *http.Response) (*GetIdBasevaluesBasevalueidResponse, error) {
+// ParsePostIdRefvaluesResponse parses an HTTP response from a PostIdRefvaluesWithResponse call
+func ParsePostIdRefvaluesResponse(rsp *http.Response) (*PostIdRefvaluesResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetIdBasevaluesBasevalueidResponse{
+	response := &PostIdRefvaluesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParseGetIdReportsResponse parses an HTTP response from a GetIdReportsWithResponse call
+func ParseGetIdReportsResponse(rsp *http.Response) (*GetIdReportsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetIdReportsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
+		var dest []ReportInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1967,15 +6252,15 @@ func ParseGetIdBasevaluesBasevalueidResponse(rsp *http.Response) (*GetIdBasevalu
 	return response, nil
 }
 
-// ParsePostIdBasevaluesBasevalueidResponse parses an HTTP response from a PostIdBasevaluesBasevalueidWithResponse call
-func ParsePostIdBasevaluesBasevalueidResponse(rsp *http.Response) (*PostIdBasevaluesBasevalueidResponse, error) {
+// ParseDeleteIdReportsReportidResponse parses an HTTP response from a DeleteIdReportsReportidWithResponse call
+func ParseDeleteIdReportsReportidResponse(rsp *http.Response) (*DeleteIdReportsReportidResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostIdBasevaluesBasevalueidResponse{
+	response := &DeleteIdReportsReportidResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1986,22 +6271,22 @@ func ParsePostIdBasevaluesBasevalueidResponse(rsp *http.Response) (*PostIdBaseva
 	return response, nil
 }
 
-// ParseGetIdNewbasevalueResponse parses an HTTP response from a GetIdNewbasevalueWithResponse call
-func ParseGetIdNewbasevalueResponse(rsp *http.Response) (*GetIdNewbasevalueResponse, error) {
+// ParseGetIdReportsReportidResponse parses an HTTP response from a GetIdReportsReportidWithResponse call
+func ParseGetIdReportsReportidResponse(rsp *http.Response) (*GetIdReportsReportidResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetIdNewbasevalueResponse{
+	response := &GetIdReportsReportidResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []BaseValueInfo
+		var dest map[string]interface{}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2015,63 +6300,86 @@ func ParseGetIdNewbasevalueResponse(rsp *http.Response) (*GetIdNewbasevalueRespo
 	return response, nil
 }
 
-// ParsePostIdNewbasevalueResponse parses an HTTP response from a PostIdNewbasevalueWithResponse call
-func ParsePostIdNewbasevalueResponse(rsp *http.Response) (*PostIdNewbasevalueResponse, error) {
+// ParseGetIdReportsReportidBioslogResponse parses an HTTP response from a GetIdReportsReportidBioslogWithResponse call
+func ParseGetIdReportsReportidBioslogResponse(rsp *http.Response) (*GetIdReportsReportidBioslogResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostIdNewbasevalueResponse{
+	response := &GetIdReportsReportidBioslogResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []BiosEvent
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetIdReportsResponse parses an HTTP response from a GetIdReportsWithResponse call
-func ParseGetIdReportsResponse(rsp *http.Response) (*GetIdReportsResponse, error) {
+// ParseGetIdTrustResponse parses an HTTP response from a GetIdTrustWithResponse call
+func ParseGetIdTrustResponse(rsp *http.Response) (*GetIdTrustResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetIdReportsResponse{
+	response := &GetIdTrustResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []ReportInfo
+		var dest TrustInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case rsp.StatusCode == 200:
-		// Content-type (text/plain) unsupported
+	}
+
+	return response, nil
+}
+
+// ParseGetUuidBasevalueResponse parses an HTTP response from a GetUuidBasevalueWithResponse call
+func ParseGetUuidBasevalueResponse(rsp *http.Response) (*GetUuidBasevalueResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUuidBasevalueResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
+	switch {
 	}
 
 	return response, nil
 }
 
-// ParseDeleteIdReportsReportidResponse parses an HTTP response from a DeleteIdReportsReportidWithResponse call
-func ParseDeleteIdReportsReportidResponse(rsp *http.Response) (*DeleteIdReportsReportidResponse, error) {
+// ParsePostUuidBasevalueResponse parses an HTTP response from a PostUuidBasevalueWithResponse call
+func ParsePostUuidBasevalueResponse(rsp *http.Response) (*PostUuidBasevalueResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteIdReportsReportidResponse{
+	response := &PostUuidBasevalueResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -2082,30 +6390,27 @@ func ParseDeleteIdReportsReportidResponse(rsp *http.Response) (*DeleteIdReportsR
 	return response, nil
 }
 
-// ParseGetIdReportsReportidResponse parses an HTTP response from a GetIdReportsReportidWithResponse call
-func ParseGetIdReportsReportidResponse(rsp *http.Response) (*GetIdReportsReportidResponse, error) {
+// ParseGetUuidStatusResponse parses an HTTP response from a GetUuidStatusWithResponse call
+func ParseGetUuidStatusResponse(rsp *http.Response) (*GetUuidStatusResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer rsp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetIdReportsReportidResponse{
+	response := &GetUuidStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
+		var dest TrustInfo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case rsp.StatusCode == 200:
-		// Content-type (text/plain) unsupported
-
 	}
 
 	return response, nil
@@ -2123,9 +6428,66 @@ type ServerInterface interface {
 	// (POST /config)
 	PostConfig(ctx echo.Context) error
 
+	// (GET /groups)
+	GetGroups(ctx echo.Context) error
+
+	// (POST /groups)
+	PostGroups(ctx echo.Context) error
+
+	// (DELETE /groups/{groupid})
+	DeleteGroupsGroupid(ctx echo.Context, groupid int64) error
+
+	// (GET /groups/{groupid})
+	GetGroupsGroupid(ctx echo.Context, groupid int64) error
+
+	// (POST /groups/{groupid}/basevalues/{basevalueid}/promote)
+	PostGroupsGroupidBasevaluesBasevalueidPromote(ctx echo.Context, groupid int64, basevalueid int64, params PostGroupsGroupidBasevaluesBasevalueidPromoteParams) error
+
+	// (POST /groups/{groupid}/basevalues/{basevalueid}/retire)
+	PostGroupsGroupidBasevaluesBasevalueidRetire(ctx echo.Context, groupid int64, basevalueid int64, params PostGroupsGroupidBasevaluesBasevalueidRetireParams) error
+
+	// (POST /groups/{groupid}/refvalues)
+	PostGroupsGroupidRefvalues(ctx echo.Context, groupid int64, params PostGroupsGroupidRefvaluesParams) error
+
+	// (GET /ima/keys)
+	GetImaKeys(ctx echo.Context) error
+
+	// (POST /ima/keys)
+	PostImaKeys(ctx echo.Context) error
+
+	// (DELETE /ima/keys/{keyid})
+	DeleteImaKeysKeyid(ctx echo.Context, keyid string) error
+
 	// (POST /login)
 	PostLogin(ctx echo.Context) error
 
+	// (GET /maintwindows)
+	GetMaintwindows(ctx echo.Context) error
+
+	// (POST /maintwindows)
+	PostMaintwindows(ctx echo.Context, params PostMaintwindowsParams) error
+
+	// (GET /maintwindows/{windowid})
+	GetMaintwindowsWindowid(ctx echo.Context, windowid int64) error
+
+	// (POST /maintwindows/{windowid}/close)
+	PostMaintwindowsWindowidClose(ctx echo.Context, windowid int64, params PostMaintwindowsWindowidCloseParams) error
+
+	// (GET /policies)
+	GetPolicies(ctx echo.Context) error
+
+	// (POST /policies)
+	PostPolicies(ctx echo.Context) error
+
+	// (DELETE /policies/{name})
+	DeletePoliciesName(ctx echo.Context, name string) error
+
+	// (GET /policies/{name})
+	GetPoliciesName(ctx echo.Context, name string) error
+
+	// (GET /storestats)
+	GetStorestats(ctx echo.Context) error
+
 	// (GET /version)
 	GetVersion(ctx echo.Context) error
 
@@ -2144,6 +6506,9 @@ type ServerInterface interface {
 	// (GET /{id}/basevalues)
 	GetIdBasevalues(ctx echo.Context, id int64) error
 
+	// (POST /{id}/basevalues/rollback)
+	PostIdBasevaluesRollback(ctx echo.Context, id int64, params PostIdBasevaluesRollbackParams) error
+
 	// (DELETE /{id}/basevalues/{basevalueid})
 	DeleteIdBasevaluesBasevalueid(ctx echo.Context, id int64, basevalueid int64) error
 
@@ -2153,64 +6518,437 @@ type ServerInterface interface {
 	// (POST /{id}/basevalues/{basevalueid})
 	PostIdBasevaluesBasevalueid(ctx echo.Context, id int64, basevalueid int64) error
 
+	// (POST /{id}/basevalues/{basevalueid}/promote)
+	PostIdBasevaluesBasevalueidPromote(ctx echo.Context, id int64, basevalueid int64, params PostIdBasevaluesBasevalueidPromoteParams) error
+
+	// (GET /{id}/basevalues/{basevalueid}/refvalue)
+	GetIdBasevaluesBasevalueidRefvalue(ctx echo.Context, id int64, basevalueid int64) error
+
+	// (POST /{id}/basevalues/{basevalueid}/retire)
+	PostIdBasevaluesBasevalueidRetire(ctx echo.Context, id int64, basevalueid int64, params PostIdBasevaluesBasevalueidRetireParams) error
+	// Return a list of trust status for all containers of a given client
+	// (GET /{id}/container/status)
+	GetIdContainerStatus(ctx echo.Context, id int64) error
+	// Return a list of trust status for all devices of a given client
+	// (GET /{id}/device/status)
+	GetIdDeviceStatus(ctx echo.Context, id int64) error
+
 	// (GET /{id}/newbasevalue)
 	GetIdNewbasevalue(ctx echo.Context, id int64) error
 
 	// (POST /{id}/newbasevalue)
 	PostIdNewbasevalue(ctx echo.Context, id int64) error
 
+	// (POST /{id}/refvalues)
+	PostIdRefvalues(ctx echo.Context, id int64, params PostIdRefvaluesParams) error
+
 	// (GET /{id}/reports)
 	GetIdReports(ctx echo.Context, id int64) error
 
-	// (DELETE /{id}/reports/{reportid})
-	DeleteIdReportsReportid(ctx echo.Context, id int64, reportid int64) error
+	// (DELETE /{id}/reports/{reportid})
+	DeleteIdReportsReportid(ctx echo.Context, id int64, reportid int64) error
+
+	// (GET /{id}/reports/{reportid})
+	GetIdReportsReportid(ctx echo.Context, id int64, reportid int64) error
+
+	// (GET /{id}/reports/{reportid}/bioslog)
+	GetIdReportsReportidBioslog(ctx echo.Context, id int64, reportid int64) error
+	// Return the trust state of a given client with its history
+	// (GET /{id}/trust)
+	GetIdTrust(ctx echo.Context, id int64) error
+	// Return the base value of a given container/device
+	// (GET /{uuid}/basevalue)
+	GetUuidBasevalue(ctx echo.Context, uuid string) error
+	// create/update the base value of the given container/device
+	// (POST /{uuid}/basevalue)
+	PostUuidBasevalue(ctx echo.Context, uuid string) error
+	// Return a trust status for given container/device
+	// (GET /{uuid}/status)
+	GetUuidStatus(ctx echo.Context, uuid string) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// Get converts echo context to params.
+func (w *ServerInterfaceWrapper) Get(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.Get(ctx)
+	return err
+}
+
+// GetConfig converts echo context to params.
+func (w *ServerInterfaceWrapper) GetConfig(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetConfig(ctx)
+	return err
+}
+
+// PostConfig converts echo context to params.
+func (w *ServerInterfaceWrapper) PostConfig(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostConfig(ctx)
+	return err
+}
+
+// GetGroups converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGroups(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetGroups(ctx)
+	return err
+}
+
+// PostGroups converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGroups(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGroups(ctx)
+	return err
+}
+
+// DeleteGroupsGroupid converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteGroupsGroupid(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupid" -------------
+	var groupid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupid", runtime.ParamLocationPath, ctx.Param("groupid"), &groupid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteGroupsGroupid(ctx, groupid)
+	return err
+}
+
+// GetGroupsGroupid converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGroupsGroupid(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupid" -------------
+	var groupid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupid", runtime.ParamLocationPath, ctx.Param("groupid"), &groupid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetGroupsGroupid(ctx, groupid)
+	return err
+}
+
+// PostGroupsGroupidBasevaluesBasevalueidPromote converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGroupsGroupidBasevaluesBasevalueidPromote(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupid" -------------
+	var groupid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupid", runtime.ParamLocationPath, ctx.Param("groupid"), &groupid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupid: %s", err))
+	}
+
+	// ------------- Path parameter "basevalueid" -------------
+	var basevalueid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, ctx.Param("basevalueid"), &basevalueid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter basevalueid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGroupsGroupidBasevaluesBasevalueidPromoteParams
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", ctx.QueryParams(), &params.Author)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter author: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGroupsGroupidBasevaluesBasevalueidPromote(ctx, groupid, basevalueid, params)
+	return err
+}
+
+// PostGroupsGroupidBasevaluesBasevalueidRetire converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGroupsGroupidBasevaluesBasevalueidRetire(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupid" -------------
+	var groupid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupid", runtime.ParamLocationPath, ctx.Param("groupid"), &groupid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupid: %s", err))
+	}
+
+	// ------------- Path parameter "basevalueid" -------------
+	var basevalueid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, ctx.Param("basevalueid"), &basevalueid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter basevalueid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGroupsGroupidBasevaluesBasevalueidRetireParams
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", ctx.QueryParams(), &params.Author)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter author: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGroupsGroupidBasevaluesBasevalueidRetire(ctx, groupid, basevalueid, params)
+	return err
+}
+
+// PostGroupsGroupidRefvalues converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGroupsGroupidRefvalues(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "groupid" -------------
+	var groupid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "groupid", runtime.ParamLocationPath, ctx.Param("groupid"), &groupid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGroupsGroupidRefvaluesParams
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Optional query parameter "enabled" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "enabled", ctx.QueryParams(), &params.Enabled)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter enabled: %s", err))
+	}
+
+	// ------------- Optional query parameter "imamode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "imamode", ctx.QueryParams(), &params.Imamode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter imamode: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGroupsGroupidRefvalues(ctx, groupid, params)
+	return err
+}
+
+// GetImaKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) GetImaKeys(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetImaKeys(ctx)
+	return err
+}
+
+// PostImaKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) PostImaKeys(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostImaKeys(ctx)
+	return err
+}
+
+// DeleteImaKeysKeyid converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteImaKeysKeyid(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "keyid" -------------
+	var keyid string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "keyid", runtime.ParamLocationPath, ctx.Param("keyid"), &keyid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter keyid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteImaKeysKeyid(ctx, keyid)
+	return err
+}
+
+// PostLogin converts echo context to params.
+func (w *ServerInterfaceWrapper) PostLogin(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostLogin(ctx)
+	return err
+}
+
+// GetMaintwindows converts echo context to params.
+func (w *ServerInterfaceWrapper) GetMaintwindows(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetMaintwindows(ctx)
+	return err
+}
+
+// PostMaintwindows converts echo context to params.
+func (w *ServerInterfaceWrapper) PostMaintwindows(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostMaintwindowsParams
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", ctx.QueryParams(), &params.Author)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter author: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostMaintwindows(ctx, params)
+	return err
+}
+
+// GetMaintwindowsWindowid converts echo context to params.
+func (w *ServerInterfaceWrapper) GetMaintwindowsWindowid(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "windowid" -------------
+	var windowid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "windowid", runtime.ParamLocationPath, ctx.Param("windowid"), &windowid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter windowid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetMaintwindowsWindowid(ctx, windowid)
+	return err
+}
+
+// PostMaintwindowsWindowidClose converts echo context to params.
+func (w *ServerInterfaceWrapper) PostMaintwindowsWindowidClose(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "windowid" -------------
+	var windowid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "windowid", runtime.ParamLocationPath, ctx.Param("windowid"), &windowid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter windowid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostMaintwindowsWindowidCloseParams
+	// ------------- Optional query parameter "author" -------------
 
-	// (GET /{id}/reports/{reportid})
-	GetIdReportsReportid(ctx echo.Context, id int64, reportid int64) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "author", ctx.QueryParams(), &params.Author)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter author: %s", err))
+	}
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostMaintwindowsWindowidClose(ctx, windowid, params)
+	return err
 }
 
-// Get converts echo context to params.
-func (w *ServerInterfaceWrapper) Get(ctx echo.Context) error {
+// GetPolicies converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPolicies(ctx echo.Context) error {
 	var err error
 
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.Get(ctx)
+	err = w.Handler.GetPolicies(ctx)
 	return err
 }
 
-// GetConfig converts echo context to params.
-func (w *ServerInterfaceWrapper) GetConfig(ctx echo.Context) error {
+// PostPolicies converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPolicies(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.GetConfig(ctx)
+	err = w.Handler.PostPolicies(ctx)
 	return err
 }
 
-// PostConfig converts echo context to params.
-func (w *ServerInterfaceWrapper) PostConfig(ctx echo.Context) error {
+// DeletePoliciesName converts echo context to params.
+func (w *ServerInterfaceWrapper) DeletePoliciesName(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
 
 	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
 
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.PostConfig(ctx)
+	err = w.Handler.DeletePoliciesName(ctx, name)
 	return err
 }
 
-// PostLogin converts echo context to params.
-func (w *ServerInterfaceWrapper) PostLogin(ctx echo.Context) error {
+// GetPoliciesName converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPoliciesName(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPoliciesName(ctx, name)
+	return err
+}
+
+// GetStorestats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStorestats(ctx echo.Context) error {
 	var err error
 
 	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
 
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.PostLogin(ctx)
+	err = w.Handler.GetStorestats(ctx)
 	return err
 }
 
@@ -2315,6 +7053,33 @@ func (w *ServerInterfaceWrapper) GetIdBasevalues(ctx echo.Context) error {
 	return err
 }
 
+// PostIdBasevaluesRollback converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIdBasevaluesRollback(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostIdBasevaluesRollbackParams
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", ctx.QueryParams(), &params.Author)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter author: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostIdBasevaluesRollback(ctx, id, params)
+	return err
+}
+
 // DeleteIdBasevaluesBasevalueid converts echo context to params.
 func (w *ServerInterfaceWrapper) DeleteIdBasevaluesBasevalueid(ctx echo.Context) error {
 	var err error
@@ -2391,6 +7156,136 @@ func (w *ServerInterfaceWrapper) PostIdBasevaluesBasevalueid(ctx echo.Context) e
 	return err
 }
 
+// PostIdBasevaluesBasevalueidPromote converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIdBasevaluesBasevalueidPromote(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "basevalueid" -------------
+	var basevalueid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, ctx.Param("basevalueid"), &basevalueid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter basevalueid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostIdBasevaluesBasevalueidPromoteParams
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", ctx.QueryParams(), &params.Author)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter author: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostIdBasevaluesBasevalueidPromote(ctx, id, basevalueid, params)
+	return err
+}
+
+// GetIdBasevaluesBasevalueidRefvalue converts echo context to params.
+func (w *ServerInterfaceWrapper) GetIdBasevaluesBasevalueidRefvalue(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "basevalueid" -------------
+	var basevalueid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, ctx.Param("basevalueid"), &basevalueid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter basevalueid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetIdBasevaluesBasevalueidRefvalue(ctx, id, basevalueid)
+	return err
+}
+
+// PostIdBasevaluesBasevalueidRetire converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIdBasevaluesBasevalueidRetire(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "basevalueid" -------------
+	var basevalueid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "basevalueid", runtime.ParamLocationPath, ctx.Param("basevalueid"), &basevalueid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter basevalueid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostIdBasevaluesBasevalueidRetireParams
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", ctx.QueryParams(), &params.Author)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter author: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostIdBasevaluesBasevalueidRetire(ctx, id, basevalueid, params)
+	return err
+}
+
+// GetIdContainerStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetIdContainerStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetIdContainerStatus(ctx, id)
+	return err
+}
+
+// GetIdDeviceStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetIdDeviceStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetIdDeviceStatus(ctx, id)
+	return err
+}
+
 // GetIdNewbasevalue converts echo context to params.
 func (w *ServerInterfaceWrapper) GetIdNewbasevalue(ctx echo.Context) error {
 	var err error
@@ -2425,6 +7320,47 @@ func (w *ServerInterfaceWrapper) PostIdNewbasevalue(ctx echo.Context) error {
 	return err
 }
 
+// PostIdRefvalues converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIdRefvalues(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostIdRefvaluesParams
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Optional query parameter "enabled" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "enabled", ctx.QueryParams(), &params.Enabled)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter enabled: %s", err))
+	}
+
+	// ------------- Optional query parameter "imamode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "imamode", ctx.QueryParams(), &params.Imamode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter imamode: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostIdRefvalues(ctx, id, params)
+	return err
+}
+
 // GetIdReports converts echo context to params.
 func (w *ServerInterfaceWrapper) GetIdReports(ctx echo.Context) error {
 	var err error
@@ -2491,6 +7427,100 @@ func (w *ServerInterfaceWrapper) GetIdReportsReportid(ctx echo.Context) error {
 	return err
 }
 
+// GetIdReportsReportidBioslog converts echo context to params.
+func (w *ServerInterfaceWrapper) GetIdReportsReportidBioslog(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "reportid" -------------
+	var reportid int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reportid", runtime.ParamLocationPath, ctx.Param("reportid"), &reportid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reportid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetIdReportsReportidBioslog(ctx, id, reportid)
+	return err
+}
+
+// GetIdTrust converts echo context to params.
+func (w *ServerInterfaceWrapper) GetIdTrust(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetIdTrust(ctx, id)
+	return err
+}
+
+// GetUuidBasevalue converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUuidBasevalue(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "uuid", runtime.ParamLocationPath, ctx.Param("uuid"), &uuid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetUuidBasevalue(ctx, uuid)
+	return err
+}
+
+// PostUuidBasevalue converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUuidBasevalue(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "uuid", runtime.ParamLocationPath, ctx.Param("uuid"), &uuid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostUuidBasevalue(ctx, uuid)
+	return err
+}
+
+// GetUuidStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUuidStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "uuid", runtime.ParamLocationPath, ctx.Param("uuid"), &uuid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	ctx.Set(Servermgt_oauth2Scopes, []string{"write:servers"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetUuidStatus(ctx, uuid)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -2522,51 +7552,132 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/", wrapper.Get)
 	router.GET(baseURL+"/config", wrapper.GetConfig)
 	router.POST(baseURL+"/config", wrapper.PostConfig)
+	router.GET(baseURL+"/groups", wrapper.GetGroups)
+	router.POST(baseURL+"/groups", wrapper.PostGroups)
+	router.DELETE(baseURL+"/groups/:groupid", wrapper.DeleteGroupsGroupid)
+	router.GET(baseURL+"/groups/:groupid", wrapper.GetGroupsGroupid)
+	router.POST(baseURL+"/groups/:groupid/basevalues/:basevalueid/promote", wrapper.PostGroupsGroupidBasevaluesBasevalueidPromote)
+	router.POST(baseURL+"/groups/:groupid/basevalues/:basevalueid/retire", wrapper.PostGroupsGroupidBasevaluesBasevalueidRetire)
+	router.POST(baseURL+"/groups/:groupid/refvalues", wrapper.PostGroupsGroupidRefvalues)
+	router.GET(baseURL+"/ima/keys", wrapper.GetImaKeys)
+	router.POST(baseURL+"/ima/keys", wrapper.PostImaKeys)
+	router.DELETE(baseURL+"/ima/keys/:keyid", wrapper.DeleteImaKeysKeyid)
 	router.POST(baseURL+"/login", wrapper.PostLogin)
+	router.GET(baseURL+"/maintwindows", wrapper.GetMaintwindows)
+	router.POST(baseURL+"/maintwindows", wrapper.PostMaintwindows)
+	router.GET(baseURL+"/maintwindows/:windowid", wrapper.GetMaintwindowsWindowid)
+	router.POST(baseURL+"/maintwindows/:windowid/close", wrapper.PostMaintwindowsWindowidClose)
+	router.GET(baseURL+"/policies", wrapper.GetPolicies)
+	router.POST(baseURL+"/policies", wrapper.PostPolicies)
+	router.DELETE(baseURL+"/policies/:name", wrapper.DeletePoliciesName)
+	router.GET(baseURL+"/policies/:name", wrapper.GetPoliciesName)
+	router.GET(baseURL+"/storestats", wrapper.GetStorestats)
 	router.GET(baseURL+"/version", wrapper.GetVersion)
 	router.GET(baseURL+"/:from/:to", wrapper.GetFromTo)
 	router.DELETE(baseURL+"/:id", wrapper.DeleteId)
 	router.GET(baseURL+"/:id", wrapper.GetId)
 	router.POST(baseURL+"/:id", wrapper.PostId)
 	router.GET(baseURL+"/:id/basevalues", wrapper.GetIdBasevalues)
+	router.POST(baseURL+"/:id/basevalues/rollback", wrapper.PostIdBasevaluesRollback)
 	router.DELETE(baseURL+"/:id/basevalues/:basevalueid", wrapper.DeleteIdBasevaluesBasevalueid)
 	router.GET(baseURL+"/:id/basevalues/:basevalueid", wrapper.GetIdBasevaluesBasevalueid)
 	router.POST(baseURL+"/:id/basevalues/:basevalueid", wrapper.PostIdBasevaluesBasevalueid)
+	router.POST(baseURL+"/:id/basevalues/:basevalueid/promote", wrapper.PostIdBasevaluesBasevalueidPromote)
+	router.GET(baseURL+"/:id/basevalues/:basevalueid/refvalue", wrapper.GetIdBasevaluesBasevalueidRefvalue)
+	router.POST(baseURL+"/:id/basevalues/:basevalueid/retire", wrapper.PostIdBasevaluesBasevalueidRetire)
+	router.GET(baseURL+"/:id/container/status", wrapper.GetIdContainerStatus)
+	router.GET(baseURL+"/:id/device/status", wrapper.GetIdDeviceStatus)
 	router.GET(baseURL+"/:id/newbasevalue", wrapper.GetIdNewbasevalue)
 	router.POST(baseURL+"/:id/newbasevalue", wrapper.PostIdNewbasevalue)
+	router.POST(baseURL+"/:id/refvalues", wrapper.PostIdRefvalues)
 	router.GET(baseURL+"/:id/reports", wrapper.GetIdReports)
 	router.DELETE(baseURL+"/:id/reports/:reportid", wrapper.DeleteIdReportsReportid)
 	router.GET(baseURL+"/:id/reports/:reportid", wrapper.GetIdReportsReportid)
+	router.GET(baseURL+"/:id/reports/:reportid/bioslog", wrapper.GetIdReportsReportidBioslog)
+	router.GET(baseURL+"/:id/trust", wrapper.GetIdTrust)
+	router.GET(baseURL+"/:uuid/basevalue", wrapper.GetUuidBasevalue)
+	router.POST(baseURL+"/:uuid/basevalue", wrapper.PostUuidBasevalue)
+	router.GET(baseURL+"/:uuid/status", wrapper.GetUuidStatus)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaW2/bNhT+KwQ3oC+e5WZbgemt7S4I1g1F0vUlCAZaOpLZUaRKHiXLDP33gaRujqlY",
-	"dowkSPtSu7ycy3e+c7GUNU1UUSoJEg2N19QkKyiY+/qGGfjIRAWnMlN2odSqBI0c3PaSK/eJNyXQmBrU",
-	"XOa0ntFEA0NAXkBwGyRbCkjtXgoZqwTSOGPCwKw9u1RKAJP2MHfnMqULhjSmXOKrH2h3kEuEHLQ7WLCg",
-	"NslGzCgTHVivZ1TD54pra+CFVb/hT299I9nLmXkwvBWXnXlq+QkStMrOoFQax3EUKj8Eyn3QGVNRJnps",
-	"63Ol0Adqa8vwXDKsdNgu1JXBqSG+YoKnbOLxnfHppfVmdJ4M7e48n3UR6HAKhfAc9BXocAinx6G5voWY",
-	"koJLmAaYhnyUEXsgH4KyFd0ZNATRGb8NjWUDJJXmeHNui4eHxDi4ihz/XiGW3h6TaF4iV5LG1K6SJTM8",
-	"IazCFUjkCbN7BBXRUCgEwhDBoF/18mwEnQ7riL3co+zUOFtaxcoKPtlW7df3VJsJde0cs9eU5v+5/bcq",
-	"ha3Fv7Ro7ImjSKiEiZUyGP+4+OlVtHHQeaNKD5gGlsZenaGx+2+jnVjgLbW4krbIXGuOECdKZjynMS1U",
-	"yrMbopkhfq3SrXR/shfaHEWmc8BW+sYl40ik/oE7vXAHXORbLnio63rA8U3Qf69kCTI/h+TdOM6k1OqK",
-	"p2DI2S/nH7JKkNfvT40NTcEky4HgCogjJNGuohrCZEqsM/2OFVkZorLmUrrpr5nTGRU8AWlc6HyDoH9U",
-	"gsn35+++O5kv6IxWG9770/NEmUTMlc7niYzaCycOMY4Cbnl55r18PfDSemVd6mllDfIAvZwv5gtXCkqQ",
-	"rOQ0pt/PF/OXtlAxXDmORPafHHAbXusfI4IbtI4vNYeMy9wxh2RKEyZE6z91Kny4T1Ma098AXeqbUknj",
-	"yXiyWNiPREkE6dSxshRNqkSfjNXZzgquACIU7uK3GjIa02+ifqqImpEiGlTQnjlMa3bjqQT/YlQKxo8u",
-	"u57dQksDVlpOBqx2IqI24+4IgWVhUmkNEjfzyirRLIj9Wy/2nhHYnmPG8Lx9cgsdUyUJGEMalJxPIV/c",
-	"1VKZABZNoWFEwvU0IN4rczcSYRvbiraCcVXDDkXji3WgRVzcKpWX9aULuVC5hy/sptsmzBBGWFpwSSrj",
-	"snrbtXdO0D6eedX3sL0rLRP52py3qNllDQaJrUMByn5sRD9ZzrLOm/6KxWSdaVXU0RpVvaOUmhISnvGk",
-	"KxENvC9ciQiB8qtWxQflyrVmBaBruRdran1xJbz9zRBTawUdjl+oK5gN/N05StazoGBU9xR7+WU1gkGc",
-	"fXxfGB/fli88rT1DBCBsc8Wvky0xW/z42R08TSfRoxnFjx7FcMqM+nBg7ZlNSqtNuAPp9MhYPQDjBz+g",
-	"jk/5Tvj48DNO/Z1tfWIYbed7kpxPVkzmcBcGB7ddWzKiJTNwxUTlTdkrH+zgaa+T5n4wNd708p9Pkmw+",
-	"bzx6Z9glfp9EuR2kQbsYxD5ad98P7CMvTL/S6xvtLj0t3vSKH4Yh4XlkuWHGo7e0ETgfptEFNO/M7i8l",
-	"jA9TSI7VcHfK36eUBGmxVw/uLxJUE2ZR35W/1oqNJwh74Hnf4UDCdefr3uOBhOuhgSXLIVxE/hxq+Tok",
-	"PMKQYEMFRYk3m71mNLNZmjZPzA7L6CcT8XCiTXfvvgnWvBs4aPRu7wZz6qzbfC7pNHgxffRculP2vtN2",
-	"G5fhpN2sRWv/5Qgzthc0Ol838T9r1D1mt9S9DU9qrG4QfOCReiRuw6R9/kF7gMpwrAn6buEHjc8d8eq6",
-	"+ysAH9/xF9GTXtH2r4LN8E04rS/r/wMAAP//12FGJD0lAAA=",
+	"H4sIAAAAAAAC/+w9W3Pbtpp/BcPdmb6oVprT05mjt6btdrNtejJ2T89Dx7MDkx9FnEAAA4C2tR799x3c",
+	"SJAEb5LsODl+SWTh+t0v+AA9JCnflZwBUzLZPCQyLWCHzcc3WMIfmFbwluVcf1EKXoJQBEwzrlTBhf6k",
+	"9iUkm0QqQdg2OaySGyzBfhlrJFxGG1JKgCmS6cacix1WySYhTH33bbLyvQlTsAVhugvAChTZxZcBhm8o",
+	"ZEHbDecUMNONW8Gr0q6UgUwFKRXhLNkkqgDEeAbI9EA8RxjJAgvIkAYK3Wp8JKs5+5sNCNnhKARkh3c8",
+	"M9ABq3bJ5s+kwLJIVokkW4ZVJSD8/DUXX7v2G66K5HrVn5LhAWyVaZySAnILcqxRKqxa28sEzlWySnBZ",
+	"Cn4Lmf6YKnKr9ylAEQFZdFtVmY2RsqpIFm24BSEN3R4iaL0jLON3Q0TeYd2VYZYCsj0RYeiuIGmBdHtD",
+	"bUQkshvMEK4U32FFUkzpfg4bGBR+rAzkmz81TwR87iAL5KXF1Y5cljhObiy3NNzdYJPf/AtSpQF/Q7j8",
+	"6RaY6stshlWc1zJQmNBQLpv5MrIFabUDzjKikYjp+9a8vfl6kwxQkLAM7uP0a7Nk0NDRLJ2W3+Is3iWE",
+	"Wdej1uG+Hr6yhPKAx5D8s9YPfQQPC5ggXBC1j3Oj1TZ3RFnmK8i2AKmQH6RZMC24BIZIjiTcgsDUDpJI",
+	"AoVUaT0F4hZEVMXYPlzEV5fVjQSllZ3+S8CWSAVa5dkZEWE5XyFKPgAqBc+qVGkwV0hzpBNBhFmGuBRA",
+	"AUtIegjroN8xdr2tQQx704Mp/XuebP58SP5TQJ5skv9YN3Zr7YzW2gxJDqsuVbR8GVk2fxEFO/NhbKq2",
+	"8Ws4GguB9421ak84Q9n3phm3YjONSFTNNDP30Xt9WCVvd/gX2MeNe05ofEMfYD8gyowrnCuI2xFZ2YUn",
+	"BdPO3wwI5o0xyTutxv9p9HcfiNChiHC9ZW6eO/2/QkBUAQL5YYgL5BwFLYAS1DzDDyzz9Kx7a/PxtdPq",
+	"PeQs8EbsVufto8RCyfikpknPV3CpQluHKeV3kCHFUVpgtjW60LO3N/M9exSz6V1GlwoL5fHS35GzwaaX",
+	"REyb4xwxrrpoH0Fkh5M8GTwiJthnmaYJBkb0zYRrfH5VxCVkN/vogudRMBFnT28vq6jx8ngJLPEbSa6n",
+	"SGMk3CGp4/LYZeIK6x2RO6zSIhKLpKrCNM5ZBdwja8m1hyeg5ELFpBDTbRRDcF9CqmBAPHGaQqldw2YZ",
+	"GcrMpFx4j6E9dZlqs5vBvbWyCLQ/h6xOIjuMtH5GJVZFDBIBWLa84u7qi0S5QzvnKzkL7paKydZ7Tkm6",
+	"79NqNDAb9J9ERReIjF37sqIQVUWBNzTLT7GLDwO5THfYMRG1MSGqI9FOZ9u+5wwnIMBUxAkAOmDrB91c",
+	"rIo2mSYFoMRKgWBDYWl0thGHynjHztH2XJ5jQpNVcoeFxol2Z0dM1ohwmH8r9oHxO2bc17QScMO5ct3s",
+	"AOc/xFeJM5gTqnrvMVa7hPwPH4p3tB/dhtuWBf5GT1bg13/9Tn/Y/SUKbhDXPYJeqzV1BjmuqEo2Cdxj",
+	"49H5jfq/t5TfjKYrzqgbeWnj19bOckwlrDrLMK4KwrbaaGiEUL5FBZbaMzKgmS9D8rX0mIJdSZ3BnMUB",
+	"mobjEaenv+wzgE+pzdKNfp4Y0Vwq6uR5XOx+8jyBynNM8831aq72iyNRm/94zKOxSPn2Ub0ossNDS5Sp",
+	"GGr6WHEFcU0sQFZULaG+RsClGRVXn7VOiy3XZB5jrUpUsvaU2rLVF5FbTEmG53cHkZE5EWQ3+A1XarZY",
+	"I7WdTXVEWNXMUJNsmJscMjexrMNQVBeEXJXUyeW90SdpAemHedGd7RqofQNPBIS1AyA0UXUU0rVipfVN",
+	"rldDKcIo3XfOMV/godW+fNQjkDLuIHZI7dHlBsRIdGWi/LjAz5daN7wv91JnhG12eB4bc0YJm9l3xI8X",
+	"sB1URnWkNob/37UcXJmeywQ3Jm1+NzV4oZy1cNTzvgJKKS5A7yhi4DLByxLa5KqG6fWxgmphbwpsq4q4",
+	"iylACe8Sz5hP4tu5i3eQ6fbtp1jVcDd7aG83hkdD2f8mUnGxH8+JnX7INqIT9Cpq33Wpdb4pWSUpZwoT",
+	"BkLDCLckhajKyQXfHcHNs6EbC5T5EQsPHFRNHAIFmApBbvZQb3QinnO0jyu7RyZV0TDcLP3f4tKIDRgh",
+	"zTGEOeqAsZdQDOjkKRPM3CBhkDRXqmsokiac9IhvvimBZXonoTqtWPMZ7kuzuVXC89wo3mjAaSw8Ufsr",
+	"jRvLDTb5vduq/y2UKvs+iv5WOykk1WeehQY9xbpNxz8CdlwBwkqBVPbb+uzJ4N8YDD24kTqzjNmLX5jr",
+	"iV/3l7bfL1w2p/wuSL2S/zPtP7jj89aX/xDU7WezXlOeYqo5ffPXV3/7bt3qaKDhpUWYAJxt7HIy2Zg/",
+	"w+MxrWoIZ1JnGQRRsEk5y8k22SQ7npF8jwSWyH5XCT+77dlM6roqLLag/OytQdKqpg8wCoXpYCjvudCi",
+	"+nAIfJk20n+pWAlsewXpr8N41qd/tyQDiS5/uvo9ryj6/v1bFxEzvAXjwhrudGlWaY4FNTBNi56yMmcP",
+	"dlDWhldeJKuEkhSYhOZANXlXUczeX/369euLV1oIWtDb3hcplym94GJ7kbK1H/DaYIwoCh0oLy2U3wdQ",
+	"aqg0SA1b1fFn8s3Fq4tXNoEADJck2SR/uXh18U0S5LzW+p8tqD56NXwYUSLNKeuNIJDb1ELOUc6FPnbx",
+	"8NtsuiX32yzZJD+DMhZAlpxJy4yvX73S/2nV7I75cVlSJyrrfznFaVXhbI0ceMo9fay/gHu1LikmZ5/7",
+	"0M28CFCVYLMRdjBTrL3EjZDAhFiVEMBUW670IgJHcf+DnfZECvSV8hA+uz172JFVmoKUyGHJwBSDxQwt",
+	"uYzgwikajBjczUPEey7HMRHfo1tI73FwqdBCmdx530T82VGV14drQ3JbATEudZQGR6hRCv/sWx5fxpqa",
+	"hhli0CF0F5IR+uIsQzjo7Es7HP7QXcFlq9DDyJXz9KTr6s5IYpwQYOxjBVK94dl+EbJmFHEcDocTKTKT",
+	"EMOI12hsH8Frsn0bY/h2N2QdAkQkuhOcbU1mWkmTLkZwT6Q6A9uvH8z/JDvY7VBQkWS5/b7NDbbKTSez",
+	"GQ8zUVxEyuJ6DPCjmdGywM92B/aMHe9A6W1qYIhe2+XgnQnf1n0bp1qJClYByaYLXa6XqB8HfIyG307S",
+	"kEhTf5DzimV2zN8mx0hFKDWYbdAq43g9mgNWYy5GSGWiCsN1Xuq1LxZsa1gVPhuqPp2oB8b0VE45RGV0",
+	"3RR+rB/qz7qhFFx7onqVuDZ3HRBGsoSU5CRtCW3eJrviFgq4V4iSHNJ9SgH5cHVIlzuSv6k3+abZ4nu3",
+	"wadjh1V07gBrZ5r/YwVi3yxQV6QMe2OPyaidOp9Bv1iT1/FEKNCjtqnphlLMvlLoBlyBV+Y5RvpkySDL",
+	"t2uiQ5Y/mykbFhNbPz4sJbZ9npAcKQiXdgsvcvB85MDdKvjixMDfu5DDDO+cfLjrMLomVYYE5CCApa5B",
+	"1rdY9q1IYLFgXNYbe3ox6LCpLyIfDrLj43wBWmRocMoVH+vv5IRjz3M553D9OOFUUzYSkSQrQh1OccFi",
+	"m7N6JDwsccSjvOo9lXnRVW+bnRjrSGftaJElO7z+APvp7IMpR9KMoBNYKQiljRNWIBFhpl7pA+yFzfL3",
+	"/HFbov80uYngOsDy5IRG8higXpEPq7JRNL3/6Z2Oon786bImO1N8FHtac42jbzzob21Bh04NpEToVRHJ",
+	"5DkYaP1g7jzMieLHsWx0ud0Y0seIo+ixUbxD0C/u0sW0QvfXM4bV+UxPYTJgH4TzFJxTvrX51jgfmmaE",
+	"JcIIZzvCUCUhngH71Uy0BDS79Al7NykEnzuYUjqRfAMiLKWVPk+0/G1q9RFn8UzAu3C1p1A/3asYxyVI",
+	"43mWQcXDS9DnDP1RVpj8FSERGJBVc0+UKNm7QDN0WdRKZO+ALMpcHdzHpPJIj/387kXrIszj5mx7HDLM",
+	"EYau8Wu+o05Gv/siNyPGL2dzOEL5Xz/YD85sjCQEIxDV10zDHKVnWu2TqCmF8E+3+CyTcdd0fq4pwgWc",
+	"Ffg7Q7z17WzeiiUOB8i8Nvp62HSZ5ji9byDnAoy2ApYhX6k3qnY8hX8wqz4hmZ9nSmIBg1hCnEf3EOnN",
+	"NKYCcLY/kcGO1j2mLNgVko2erlOsQCrkb4VrK0qp/tN6b/q7eq6IknnftD2+xxHc3Tou4IkDW8M3eUDb",
+	"R8t+pY2HjZSDaYnSt2GJ8meHMeltoe78dt5fWntcEx+SZPpc1qIsDM7MIWuDu3Ow/PpBq6BZZ6yU+oVd",
+	"MBYh8EAg5qnnnp+YVrf1/cvHjsNKT/izH1Yeha5ASTw5rp6R+ukir0Upw79ScWEq26aVdsorplw2OCzc",
+	"C/1DU5Fusy4ZVtg0YblnaSE445WkUVJdNZt4RK0R3ByYp7gNbmqwT9ESwQW1WVVngVJXJqkpFcIlieHu",
+	"j/oS7zOtPMM1NM0QjZMHHeoe1g+KTwUn9VGdL/Rz6P3KZgwjSPkvwXe/81lSr3fxKOdvin/iUOYzK+cM",
+	"6Gzp+1WTETb8MreEqTPNUE5zXlj6SSqR+jA8RhHQALpj5wufFldPwPHBww7nZ/l68uES5mHWnyzOnUlG",
+	"c9DxHHneHqyP4eBos/vQLhWZa2bq9bX7NFEK9zaoAfmChGTiMaFTxWRq+iWC0iXS4RCj/VpwSm+wvQs9",
+	"EG2nitxiBWHQ7gtHuvl7TjMQSBXYeopmKCDOXA3RlAmywthwzqXf3JNw0Odfz+NJ9dlX9DyMFbMd4+18",
+	"JWOVbYM+ULSA7Wm58LGq1s7ieA2g82ncscjKkzbo34WMT2PuzuUWTs6/xOBF2WKRp9iurlpurv69dcVy",
+	"fD6WgTj1UsDIJYAVMq90G3jcM92r+pM+ffCekeLWGsMSrllyV+ClPPrlmsBZhCV8pH40CztQGs3zuep4",
+	"gZH2hdIvxvqkCuVWrX+bbit9Y/UWhObvurqKwhane6SNunlBGCCDbCh0PPcNkxPM7YK7JS968+VayQK1",
+	"WT/gs7ZPbwRaMqLLfvC9r2znLyfz1byNNCMt9T36n6u//4ZMu5btGonBKyYg61I6IlDRvGU0SGL76NM5",
+	"yLtKZLXbYbFPNsll97mM1kMr/rWMGgJn77bkFpjbUdJwi33iaRar/Gi6vvBJyCcWfZ8tk9jtj3MIg7va",
+	"VCxOvHeuAJV4O+BT/Rau8pJ+/wTpd00q2JVq386PTT060rvjNdctejYUX3aF7fxJgfPf/VxAhWX3O1+u",
+	"dr5c7ZwvFyfd7jxZqOx9m2NOiofu6hhDdVk3fik2Kngj/ewGanTupYfDni5hdO++Wz/YD2c4bKt/MSZ+",
+	"0Obof+mW+5QRvGj28KzO1xwGn/hsbYBuodB++UR7As1wrqO08cmPOkerGW9MP6yD330YzR5nkPIMsvBH",
+	"R/SPgkxljxfw4Zv6Rwde2HFOtFP/0Ol8W9IcAxgKyqYSW8NtaatJEPKMCZXHcyEmafB5+AAzcx99DAZJ",
+	"DY+1MEXQPDj3ybMc7deGoZ/OiOzVULuqWicEYzT/RxW8EjWL8lU1QfuRbPiTJ8O7GWnbrcV6rKJ0APnd",
+	"gxGH+zoT7R6SD3IJ/Vj0qRB8/rjsGOTqkOkGkARlknHDCJt2zfoE6MzmL3ufQeDs7xGs7YQR0gdKogdL",
+	"IHHT6WbNDQtSzafI2nPRqh2ELdSv3dGPlU/u5ZEHyV2//m+pNvwA/ayn2Zsn4GX4Ar7+VcP/HwDaYRbe",
+	"1IAAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -26,6 +26,20 @@ curl -X GET http://localhost:40002/
 GET /version            显示当前rest api版本信息
 POST /login             采用账号密码方式登录的入口
 GET/POST /config        对RAS进行运行时配置的入口
+GET/POST /ima/keys      显示/新增IMA文件签名证书
+DELETE /ima/keys/{kid}  删除指定IMA文件签名证书
+GET/POST /policies      显示所有校验策略的最新版本/新增校验策略或其新版本
+GET /policies/{name}    显示指定校验策略的所有版本
+DELETE /policies/{name} 删除指定校验策略
+GET/POST /groups        显示所有节点组/按注册信息选择器新增节点组
+GET /groups/{gid}       显示指定节点组及其server和基准值
+DELETE /groups/{gid}    删除指定节点组及其基准值
+POST /groups/{gid}/refvalues    以JSON格式参考值新增指定节点组共享的基准值
+POST /groups/{gid}/basevalues/{bid}/promote 将指定节点组的指定基准值提升到下一生命周期状态
+POST /groups/{gid}/basevalues/{bid}/retire  停用指定节点组的指定基准值
+GET/POST /maintwindows  显示所有维护窗口/为指定server或节点组开启维护窗口
+GET /maintwindows/{wid} 显示指定维护窗口及其中自动更新的基准值
+POST /maintwindows/{wid}/close  提前关闭指定维护窗口
 GET /                   显示所有server的基本信息
 GET /{from}/{to}        显示指定从from到to的server的基本信息
 
@@ -34,12 +48,22 @@ POST    /{id}                   修改指定server的基本信息
 DELETE  /{id}                   删除指定server
 GET     /{id}/reports           显示指定server的所有可信报告
 GET     /{id}/reports/{rid}     显示指定server的指定可信报告
+GET     /{id}/reports/{rid}/bioslog  显示指定server的指定可信报告中解析后的BIOS日志
 DELETE  /{id}/reports/{rid}     删除指定server的指定可信报告
 GET     /{id}/basevalues        显示指定server的所有基准值
 POST    /{id}/basevalues        新增指定server的基准值
 GET     /{id}/basevalues/{bid}  显示指定server的指定基准值
 POST    /{id}/basevalues/{bid}  修改指定server的指定基准值
 DELETE  /{id}/basevalues/{bid}  删除指定server的指定基准值
+GET     /{id}/basevalues/{bid}/refvalue  以JSON格式参考值显示指定server的指定基准值
+POST    /{id}/refvalues         以JSON格式参考值新增指定server的基准值
+POST    /{id}/basevalues/{bid}/promote  将指定server的指定基准值提升到下一生命周期状态
+POST    /{id}/basevalues/{bid}/retire   停用指定server的指定基准值
+POST    /{id}/basevalues/rollback       回滚指定server的主机基准值到上一个版本
+GET     /{id}/trust             显示指定server的当前可信状态及其历史
+GET     /{id}/container/status  显示指定server所有容器的当前可信状态及其历史
+GET     /{id}/device/status     显示指定server所有设备的当前可信状态及其历史
+GET     /{uuid}/status          显示指定容器/设备的当前可信状态及其历史
 */
 
 // restapi package provides the restful api interface based on openapi standard.
@@ -48,7 +72,12 @@ package restapi
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io/ioutil"
 	"math"
 	"net/http"
@@ -61,6 +90,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/lestrrat-go/jwx/jwt"
 
+	"gitee.com/openeuler/kunpengsecl/attestation/common/cryptotools"
 	"gitee.com/openeuler/kunpengsecl/attestation/common/logger"
 	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
 	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
@@ -110,15 +140,19 @@ $.ajax({url:this.value,type:"DELETE",success:function(result,status,xhr)
 	htmlListBaseValues = `<html><head><title>Base Values List</title>
 <script src="https://apps.bdimg.com/libs/jquery/2.1.4/jquery.min.js"></script></head><body>
 <script>$(document).ready(function(){$("button").click(function(){$.ajax({url:this.value,
-type:"DELETE",success:function(result,status,xhr){if(status=="success"){location.reload(true);
-}},});});});</script><a href="/">Back</a>&emsp;<a href="/%d/newbasevalue">Add</a><br/>
+type:this.name,success:function(result,status,xhr){if(status=="success"){location.reload(true);
+}},});});});</script><a href="/">Back</a>&emsp;<a href="/%d/newbasevalue">Add</a>
+&emsp;<button type="button" name="POST" value="/%d/basevalues/rollback">Rollback</button><br/>
 <table border="1"><tr align="center" bgcolor="#00FF00">
-<th>Index</th><th>Create Time</th><th>Name</th><th>Enabled</th><th>Verified</th>
+<th>Index</th><th>Version</th><th>Create Time</th><th>Name</th><th>State</th><th>Author</th>
+<th>Update Time</th><th>Enabled</th><th>Verified</th>
 <th>Trusted</th><th>Pcr</th><th>Bios</th><th>Ima</th><th>Action</th></tr>`
-	htmlBaseValueInfo = `<tr><td>%d</td><td>%s</td><td>%s</td><td>%v</td>
-<td>%v</td><td>%v</td><td><a href="/%d/basevalues/%d">Link</a></td>
+	htmlBaseValueInfo = `<tr><td>%d</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td>
+<td>%s</td><td>%v</td><td>%v</td><td>%v</td><td><a href="/%d/basevalues/%d">Link</a></td>
 <td><a href="/%d/basevalues/%d">Link</a></td><td><a href="/%d/basevalues/%d">Link</a></td>
-<td><button type="button" value="/%d/basevalues/%d">Delete</button></td></tr>`
+<td><button type="button" name="POST" value="/%d/basevalues/%d/promote">Promote</button>
+<button type="button" name="POST" value="/%d/basevalues/%d/retire">Retire</button>
+<button type="button" name="DELETE" value="/%d/basevalues/%d">Delete</button></td></tr>`
 
 	// (GET /{id}/basevalues/{basevalueid})
 	htmlOneBaseValue = `<html><head><title>Base Value Detail</title></head><body>
@@ -138,6 +172,10 @@ type:"DELETE",success:function(result,status,xhr){if(status=="success"){location
 <tr><td>PCR Base Value File</td><td><input type="file" name="Pcr" /></td></tr>
 <tr><td>BIOS Base Value File</td><td><input type="file" name="Bios" /></td></tr>
 <tr><td>IMA Base Value File</td><td><input type="file" name="Ima" /></td></tr>
+<tr><td>IMA Verification Mode</td><td><select name="ImaMode"><option value ="hash">Hash</option>
+<option value ="signature">Signature</option>
+<option value ="signature-or-hash">Signature or Hash</option><option value ="both">Both</option></select></td></tr>
+<tr><td>Reference Value File (JSON)</td><td><input type="file" name="RefValue" /></td></tr>
 </table><br/><input type="submit" value="Save" /></form></body></html>`
 
 	// (GET /{id}/reports)
@@ -169,15 +207,19 @@ type:"DELETE",success:function(result,status,xhr){if(status=="success"){location
 	strCreateTime     = `Create Time`
 	strOnline         = `Online`
 	strTrusted        = `Trusted`
-	strUnknown        = "unknown"
-	strUntrusted      = "untrusted"
-	strNotFound       = "not found"
+	strTrustState     = `Trust State`
+	strTrustReason    = `Trust Reason`
 	strValidated      = `Validated`
 	strQuoted         = `Quoted`
 	strSignature      = `Signature`
 	strPcrLog         = `PcrLog`
 	strBiosLog        = `BiosLog`
 	strImaLog         = `ImaLog`
+	strSecureBoot     = `SecureBoot`
+	strVerdict        = `Verdict`
+	strResult         = `Result`
+	strPassed         = `passed`
+	strFailed         = `failed`
 	strClientID       = `ClientID`
 	strBaseType       = `BaseType`
 	strContainer      = "container"
@@ -185,11 +227,21 @@ type:"DELETE",success:function(result,status,xhr){if(status=="success"){location
 	strName           = "Name"
 	strEnabled        = "Enabled"
 	strIsAutoUpdate   = "IsAutoUpdate"
+	strPcrSelection   = "PcrSelection"
 	strVerified       = "Verified"
 	strPCR            = "Pcr"
 	strBIOS           = "Bios"
 	strIMA            = "Ima"
+	strImaMode        = "ImaMode"
+	strRefValue       = "RefValue"
+	strKeyID          = "KeyID"
+	strCert           = "Cert"
 	strBaseValueID    = `BaseValue ID`
+	strState          = "State"
+	strVersion        = "Version"
+	strAuthor         = "Author"
+	strUpdateTime     = `Update Time`
+	strRestAuthor     = "restapi"
 	errNoClient       = `rest api error: %v`
 
 	strDeleteClientSuccess    = `delete client %d success`
@@ -197,26 +249,41 @@ type:"DELETE",success:function(result,status,xhr){if(status=="success"){location
 	strDeleteReportFail       = `delete client %d report %d fail, %v`
 	strDeleteBaseValueSuccess = `delete client %d base value %d success`
 	strDeleteBaseValueFail    = `delete client %d base value %d fail, %v`
+	strDeleteImaKeySuccess    = `delete ima key %s success`
+	strDeletePolicySuccess    = `delete policy %s success`
+	strAddBaseValueSuccess    = `add client %d base value success`
+	strBaseValueNotFound      = `client %d base value %d not found`
+	strDeleteGroupSuccess     = `delete node group %d success`
+	strAddGroupBaseSuccess    = `add node group %d base value success`
+	strGroupBaseNotFound      = `node group %d base value %d not found`
+	strNotAutoUpdate          = `mgrstrategy isn't auto-update, open a maintenance window instead`
 )
 
+// MyRestAPIServer implements the rest api by trust manager mgr.
 type MyRestAPIServer struct {
+	mgr *trustmgr.TrustManager
 }
 
 type JsonResult struct {
 	Result string
 }
 
-func StartServer(https bool) {
+// NewRestAPIServer creates a rest api server which uses trust manager mgr.
+func NewRestAPIServer(mgr *trustmgr.TrustManager) *MyRestAPIServer {
+	return &MyRestAPIServer{mgr: mgr}
+}
+
+func StartServer(https bool, mgr *trustmgr.TrustManager) {
 	if !https {
 		//https off ,use http protocol
-		StartServerHttp(config.GetRestPort())
+		StartServerHttp(config.GetRestPort(), mgr)
 	} else {
 		//https on
-		StartServerHttps(config.GetHttpsPort())
+		StartServerHttps(config.GetHttpsPort(), mgr)
 	}
 }
 
-func StartServerHttp(port string) {
+func StartServerHttp(port string, mgr *trustmgr.TrustManager) {
 	e := echo.New()
 	// TODO: need to be replaced with a formal authenticator implementation
 	v, err := internal.NewFakeAuthenticator(config.GetAuthKeyFile())
@@ -225,11 +292,11 @@ func StartServerHttp(port string) {
 		return
 	}
 	logger.L.Sugar().Debug(CreateAuthValidator(v))
-	RegisterHandlers(e, &MyRestAPIServer{})
+	RegisterHandlers(e, NewRestAPIServer(mgr))
 	logger.L.Sugar().Debug(e.Start(port))
 }
 
-func StartServerHttps(httpsPort string) {
+func StartServerHttps(httpsPort string, mgr *trustmgr.TrustManager) {
 	e := echo.New()
 	// TODO: need to be replaced with a formal authenticator implementation
 	v, err := internal.NewFakeAuthenticator(config.GetAuthKeyFile())
@@ -238,7 +305,7 @@ func StartServerHttps(httpsPort string) {
 		return
 	}
 	logger.L.Sugar().Debug(CreateAuthValidator(v))
-	RegisterHandlers(e, &MyRestAPIServer{})
+	RegisterHandlers(e, NewRestAPIServer(mgr))
 	e.Logger.Fatal(e.StartTLS(httpsPort, "pca-root.crt", "pca-root.key"))
 }
 
@@ -370,8 +437,8 @@ func genAllListHtml(nodes []typdefs.NodeInfo) string {
 	return buf.String()
 }
 
-func showListNodesByRange(ctx echo.Context, from, to int64) error {
-	nodes, err := trustmgr.GetAllNodes(from, to)
+func (s *MyRestAPIServer) showListNodesByRange(ctx echo.Context, from, to int64) error {
+	nodes, err := s.mgr.GetAllNodes(from, to)
 	if checkJSON(ctx) {
 		if err != nil {
 			logger.L.Sugar().Debugf(errNoClient, err)
@@ -393,20 +460,27 @@ func showListNodesByRange(ctx echo.Context, from, to int64) error {
 //  read all nodes information as json
 //    curl -X GET -H "Content-type: application/json" http://localhost:40002
 func (s *MyRestAPIServer) Get(ctx echo.Context) error {
-	return showListNodesByRange(ctx, math.MinInt64, math.MaxInt64)
+	return s.showListNodesByRange(ctx, math.MinInt64, math.MaxInt64)
 }
 
 // TODO: add more parameters in this struct to export to outside control.
 type cfgRecord struct {
 	HBDuration    time.Duration `json:"hbduration" form:"hbduration"`
 	TrustDuration time.Duration `json:"trustduration" form:"trustduration"`
+	MgrStrategy   string        `json:"mgrstrategy,omitempty" form:"mgrstrategy"`
+	ChangeTime    string        `json:"changetime,omitempty" form:"-"`
 }
 
 func genConfigJson() *cfgRecord {
-	return &cfgRecord{
+	cfg := &cfgRecord{
 		HBDuration:    config.GetHBDuration() / time.Second,
 		TrustDuration: config.GetTrustDuration() / time.Second,
+		MgrStrategy:   config.GetMgrStrategy(),
 	}
+	if t := config.GetChangeTime(); !t.IsZero() {
+		cfg.ChangeTime = t.Format(typdefs.StrTimeFormat)
+	}
+	return cfg
 }
 
 func genConfigHtml() string {
@@ -439,6 +513,8 @@ func (s *MyRestAPIServer) GetConfig(ctx echo.Context) error {
 //    curl -X POST -d "hbduration=20" -d "trustduration=30" http://localhost:40002/config
 //  write config as json
 //    curl -X POST -H "Content-type: application/json" -d '{"hbduration": 100, "trustduration": 200}' http://localhost:40002/config
+//  write base value management strategy, auto or auto-update
+//    curl -X POST -H "Content-type: application/json" -d '{"hbduration": 100, "trustduration": 200, "mgrstrategy": "auto-update"}' http://localhost:40002/config
 // Notice: key name must be enclosed by "" in json format!!!
 func (s *MyRestAPIServer) PostConfig(ctx echo.Context) error {
 	cfg := new(cfgRecord)
@@ -449,7 +525,18 @@ func (s *MyRestAPIServer) PostConfig(ctx echo.Context) error {
 	}
 	config.SetHBDuration(cfg.HBDuration * time.Second)
 	config.SetTrustDuration(cfg.TrustDuration * time.Second)
-	trustmgr.UpdateAllNodes()
+	if cfg.MgrStrategy != "" {
+		config.SetMgrStrategy(cfg.MgrStrategy)
+		// the clients update base values automatically only in maintenance
+		// windows except under the auto-update strategy.
+		if config.GetMgrStrategy() != config.AutoUpdateStrategy {
+			err = s.mgr.StopAutoUpdate()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	s.mgr.UpdateAllNodes()
 	if checkJSON(ctx) {
 		return ctx.JSON(http.StatusOK, genConfigJson())
 	}
@@ -481,6 +568,437 @@ func (s *MyRestAPIServer) GetVersion(ctx echo.Context) error {
 	return ctx.HTML(http.StatusOK, res)
 }
 
+// getImaMode returns the ima verification mode in form, the empty one
+// means hash mode.
+func getImaMode(ctx echo.Context) (string, error) {
+	mode := ctx.FormValue(strImaMode)
+	switch mode {
+	case "", typdefs.ImaModeHash, typdefs.ImaModeSignature, typdefs.ImaModeSignatureOrHash, typdefs.ImaModeBoth:
+		return mode, nil
+	}
+	return "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("wrong %s %s", strImaMode, mode))
+}
+
+// (GET /storestats)
+// get the counters of reports and base values saved into database, the
+// dropped ones are lost because the queue is full or the database fails
+//    curl -X GET http://localhost:40002/storestats
+func (s *MyRestAPIServer) GetStorestats(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, s.mgr.GetStoreStats())
+}
+
+// (GET /ima/keys)
+// get all file signing certificates in ima keyring
+//    curl -X GET http://localhost:40002/ima/keys
+func (s *MyRestAPIServer) GetImaKeys(ctx echo.Context) error {
+	keyring := config.GetImaKeyring()
+	if keyring == nil {
+		return ctx.JSON(http.StatusOK, []cryptotools.ImaKeyInfo{})
+	}
+	return ctx.JSON(http.StatusOK, keyring.Keys())
+}
+
+// (POST /ima/keys)
+// add file signing certificates in PEM or DER format into ima keyring, they
+// are also saved into the keyring directory if it is configured
+//    curl -X POST -H "Content-type: multipart/form-data" -F "Cert=@./filename" http://localhost:40002/ima/keys
+//    curl -X POST -H "Content-type: application/octet-stream" --data-binary @./filename http://localhost:40002/ima/keys
+func (s *MyRestAPIServer) PostImaKeys(ctx echo.Context) error {
+	keyring := config.GetImaKeyring()
+	if keyring == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "ima keyring is not loaded")
+	}
+	data, err := s.getFile(ctx, strCert)
+	if err == http.ErrMissingFile || err == http.ErrNotMultipart {
+		var b []byte
+		b, err = ioutil.ReadAll(ctx.Request().Body)
+		data = string(b)
+	}
+	if err != nil {
+		return err
+	}
+	ids, err := keyring.AddCertData([]byte(data))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return ctx.JSON(http.StatusOK, ids)
+}
+
+// (DELETE /ima/keys/{keyid})
+// delete the file signing certificates of key id from ima keyring
+//    curl -X DELETE http://localhost:40002/ima/keys/{keyid}
+func (s *MyRestAPIServer) DeleteImaKeysKeyid(ctx echo.Context, keyid string) error {
+	keyring := config.GetImaKeyring()
+	if keyring == nil {
+		return echo.NewHTTPError(http.StatusNotFound, cryptotools.ErrImaKeyNotFound.Error())
+	}
+	err := keyring.RemoveKey(keyid)
+	switch err {
+	case nil:
+	case cryptotools.ErrImaKeyNotFound:
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case cryptotools.ErrWrongParams:
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("wrong %s %s", strKeyID, keyid))
+	default:
+		return err
+	}
+	if checkJSON(ctx) {
+		res := JsonResult{}
+		res.Result = fmt.Sprintf(strDeleteImaKeySuccess, keyid)
+		return ctx.JSON(http.StatusOK, res)
+	}
+	return ctx.HTML(http.StatusOK, fmt.Sprintf(strDeleteImaKeySuccess, keyid))
+}
+
+// policyVersion is one version of a verification policy returned by rest api.
+type policyVersion struct {
+	*typdefs.Policy
+	Version    int    `json:"version"`
+	CreateTime string `json:"createtime"`
+}
+
+func genPolicyVersions(rows []typdefs.PolicyRow) ([]policyVersion, error) {
+	pvs := make([]policyVersion, 0, len(rows))
+	for i := range rows {
+		p, err := trustmgr.PolicyFromRow(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		pvs = append(pvs, policyVersion{Policy: p, Version: rows[i].Version,
+			CreateTime: rows[i].CreateTime.Format(typdefs.StrTimeFormat)})
+	}
+	return pvs, nil
+}
+
+// (GET /policies)
+// get the latest version of all verification policies
+//    curl -X GET http://localhost:40002/policies
+func (s *MyRestAPIServer) GetPolicies(ctx echo.Context) error {
+	pvs, err := genPolicyVersions(s.mgr.GetPolicies())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, pvs)
+}
+
+// (POST /policies)
+// add a verification policy, or a new version of it if the name exists,
+// the policy is applied to the following reports of the selected clients
+//    curl -X POST -H "Content-type: application/json" -d '{"name":"p1","enabled":true,"rules":[{"name":"r1","type":"pcr","severity":"fail","pcrs":[0,7]}]}' http://localhost:40002/policies
+func (s *MyRestAPIServer) PostPolicies(ctx echo.Context) error {
+	data, err := ioutil.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return err
+	}
+	p, err := typdefs.ParsePolicy(data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	row, err := s.mgr.SavePolicy(p)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, policyVersion{Policy: p, Version: row.Version,
+		CreateTime: row.CreateTime.Format(typdefs.StrTimeFormat)})
+}
+
+// (GET /policies/{name})
+// get all versions of verification policy {name}
+//    curl -X GET http://localhost:40002/policies/{name}
+func (s *MyRestAPIServer) GetPoliciesName(ctx echo.Context, name string) error {
+	rows, err := s.mgr.FindPolicyVersions(name)
+	if err == trustmgr.ErrPolicyNotFound {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	pvs, err := genPolicyVersions(rows)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, pvs)
+}
+
+// (DELETE /policies/{name})
+// delete all versions of verification policy {name}
+//    curl -X DELETE http://localhost:40002/policies/{name}
+func (s *MyRestAPIServer) DeletePoliciesName(ctx echo.Context, name string) error {
+	err := s.mgr.DeletePolicy(name)
+	if err == trustmgr.ErrPolicyNotFound {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	if checkJSON(ctx) {
+		res := JsonResult{}
+		res.Result = fmt.Sprintf(strDeletePolicySuccess, name)
+		return ctx.JSON(http.StatusOK, res)
+	}
+	return ctx.HTML(http.StatusOK, fmt.Sprintf(strDeletePolicySuccess, name))
+}
+
+// nodeGroup is a node group returned by rest api, the detail of one group
+// has its clients and base values.
+type nodeGroup struct {
+	*typdefs.Group
+	ID         int64             `json:"id"`
+	CreateTime string            `json:"createtime"`
+	Clients    []int64           `json:"clients,omitempty"`
+	BaseValues []typdefs.BaseRow `json:"basevalues,omitempty"`
+}
+
+func genNodeGroup(row *typdefs.GroupRow) *nodeGroup {
+	return &nodeGroup{
+		Group: &typdefs.Group{Name: row.Name, Priority: row.Priority,
+			Selector: json.RawMessage(row.Selector)},
+		ID:         row.ID,
+		CreateTime: row.CreateTime.Format(typdefs.StrTimeFormat),
+	}
+}
+
+// (GET /groups)
+// get all node groups
+//    curl -X GET http://localhost:40002/groups
+func (s *MyRestAPIServer) GetGroups(ctx echo.Context) error {
+	rows := s.mgr.GetGroups()
+	groups := make([]*nodeGroup, 0, len(rows))
+	for i := range rows {
+		groups = append(groups, genNodeGroup(&rows[i]))
+	}
+	return ctx.JSON(http.StatusOK, groups)
+}
+
+// (POST /groups)
+// add a node group of the nodes whose registered client info contains the
+// selector, they are verified by the active host base value of the group
+// unless they have their own one
+//    curl -X POST -H "Content-type: application/json" -d '{"name":"g1","priority":1,"selector":{"productname":"TaiShan 200","osrelease":"openEuler 22.03 LTS"}}' http://localhost:40002/groups
+func (s *MyRestAPIServer) PostGroups(ctx echo.Context) error {
+	data, err := ioutil.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return err
+	}
+	g, err := typdefs.ParseGroup(data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	row, err := s.mgr.AddGroup(g)
+	if err == trustmgr.ErrGroupExists {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, genNodeGroup(row))
+}
+
+// (GET /groups/{groupid})
+// get node group {groupid} with its nodes and base values
+//    curl -X GET http://localhost:40002/groups/{groupid}
+func (s *MyRestAPIServer) GetGroupsGroupid(ctx echo.Context, groupid int64) error {
+	row, err := s.mgr.FindGroupByID(groupid)
+	if err == trustmgr.ErrGroupNotFound {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	g := genNodeGroup(row)
+	clients, err := s.mgr.FindGroupClients(groupid)
+	if err != nil {
+		return err
+	}
+	for _, c := range clients {
+		g.Clients = append(g.Clients, c.ID)
+	}
+	g.BaseValues, err = s.mgr.FindGroupBaseValues(groupid)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, g)
+}
+
+// (DELETE /groups/{groupid})
+// delete node group {groupid} which has no base value or maintenance window
+//    curl -X DELETE http://localhost:40002/groups/{groupid}
+func (s *MyRestAPIServer) DeleteGroupsGroupid(ctx echo.Context, groupid int64) error {
+	err := s.mgr.DeleteGroup(groupid)
+	if err == trustmgr.ErrGroupNotFound {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err == trustmgr.ErrGroupInUse {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	if checkJSON(ctx) {
+		res := JsonResult{}
+		res.Result = fmt.Sprintf(strDeleteGroupSuccess, groupid)
+		return ctx.JSON(http.StatusOK, res)
+	}
+	return ctx.HTML(http.StatusOK, fmt.Sprintf(strDeleteGroupSuccess, groupid))
+}
+
+// (POST /groups/{groupid}/refvalues)
+// save node group {groupid} a new base value of json reference values
+// shared by its nodes
+//    curl -X POST -H "Content-type: application/json" --data-binary @./refvalue.json "http://localhost:40002/groups/{groupid}/refvalues?name=XX&enabled=true&imamode=hash"
+func (s *MyRestAPIServer) PostGroupsGroupidRefvalues(ctx echo.Context, groupid int64, params PostGroupsGroupidRefvaluesParams) error {
+	_, err := s.mgr.FindGroupByID(groupid)
+	if err == trustmgr.ErrGroupNotFound {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	row, err := newRefValueBase(ctx, params.Name, params.Enabled, (*string)(params.Imamode))
+	if err != nil {
+		return err
+	}
+	row.GroupID = groupid
+	s.mgr.SaveBaseValue(row)
+	return ctx.JSON(http.StatusOK, JsonResult{Result: fmt.Sprintf(strAddGroupBaseSuccess, groupid)})
+}
+
+// (POST /groups/{groupid}/basevalues/{basevalueid}/promote)
+// promote node group {groupid} one base value {basevalueid} to the next
+// lifecycle state
+//    curl -X POST "http://localhost:40002/groups/{groupid}/basevalues/{basevalueid}/promote?author=XX"
+func (s *MyRestAPIServer) PostGroupsGroupidBasevaluesBasevalueidPromote(ctx echo.Context, groupid int64, basevalueid int64, params PostGroupsGroupidBasevaluesBasevalueidPromoteParams) error {
+	err := s.checkGroupBase(groupid, basevalueid)
+	if err != nil {
+		return err
+	}
+	row, err := s.mgr.PromoteBaseValue(basevalueid, getAuthor(params.Author))
+	return baseStateResult(ctx, row, err)
+}
+
+// (POST /groups/{groupid}/basevalues/{basevalueid}/retire)
+// retire node group {groupid} one base value {basevalueid}
+//    curl -X POST "http://localhost:40002/groups/{groupid}/basevalues/{basevalueid}/retire?author=XX"
+func (s *MyRestAPIServer) PostGroupsGroupidBasevaluesBasevalueidRetire(ctx echo.Context, groupid int64, basevalueid int64, params PostGroupsGroupidBasevaluesBasevalueidRetireParams) error {
+	err := s.checkGroupBase(groupid, basevalueid)
+	if err != nil {
+		return err
+	}
+	row, err := s.mgr.RetireBaseValue(basevalueid, getAuthor(params.Author))
+	return baseStateResult(ctx, row, err)
+}
+
+// checkGroupBase checks the base value exists and belongs to the node group.
+func (s *MyRestAPIServer) checkGroupBase(groupid, basevalueid int64) error {
+	row, err := s.mgr.FindBaseValueByID(basevalueid)
+	if err == sql.ErrNoRows || (err == nil && row.GroupID != groupid) {
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf(strGroupBaseNotFound, groupid, basevalueid))
+	}
+	return err
+}
+
+// maintWindow is a maintenance window returned by rest api, the detail of
+// one window has the base values updated in it.
+type maintWindow struct {
+	*typdefs.MaintWindow
+	ID         int64             `json:"id"`
+	Author     string            `json:"author"`
+	CreateTime string            `json:"createtime"`
+	ClosedBy   string            `json:"closedby,omitempty"`
+	State      string            `json:"state"`
+	BaseValues []typdefs.BaseRow `json:"basevalues,omitempty"`
+}
+
+func genMaintWindow(row *typdefs.MaintWindowRow) *maintWindow {
+	return &maintWindow{
+		MaintWindow: &typdefs.MaintWindow{ClientID: row.ClientID, GroupID: row.GroupID,
+			StartTime: row.StartTime, EndTime: row.EndTime, Parts: strings.Split(row.Parts, ",")},
+		ID:         row.ID,
+		Author:     row.Author,
+		CreateTime: row.CreateTime.Format(typdefs.StrTimeFormat),
+		ClosedBy:   row.ClosedBy,
+		State:      row.State(time.Now()),
+	}
+}
+
+// (GET /maintwindows)
+// get all maintenance windows including the closed ones
+//    curl -X GET http://localhost:40002/maintwindows
+func (s *MyRestAPIServer) GetMaintwindows(ctx echo.Context) error {
+	rows, err := s.mgr.GetMaintWindows()
+	if err != nil {
+		return err
+	}
+	windows := make([]*maintWindow, 0, len(rows))
+	for i := range rows {
+		windows = append(windows, genMaintWindow(&rows[i]))
+	}
+	return ctx.JSON(http.StatusOK, windows)
+}
+
+// (POST /maintwindows)
+// open a maintenance window of a node or node group, the host base value
+// is updated automatically from the trust reports in the window, but only
+// the allowed parts change and the new base values are attributed to it
+//    curl -X POST -H "Content-type: application/json" -d '{"clientid":1,"starttime":"2022-06-01T20:00:00+08:00","endtime":"2022-06-01T22:00:00+08:00","parts":["bios","ima"]}' "http://localhost:40002/maintwindows?author=XX"
+func (s *MyRestAPIServer) PostMaintwindows(ctx echo.Context, params PostMaintwindowsParams) error {
+	data, err := ioutil.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return err
+	}
+	w, err := typdefs.ParseMaintWindow(data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	row, err := s.mgr.OpenMaintWindow(w, getAuthor(params.Author))
+	if errors.Is(err, typdefs.ErrMaintWindowWrong) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err == typdefs.ErrDoesnotRegistered || err == trustmgr.ErrGroupNotFound {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, genMaintWindow(row))
+}
+
+// (GET /maintwindows/{windowid})
+// get maintenance window {windowid} with the base values updated in it
+//    curl -X GET http://localhost:40002/maintwindows/{windowid}
+func (s *MyRestAPIServer) GetMaintwindowsWindowid(ctx echo.Context, windowid int64) error {
+	row, err := s.mgr.FindMaintWindowByID(windowid)
+	if err == trustmgr.ErrMaintWindowNotFound {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	w := genMaintWindow(row)
+	w.BaseValues, err = s.mgr.FindMaintWindowBaseValues(windowid)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, w)
+}
+
+// (POST /maintwindows/{windowid}/close)
+// close maintenance window {windowid} before its end time
+//    curl -X POST "http://localhost:40002/maintwindows/{windowid}/close?author=XX"
+func (s *MyRestAPIServer) PostMaintwindowsWindowidClose(ctx echo.Context, windowid int64, params PostMaintwindowsWindowidCloseParams) error {
+	row, err := s.mgr.CloseMaintWindow(windowid, getAuthor(params.Author))
+	if err == trustmgr.ErrMaintWindowNotFound {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err == trustmgr.ErrMaintWindowClosed {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, genMaintWindow(row))
+}
+
 // (GET /{from}/{to})
 // get nodes information from "from" node to "to" node sequentially
 //  read a range nodes info as html
@@ -488,7 +1006,7 @@ func (s *MyRestAPIServer) GetVersion(ctx echo.Context) error {
 //  read a range nodes info as json
 //    curl -X GET -H "Content-type: application/json" http://localhost:40002/{from}/{to}
 func (s *MyRestAPIServer) GetFromTo(ctx echo.Context, from int64, to int64) error {
-	return showListNodesByRange(ctx, from, to)
+	return s.showListNodesByRange(ctx, from, to)
 }
 
 // (DELETE /{id})
@@ -498,7 +1016,7 @@ func (s *MyRestAPIServer) GetFromTo(ctx echo.Context, from int64, to int64) erro
 //  delete a node by json
 //    curl -X DELETE -H "Content-type: application/json" http://localhost:40002/{id}
 func (s *MyRestAPIServer) DeleteId(ctx echo.Context, id int64) error {
-	trustmgr.UnRegisterClientByID(id)
+	s.mgr.UnRegisterClientByID(id)
 	if checkJSON(ctx) {
 		res := JsonResult{}
 		res.Result = fmt.Sprintf(strDeleteClientSuccess, id)
@@ -514,6 +1032,9 @@ func genNodeHtml(c *cache.Cache) string {
 	buf.WriteString(fmt.Sprintf(htmlNodeInfo, strRegTime, c.GetRegTime()))
 	buf.WriteString(fmt.Sprintf(htmlNodeInfo, strOnline, c.GetOnline()))
 	buf.WriteString(fmt.Sprintf(htmlNodeInfo, strTrusted, c.GetTrusted()))
+	st := c.GetTrustState()
+	buf.WriteString(fmt.Sprintf(htmlNodeInfo, strTrustState, st.State))
+	buf.WriteString(fmt.Sprintf(htmlNodeInfo, strTrustReason, st.Reason))
 	buf.WriteString(htmlTableEnd)
 	return buf.String()
 }
@@ -525,18 +1046,22 @@ func genNodeHtml(c *cache.Cache) string {
 //  read node {id} info as json
 //    curl -X GET -H "Content-type: application/json" http://localhost:40002/{id}
 func (s *MyRestAPIServer) GetId(ctx echo.Context, id int64) error {
-	c, err := trustmgr.GetCache(id)
+	c, err := s.mgr.GetCache(id)
 	if checkJSON(ctx) {
 		if err != nil {
 			logger.L.Sugar().Debugf(errNoClient, err)
 			return ctx.JSON(http.StatusNotFound, &typdefs.NodeInfo{ID: id})
 		}
+		st := c.GetTrustState()
 		ni := typdefs.NodeInfo{
 			ID:           id,
 			RegTime:      c.GetRegTime(),
 			Online:       c.GetOnline(),
-			Trusted:      c.GetTrusted(),
-			IsAutoUpdate: c.GetIsAutoUpdate(),
+			Trusted:      st.State == typdefs.TrustStateTrusted,
+			IsAutoUpdate: s.mgr.IsAutoUpdate(id),
+			PcrSelection: s.mgr.GetPcrSelection(id),
+			State:        st.State,
+			Reason:       st.Reason,
 		}
 		return ctx.JSON(http.StatusOK, &ni)
 	}
@@ -551,25 +1076,39 @@ func (s *MyRestAPIServer) GetId(ctx echo.Context, id int64) error {
 // modify node {id} information
 //  modify node {id} information by json
 //    curl -X POST -H "Content-type: multipart/form-data" -F "IsAutoUpdate=true;type=application/json" http://localhost:40002/{id}
+//  modify node {id} pcr banks and pcrs to be quoted
+//    curl -X POST -F "PcrSelection=sha256:0-23;sm3:0-23" http://localhost:40002/{id}
 func (s *MyRestAPIServer) PostId(ctx echo.Context, id int64) error {
 	sIsU := ctx.FormValue(strIsAutoUpdate)
 	isAutoUpdate, _ := strconv.ParseBool(sIsU)
-	c, err := trustmgr.GetCache(id)
+	// the node updates its base value automatically in a maintenance
+	// window of rasconfig.autoupdatewindow only under the auto-update
+	// strategy.
+	if isAutoUpdate && config.GetMgrStrategy() != config.AutoUpdateStrategy {
+		return echo.NewHTTPError(http.StatusBadRequest, strNotAutoUpdate)
+	}
+	err := s.mgr.SetAutoUpdate(id, isAutoUpdate, config.GetAutoUpdateWindow())
 	if err != nil {
 		return err
 	}
-	c.SetIsAutoUpdate(isAutoUpdate)
+	if sel := ctx.FormValue(strPcrSelection); sel != "" {
+		err = s.mgr.SetPcrSelection(id, sel)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, err.Error())
+		}
+	}
 	res := fmt.Sprintf("change server %d information", id)
 	return ctx.HTML(http.StatusOK, res)
 }
 
 func genBaseValuesHtml(id int64, rows []typdefs.BaseRow) string {
 	var buf bytes.Buffer
-	buf.WriteString(fmt.Sprintf(htmlListBaseValues, id))
+	buf.WriteString(fmt.Sprintf(htmlListBaseValues, id, id))
 	for _, n := range rows {
-		buf.WriteString(fmt.Sprintf(htmlBaseValueInfo, n.ID,
-			n.CreateTime.Format(typdefs.StrTimeFormat), n.Name, n.Enabled,
-			n.Verified, n.Trusted, id, n.ID, id, n.ID, id, n.ID, id, n.ID))
+		buf.WriteString(fmt.Sprintf(htmlBaseValueInfo, n.ID, n.Version,
+			n.CreateTime.Format(typdefs.StrTimeFormat), html.EscapeString(n.Name), n.State,
+			html.EscapeString(n.Author), n.UpdateTime.Format(typdefs.StrTimeFormat), n.Enabled,
+			n.Verified, n.Trusted, id, n.ID, id, n.ID, id, n.ID, id, n.ID, id, n.ID, id, n.ID))
 	}
 	buf.WriteString(htmlTableEnd)
 	return buf.String()
@@ -578,7 +1117,7 @@ func genBaseValuesHtml(id int64, rows []typdefs.BaseRow) string {
 // (GET /{id}/basevalues)
 // get node {id} all base values
 func (s *MyRestAPIServer) GetIdBasevalues(ctx echo.Context, id int64) error {
-	rows, err := trustmgr.FindBaseValuesByClientID(id)
+	rows, err := s.mgr.FindBaseValuesByClientID(id)
 	if checkJSON(ctx) {
 		if err != nil {
 			return err
@@ -594,7 +1133,7 @@ func (s *MyRestAPIServer) GetIdBasevalues(ctx echo.Context, id int64) error {
 // (DELETE /{id}/basevalues/{basevalueid})
 // delete node {id} one base value {basevalueid}
 func (s *MyRestAPIServer) DeleteIdBasevaluesBasevalueid(ctx echo.Context, id int64, basevalueid int64) error {
-	err := trustmgr.DeleteBaseValueByID(basevalueid)
+	err := s.mgr.DeleteBaseValueByID(basevalueid)
 	if checkJSON(ctx) {
 		res := JsonResult{}
 		if err != nil {
@@ -617,12 +1156,19 @@ func genBaseValueHtml(basevalue *typdefs.BaseRow) string {
 	buf.WriteString(fmt.Sprintf(htmlBaseValue, strCreateTime,
 		basevalue.CreateTime.Format(typdefs.StrTimeFormat)))
 	buf.WriteString(fmt.Sprintf(htmlBaseValue, strName, basevalue.Name))
+	buf.WriteString(fmt.Sprintf(htmlBaseValue, strVersion, basevalue.Version))
+	buf.WriteString(fmt.Sprintf(htmlBaseValue, strState, basevalue.State))
+	buf.WriteString(fmt.Sprintf(htmlBaseValue, strAuthor, basevalue.Author))
+	buf.WriteString(fmt.Sprintf(htmlBaseValue, strUpdateTime,
+		basevalue.UpdateTime.Format(typdefs.StrTimeFormat)))
 	buf.WriteString(fmt.Sprintf(htmlBaseValue, strEnabled, basevalue.Enabled))
 	buf.WriteString(fmt.Sprintf(htmlBaseValue, strVerified, basevalue.Verified))
 	buf.WriteString(fmt.Sprintf(htmlBaseValue, strTrusted, basevalue.Trusted))
 	buf.WriteString(fmt.Sprintf(htmlBaseValue, strPCR, basevalue.Pcr))
 	buf.WriteString(fmt.Sprintf(htmlBaseValue, strBIOS, basevalue.Bios))
 	buf.WriteString(fmt.Sprintf(htmlBaseValue, strIMA, basevalue.Ima))
+	buf.WriteString(fmt.Sprintf(htmlBaseValue, strImaMode, basevalue.ImaMode))
+	buf.WriteString(fmt.Sprintf(htmlBaseValue, strRefValue, basevalue.RefValue))
 	buf.WriteString(htmlTableEnd)
 	return buf.String()
 }
@@ -630,7 +1176,7 @@ func genBaseValueHtml(basevalue *typdefs.BaseRow) string {
 // (GET /{id}/basevalues/{basevalueid})
 // get node {id} one base value {basevalueid}
 func (s *MyRestAPIServer) GetIdBasevaluesBasevalueid(ctx echo.Context, id int64, basevalueid int64) error {
-	row, err := trustmgr.FindBaseValueByID(basevalueid)
+	row, err := s.mgr.FindBaseValueByID(basevalueid)
 	if checkJSON(ctx) {
 		if err != nil {
 			return err
@@ -653,6 +1199,70 @@ func (s *MyRestAPIServer) PostIdBasevaluesBasevalueid(ctx echo.Context, id int64
 	return ctx.HTML(http.StatusOK, res)
 }
 
+// (POST /{id}/basevalues/{basevalueid}/promote)
+// promote node {id} one base value {basevalueid} to the next lifecycle state,
+// draft to approved, approved or retired to active
+//    curl -X POST "http://localhost:40002/{id}/basevalues/{basevalueid}/promote?author=XX"
+func (s *MyRestAPIServer) PostIdBasevaluesBasevalueidPromote(ctx echo.Context, id int64, basevalueid int64, params PostIdBasevaluesBasevalueidPromoteParams) error {
+	err := s.checkClientBase(id, basevalueid)
+	if err != nil {
+		return err
+	}
+	row, err := s.mgr.PromoteBaseValue(basevalueid, getAuthor(params.Author))
+	return baseStateResult(ctx, row, err)
+}
+
+// (POST /{id}/basevalues/{basevalueid}/retire)
+// retire node {id} one base value {basevalueid}
+//    curl -X POST "http://localhost:40002/{id}/basevalues/{basevalueid}/retire?author=XX"
+func (s *MyRestAPIServer) PostIdBasevaluesBasevalueidRetire(ctx echo.Context, id int64, basevalueid int64, params PostIdBasevaluesBasevalueidRetireParams) error {
+	err := s.checkClientBase(id, basevalueid)
+	if err != nil {
+		return err
+	}
+	row, err := s.mgr.RetireBaseValue(basevalueid, getAuthor(params.Author))
+	return baseStateResult(ctx, row, err)
+}
+
+// (POST /{id}/basevalues/rollback)
+// activate the latest retired host base value of node {id} which is older
+// than the active one
+//    curl -X POST "http://localhost:40002/{id}/basevalues/rollback?author=XX"
+func (s *MyRestAPIServer) PostIdBasevaluesRollback(ctx echo.Context, id int64, params PostIdBasevaluesRollbackParams) error {
+	row, err := s.mgr.RollbackBaseValue(id, getAuthor(params.Author))
+	if err == trustmgr.ErrNoRollbackBase {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return baseStateResult(ctx, row, err)
+}
+
+// checkClientBase checks the base value exists and belongs to the client.
+func (s *MyRestAPIServer) checkClientBase(id, basevalueid int64) error {
+	row, err := s.mgr.FindBaseValueByID(basevalueid)
+	if err == sql.ErrNoRows || (err == nil && row.ClientID != id) {
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf(strBaseValueNotFound, id, basevalueid))
+	}
+	return err
+}
+
+func getAuthor(author *string) string {
+	if author == nil || *author == "" {
+		return strRestAuthor
+	}
+	return *author
+}
+
+// baseStateResult returns the base value after its state is changed.
+func baseStateResult(ctx echo.Context, row *typdefs.BaseRow, err error) error {
+	if err == typdefs.ErrBaseStateWrong {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, row)
+}
+
 func genNewBaseValueHtml(id int64) string {
 	var buf bytes.Buffer
 	buf.WriteString(fmt.Sprintf(htmlNewBaseValue, id, id))
@@ -685,7 +1295,9 @@ func (s *MyRestAPIServer) getFile(ctx echo.Context, name string) (string, error)
 
 // (POST /{id}/newbasevalue)
 //  save node {id} a new base value by html
-//    curl -X POST -H "Content-type: multipart/form-data" -F "Name=XX" -F "Enabled=true" -F "Pcr=@./filename" -F "Bios=@./filename" -F "Ima=@./filename" http://localhost:40002/1/newbasevalue
+//    curl -X POST -H "Content-type: multipart/form-data" -F "Name=XX" -F "Enabled=true" -F "Pcr=@./filename" -F "Bios=@./filename" -F "Ima=@./filename" -F "ImaMode=signature" http://localhost:40002/1/newbasevalue
+//  save node {id} a new base value of json reference values by html
+//    curl -X POST -H "Content-type: multipart/form-data" -F "Name=XX" -F "Enabled=true" -F "RefValue=@./refvalue.json" http://localhost:40002/1/newbasevalue
 //  save node {id} a new base value by json
 //    curl -X POST -H "Content-type: multipart/form-data" -F "Name=test;type=application/json" -F "Enabled=true;type=application/json" -F "Pcr=@./cmd_history;type=application/json" -F "Bios=@./cmd_history;type=application/json" -F "Ima=@./cmd_history;type=application/json" http://localhost:40002/{id}/newbasevalue
 func (s *MyRestAPIServer) PostIdNewbasevalue(ctx echo.Context, id int64) error {
@@ -704,6 +1316,10 @@ func (s *MyRestAPIServer) PostIdNewbasevalue(ctx echo.Context, id int64) error {
 	if err != nil && err != http.ErrMissingFile {
 		return err
 	}
+	imaMode, err := getImaMode(ctx)
+	if err != nil {
+		return err
+	}
 	row := &typdefs.BaseRow{
 		ClientID:   id,
 		BaseType:   "host",
@@ -713,8 +1329,14 @@ func (s *MyRestAPIServer) PostIdNewbasevalue(ctx echo.Context, id int64) error {
 		Pcr:        pcr,
 		Bios:       bios,
 		Ima:        ima,
+		ImaMode:    imaMode,
+		Author:     strRestAuthor,
 	}
-	trustmgr.SaveBaseValue(row)
+	err = s.getRefValue(ctx, row)
+	if err != nil {
+		return err
+	}
+	s.mgr.SaveBaseValue(row)
 	/* // no use???
 	if checkJSON(ctx) {
 		return ctx.JSON(http.StatusFound, row)
@@ -723,6 +1345,104 @@ func (s *MyRestAPIServer) PostIdNewbasevalue(ctx echo.Context, id int64) error {
 	return ctx.Redirect(http.StatusFound, fmt.Sprintf("/%d/basevalues", id))
 }
 
+// getRefValue reads and validates the optional json reference values file
+// in form.
+func (s *MyRestAPIServer) getRefValue(ctx echo.Context, row *typdefs.BaseRow) error {
+	data, err := s.getFile(ctx, strRefValue)
+	if err == http.ErrMissingFile {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return setRefValue(row, []byte(data))
+}
+
+// setRefValue validates the json reference values and saves them into row,
+// the legacy text base values which aren't uploaded are filled by them to
+// be used as the extract templates.
+func setRefValue(row *typdefs.BaseRow, data []byte) error {
+	rv, err := typdefs.ParseRefValues(data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	buf, _ := json.Marshal(rv)
+	row.RefValue = string(buf)
+	pcr, bios, ima := rv.LegacyText()
+	if row.Pcr == "" {
+		row.Pcr = pcr
+	}
+	if row.Bios == "" {
+		row.Bios = bios
+	}
+	if row.Ima == "" {
+		row.Ima = ima
+	}
+	return nil
+}
+
+// newRefValueBase returns a new host base value of the json reference
+// values in request body with the optional query parameters.
+func newRefValueBase(ctx echo.Context, name *string, enabled *bool, imaMode *string) (*typdefs.BaseRow, error) {
+	data, err := ioutil.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return nil, err
+	}
+	row := &typdefs.BaseRow{
+		BaseType:   "host",
+		CreateTime: time.Now(),
+		Author:     strRestAuthor,
+	}
+	if name != nil {
+		row.Name = *name
+	}
+	if enabled != nil {
+		row.Enabled = *enabled
+	}
+	if imaMode != nil {
+		switch m := *imaMode; m {
+		case typdefs.ImaModeHash, typdefs.ImaModeSignature, typdefs.ImaModeSignatureOrHash, typdefs.ImaModeBoth:
+			row.ImaMode = m
+		default:
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("wrong %s %s", strImaMode, m))
+		}
+	}
+	err = setRefValue(row, data)
+	if err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// (POST /{id}/refvalues)
+// save node {id} a new base value of json reference values
+//    curl -X POST -H "Content-type: application/json" --data-binary @./refvalue.json "http://localhost:40002/{id}/refvalues?name=XX&enabled=true&imamode=hash"
+func (s *MyRestAPIServer) PostIdRefvalues(ctx echo.Context, id int64, params PostIdRefvaluesParams) error {
+	row, err := newRefValueBase(ctx, params.Name, params.Enabled, (*string)(params.Imamode))
+	if err != nil {
+		return err
+	}
+	row.ClientID = id
+	s.mgr.SaveBaseValue(row)
+	return ctx.JSON(http.StatusOK, JsonResult{Result: fmt.Sprintf(strAddBaseValueSuccess, id)})
+}
+
+// (GET /{id}/basevalues/{basevalueid}/refvalue)
+// get node {id} one base value {basevalueid} as json reference values, the
+// legacy text base value is converted
+//    curl -X GET http://localhost:40002/{id}/basevalues/{basevalueid}/refvalue
+func (s *MyRestAPIServer) GetIdBasevaluesBasevalueidRefvalue(ctx echo.Context, id int64, basevalueid int64) error {
+	row, err := s.mgr.FindBaseValueByID(basevalueid)
+	if err != nil {
+		return err
+	}
+	rv, err := trustmgr.GetRefValues(row)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return ctx.JSON(http.StatusOK, rv)
+}
+
 func genReportsHtml(id int64, rows []typdefs.ReportRow) string {
 	var buf bytes.Buffer
 	buf.WriteString(fmt.Sprintf(htmlListReports, id))
@@ -742,7 +1462,7 @@ func genReportsHtml(id int64, rows []typdefs.ReportRow) string {
 //  get node {id} all reports as json
 //    curl -X GET -H "Content-type: application/json" http://localhost:40002/{id}/reports
 func (s *MyRestAPIServer) GetIdReports(ctx echo.Context, id int64) error {
-	rows, err := trustmgr.FindReportsByClientID(id)
+	rows, err := s.mgr.FindReportsByClientID(id)
 	if checkJSON(ctx) {
 		if err != nil {
 			return err
@@ -762,7 +1482,7 @@ func (s *MyRestAPIServer) GetIdReports(ctx echo.Context, id int64) error {
 //  delete node {id} report {reportid} by json
 //    curl -X DELETE -H "Content-type: application/json" http://localhost:40002/{id}/reports/{reportid}
 func (s *MyRestAPIServer) DeleteIdReportsReportid(ctx echo.Context, id int64, reportid int64) error {
-	err := trustmgr.DeleteReportByID(reportid)
+	err := s.mgr.DeleteReportByID(reportid)
 	if checkJSON(ctx) {
 		res := JsonResult{}
 		if err != nil {
@@ -791,10 +1511,50 @@ func genReportHtml(report *typdefs.ReportRow) string {
 	buf.WriteString(fmt.Sprintf(htmlReportValue, strPcrLog, report.PcrLog))
 	buf.WriteString(fmt.Sprintf(htmlReportValue, strBiosLog, report.BiosLog))
 	buf.WriteString(fmt.Sprintf(htmlReportValue, strImaLog, report.ImaLog))
+	buf.WriteString(fmt.Sprintf(htmlReportValue, strSecureBoot, report.SecureBoot))
+	buf.WriteString(fmt.Sprintf(htmlReportValue, strVerdict, report.Verdict))
+	for i := range report.Results {
+		res := &report.Results[i]
+		buf.WriteString(fmt.Sprintf(htmlReportValue, strResult+" "+res.Check,
+			genReportResultHtml(report.ClientID, res)))
+	}
 	buf.WriteString(htmlTableEnd)
 	return buf.String()
 }
 
+// genReportResultHtml shows the result of one check, the base value used
+// and the expected/actual digests of each mismatch.
+func genReportResultHtml(id int64, res *typdefs.ReportResult) string {
+	var buf bytes.Buffer
+	if res.Passed {
+		buf.WriteString(strPassed)
+	} else {
+		buf.WriteString(strFailed)
+	}
+	if res.BaseID != 0 {
+		buf.WriteString(fmt.Sprintf(", %s <a href=\"/%d/basevalues/%d\">%d</a>",
+			strBaseValueID, id, res.BaseID, res.BaseID))
+	}
+	buf.WriteString("\n")
+	if len(res.Mismatches) == 0 {
+		buf.WriteString(html.EscapeString(res.Detail))
+		return buf.String()
+	}
+	for _, m := range res.Mismatches {
+		buf.WriteString(html.EscapeString(m.String()))
+		if len(m.Expected) > 0 {
+			expected := make([]string, len(m.Expected))
+			for i, d := range m.Expected {
+				expected[i] = hex.EncodeToString(d)
+			}
+			buf.WriteString(", expected " + strings.Join(expected, "/"))
+			buf.WriteString(", actual " + hex.EncodeToString(m.Actual))
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
 // (GET /{id}/reports/{reportid})
 // get node {id} one report {reportid}
 //  get node {id} report {reportid} as html
@@ -802,7 +1562,7 @@ func genReportHtml(report *typdefs.ReportRow) string {
 //  get node {id} report {reportid} as json
 //    curl -X GET -H "Content-type: application/json" http://localhost:40002/{id}/reports/{reportid}
 func (s *MyRestAPIServer) GetIdReportsReportid(ctx echo.Context, id int64, reportid int64) error {
-	row, err := trustmgr.FindReportByID(reportid)
+	row, err := s.mgr.FindReportByID(reportid)
 	if checkJSON(ctx) {
 		if err != nil {
 			return err
@@ -815,68 +1575,66 @@ func (s *MyRestAPIServer) GetIdReportsReportid(ctx echo.Context, id int64, repor
 	return ctx.HTML(http.StatusOK, genReportHtml(row))
 }
 
-// Return a list of trust status for all containers of a given client
-// (GET /{id}/container/status)
-func (s *MyRestAPIServer) GetIdContainerStatus(ctx echo.Context, cid int64) error {
-	c, err := trustmgr.GetCache(cid)
+// (GET /{id}/reports/{reportid}/bioslog)
+// get node {id} report {reportid} bios log as typed events in json
+//    curl -X GET http://localhost:40002/{id}/reports/{reportid}/bioslog
+func (s *MyRestAPIServer) GetIdReportsReportidBioslog(ctx echo.Context, id int64, reportid int64) error {
+	row, err := s.mgr.FindReportByID(reportid)
 	if err != nil {
 		return err
 	}
-	rows := c.ContainerBases
-	var buf bytes.Buffer
-	for i := 0; i < len(rows); i++ {
-		if rows[i].BaseType != strContainer {
-			continue
-		}
-		var status string
-		if rows[i].Verified {
-			status = strUnknown
-		} else {
-			if rows[i].Trusted && !time.Now().After(c.GetTrustExpiration()) { //验证过并且未超时
-				status = strTrusted
-			} else {
-				status = strUntrusted
-			}
-		}
-		buf.WriteString(fmt.Sprintf("%s : %s\n", rows[i].Uuid, status))
+	events, err := typdefs.ParseBiosTxtLog([]byte(row.BiosLog))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	if events == nil {
+		events = []*typdefs.BiosEvent{}
+	}
+	return ctx.JSON(http.StatusOK, events)
+}
 
-	return ctx.JSON(http.StatusOK, buf.String())
+// Return a list of trust status for all containers of a given client
+// (GET /{id}/container/status)
+//  read the trust states of node {id} containers with their history
+//    curl -X GET http://localhost:40002/{id}/container/status
+func (s *MyRestAPIServer) GetIdContainerStatus(ctx echo.Context, cid int64) error {
+	return s.getUuidTrustInfos(ctx, cid, typdefs.BaseTypeContainer)
 }
 
 // Return a list of trust status for all devices of a given client
 // (GET /{id}/device/status)
+//  read the trust states of node {id} devices with their history
+//    curl -X GET http://localhost:40002/{id}/device/status
 func (s *MyRestAPIServer) GetIdDeviceStatus(ctx echo.Context, cid int64) error {
-	c, err := trustmgr.GetCache(cid)
+	return s.getUuidTrustInfos(ctx, cid, typdefs.BaseTypeDevice)
+}
+
+func (s *MyRestAPIServer) getUuidTrustInfos(ctx echo.Context, cid int64, baseType string) error {
+	infos, err := s.mgr.GetUuidTrustInfos(cid, baseType)
 	if err != nil {
-		return err
-	}
-	rows := c.DeviceBases
-	var buf bytes.Buffer
-	for i := 0; i < len(rows); i++ {
-		if rows[i].BaseType != strDevice {
-			continue
-		}
-		var status string
-		if rows[i].Verified {
-			status = strUnknown
-		} else {
-			if rows[i].Trusted && !time.Now().After(c.GetTrustExpiration()) {
-				status = strTrusted
-			} else {
-				status = strUntrusted
-			}
-		}
-		buf.WriteString(fmt.Sprintf("%s : %s\n", rows[i].Uuid, status))
+		logger.L.Sugar().Debugf(errNoClient, err)
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
 	}
+	return ctx.JSON(http.StatusOK, infos)
+}
 
-	return ctx.JSON(http.StatusOK, buf.String())
+// Return the trust state of a given client with its history
+// (GET /{id}/trust)
+//  read the trust state of node {id} with its history
+//    curl -X GET http://localhost:40002/{id}/trust
+func (s *MyRestAPIServer) GetIdTrust(ctx echo.Context, id int64) error {
+	info, err := s.mgr.GetTrustInfo(id)
+	if err != nil {
+		logger.L.Sugar().Debugf(errNoClient, err)
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return ctx.JSON(http.StatusOK, info)
 }
 
 // Return the base value of a given container/device
-// (GET /{uuid}/device/basevalue)
+// (GET /{uuid}/basevalue)
 func (s *MyRestAPIServer) GetUuidBasevalue(ctx echo.Context, uuid string) error {
-	row, err := trustmgr.FindBaseValueByUuid(uuid)
+	row, err := s.mgr.FindBaseValueByUuid(uuid)
 	if checkJSON(ctx) {
 		if err != nil {
 			return err
@@ -890,9 +1648,9 @@ func (s *MyRestAPIServer) GetUuidBasevalue(ctx echo.Context, uuid string) error
 }
 
 // create/update the base value of the given device
-// (POST /{uuid}/device/basevalue)
+// (POST /{uuid}/basevalue)
 //  save node {id} a new base value by html
-//    curl -X POST -H "Content-type: multipart/form-data" -F "ClientID=XX"  -F "BaseType=XX" -F "Name=XX" -F "Enabled=true" -F "Pcr=@./filename" -F "Bios=@./filename" -F "Ima=@./filename" http://localhost:40002/{uuid}/device/basevalue
+//    curl -X POST -H "Content-type: multipart/form-data" -F "ClientID=XX"  -F "BaseType=XX" -F "Name=XX" -F "Enabled=true" -F "Pcr=@./filename" -F "Bios=@./filename" -F "Ima=@./filename" -F "ImaMode=signature" http://localhost:40002/{uuid}/device/basevalue
 //  save node {id} a new base value by json
 //    curl -X POST -H "Content-type: multipart/form-data" -F "ClientID=1234;type=application/json" -F "BaseType=container;type=application/json" -F "Name=test;type=application/json" -F "Enabled=true;type=application/json" -F "Pcr=@./cmd_history;type=application/json" -F "Bios=@./cmd_history;type=application/json" -F "Ima=@./cmd_history;type=application/json" http://localhost:40002/{uuid}/device/basevalue
 func (s *MyRestAPIServer) PostUuidBasevalue(ctx echo.Context, uuid string) error {
@@ -914,6 +1672,10 @@ func (s *MyRestAPIServer) PostUuidBasevalue(ctx echo.Context, uuid string) error
 	if err != nil && err != http.ErrMissingFile {
 		return err
 	}
+	imaMode, err := getImaMode(ctx)
+	if err != nil {
+		return err
+	}
 	row := &typdefs.BaseRow{
 		ClientID:   id,
 		BaseType:   baseType,
@@ -924,8 +1686,14 @@ func (s *MyRestAPIServer) PostUuidBasevalue(ctx echo.Context, uuid string) error
 		Pcr:        pcr,
 		Bios:       bios,
 		Ima:        ima,
+		ImaMode:    imaMode,
+		Author:     strRestAuthor,
 	}
-	trustmgr.SaveBaseValue(row)
+	err = s.getRefValue(ctx, row)
+	if err != nil {
+		return err
+	}
+	s.mgr.SaveBaseValue(row)
 	/* // no use???
 	if checkJSON(ctx) {
 		return ctx.JSON(http.StatusFound, row)
@@ -935,39 +1703,13 @@ func (s *MyRestAPIServer) PostUuidBasevalue(ctx echo.Context, uuid string) error
 }
 
 // Return a trust status for given container/device
-// (GET /{uuid}/device/status)
+// (GET /{uuid}/status)
+//  read the trust state of container/device {uuid} with its history
+//    curl -X GET http://localhost:40002/{uuid}/status
 func (s *MyRestAPIServer) GetUuidStatus(ctx echo.Context, uuid string) error {
-	baseRow, err := trustmgr.FindBaseValueByUuid(uuid)
-	if err != nil {
-		logger.L.Sugar().Errorf("can't find target base")
-		return err
-	}
-	var ans string
-	c, err := trustmgr.GetCache(baseRow.ClientID)
+	info, err := s.mgr.GetUuidTrustInfo(uuid)
 	if err != nil {
-		return err
-	}
-	var row *typdefs.BaseRow
-	baseRows := append(c.ContainerBases, c.DeviceBases...)
-	find := false
-	for _, v := range baseRows {
-		if v.Uuid == uuid {
-			row = v
-			find = true
-			break
-		}
-	}
-	if !find {
-		ans = strNotFound
-	}
-	if row.Verified {
-		ans = strUnknown
-	} else {
-		if row.Trusted {
-			ans = strTrusted
-		} else {
-			ans = strUntrusted
-		}
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
 	}
-	return ctx.JSON(http.StatusOK, ans)
+	return ctx.JSON(http.StatusOK, info)
 }
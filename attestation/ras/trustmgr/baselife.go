@@ -0,0 +1,152 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the lifecycle of base values, a client or node group has one
+	active host base value at most which is used to verify the trust reports.
+*/
+
+package trustmgr
+
+import (
+	"errors"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+var (
+	// ErrNoRollbackBase means the client has no retired host base value
+	// older than the active one to roll back to.
+	ErrNoRollbackBase = errors.New("no previous host base value to roll back")
+)
+
+// prepareBaseValues sets the states and versions of the new base values
+// before they are saved, maxVersion returns the latest saved version of
+// the base values of a type which have the same client or node group as
+// v. Only the last active host base value of a client or node group in
+// rows stays active, the earlier ones are retired.
+func prepareBaseValues(rows []*typdefs.BaseRow, maxVersion func(v *typdefs.BaseRow) (int, error)) error {
+	type ownerKey struct {
+		clientID int64
+		groupID  int64
+	}
+	type baseKey struct {
+		ownerKey
+		baseType string
+	}
+	versions := map[baseKey]int{}
+	active := map[ownerKey]*typdefs.BaseRow{}
+	for _, v := range rows {
+		v.InitBaseState()
+		owner := ownerKey{v.ClientID, v.GroupID}
+		k := baseKey{owner, v.BaseType}
+		n, ok := versions[k]
+		if !ok {
+			var err error
+			n, err = maxVersion(v)
+			if err != nil {
+				return err
+			}
+		}
+		versions[k] = n + 1
+		v.Version = n + 1
+		if v.BaseType == typdefs.BaseTypeHost && v.State == typdefs.BaseStateActive {
+			if old, ok := active[owner]; ok {
+				old.State = typdefs.BaseStateRetired
+				old.Enabled = false
+			}
+			active[owner] = v
+		}
+	}
+	return nil
+}
+
+// loadBaseValues reads the active base values of clients into cache and
+// the ones of node groups into groups.
+func (t *TrustManager) loadBaseValues() error {
+	rows, err := t.store.FindActiveBaseValues()
+	if err != nil {
+		return err
+	}
+	for i := range rows {
+		if rows[i].GroupID != 0 {
+			t.updateGroupBase(&rows[i])
+		} else if c, ok := t.cache[rows[i].ClientID]; ok {
+			c.UpdateBase(&rows[i])
+		}
+	}
+	return nil
+}
+
+// PromoteBaseValue moves the base value to the next lifecycle state, the
+// activated host base value replaces the old active one of its client.
+func (t *TrustManager) PromoteBaseValue(id int64, author string) (*typdefs.BaseRow, error) {
+	return t.changeBaseState(id, author, typdefs.PromoteBaseState)
+}
+
+// RetireBaseValue retires the base value, the client has no active host
+// base value after its active one is retired.
+func (t *TrustManager) RetireBaseValue(id int64, author string) (*typdefs.BaseRow, error) {
+	return t.changeBaseState(id, author, typdefs.RetireBaseState)
+}
+
+// RollbackBaseValue activates the latest retired host base value of the
+// client which is older than the active one.
+func (t *TrustManager) RollbackBaseValue(clientID int64, author string) (*typdefs.BaseRow, error) {
+	rows, err := t.store.FindBaseValuesByClientID(clientID)
+	if err != nil {
+		return nil, err
+	}
+	current := 0
+	for _, b := range rows {
+		if b.BaseType == typdefs.BaseTypeHost && b.State == typdefs.BaseStateActive {
+			current = b.Version
+		}
+	}
+	var prev *typdefs.BaseRow
+	for i, b := range rows {
+		if b.BaseType != typdefs.BaseTypeHost || b.State != typdefs.BaseStateRetired {
+			continue
+		}
+		if (current == 0 || b.Version < current) && (prev == nil || b.Version > prev.Version) {
+			prev = &rows[i]
+		}
+	}
+	if prev == nil {
+		return nil, ErrNoRollbackBase
+	}
+	return t.changeBaseState(prev.ID, author, func(string) (string, error) {
+		return typdefs.BaseStateActive, nil
+	})
+}
+
+// changeBaseState saves the next state of base value and updates cache.
+func (t *TrustManager) changeBaseState(id int64, author string,
+	next func(state string) (string, error)) (*typdefs.BaseRow, error) {
+	t.baseMu.Lock()
+	defer t.baseMu.Unlock()
+	row, err := t.store.FindBaseValueByID(id)
+	if err != nil {
+		return nil, err
+	}
+	state, err := next(row.State)
+	if err != nil {
+		return nil, err
+	}
+	row.State = state
+	row.Author = author
+	row.UpdateTime = time.Now()
+	err = t.store.UpdateBaseValueState(row)
+	if err != nil {
+		return nil, err
+	}
+	t.updateCacheBase(row)
+	return row, nil
+}
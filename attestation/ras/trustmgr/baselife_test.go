@@ -0,0 +1,99 @@
+package trustmgr
+
+import (
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+func TestBaseValueLifecycle(t *testing.T) {
+	s := NewMemoryStore()
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: "{}"})
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: "{}"})
+	for i := 0; i < 3; i++ {
+		s.InsertBaseValue(&typdefs.BaseRow{ClientID: 1, BaseType: typdefs.BaseTypeHost,
+			CreateTime: time.Now(), Name: "draft"})
+	}
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	c, _ := tm.GetCache(1)
+	if _, err = tm.RollbackBaseValue(1, "admin"); err != ErrNoRollbackBase {
+		t.Errorf("test RollbackBaseValue without retired base error, %v\n", err)
+	}
+	testCases := []struct {
+		promote bool
+		id      int64
+		state   string
+		active  int64
+	}{
+		{true, 1, typdefs.BaseStateApproved, 0},
+		{true, 1, typdefs.BaseStateActive, 1},
+		{true, 1, "", 1},
+		{true, 2, typdefs.BaseStateApproved, 1},
+		{true, 2, typdefs.BaseStateActive, 2},
+		{false, 3, typdefs.BaseStateRetired, 2},
+		{false, 3, "", 2},
+		{true, 3, typdefs.BaseStateActive, 3},
+		{false, 3, typdefs.BaseStateRetired, 0},
+		{true, 100, "", 0},
+	}
+	for i, tc := range testCases {
+		var row *typdefs.BaseRow
+		if tc.promote {
+			row, err = tm.PromoteBaseValue(tc.id, "admin")
+		} else {
+			row, err = tm.RetireBaseValue(tc.id, "admin")
+		}
+		if (err == nil) != (tc.state != "") || (err == nil && (row.State != tc.state || row.Author != "admin")) {
+			t.Errorf("test change base state error at case %d, %v %v\n", i, row, err)
+		}
+		bs := c.GetHostBases()
+		if (tc.active == 0 && len(bs) != 0) || (tc.active != 0 && (len(bs) != 1 || bs[0].ID != tc.active)) {
+			t.Errorf("test active base in cache error at case %d, %v\n", i, bs)
+		}
+	}
+	// a retired base value can be activated again.
+	tm.PromoteBaseValue(2, "admin")
+	rows, _ := s.FindBaseValuesByClientID(1)
+	if rows[0].State != typdefs.BaseStateRetired || rows[1].State != typdefs.BaseStateActive ||
+		rows[2].State != typdefs.BaseStateRetired {
+		t.Errorf("test only one active base error, %v\n", rows)
+	}
+
+	// roll back to the retired one older than the active one.
+	row, err := tm.RollbackBaseValue(1, "admin")
+	if err != nil || row.ID != 1 || c.GetHostBases()[0].ID != 1 {
+		t.Errorf("test RollbackBaseValue error, %v %v\n", row, err)
+	}
+	if _, err = tm.RollbackBaseValue(1, "admin"); err != ErrNoRollbackBase {
+		t.Errorf("test RollbackBaseValue of first version error, %v\n", err)
+	}
+
+	// a new active base value replaces the old one when it is saved.
+	tm.SaveBaseValue(&typdefs.BaseRow{ClientID: 1, BaseType: typdefs.BaseTypeHost,
+		CreateTime: time.Now(), Enabled: true})
+	tm.Close()
+	rows, _ = s.FindBaseValuesByClientID(1)
+	if len(rows) != 4 || rows[0].State != typdefs.BaseStateRetired || rows[3].State != typdefs.BaseStateActive ||
+		rows[3].Version != 4 {
+		t.Errorf("test save active base error, %v\n", rows)
+	}
+
+	// the active base values are loaded by a new trust manager.
+	tm, err = New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	c, _ = tm.GetCache(1)
+	if bs := c.GetHostBases(); len(bs) != 1 || bs[0].ID != rows[3].ID {
+		t.Errorf("test loadBaseValues error, %v\n", bs)
+	}
+	c, _ = tm.GetCache(2)
+	if bs := c.GetHostBases(); len(bs) != 0 {
+		t.Errorf("test loadBaseValues of client without base error, %v\n", bs)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: verify the measurements of running containers in trust report
+	by the container base values of the same uuid.
+*/
+
+package trustmgr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+)
+
+var (
+	// ErrContainerBaseNotFound means the container has no enabled base value.
+	ErrContainerBaseNotFound = errors.New("container base value not found")
+	// ErrContainerImaNotMeasured means the ima entry of container isn't in
+	// the host ima log.
+	ErrContainerImaNotMeasured = errors.New("container ima entry isn't in host ima log")
+)
+
+// verifyContainers verifies the manifests of each running container in
+// report by the enabled container base value of the same uuid, saves the
+// results into the cache and returns them as report results. A container
+// without base value is untrusted, and the trust of client isn't changed
+// by its containers.
+func (t *TrustManager) verifyContainers(c *cache.Cache, report *typdefs.TrustReport) []typdefs.ReportResult {
+	if len(report.Containers) == 0 {
+		return nil
+	}
+	// the host ima log has been replayed and checked with the quote.
+	hostIma := map[string]bool{}
+	for _, ln := range bytes.Split(findManifest(report, typdefs.StrIma), typdefs.NewLine) {
+		hostIma[string(bytes.TrimRight(ln, "\r"))] = true
+	}
+	return verifyUuidManifests(c, typdefs.CheckContainer, report.Containers, ErrContainerBaseNotFound,
+		func(base *typdefs.BaseRow, m *typdefs.UuidManifest) error {
+			return verifyContainer(base, m, hostIma, report.ImaOffset)
+		})
+}
+
+// verifyUuidManifests verifies each manifest of ms by the enabled base
+// value of the same uuid, the verification result is saved into the cache
+// with the trust state of uuid and returned as a report result of check.
+// A manifest without base value gets the notFound error.
+func verifyUuidManifests(c *cache.Cache, check string, ms []typdefs.UuidManifest, notFound error,
+	verify func(base *typdefs.BaseRow, m *typdefs.UuidManifest) error) []typdefs.ReportResult {
+	results := make([]typdefs.ReportResult, 0, len(ms))
+	for i := range ms {
+		m := &ms[i]
+		var res typdefs.ReportResult
+		found := c.VerifyUuidBase(m.Uuid, func(base *typdefs.BaseRow) error {
+			err := verify(base, m)
+			if err != nil {
+				err = fmt.Errorf("%s %s: %w", check, m.Uuid, err)
+			}
+			res = typdefs.NewReportResult(check, base.ID, err)
+			return err
+		})
+		state, reason := typdefs.TrustStateTrusted, typdefs.TrustReasonReportVerified
+		if !found {
+			res = typdefs.NewReportResult(check, 0, fmt.Errorf("%s %s: %w", check, m.Uuid, notFound))
+			state, reason = typdefs.TrustStateUntrusted, typdefs.TrustReasonBaseNotFound
+		} else if !res.Passed {
+			state, reason = typdefs.TrustStateUntrusted, typdefs.TrustReasonBaseMismatch
+		}
+		c.SetUuidTrustState(check, m.Uuid, state, reason, res.Detail)
+		results = append(results, res)
+	}
+	return results
+}
+
+// verifyContainer checks the ima entries of container are in the host ima
+// log, then verifies its ima and rootfs manifests by the base value.
+func verifyContainer(base *typdefs.BaseRow, m *typdefs.UuidManifest, hostIma map[string]bool, imaOffset uint64) error {
+	report := &typdefs.TrustReport{Manifests: m.Manifests, ImaOffset: imaOffset}
+	for _, ln := range bytes.Split(findManifest(report, typdefs.StrIma), typdefs.NewLine) {
+		ln = bytes.TrimRight(ln, "\r")
+		if len(ln) > 0 && !hostIma[string(ln)] {
+			return fmt.Errorf("%w: %s", ErrContainerImaNotMeasured, ln)
+		}
+	}
+	rv, err := GetRefValues(base)
+	if err != nil {
+		return fmt.Errorf("base value format wrong, error: %w", err)
+	}
+	if err = verifyIMA(report, base.ImaMode, rv); err != nil {
+		return fmt.Errorf("ima manifest verification failed, error: %w", err)
+	}
+	if err = verifyRootfs(report, rv); err != nil {
+		return fmt.Errorf("rootfs manifest verification failed, error: %w", err)
+	}
+	return nil
+}
+
+// verifyRootfs checks the "alg:hex path" lines of rootfs manifest by the
+// rootfs reference values. Unlike the ima entries, the rootfs digest is
+// asserted by the agent and isn't bound to the quote.
+func verifyRootfs(report *typdefs.TrustReport, rv *typdefs.RefValues) error {
+	return verifyDigestLines(findManifest(report, typdefs.StrRootfs), typdefs.StrRootfs, rv.Rootfs)
+}
+
+// verifyDigestLines checks the "alg:hex name [...]" lines of manifest like
+// verifyIMAHash, each line matched by the reference values must be accepted
+// by one of them, and the ones which aren't optional must match at least
+// one line. All mismatched names are returned in *MismatchError of typ.
+func verifyDigestLines(manifest []byte, typ string, refs []typdefs.RefValue) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	misErr := &typdefs.MismatchError{}
+	found := make([]bool, len(refs))
+	for _, ln := range strings.Split(string(manifest), "\n") {
+		words := strings.Fields(ln)
+		if len(words) < 2 {
+			continue
+		}
+		i := strings.Index(words[0], ":")
+		if i <= 0 {
+			continue
+		}
+		alg, name := words[0][:i], words[1]
+		d, err := hex.DecodeString(words[0][i+1:])
+		if err != nil {
+			continue
+		}
+		matched, accepted := false, false
+		var expected []typdefs.HexBytes
+		for j := range refs {
+			ref := &refs[j]
+			if !ref.MatchName(name) {
+				continue
+			}
+			found[j], matched = true, true
+			accepted = accepted || ref.MatchDigest(alg, d)
+			if ref.Alg == alg {
+				expected = append(expected, ref.Digests...)
+			}
+		}
+		if matched && !accepted {
+			misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typ,
+				Name: name, Reason: typdefs.MismatchNotEqual, Alg: alg, Expected: expected, Actual: d})
+		}
+	}
+	for i := range refs {
+		if !found[i] && !refs[i].Optional {
+			misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typ,
+				Name: refs[i].Name, Reason: typdefs.MismatchNotFound})
+		}
+	}
+	if len(misErr.Mismatches) > 0 {
+		return misErr
+	}
+	return nil
+}
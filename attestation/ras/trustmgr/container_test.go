@@ -0,0 +1,77 @@
+package trustmgr
+
+import (
+	"strings"
+	"testing"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+)
+
+func TestVerifyContainers(t *testing.T) {
+	app1 := strings.Repeat("a1", 32)
+	app2 := strings.Repeat("a2", 32)
+	rootfs1 := strings.Repeat("f1", 32)
+	rootfs2 := strings.Repeat("f2", 32)
+	imaLine := func(d string) string {
+		return "10 " + strings.Repeat("1", 40) + " ima-ng sha256:" + d + " /usr/bin/app"
+	}
+	tm, err := New(NewMemoryStore(), Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	c := cache.NewCache()
+	c.UpdateBase(&typdefs.BaseRow{ID: 1, BaseType: typdefs.BaseTypeContainer, Uuid: "c1", Enabled: true,
+		RefValue: `{"version":1,"ima":[{"name":"/usr/bin/app","alg":"sha256","digests":["` + app1 + `"]}],` +
+			`"rootfs":[{"name":"/","alg":"sha256","digests":["` + rootfs1 + `"]}]}`})
+	c.UpdateBase(&typdefs.BaseRow{ID: 2, BaseType: typdefs.BaseTypeContainer, Uuid: "c2", Enabled: true,
+		Ima: "ima-ng sha256:" + app1 + " /usr/bin/app\n"})
+
+	report := &typdefs.TrustReport{Manifests: []typdefs.Manifest{
+		{Key: typdefs.StrIma, Value: []byte(imaLine(app1) + "\n" + imaLine(app2) + "\n")}}}
+	container := func(uuid, ima, rootfs string) typdefs.UuidManifest {
+		return typdefs.UuidManifest{Uuid: uuid, Manifests: []typdefs.Manifest{
+			{Key: typdefs.StrIma, Value: []byte(ima)},
+			{Key: typdefs.StrRootfs, Value: []byte(rootfs)}}}
+	}
+	testCases := []struct {
+		container typdefs.UuidManifest
+		baseID    int64
+		err       error
+		mismatch  string
+	}{
+		{container("c1", imaLine(app1)+"\n", "sha256:"+rootfs1+" /\n"), 1, nil, ""},
+		{container("c1", imaLine(app1)+"\n", "sha256:"+rootfs2+" /\n"), 1, nil, typdefs.StrRootfs},
+		{container("c1", imaLine(app2)+"\n", "sha256:"+rootfs1+" /\n"), 1, nil, typdefs.StrIma},
+		{container("c1", "", ""), 1, nil, typdefs.StrIma},
+		{container("c2", imaLine(app1)+"\n", ""), 2, nil, ""},
+		{container("c2", imaLine(app1[:60]+"ffff")+"\n", ""), 2, ErrContainerImaNotMeasured, ""},
+		{container("c3", "", ""), 0, ErrContainerBaseNotFound, ""},
+	}
+	for i, tc := range testCases {
+		report.Containers = []typdefs.UuidManifest{tc.container}
+		res := tm.verifyContainers(c, report)
+		passed := tc.err == nil && tc.mismatch == ""
+		if len(res) != 1 || res[0].Check != typdefs.CheckContainer || res[0].BaseID != tc.baseID ||
+			res[0].Passed != passed || (tc.mismatch != "" &&
+			(len(res[0].Mismatches) == 0 || res[0].Mismatches[0].Type != tc.mismatch)) {
+			t.Errorf("test verifyContainers error at case %d, %+v\n", i, res)
+			continue
+		}
+		if !passed && (!strings.Contains(res[0].Detail, tc.container.Uuid) ||
+			tc.err != nil && !strings.Contains(res[0].Detail, tc.err.Error())) {
+			t.Errorf("test verifyContainers detail error at case %d, %s\n", i, res[0].Detail)
+		}
+		for _, b := range c.GetContainerBases() {
+			if b.Uuid == tc.container.Uuid && (!b.Verified || b.Trusted != passed) {
+				t.Errorf("test verifyContainers cache error at case %d, %+v\n", i, b)
+			}
+		}
+	}
+
+	report.Containers = nil
+	if res := tm.verifyContainers(c, report); res != nil {
+		t.Errorf("test verifyContainers without containers error, %v\n", res)
+	}
+}
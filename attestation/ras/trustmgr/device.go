@@ -0,0 +1,52 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: verify the firmware measurements of devices in trust report
+	by the device base values of the same uuid.
+*/
+
+package trustmgr
+
+import (
+	"errors"
+	"fmt"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+)
+
+// ErrDeviceBaseNotFound means the device has no enabled base value.
+var ErrDeviceBaseNotFound = errors.New("device base value not found")
+
+// verifyDevices verifies the firmware manifest of each device in report by
+// the enabled device base value of the same uuid, saves the results into
+// the cache and returns them as report results. A device without base
+// value is untrusted, and the trust of client isn't changed by its devices.
+func (t *TrustManager) verifyDevices(c *cache.Cache, report *typdefs.TrustReport) []typdefs.ReportResult {
+	if len(report.Devices) == 0 {
+		return nil
+	}
+	return verifyUuidManifests(c, typdefs.CheckDevice, report.Devices, ErrDeviceBaseNotFound, verifyDevice)
+}
+
+// verifyDevice verifies the "alg:hex name [version]" lines of the device
+// firmware manifest by the firmware reference values of base.
+func verifyDevice(base *typdefs.BaseRow, m *typdefs.UuidManifest) error {
+	rv, err := GetRefValues(base)
+	if err != nil {
+		return fmt.Errorf("base value format wrong, error: %w", err)
+	}
+	report := &typdefs.TrustReport{Manifests: m.Manifests}
+	err = verifyDigestLines(findManifest(report, typdefs.StrFirmware), typdefs.StrFirmware, rv.Firmware)
+	if err != nil {
+		return fmt.Errorf("firmware manifest verification failed, error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package trustmgr
+
+import (
+	"strings"
+	"testing"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+)
+
+func TestVerifyDevices(t *testing.T) {
+	nic1 := strings.Repeat("b1", 32)
+	nic2 := strings.Repeat("b2", 32)
+	rom := strings.Repeat("c1", 32)
+	tm, err := New(NewMemoryStore(), Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	c := cache.NewCache()
+	c.UpdateBase(&typdefs.BaseRow{ID: 1, BaseType: typdefs.BaseTypeDevice, Uuid: "d1", Enabled: true,
+		RefValue: `{"version":1,"firmware":[{"name":"nic","alg":"sha256","digests":["` + nic1 + `"]},` +
+			`{"name":"nic-rom","alg":"sha256","digests":["` + rom + `"],"optional":true}]}`})
+	c.UpdateBase(&typdefs.BaseRow{ID: 2, BaseType: typdefs.BaseTypeDevice, Uuid: "d2", Enabled: true,
+		RefValue: `{"version":1,"firmware":[]}`})
+
+	device := func(uuid, firmware string) typdefs.UuidManifest {
+		return typdefs.UuidManifest{Uuid: uuid, Manifests: []typdefs.Manifest{
+			{Key: typdefs.StrFirmware, Value: []byte(firmware)}}}
+	}
+	testCases := []struct {
+		device   typdefs.UuidManifest
+		baseID   int64
+		err      error
+		mismatch string
+	}{
+		{device("d1", "sha256:"+nic1+" nic 22.31\n"), 1, nil, ""},
+		{device("d1", "sha256:"+nic1+" nic\nsha256:"+rom+" nic-rom 1.0\n"), 1, nil, ""},
+		{device("d1", "sha256:"+nic2+" nic 22.32\n"), 1, nil, typdefs.StrFirmware},
+		{device("d1", "sha256:"+nic1+" nic\nsha256:"+nic2+" nic-rom\n"), 1, nil, typdefs.StrFirmware},
+		{device("d1", "sha256:"+rom+" nic-rom\n"), 1, nil, typdefs.StrFirmware},
+		{device("d2", "sha256:"+nic2+" gpu\n"), 2, nil, ""},
+		{device("d3", ""), 0, ErrDeviceBaseNotFound, ""},
+	}
+	for i, tc := range testCases {
+		report := &typdefs.TrustReport{Devices: []typdefs.UuidManifest{tc.device}}
+		res := tm.verifyDevices(c, report)
+		passed := tc.err == nil && tc.mismatch == ""
+		if len(res) != 1 || res[0].Check != typdefs.CheckDevice || res[0].BaseID != tc.baseID ||
+			res[0].Passed != passed || (tc.mismatch != "" &&
+			(len(res[0].Mismatches) == 0 || res[0].Mismatches[0].Type != tc.mismatch)) {
+			t.Errorf("test verifyDevices error at case %d, %+v\n", i, res)
+			continue
+		}
+		if !passed && (!strings.Contains(res[0].Detail, tc.device.Uuid) ||
+			tc.err != nil && !strings.Contains(res[0].Detail, tc.err.Error())) {
+			t.Errorf("test verifyDevices detail error at case %d, %s\n", i, res[0].Detail)
+		}
+		for _, b := range c.GetDeviceBases() {
+			if b.Uuid == tc.device.Uuid && (!b.Verified || b.Trusted != passed) {
+				t.Errorf("test verifyDevices cache error at case %d, %+v\n", i, b)
+			}
+		}
+	}
+
+	if res := tm.verifyDevices(c, &typdefs.TrustReport{}); res != nil {
+		t.Errorf("test verifyDevices without devices error, %v\n", res)
+	}
+}
@@ -0,0 +1,312 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: manage the node groups selected by registered client info,
+	the clients of a group share its host base value unless they have
+	their own active one.
+*/
+
+package trustmgr
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+)
+
+var (
+	// ErrGroupNotFound means no node group has the id.
+	ErrGroupNotFound = errors.New("node group not found")
+	// ErrGroupExists means a node group has the name already.
+	ErrGroupExists = errors.New("node group name exists")
+	// ErrGroupInUse means the node group still has base values or
+	// maintenance windows.
+	ErrGroupInUse = errors.New("node group has base values or maintenance windows")
+)
+
+type (
+	// groupEntry is a node group in trust manager.
+	groupEntry struct {
+		row      typdefs.GroupRow
+		selector interface{}
+		// the active host base value of group, nil if none.
+		base *typdefs.BaseRow
+	}
+)
+
+func newGroupEntry(row *typdefs.GroupRow) *groupEntry {
+	e := &groupEntry{row: *row}
+	e.selector, _ = parseClientInfo(row.Selector)
+	return e
+}
+
+// loadGroups reads all node groups from store.
+func (t *TrustManager) loadGroups() error {
+	rows, err := t.store.FindGroups()
+	if err != nil {
+		return err
+	}
+	groups := make(map[int64]*groupEntry, len(rows))
+	for i := range rows {
+		groups[rows[i].ID] = newGroupEntry(&rows[i])
+	}
+	t.groupMu.Lock()
+	t.groups = groups
+	t.groupMu.Unlock()
+	return nil
+}
+
+// AddGroup validates and saves a new node group, the name must be unique.
+func (t *TrustManager) AddGroup(g *typdefs.Group) (*typdefs.GroupRow, error) {
+	err := g.Validate()
+	if err != nil {
+		return nil, err
+	}
+	row := typdefs.GroupRow{
+		Name:       g.Name,
+		CreateTime: time.Now(),
+		Priority:   g.Priority,
+		Selector:   string(g.Selector),
+	}
+	t.groupMu.Lock()
+	for _, e := range t.groups {
+		if e.row.Name == g.Name {
+			t.groupMu.Unlock()
+			return nil, ErrGroupExists
+		}
+	}
+	err = t.store.InsertGroup(&row)
+	if err != nil {
+		t.groupMu.Unlock()
+		return nil, err
+	}
+	t.groups[row.ID] = newGroupEntry(&row)
+	t.groupMu.Unlock()
+	t.resolveClientGroups()
+	return &row, nil
+}
+
+// GetGroups returns all node groups sorted by id.
+func (t *TrustManager) GetGroups() []typdefs.GroupRow {
+	t.groupMu.RLock()
+	defer t.groupMu.RUnlock()
+	rows := make([]typdefs.GroupRow, 0, len(t.groups))
+	for _, e := range t.groups {
+		rows = append(rows, e.row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	return rows
+}
+
+// FindGroupByID returns the node group by id.
+func (t *TrustManager) FindGroupByID(id int64) (*typdefs.GroupRow, error) {
+	t.groupMu.RLock()
+	defer t.groupMu.RUnlock()
+	e, ok := t.groups[id]
+	if !ok {
+		return nil, ErrGroupNotFound
+	}
+	row := e.row
+	return &row, nil
+}
+
+// DeleteGroup deletes the node group which has no base value or
+// maintenance window, they are kept with the group as history instead of
+// being deleted. Its clients are verified by their own base values or the
+// ones of other groups later.
+func (t *TrustManager) DeleteGroup(id int64) error {
+	t.groupMu.Lock()
+	if _, ok := t.groups[id]; !ok {
+		t.groupMu.Unlock()
+		return ErrGroupNotFound
+	}
+	err := t.checkGroupUnused(id)
+	if err == nil {
+		err = t.store.DeleteGroupByID(id)
+	}
+	if err != nil {
+		t.groupMu.Unlock()
+		return err
+	}
+	delete(t.groups, id)
+	t.groupMu.Unlock()
+	t.resolveClientGroups()
+	return nil
+}
+
+// checkGroupUnused returns ErrGroupInUse if the node group has any base
+// value or maintenance window.
+func (t *TrustManager) checkGroupUnused(id int64) error {
+	bases, err := t.store.FindBaseValuesByGroupID(id)
+	if err != nil {
+		return err
+	}
+	if len(bases) > 0 {
+		return ErrGroupInUse
+	}
+	windows, err := t.store.FindMaintWindows()
+	if err != nil {
+		return err
+	}
+	for _, w := range windows {
+		if w.GroupID == id {
+			return ErrGroupInUse
+		}
+	}
+	return nil
+}
+
+// FindGroupClients returns the registered clients which belong to the
+// node group, a client selected by several groups belongs to one of them.
+func (t *TrustManager) FindGroupClients(id int64) ([]typdefs.ClientRow, error) {
+	t.groupMu.RLock()
+	e, ok := t.groups[id]
+	t.groupMu.RUnlock()
+	if !ok {
+		return nil, ErrGroupNotFound
+	}
+	rows, err := t.store.FindClientsByInfo(e.row.Selector)
+	if err != nil {
+		return nil, err
+	}
+	t.groupMu.RLock()
+	defer t.groupMu.RUnlock()
+	res := make([]typdefs.ClientRow, 0, len(rows))
+	for _, c := range rows {
+		if !c.Deleted && t.matchGroup(c.Info) == e {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+// FindGroupBaseValues returns all base values of the node group.
+func (t *TrustManager) FindGroupBaseValues(id int64) ([]typdefs.BaseRow, error) {
+	_, err := t.FindGroupByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return t.store.FindBaseValuesByGroupID(id)
+}
+
+// matchGroup returns the node group which the client info belongs to, the
+// one with the highest priority and then the smallest id is chosen if
+// several groups select it, must be called with groupMu locked.
+func (t *TrustManager) matchGroup(info string) *groupEntry {
+	doc, err := parseClientInfo(info)
+	if err != nil {
+		return nil
+	}
+	var res *groupEntry
+	for _, e := range t.groups {
+		if e.selector == nil || !containsJSON(doc, e.selector) {
+			continue
+		}
+		if res == nil || e.row.Priority > res.row.Priority ||
+			(e.row.Priority == res.row.Priority && e.row.ID < res.row.ID) {
+			res = e
+		}
+	}
+	return res
+}
+
+// groupHostBase returns a copy of the active host base value of the node
+// group which the client of cache c belongs to, or nil.
+func (t *TrustManager) groupHostBase(c *cache.Cache) *typdefs.BaseRow {
+	id := c.GetGroupID()
+	if id == 0 {
+		return nil
+	}
+	t.groupMu.RLock()
+	defer t.groupMu.RUnlock()
+	e, ok := t.groups[id]
+	if !ok || e.base == nil {
+		return nil
+	}
+	base := *e.base
+	return &base
+}
+
+// clientGroupID returns the id of node group which the client info
+// belongs to, 0 if none.
+func (t *TrustManager) clientGroupID(info string) int64 {
+	t.groupMu.RLock()
+	defer t.groupMu.RUnlock()
+	if e := t.matchGroup(info); e != nil {
+		return e.row.ID
+	}
+	return 0
+}
+
+// resolveClientGroups resolves the node group of all clients in cache by
+// their registered info again after the node groups change, so reports
+// don't need to do it.
+func (t *TrustManager) resolveClientGroups() {
+	t.mu.Lock()
+	caches := make([]*cache.Cache, 0, len(t.cache))
+	for _, c := range t.cache {
+		caches = append(caches, c)
+	}
+	t.mu.Unlock()
+	for _, c := range caches {
+		c.SetGroupID(t.clientGroupID(c.GetClientInfo()))
+	}
+}
+
+// effectiveHostBase returns the host base value which verifies the client
+// reports, the latest enabled one of the client in cache overrides the
+// active one of its node group.
+func (t *TrustManager) effectiveHostBase(c *cache.Cache) *typdefs.BaseRow {
+	var base *typdefs.BaseRow
+	for _, b := range c.GetHostBases() {
+		if b.Enabled && (base == nil || !b.CreateTime.Before(base.CreateTime)) {
+			base = b
+		}
+	}
+	if base != nil {
+		return base
+	}
+	return t.groupHostBase(c)
+}
+
+// updateGroupBase saves the active host base value of node group, or
+// removes it after it isn't active any more.
+func (t *TrustManager) updateGroupBase(v *typdefs.BaseRow) {
+	if v.BaseType != typdefs.BaseTypeHost {
+		return
+	}
+	t.groupMu.Lock()
+	defer t.groupMu.Unlock()
+	e, ok := t.groups[v.GroupID]
+	if !ok {
+		return
+	}
+	if v.State == typdefs.BaseStateActive {
+		base := *v
+		e.base = &base
+	} else if e.base != nil && e.base.ID == v.ID {
+		e.base = nil
+	}
+}
+
+// removeGroupBase removes the deleted base value of id if it is the
+// active host base value of a node group.
+func (t *TrustManager) removeGroupBase(id int64) {
+	t.groupMu.Lock()
+	defer t.groupMu.Unlock()
+	for _, e := range t.groups {
+		if e.base != nil && e.base.ID == id {
+			e.base = nil
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package trustmgr
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+func TestNodeGroup(t *testing.T) {
+	s := NewMemoryStore()
+	infos := []string{
+		`{"productname":"P1","osrelease":"oe"}`,
+		`{"productname":"P1","osrelease":"ubuntu"}`,
+		`{"productname":"P2","osrelease":"oe"}`,
+	}
+	for _, info := range infos {
+		s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: info})
+	}
+	s.InsertGroup(&typdefs.GroupRow{Name: "p1", CreateTime: time.Now(), Selector: `{"productname":"P1"}`})
+	s.InsertBaseValue(&typdefs.BaseRow{GroupID: 1, BaseType: typdefs.BaseTypeHost,
+		CreateTime: time.Now(), Enabled: true})
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	// the group with the higher priority wins.
+	g, err := tm.AddGroup(&typdefs.Group{Name: "p1-oe", Priority: 1,
+		Selector: json.RawMessage(`{"productname":"P1","osrelease":"oe"}`)})
+	if err != nil || g.ID != 2 {
+		t.Fatalf("test AddGroup error, %v %v\n", g, err)
+	}
+	if _, err = tm.AddGroup(&typdefs.Group{Name: "p1", Selector: json.RawMessage(`{}`)}); err != ErrGroupExists {
+		t.Errorf("test AddGroup duplicated name error, %v\n", err)
+	}
+	if _, err = tm.AddGroup(&typdefs.Group{Name: "x"}); !errors.Is(err, typdefs.ErrGroupWrong) {
+		t.Errorf("test AddGroup wrong selector error, %v\n", err)
+	}
+	if gs := tm.GetGroups(); len(gs) != 2 || gs[0].Name != "p1" || gs[1].Name != "p1-oe" {
+		t.Errorf("test GetGroups error, %v\n", gs)
+	}
+	members := map[int64][]int64{1: {2}, 2: {1}}
+	for id, ids := range members {
+		cs, err := tm.FindGroupClients(id)
+		if err != nil || len(cs) != len(ids) || cs[0].ID != ids[0] {
+			t.Errorf("test FindGroupClients of group %d error, %v %v\n", id, cs, err)
+		}
+	}
+	if _, err = tm.FindGroupClients(100); err != ErrGroupNotFound {
+		t.Errorf("test FindGroupClients of unknown group error, %v\n", err)
+	}
+
+	// a draft of group 2 is activated and used by its client.
+	s.InsertBaseValue(&typdefs.BaseRow{GroupID: 2, BaseType: typdefs.BaseTypeHost, CreateTime: time.Now()})
+	tm.PromoteBaseValue(2, "admin")
+	if _, err = tm.PromoteBaseValue(2, "admin"); err != nil {
+		t.Errorf("test PromoteBaseValue of group error, %v\n", err)
+	}
+	// client 2 has its own active host base value which overrides the group one.
+	c2, _ := tm.GetCache(2)
+	c2.UpdateBase(&typdefs.BaseRow{ID: 100, ClientID: 2, BaseType: typdefs.BaseTypeHost,
+		Enabled: true, State: typdefs.BaseStateActive})
+	testCases := []struct {
+		clientID int64
+		baseID   int64
+	}{
+		{1, 2},
+		{2, 100},
+		{3, 0},
+	}
+	for i, tc := range testCases {
+		c, _ := tm.GetCache(tc.clientID)
+		base := tm.effectiveHostBase(c)
+		if (base == nil) != (tc.baseID == 0) || (base != nil && base.ID != tc.baseID) {
+			t.Errorf("test effectiveHostBase error at case %d, %v\n", i, base)
+		}
+		res, err := tm.verifyReport(c, &typdefs.TrustReport{ClientID: tc.clientID}, nil)
+		if err != nil || (len(res) == 0) != (tc.baseID == 0) || (len(res) != 0 && res[0].BaseID != tc.baseID) {
+			t.Errorf("test verifyReport error at case %d, %v %v\n", i, res, err)
+		}
+	}
+
+	// the group with base values or maintenance windows can't be deleted.
+	g, err = tm.AddGroup(&typdefs.Group{Name: "p2", Selector: json.RawMessage(`{"productname":"P2"}`)})
+	if err != nil {
+		t.Fatalf("test AddGroup error, %v %v\n", g, err)
+	}
+	c3, _ := tm.GetCache(3)
+	if c3.GetGroupID() != g.ID {
+		t.Errorf("test resolveClientGroups after AddGroup error, %d\n", c3.GetGroupID())
+	}
+	_, err = tm.OpenMaintWindow(&typdefs.MaintWindow{GroupID: g.ID,
+		EndTime: time.Now().Add(time.Hour), Parts: []string{typdefs.WindowPartIma}}, "admin")
+	if err != nil {
+		t.Fatalf("test OpenMaintWindow error, %v\n", err)
+	}
+	inUse := []int64{2, g.ID}
+	for i, id := range inUse {
+		if err = tm.DeleteGroup(id); err != ErrGroupInUse {
+			t.Errorf("test DeleteGroup in use error at case %d, %v\n", i, err)
+		}
+		if _, err = tm.FindGroupByID(id); err != nil {
+			t.Errorf("test FindGroupByID in use error at case %d, %v\n", i, err)
+		}
+	}
+
+	// client 1 belongs to group 1 after group 2 and its base value are deleted.
+	if err = tm.DeleteBaseValueByID(2); err != nil {
+		t.Errorf("test DeleteBaseValueByID error, %v\n", err)
+	}
+	c1, _ := tm.GetCache(1)
+	if base := tm.groupHostBase(c1); base != nil {
+		t.Errorf("test groupHostBase after DeleteBaseValueByID error, %v\n", base)
+	}
+	if err = tm.DeleteGroup(2); err != nil {
+		t.Errorf("test DeleteGroup error, %v\n", err)
+	}
+	if err = tm.DeleteGroup(2); err != ErrGroupNotFound {
+		t.Errorf("test DeleteGroup again error, %v\n", err)
+	}
+	if base := tm.groupHostBase(c1); base == nil || base.ID != 1 {
+		t.Errorf("test groupHostBase after DeleteGroup error, %v\n", base)
+	}
+	if bs, err := tm.FindGroupBaseValues(2); err != ErrGroupNotFound || len(bs) != 0 {
+		t.Errorf("test FindGroupBaseValues of deleted group error, %v %v\n", bs, err)
+	}
+	tm.RetireBaseValue(1, "admin")
+	if base := tm.groupHostBase(c1); base != nil {
+		t.Errorf("test groupHostBase after RetireBaseValue error, %v\n", base)
+	}
+	tm.PromoteBaseValue(1, "admin")
+	tm.Close()
+
+	// the groups and their active base values are loaded by a new trust manager.
+	tm, err = New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	if gs := tm.GetGroups(); len(gs) != 2 || gs[0].ID != 1 {
+		t.Errorf("test loadGroups error, %v\n", gs)
+	}
+	c1, _ = tm.GetCache(1)
+	if c1.GetGroupID() != 1 {
+		t.Errorf("test resolveClientGroups after reload error, %d\n", c1.GetGroupID())
+	}
+	if base := tm.groupHostBase(c1); base == nil || base.ID != 1 || base.Author != "admin" {
+		t.Errorf("test groupHostBase after reload error, %v\n", base)
+	}
+}
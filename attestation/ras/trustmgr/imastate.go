@@ -0,0 +1,219 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: the ima log replay state of clients, which allows a client
+	to send only the ima log entries after the acknowledged ones.
+*/
+
+package trustmgr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/logger"
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/config"
+	"github.com/google/go-tpm/tpm2"
+)
+
+const (
+	// imaPcr is the pcr which ima extends the measurements into.
+	imaPcr = 10
+)
+
+var (
+	errImaPcrNotQuoted = errors.New("ima pcr is not quoted")
+	errImaPcrNotMatch  = errors.New("ima log doesn't match the quoted ima pcr")
+)
+
+// GetImaCount returns the number of ima log entries acknowledged for the
+// client, its next report only needs the entries after them. It is 0 if
+// the client must send the full ima log, like when the ima part of its
+// base value is being updated automatically from the report.
+func (t *TrustManager) GetImaCount(id int64) uint64 {
+	c, err := t.GetCache(id)
+	if err != nil || t.needFullImaLog(c, id) {
+		return 0
+	}
+	st := c.GetImaState()
+	if st == nil {
+		return 0
+	}
+	return st.Count
+}
+
+// needFullImaLog returns whether the ima part of the client base value is
+// being updated automatically, which is extracted from the full ima log.
+func (t *TrustManager) needFullImaLog(c *cache.Cache, id int64) bool {
+	w, auto := t.autoUpdate(c, id)
+	return auto && (w == nil || w.Allows(typdefs.WindowPartIma))
+}
+
+// loadImaStates reads the ima log replay states of clients into cache.
+func (t *TrustManager) loadImaStates() error {
+	states, err := t.store.FindImaStates()
+	if err != nil {
+		return err
+	}
+	for i := range states {
+		if c, ok := t.cache[states[i].ClientID]; ok {
+			c.SetImaState(&states[i])
+		}
+	}
+	return nil
+}
+
+// imaDigestAlg returns the pcr bank which the ima log is replayed in.
+func imaDigestAlg() string {
+	alg := config.GetDigestAlgorithm()
+	if alg == "" {
+		alg = typdefs.Sha1AlgStr
+	}
+	return alg
+}
+
+// updateImaState replays the ima log of report from the replay state of
+// client, or from the beginning for a full ima log, until its pcr equals
+// the quoted one, the entries after it are left for the next report.
+// An incremental ima log which doesn't match the state, or is quoted after
+// the tpm is reset, is refused and the state is reset, so that the client
+// sends the full ima log again. A full ima log which doesn't match the
+// quoted pcr fails the report, it isn't bound to the quote.
+func (t *TrustManager) updateImaState(c *cache.Cache, report *typdefs.TrustReport) error {
+	alg := imaDigestAlg()
+	quoted, err := tpm2.DecodeAttestationData(report.Quoted)
+	if err != nil {
+		return err
+	}
+	resets := quoted.ClockInfo.ResetCount
+	st := c.GetImaState()
+	if report.ImaOffset > 0 {
+		if t.needFullImaLog(c, report.ClientID) || st == nil || st.Count != report.ImaOffset ||
+			st.Alg != alg || st.ResetCount != resets {
+			t.resetImaState(c, report.ClientID)
+			return typdefs.ErrImaStateMismatch
+		}
+	} else {
+		st = &typdefs.ImaStateRow{ClientID: report.ClientID, Alg: alg, ResetCount: resets,
+			Pcr: hex.EncodeToString(make([]byte, typdefs.SupportAlgAndLenMap[alg]))}
+	}
+	next, err := replayImaState(st, report)
+	if err != nil {
+		t.resetImaState(c, report.ClientID)
+		if report.ImaOffset > 0 {
+			return typdefs.ErrImaStateMismatch
+		}
+		return err
+	}
+	next.UpdateTime = time.Now()
+	c.SetImaState(next)
+	err = t.store.SaveImaState(next)
+	if err != nil {
+		logger.L.Sugar().Errorf("save client(%d) ima state error, %v", report.ClientID, err)
+	}
+	return nil
+}
+
+// resetImaState clears the ima log replay state of client.
+func (t *TrustManager) resetImaState(c *cache.Cache, id int64) {
+	if c.GetImaState() == nil {
+		return
+	}
+	c.SetImaState(nil)
+	err := t.store.DeleteImaState(id)
+	if err != nil {
+		logger.L.Sugar().Errorf("delete client(%d) ima state error, %v", id, err)
+	}
+}
+
+// replayImaState extends the ima pcr of state by the ima log entries of
+// report, and returns the state after the entry which makes it equal to
+// the quoted ima pcr of the same bank.
+func replayImaState(st *typdefs.ImaStateRow, report *typdefs.TrustReport) (*typdefs.ImaStateRow, error) {
+	// only the pcr log values of the quoted pcrs are checked with quote.
+	sels, err := getQuotedSelections(report)
+	if err != nil {
+		return nil, err
+	}
+	if !isPcrQuoted(sels, st.Alg, imaPcr) {
+		return nil, errImaPcrNotQuoted
+	}
+	pcrMap, _ := pcrLogToBankMaps(findManifest(report, typdefs.StrPcr))
+	v, ok := pcrMap[st.Alg][imaPcr]
+	if !ok {
+		return nil, errImaPcrNotQuoted
+	}
+	quoted, err := hex.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	pcr, err := hex.DecodeString(st.Pcr)
+	if err != nil {
+		return nil, err
+	}
+	h, err := typdefs.GetHFromAlg(st.Alg)
+	if err != nil {
+		return nil, err
+	}
+	var matched *typdefs.ImaStateRow
+	if bytes.Equal(pcr, quoted) {
+		m := *st
+		matched = &m
+	}
+	count := st.Count
+	for _, ln := range bytes.Split(findManifest(report, typdefs.StrIma), typdefs.NewLine) {
+		if len(bytes.TrimSpace(ln)) == 0 {
+			continue
+		}
+		e, err2 := typdefs.ParseImaEntry(ln)
+		if err2 != nil {
+			return nil, err2
+		}
+		count++
+		if e.Pcr != imaPcr {
+			continue
+		}
+		d, err2 := e.TemplateDigest(st.Alg)
+		if err2 != nil {
+			return nil, err2
+		}
+		h.Reset()
+		h.Write(pcr)
+		h.Write(d)
+		pcr = h.Sum(nil)
+		if bytes.Equal(pcr, quoted) {
+			matched = &typdefs.ImaStateRow{ClientID: st.ClientID, Count: count,
+				Alg: st.Alg, Pcr: hex.EncodeToString(pcr), ResetCount: st.ResetCount}
+		}
+	}
+	if matched == nil {
+		return nil, errImaPcrNotMatch
+	}
+	return matched, nil
+}
+
+// isPcrQuoted returns whether pcr of bank alg is in the quoted selections.
+func isPcrQuoted(sels []typdefs.PcrSelection, alg string, pcr int) bool {
+	for _, sel := range sels {
+		if sel.HashAlg != alg {
+			continue
+		}
+		for _, p := range sel.PCRs {
+			if p == pcr {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package trustmgr
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"github.com/google/go-tpm/tpm2"
+)
+
+// testImaLog returns n ima-ng log lines and the sha1 ima pcr values after
+// each of them, pcrs[0] is the initial zero value.
+func testImaLog(t *testing.T, n int) ([]string, []string) {
+	lines := make([]string, n)
+	pcrs := make([]string, n+1)
+	pcr := make([]byte, sha1.Size)
+	pcrs[0] = hex.EncodeToString(pcr)
+	for i := 0; i < n; i++ {
+		lines[i] = fmt.Sprintf("10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha1:%040x /usr/bin/f%d", i, i)
+		e, err := typdefs.ParseImaEntry([]byte(lines[i]))
+		if err != nil {
+			t.Fatalf("test ParseImaEntry error at line %d, %v\n", i, err)
+		}
+		d, _ := e.TemplateDigest(typdefs.Sha1AlgStr)
+		h := sha1.New()
+		h.Write(pcr)
+		h.Write(d)
+		pcr = h.Sum(nil)
+		pcrs[i+1] = hex.EncodeToString(pcr)
+	}
+	return lines, pcrs
+}
+
+// testImaReport returns a report whose quote of sha1 ima pcr is made after
+// the tpm is reset resets times, the pcr digest isn't checked here.
+func testImaReport(t *testing.T, lines []string, pcr string, offset uint64, resets uint32) *typdefs.TrustReport {
+	ima := ""
+	for _, ln := range lines {
+		ima += ln + "\n"
+	}
+	quoted := createTestResetQuote(t, nil, tpm2.AlgSHA1, []int{imaPcr}, nil, resets)
+	return &typdefs.TrustReport{ClientID: 1, ImaOffset: offset, Quoted: quoted,
+		Manifests: []typdefs.Manifest{
+			{Key: typdefs.StrPcr, Value: []byte(pcr + " sha1 10\n")},
+			{Key: typdefs.StrIma, Value: []byte(ima)},
+		}}
+}
+
+func TestReplayImaState(t *testing.T) {
+	lines, pcrs := testImaLog(t, 4)
+	init := &typdefs.ImaStateRow{ClientID: 1, Alg: typdefs.Sha1AlgStr, Pcr: pcrs[0]}
+	testCases := []struct {
+		st     *typdefs.ImaStateRow
+		lines  []string
+		pcr    string
+		count  uint64
+		result bool
+	}{
+		{init, lines, pcrs[4], 4, true},
+		// the entries after the quoted pcr are left for the next report.
+		{init, lines, pcrs[2], 2, true},
+		{init, nil, pcrs[0], 0, true},
+		{&typdefs.ImaStateRow{Count: 2, Alg: typdefs.Sha1AlgStr, Pcr: pcrs[2]}, lines[2:], pcrs[4], 4, true},
+		{&typdefs.ImaStateRow{Count: 1, Alg: typdefs.Sha1AlgStr, Pcr: pcrs[1]}, lines[2:], pcrs[4], 0, false},
+		{init, lines, pcrs[0][1:] + "1", 0, false},
+		{&typdefs.ImaStateRow{Alg: typdefs.Sha256AlgStr, Pcr: pcrs[0]}, lines, pcrs[4], 0, false},
+	}
+	for i, tc := range testCases {
+		st, err := replayImaState(tc.st, testImaReport(t, tc.lines, tc.pcr, 0, 0))
+		if (err == nil) != tc.result || (err == nil && (st.Count != tc.count || st.Pcr != tc.pcr)) {
+			t.Errorf("test replayImaState error at case %d, %v %v\n", i, st, err)
+		}
+	}
+	// the pcr log value of an unquoted ima pcr isn't trusted.
+	report := testImaReport(t, lines, pcrs[4], 0, 0)
+	report.Quoted = createTestPcrQuote(t, nil, tpm2.AlgSHA1, []int{0}, nil)
+	if _, err := replayImaState(init, report); err != errImaPcrNotQuoted {
+		t.Errorf("test replayImaState with unquoted ima pcr error, %v\n", err)
+	}
+}
+
+func TestUpdateImaState(t *testing.T) {
+	s := NewMemoryStore()
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: "{}"})
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	c, _ := tm.GetCache(1)
+	lines, pcrs := testImaLog(t, 6)
+	testCases := []struct {
+		lines  []string
+		pcr    string
+		offset uint64
+		resets uint32
+		count  uint64
+		result error
+	}{
+		// the full ima log which doesn't match the quoted pcr is refused.
+		{lines[:3], pcrs[4], 0, 0, 0, errImaPcrNotMatch},
+		{lines[:3], pcrs[3], 0, 0, 3, nil},
+		{lines[3:5], pcrs[5], 3, 0, 5, nil},
+		// the offset doesn't match the state.
+		{lines[4:], pcrs[6], 4, 0, 0, typdefs.ErrImaStateMismatch},
+		{lines[5:], pcrs[6], 5, 0, 0, typdefs.ErrImaStateMismatch},
+		{lines, pcrs[6], 0, 0, 6, nil},
+		// the incremental ima log doesn't match the quoted pcr.
+		{lines[6:], pcrs[5], 6, 0, 0, typdefs.ErrImaStateMismatch},
+		{lines[:4], pcrs[4], 0, 0, 4, nil},
+		// the tpm is reset after the state, even if the log happens to match.
+		{lines[4:5], pcrs[5], 4, 1, 0, typdefs.ErrImaStateMismatch},
+		{lines[:4], pcrs[4], 0, 1, 4, nil},
+	}
+	for i, tc := range testCases {
+		err = tm.updateImaState(c, testImaReport(t, tc.lines, tc.pcr, tc.offset, tc.resets))
+		if err != tc.result || tm.GetImaCount(1) != tc.count {
+			t.Errorf("test updateImaState error at case %d, %d %v\n", i, tm.GetImaCount(1), err)
+		}
+	}
+	tm.SetAutoUpdate(1, true, time.Hour)
+	if tm.GetImaCount(1) != 0 {
+		t.Errorf("test GetImaCount in auto update mode error\n")
+	}
+	tm.SetAutoUpdate(1, false, 0)
+	tm.Close()
+
+	// the state is reloaded by a new trust manager.
+	tm, err = New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	if tm.GetImaCount(1) != 4 {
+		t.Errorf("test loadImaStates error, %d\n", tm.GetImaCount(1))
+	}
+	c, _ = tm.GetCache(1)
+	err = tm.updateImaState(c, testImaReport(t, lines[4:5], pcrs[5], 4, 1))
+	if err != nil || tm.GetImaCount(1) != 5 {
+		t.Errorf("test updateImaState after reload error, %d %v\n", tm.GetImaCount(1), err)
+	}
+}
+
+func TestValidateReportImaLog(t *testing.T) {
+	tm, err := New(NewMemoryStore(), Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	rc := newTestReportClient(t, tm)
+	ima, pcr := createTestFullImaLog(t, 2)
+	trusted, err := tm.ValidateReport(rc.report(t, ima, pcr, 0))
+	if err != nil || !trusted || tm.GetImaCount(rc.id) != 3 {
+		t.Fatalf("test ValidateReport with full ima log error, %v %v\n", trusted, err)
+	}
+
+	// the incremental ima log which doesn't match the state is resent in
+	// full by client, the trust state isn't changed.
+	_, err = tm.ValidateReport(rc.report(t, "", pcr, 5))
+	if vs := rc.verdicts(t, tm); err != typdefs.ErrImaStateMismatch || len(vs) != 1 ||
+		vs[0].ToState != typdefs.TrustStateTrusted || tm.GetImaCount(rc.id) != 0 {
+		t.Errorf("test ValidateReport with wrong ima offset error, %v %+v\n", err, vs)
+	}
+	trusted, err = tm.ValidateReport(rc.report(t, ima, pcr, 0))
+	if err != nil || !trusted {
+		t.Errorf("test ValidateReport with resent ima log error, %v\n", err)
+	}
+
+	// the full ima log which doesn't reach the quoted pcr isn't trusted.
+	other := append([]byte{}, pcr...)
+	other[0] ^= 0xff
+	_, err = tm.ValidateReport(rc.report(t, ima, other, 0))
+	vs := rc.verdicts(t, tm)
+	if last := vs[len(vs)-1]; err != errImaPcrNotMatch || last.ToState != typdefs.TrustStateUntrusted ||
+		last.Reason != typdefs.TrustReasonReportInvalid || tm.GetImaCount(rc.id) != 0 {
+		t.Errorf("test ValidateReport with forged ima log error, %v %+v\n", err, last)
+	}
+}
@@ -0,0 +1,283 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: manage the maintenance windows of clients and node groups,
+	the host base values are updated automatically from the trust reports
+	in an open window, and the new ones are attributed to the window.
+*/
+
+package trustmgr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+)
+
+var (
+	// ErrMaintWindowNotFound means no maintenance window has the id.
+	ErrMaintWindowNotFound = errors.New("maintenance window not found")
+	// ErrMaintWindowClosed means the maintenance window is closed already.
+	ErrMaintWindowClosed = errors.New("maintenance window is closed")
+)
+
+// loadMaintWindows reads the maintenance windows which are not closed yet.
+func (t *TrustManager) loadMaintWindows() error {
+	rows, err := t.store.FindMaintWindows()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	windows := map[int64]*typdefs.MaintWindowRow{}
+	for i := range rows {
+		if rows[i].State(now) != typdefs.WindowStateClosed {
+			windows[rows[i].ID] = &rows[i]
+		}
+	}
+	t.windowMu.Lock()
+	t.windows = windows
+	t.windowMu.Unlock()
+	for _, w := range windows {
+		t.syncAutoUpdate(w)
+	}
+	return nil
+}
+
+// OpenMaintWindow saves a new maintenance window of a registered client or
+// an existing node group opened by author, it must end in the future.
+func (t *TrustManager) OpenMaintWindow(w *typdefs.MaintWindow, author string) (*typdefs.MaintWindowRow, error) {
+	err := w.Validate()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	row := typdefs.MaintWindowRow{
+		ClientID:   w.ClientID,
+		GroupID:    w.GroupID,
+		StartTime:  w.StartTime,
+		EndTime:    w.EndTime,
+		Parts:      strings.Join(w.Parts, ","),
+		Author:     author,
+		CreateTime: now,
+	}
+	if row.StartTime.IsZero() {
+		row.StartTime = now
+	}
+	if !row.EndTime.After(now) {
+		return nil, fmt.Errorf("%w: endtime is passed", typdefs.ErrMaintWindowWrong)
+	}
+	if w.ClientID != 0 {
+		_, err = t.GetCache(w.ClientID)
+	} else {
+		_, err = t.FindGroupByID(w.GroupID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = t.store.InsertMaintWindow(&row)
+	if err != nil {
+		return nil, err
+	}
+	saved := row
+	t.windowMu.Lock()
+	t.windows[row.ID] = &saved
+	t.windowMu.Unlock()
+	t.syncAutoUpdate(&row)
+	return &row, nil
+}
+
+// GetMaintWindows returns all maintenance windows including the closed
+// ones sorted by id.
+func (t *TrustManager) GetMaintWindows() ([]typdefs.MaintWindowRow, error) {
+	return t.store.FindMaintWindows()
+}
+
+// FindMaintWindowByID returns the maintenance window by id.
+func (t *TrustManager) FindMaintWindowByID(id int64) (*typdefs.MaintWindowRow, error) {
+	rows, err := t.store.FindMaintWindows()
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		if rows[i].ID == id {
+			return &rows[i], nil
+		}
+	}
+	return nil, ErrMaintWindowNotFound
+}
+
+// FindMaintWindowBaseValues returns the base values updated automatically
+// in the maintenance window.
+func (t *TrustManager) FindMaintWindowBaseValues(id int64) ([]typdefs.BaseRow, error) {
+	_, err := t.FindMaintWindowByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return t.store.FindBaseValuesByWindowID(id)
+}
+
+// CloseMaintWindow closes the scheduled or open maintenance window by
+// author before its end time, the end time is set to now.
+func (t *TrustManager) CloseMaintWindow(id int64, author string) (*typdefs.MaintWindowRow, error) {
+	now := time.Now()
+	t.windowMu.Lock()
+	defer t.windowMu.Unlock()
+	w, ok := t.windows[id]
+	if !ok || w.State(now) == typdefs.WindowStateClosed {
+		delete(t.windows, id)
+		_, err := t.FindMaintWindowByID(id)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrMaintWindowClosed
+	}
+	row := *w
+	row.EndTime = now
+	row.ClosedBy = author
+	err := t.store.UpdateMaintWindow(&row)
+	if err != nil {
+		return nil, err
+	}
+	delete(t.windows, id)
+	t.syncAutoUpdate(&row)
+	return &row, nil
+}
+
+// openWindows returns copies of the open maintenance windows sorted by id,
+// and forgets the closed ones.
+func (t *TrustManager) openWindows(now time.Time) []typdefs.MaintWindowRow {
+	t.windowMu.Lock()
+	defer t.windowMu.Unlock()
+	var res []typdefs.MaintWindowRow
+	for id, w := range t.windows {
+		switch w.State(now) {
+		case typdefs.WindowStateClosed:
+			delete(t.windows, id)
+		case typdefs.WindowStateOpen:
+			res = append(res, *w)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+// findMaintWindow returns the open maintenance window of the client, or
+// the one of its node group in cache c if it has none, nil if neither is
+// open.
+func (t *TrustManager) findMaintWindow(c *cache.Cache, clientID int64) *typdefs.MaintWindowRow {
+	windows := t.openWindows(time.Now())
+	hasGroup := false
+	for i := range windows {
+		if windows[i].ClientID == clientID {
+			return &windows[i]
+		}
+		hasGroup = hasGroup || windows[i].GroupID != 0
+	}
+	if !hasGroup {
+		return nil
+	}
+	groupID := c.GetGroupID()
+	for i := range windows {
+		if groupID != 0 && windows[i].GroupID == groupID {
+			return &windows[i]
+		}
+	}
+	return nil
+}
+
+// autoUpdate returns whether the host base value of client is updated
+// automatically from its reports now, with the open maintenance window
+// which allows it. There is no update without window.
+func (t *TrustManager) autoUpdate(c *cache.Cache, clientID int64) (*typdefs.MaintWindowRow, bool) {
+	w := t.findMaintWindow(c, clientID)
+	return w, w != nil
+}
+
+// syncAutoUpdate saves the end time of w into the cache of its client if
+// w is opened by SetAutoUpdate.
+func (t *TrustManager) syncAutoUpdate(w *typdefs.MaintWindowRow) {
+	if w.Author != typdefs.WindowAuthorAutoUpdate || w.ClientID == 0 {
+		return
+	}
+	c, err := t.GetCache(w.ClientID)
+	if err == nil {
+		c.SetAutoUpdateEnd(w.EndTime)
+	}
+}
+
+// autoUpdateWindows returns the ids of scheduled or open maintenance
+// windows of the client opened by SetAutoUpdate, all clients if clientID
+// is 0.
+func (t *TrustManager) autoUpdateWindows(clientID int64) []int64 {
+	now := time.Now()
+	t.windowMu.Lock()
+	defer t.windowMu.Unlock()
+	var ids []int64
+	for id, w := range t.windows {
+		if w.Author == typdefs.WindowAuthorAutoUpdate && (clientID == 0 || w.ClientID == clientID) &&
+			w.State(now) != typdefs.WindowStateClosed {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// closeAutoUpdateWindows closes the maintenance windows opened by
+// SetAutoUpdate of the client, all clients if clientID is 0.
+func (t *TrustManager) closeAutoUpdateWindows(clientID int64) error {
+	for _, id := range t.autoUpdateWindows(clientID) {
+		_, err := t.CloseMaintWindow(id, typdefs.WindowAuthorAutoUpdate)
+		if err != nil && err != ErrMaintWindowClosed {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetAutoUpdate sets the client to update its host base value from its
+// reports automatically in the next d or not. It opens a maintenance
+// window of the client allowing all parts, which replaces the one opened
+// before, or closes it.
+func (t *TrustManager) SetAutoUpdate(clientID int64, on bool, d time.Duration) error {
+	_, err := t.GetCache(clientID)
+	if err != nil {
+		return err
+	}
+	err = t.closeAutoUpdateWindows(clientID)
+	if err != nil || !on {
+		return err
+	}
+	w := &typdefs.MaintWindow{
+		ClientID: clientID,
+		EndTime:  time.Now().Add(d),
+		Parts:    []string{typdefs.WindowPartPcr, typdefs.WindowPartBios, typdefs.WindowPartIma},
+	}
+	_, err = t.OpenMaintWindow(w, typdefs.WindowAuthorAutoUpdate)
+	return err
+}
+
+// IsAutoUpdate returns whether the client is set to update automatically
+// by SetAutoUpdate now.
+func (t *TrustManager) IsAutoUpdate(clientID int64) bool {
+	c, err := t.GetCache(clientID)
+	return err == nil && c.GetIsAutoUpdate()
+}
+
+// StopAutoUpdate closes the maintenance windows opened by SetAutoUpdate
+// of all clients, after the base value management strategy isn't
+// auto-update any more.
+func (t *TrustManager) StopAutoUpdate() error {
+	return t.closeAutoUpdateWindows(0)
+}
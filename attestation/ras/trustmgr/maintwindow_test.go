@@ -0,0 +1,193 @@
+package trustmgr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+func TestMaintWindow(t *testing.T) {
+	pcrOld := strings.Repeat("a", 64)
+	pcrNew := strings.Repeat("c", 64)
+	imaOld := "ima-ng sha1:" + strings.Repeat("1", 40) + " /bin/a\n"
+	s := NewMemoryStore()
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: `{"productname":"P1"}`})
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: `{"productname":"P2"}`})
+	s.InsertGroup(&typdefs.GroupRow{Name: "p2", CreateTime: time.Now(), Selector: `{"productname":"P2"}`})
+	s.InsertBaseValue(&typdefs.BaseRow{ClientID: 1, BaseType: typdefs.BaseTypeHost, CreateTime: time.Now(),
+		Enabled: true, Pcr: "1:" + pcrOld + "\n", Ima: imaOld})
+	s.InsertBaseValue(&typdefs.BaseRow{GroupID: 1, BaseType: typdefs.BaseTypeHost, CreateTime: time.Now(),
+		Enabled: true, Pcr: "1:" + pcrOld + "\n"})
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	g, _ := tm.FindGroupByID(1)
+
+	end := time.Now().Add(time.Hour)
+	testCases := []struct {
+		w   typdefs.MaintWindow
+		err error
+	}{
+		{typdefs.MaintWindow{ClientID: 100, EndTime: end, Parts: []string{"pcr"}}, typdefs.ErrDoesnotRegistered},
+		{typdefs.MaintWindow{GroupID: 100, EndTime: end, Parts: []string{"pcr"}}, ErrGroupNotFound},
+		{typdefs.MaintWindow{ClientID: 1, EndTime: time.Now().Add(-time.Hour), Parts: []string{"pcr"}}, typdefs.ErrMaintWindowWrong},
+		{typdefs.MaintWindow{ClientID: 1, EndTime: end, Parts: []string{"pcr"}}, nil},
+		{typdefs.MaintWindow{GroupID: g.ID, StartTime: end, EndTime: end.Add(time.Hour), Parts: []string{"ima"}}, nil},
+		{typdefs.MaintWindow{GroupID: g.ID, EndTime: end, Parts: []string{"pcr", "ima"}}, nil},
+	}
+	for i, tc := range testCases {
+		w, err := tm.OpenMaintWindow(&tc.w, "admin")
+		if !errors.Is(err, tc.err) || (err == nil && (w.ID != int64(i-2) || w.StartTime.IsZero() || w.Author != "admin")) {
+			t.Errorf("test OpenMaintWindow error at case %d, %v %v\n", i, w, err)
+		}
+	}
+
+	// client 1 is in window 1, client 2 is in window 3 of its group.
+	windows := []struct {
+		clientID int64
+		windowID int64
+		fullIma  bool
+	}{
+		{1, 1, false},
+		{2, 3, true},
+	}
+	for i, tc := range windows {
+		c, _ := tm.GetCache(tc.clientID)
+		w, auto := tm.autoUpdate(c, tc.clientID)
+		if !auto || w == nil || w.ID != tc.windowID || tm.needFullImaLog(c, tc.clientID) != tc.fullIma {
+			t.Errorf("test autoUpdate error at case %d, %v %v\n", i, w, auto)
+		}
+	}
+
+	// the parts allowed by window aren't verified.
+	c, _ := tm.GetCache(1)
+	report := &typdefs.TrustReport{ClientID: 1, Manifests: []typdefs.Manifest{
+		{Key: typdefs.StrPcr, Value: []byte(pcrNew + " sha256 1\n")}}}
+	w, _ := tm.autoUpdate(c, 1)
+	if res, err := tm.verifyReport(c, report, nil); err != nil || len(res) != 1 || res[0].Passed {
+		t.Errorf("test verifyReport without window error, %v %v\n", res, err)
+	}
+	if res, err := tm.verifyReport(c, report, w); err != nil || len(res) != 1 || !res[0].Passed {
+		t.Errorf("test verifyReport in window error, %v %v\n", res, err)
+	}
+
+	// the new base values only change the allowed parts and are attributed
+	// to the windows, the group one is shared by the group.
+	if err = tm.recordAutoUpdateReport(report, w); err != nil {
+		t.Errorf("test recordAutoUpdateReport of client error, %v\n", err)
+	}
+	c2, _ := tm.GetCache(2)
+	w2, _ := tm.autoUpdate(c2, 2)
+	report.ClientID = 2
+	if err = tm.recordAutoUpdateReport(report, w2); err != nil {
+		t.Errorf("test recordAutoUpdateReport of group error, %v\n", err)
+	}
+	tm.Close()
+	bases := []struct {
+		windowID int64
+		clientID int64
+		groupID  int64
+		ima      string
+	}{
+		{1, 1, 0, imaOld},
+		{3, 0, g.ID, ""},
+	}
+	for i, tc := range bases {
+		bs, err := s.FindBaseValuesByWindowID(tc.windowID)
+		if err != nil || len(bs) != 1 {
+			t.Errorf("test base values of window error at case %d, %v %v\n", i, bs, err)
+			continue
+		}
+		b, _ := s.FindBaseValueByID(bs[0].ID)
+		if b.ClientID != tc.clientID || b.GroupID != tc.groupID || b.Author != "admin" ||
+			b.State != typdefs.BaseStateActive || b.Pcr != "1:"+pcrNew+"\n" || b.Ima != tc.ima {
+			t.Errorf("test base value of window error at case %d, %+v\n", i, b)
+		}
+	}
+
+	// the windows which aren't closed are loaded by a new trust manager.
+	tm, err = New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	if w, err = tm.CloseMaintWindow(1, "admin2"); err != nil || w.ClosedBy != "admin2" || w.EndTime.After(time.Now()) {
+		t.Errorf("test CloseMaintWindow error, %v %v\n", w, err)
+	}
+	if _, err = tm.CloseMaintWindow(1, "admin2"); err != ErrMaintWindowClosed {
+		t.Errorf("test CloseMaintWindow again error, %v\n", err)
+	}
+	if _, err = tm.CloseMaintWindow(100, "admin2"); err != ErrMaintWindowNotFound {
+		t.Errorf("test CloseMaintWindow of unknown window error, %v\n", err)
+	}
+	c, _ = tm.GetCache(1)
+	if w, auto := tm.autoUpdate(c, 1); auto || w != nil {
+		t.Errorf("test autoUpdate after CloseMaintWindow error, %v\n", w)
+	}
+	if ws, err := tm.GetMaintWindows(); err != nil || len(ws) != 3 || ws[0].State(time.Now()) != typdefs.WindowStateClosed {
+		t.Errorf("test GetMaintWindows error, %v %v\n", ws, err)
+	}
+	if bs, err := tm.FindMaintWindowBaseValues(1); err != nil || len(bs) != 1 {
+		t.Errorf("test FindMaintWindowBaseValues error, %v %v\n", bs, err)
+	}
+}
+
+func TestSetAutoUpdate(t *testing.T) {
+	s := NewMemoryStore()
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: `{"productname":"P1"}`})
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: `{"productname":"P1"}`})
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	if err = tm.SetAutoUpdate(100, true, time.Hour); err != typdefs.ErrDoesnotRegistered {
+		t.Errorf("test SetAutoUpdate of unknown client error, %v\n", err)
+	}
+	// the flag opens a bounded window allowing all parts, setting it again
+	// replaces the window.
+	for i := 0; i < 2; i++ {
+		if err = tm.SetAutoUpdate(1, true, time.Hour); err != nil {
+			t.Errorf("test SetAutoUpdate error at case %d, %v\n", i, err)
+		}
+	}
+	tm.SetAutoUpdate(2, true, time.Hour)
+	c, _ := tm.GetCache(1)
+	w, auto := tm.autoUpdate(c, 1)
+	if !auto || w == nil || w.ID != 2 || !w.AllowsAll() || w.Author != typdefs.WindowAuthorAutoUpdate ||
+		w.EndTime.After(time.Now().Add(time.Hour)) || !tm.IsAutoUpdate(1) {
+		t.Errorf("test SetAutoUpdate window error, %v %v\n", w, auto)
+	}
+	if ws, _ := s.FindMaintWindows(); len(ws) != 3 || ws[0].State(time.Now()) != typdefs.WindowStateClosed {
+		t.Errorf("test SetAutoUpdate replace window error, %v\n", ws)
+	}
+	// the windows opened by admin are kept.
+	tm.OpenMaintWindow(&typdefs.MaintWindow{ClientID: 2, EndTime: time.Now().Add(time.Hour),
+		Parts: []string{typdefs.WindowPartIma}}, "admin")
+	if err = tm.SetAutoUpdate(1, false, 0); err != nil || tm.IsAutoUpdate(1) {
+		t.Errorf("test SetAutoUpdate off error, %v\n", err)
+	}
+	if w, auto = tm.autoUpdate(c, 1); auto || w != nil {
+		t.Errorf("test autoUpdate after SetAutoUpdate off error, %v\n", w)
+	}
+	if err = tm.StopAutoUpdate(); err != nil || tm.IsAutoUpdate(2) {
+		t.Errorf("test StopAutoUpdate error, %v\n", err)
+	}
+	c2, _ := tm.GetCache(2)
+	if w, auto = tm.autoUpdate(c2, 2); !auto || w == nil || w.Author != "admin" {
+		t.Errorf("test autoUpdate after StopAutoUpdate error, %v\n", w)
+	}
+	// closing the window by admin also stops the auto-update.
+	tm.SetAutoUpdate(1, true, time.Hour)
+	ids := tm.autoUpdateWindows(1)
+	if len(ids) != 1 || !tm.IsAutoUpdate(1) {
+		t.Fatalf("test SetAutoUpdate again error, %v\n", ids)
+	}
+	if _, err = tm.CloseMaintWindow(ids[0], "admin"); err != nil || tm.IsAutoUpdate(1) {
+		t.Errorf("test IsAutoUpdate after window closed error, %v\n", err)
+	}
+}
@@ -0,0 +1,593 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: in-memory implementation of the trust manager store.
+*/
+
+package trustmgr
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+var (
+	errDuplicatedPolicy = errors.New("duplicated policy name and version")
+	errDuplicatedGroup  = errors.New("duplicated node group name")
+)
+
+type (
+	// MemoryStore saves clients, reports and base values in memory,
+	// all data will be lost after ras exits, only for test and lab.
+	MemoryStore struct {
+		mu       sync.Mutex
+		clients  []typdefs.ClientRow
+		reports  []typdefs.ReportRow
+		bases    []typdefs.BaseRow
+		policies []typdefs.PolicyRow
+		groups   []typdefs.GroupRow
+		windows  []typdefs.MaintWindowRow
+		states   map[int64]typdefs.ImaStateRow
+		history  []typdefs.TrustHistoryRow
+		// last used ids, same as the database sequences.
+		clientID int64
+		reportID int64
+		baseID   int64
+		policyID int64
+		groupID  int64
+		windowID int64
+		trustID  int64
+	}
+)
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: map[int64]typdefs.ImaStateRow{}}
+}
+
+// Close does nothing for in-memory store.
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// FindAllEnabledClients returns all clients which are not deleted.
+func (s *MemoryStore) FindAllEnabledClients() ([]typdefs.ClientRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cs := make([]typdefs.ClientRow, 0, len(s.clients))
+	for _, c := range s.clients {
+		if !c.Deleted {
+			cs = append(cs, c)
+		}
+	}
+	return cs, nil
+}
+
+// RegisterClient saves a new client and sets its id.
+func (s *MemoryStore) RegisterClient(c *typdefs.ClientRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientID++
+	c.ID = s.clientID
+	s.clients = append(s.clients, *c)
+	return nil
+}
+
+// UnRegisterClientByID marks a client as deleted.
+func (s *MemoryStore) UnRegisterClientByID(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.clients {
+		if s.clients[i].ID == id {
+			s.clients[i].Deleted = true
+		}
+	}
+	return nil
+}
+
+// FindClientByIK gets client by ik.
+func (s *MemoryStore) FindClientByIK(ikCert string) (*typdefs.ClientRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.clients {
+		if c.IKCert == ikCert {
+			return &c, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// FindClientByID gets client by id.
+func (s *MemoryStore) FindClientByID(id int64) (*typdefs.ClientRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.clients {
+		if c.ID == id {
+			return &c, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// FindClientsByInfo gets clients ref by info, info must be a json
+// string like `{"key": "value"}`.
+func (s *MemoryStore) FindClientsByInfo(info string) ([]typdefs.ClientRow, error) {
+	sub, err := parseClientInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cs := make([]typdefs.ClientRow, 0, 10)
+	for _, c := range s.clients {
+		if containsClientInfo(c.Info, sub) {
+			cs = append(cs, c)
+		}
+	}
+	return cs, nil
+}
+
+// InsertReport saves a trust report and sets its id.
+func (s *MemoryStore) InsertReport(v *typdefs.ReportRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reportID++
+	v.ID = s.reportID
+	row := *v
+	s.reports = append(s.reports, row)
+	return nil
+}
+
+// InsertReports saves a batch of trust reports.
+func (s *MemoryStore) InsertReports(rows []*typdefs.ReportRow) error {
+	for _, v := range rows {
+		s.InsertReport(v)
+	}
+	return nil
+}
+
+// FindReportsByClientID returns all reports by a specific client id.
+func (s *MemoryStore) FindReportsByClientID(id int64) ([]typdefs.ReportRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reports := make([]typdefs.ReportRow, 0, 100)
+	for _, r := range s.reports {
+		if r.ClientID == id {
+			reports = append(reports, typdefs.ReportRow{ID: r.ID, ClientID: r.ClientID,
+				CreateTime: r.CreateTime, Validated: r.Validated, Trusted: r.Trusted})
+		}
+	}
+	return reports, nil
+}
+
+// FindReportByID returns the report by a specific report id.
+func (s *MemoryStore) FindReportByID(id int64) (*typdefs.ReportRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.reports {
+		if r.ID == id {
+			return &r, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// DeleteReportByID deletes a specific report by report id.
+func (s *MemoryStore) DeleteReportByID(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, r := range s.reports {
+		if r.ID == id {
+			s.reports = append(s.reports[:i], s.reports[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// InsertBaseValue saves a base value and sets its id.
+func (s *MemoryStore) InsertBaseValue(v *typdefs.BaseRow) error {
+	return s.InsertBaseValues([]*typdefs.BaseRow{v})
+}
+
+// InsertBaseValues saves a batch of base values and sets their ids and
+// versions, an active host base value retires the old active one.
+func (s *MemoryStore) InsertBaseValues(rows []*typdefs.BaseRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prepareBaseValues(rows, func(v *typdefs.BaseRow) (int, error) {
+		version := 0
+		for _, b := range s.bases {
+			if b.ClientID == v.ClientID && b.GroupID == v.GroupID && b.BaseType == v.BaseType &&
+				b.Version > version {
+				version = b.Version
+			}
+		}
+		return version, nil
+	})
+	for _, v := range rows {
+		if v.BaseType == typdefs.BaseTypeHost && v.State == typdefs.BaseStateActive {
+			s.retireActiveHostBase(v, 0)
+		}
+		s.baseID++
+		v.ID = s.baseID
+		s.bases = append(s.bases, *v)
+	}
+	return nil
+}
+
+// retireActiveHostBase retires the active host base values of the client
+// or node group of v except the one of id.
+func (s *MemoryStore) retireActiveHostBase(v *typdefs.BaseRow, id int64) {
+	for i := range s.bases {
+		b := &s.bases[i]
+		if b.ClientID == v.ClientID && b.GroupID == v.GroupID && b.BaseType == typdefs.BaseTypeHost &&
+			b.State == typdefs.BaseStateActive && b.ID != id {
+			b.State = typdefs.BaseStateRetired
+			b.Enabled = false
+			b.UpdateTime = v.UpdateTime
+		}
+	}
+}
+
+// FindBaseValuesByClientID returns all base values by a specific client id.
+func (s *MemoryStore) FindBaseValuesByClientID(id int64) ([]typdefs.BaseRow, error) {
+	return s.findBaseValues(func(b *typdefs.BaseRow) bool { return b.ClientID == id })
+}
+
+// FindBaseValuesByGroupID returns all base values by a specific node group id.
+func (s *MemoryStore) FindBaseValuesByGroupID(id int64) ([]typdefs.BaseRow, error) {
+	return s.findBaseValues(func(b *typdefs.BaseRow) bool { return b.GroupID == id })
+}
+
+// FindBaseValuesByWindowID returns all base values updated in a specific
+// maintenance window.
+func (s *MemoryStore) FindBaseValuesByWindowID(id int64) ([]typdefs.BaseRow, error) {
+	return s.findBaseValues(func(b *typdefs.BaseRow) bool { return b.WindowID == id })
+}
+
+// findBaseValues returns the base values without content selected by match.
+func (s *MemoryStore) findBaseValues(match func(b *typdefs.BaseRow) bool) ([]typdefs.BaseRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	basevalues := make([]typdefs.BaseRow, 0, 20)
+	for i := range s.bases {
+		if b := &s.bases[i]; match(b) {
+			basevalues = append(basevalues, typdefs.BaseRow{ID: b.ID, ClientID: b.ClientID,
+				GroupID: b.GroupID, WindowID: b.WindowID, BaseType: b.BaseType,
+				Uuid: b.Uuid, CreateTime: b.CreateTime, Name: b.Name, Enabled: b.Enabled,
+				Verified: b.Verified, Trusted: b.Trusted, State: b.State, Version: b.Version,
+				Author: b.Author, UpdateTime: b.UpdateTime})
+		}
+	}
+	return basevalues, nil
+}
+
+// FindBaseValueByID returns a specific base value by base value id.
+func (s *MemoryStore) FindBaseValueByID(id int64) (*typdefs.BaseRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.bases {
+		if b.ID == id {
+			return &b, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// FindBaseValueByUuid returns a specific base value by base value uuid.
+func (s *MemoryStore) FindBaseValueByUuid(uuid string) (*typdefs.BaseRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.bases {
+		if b.Uuid == uuid {
+			return &b, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// FindActiveBaseValues returns the active base values of all clients and
+// node groups.
+func (s *MemoryStore) FindActiveBaseValues() ([]typdefs.BaseRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res []typdefs.BaseRow
+	for _, b := range s.bases {
+		if b.State == typdefs.BaseStateActive {
+			res = append(res, b)
+		}
+	}
+	return res, nil
+}
+
+// UpdateBaseValueState saves the state, author and update time of base
+// value, activating a host base value retires the old active one.
+func (s *MemoryStore) UpdateBaseValueState(v *typdefs.BaseRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v.Enabled = v.State == typdefs.BaseStateActive
+	for i := range s.bases {
+		b := &s.bases[i]
+		if b.ID != v.ID {
+			continue
+		}
+		if b.BaseType == typdefs.BaseTypeHost && v.State == typdefs.BaseStateActive {
+			s.retireActiveHostBase(&typdefs.BaseRow{ClientID: b.ClientID, GroupID: b.GroupID,
+				UpdateTime: v.UpdateTime}, b.ID)
+		}
+		b.State, b.Enabled, b.Author, b.UpdateTime = v.State, v.Enabled, v.Author, v.UpdateTime
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
+// DeleteBaseValueByID deletes a specific base value by base value id.
+func (s *MemoryStore) DeleteBaseValueByID(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, b := range s.bases {
+		if b.ID == id {
+			s.bases = append(s.bases[:i], s.bases[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// InsertPolicy saves a policy version and sets its id, the name and
+// version must be unique.
+func (s *MemoryStore) InsertPolicy(p *typdefs.PolicyRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.policies {
+		if v.Name == p.Name && v.Version == p.Version {
+			return errDuplicatedPolicy
+		}
+	}
+	s.policyID++
+	p.ID = s.policyID
+	s.policies = append(s.policies, *p)
+	return nil
+}
+
+// FindPolicies returns all versions of all policies ordered by name and
+// version.
+func (s *MemoryStore) FindPolicies() ([]typdefs.PolicyRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	policies := make([]typdefs.PolicyRow, len(s.policies))
+	copy(policies, s.policies)
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Name != policies[j].Name {
+			return policies[i].Name < policies[j].Name
+		}
+		return policies[i].Version < policies[j].Version
+	})
+	return policies, nil
+}
+
+// DeletePolicyByName deletes all versions of a policy.
+func (s *MemoryStore) DeletePolicyByName(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	policies := s.policies[:0]
+	for _, p := range s.policies {
+		if p.Name != name {
+			policies = append(policies, p)
+		}
+	}
+	s.policies = policies
+	return nil
+}
+
+// InsertGroup saves a node group and sets its id, the name must be unique.
+func (s *MemoryStore) InsertGroup(g *typdefs.GroupRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.groups {
+		if v.Name == g.Name {
+			return errDuplicatedGroup
+		}
+	}
+	s.groupID++
+	g.ID = s.groupID
+	s.groups = append(s.groups, *g)
+	return nil
+}
+
+// FindGroups returns all node groups ordered by id.
+func (s *MemoryStore) FindGroups() ([]typdefs.GroupRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	groups := make([]typdefs.GroupRow, len(s.groups))
+	copy(groups, s.groups)
+	return groups, nil
+}
+
+// DeleteGroupByID deletes a node group, it refuses if the group still has
+// base values or maintenance windows, same as the database.
+func (s *MemoryStore) DeleteGroupByID(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.bases {
+		if b.GroupID == id {
+			return ErrGroupInUse
+		}
+	}
+	for _, w := range s.windows {
+		if w.GroupID == id {
+			return ErrGroupInUse
+		}
+	}
+	groups := s.groups[:0]
+	for _, g := range s.groups {
+		if g.ID != id {
+			groups = append(groups, g)
+		}
+	}
+	s.groups = groups
+	return nil
+}
+
+// InsertMaintWindow saves a maintenance window and sets its id.
+func (s *MemoryStore) InsertMaintWindow(w *typdefs.MaintWindowRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.windowID++
+	w.ID = s.windowID
+	s.windows = append(s.windows, *w)
+	return nil
+}
+
+// FindMaintWindows returns all maintenance windows ordered by id.
+func (s *MemoryStore) FindMaintWindows() ([]typdefs.MaintWindowRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	windows := make([]typdefs.MaintWindowRow, len(s.windows))
+	copy(windows, s.windows)
+	return windows, nil
+}
+
+// UpdateMaintWindow saves the end time and closer of a maintenance window.
+func (s *MemoryStore) UpdateMaintWindow(w *typdefs.MaintWindowRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.windows {
+		if s.windows[i].ID == w.ID {
+			s.windows[i].EndTime = w.EndTime
+			s.windows[i].ClosedBy = w.ClosedBy
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// InsertTrustHistory saves a batch of trust state transitions and sets
+// their ids.
+func (s *MemoryStore) InsertTrustHistory(rows []*typdefs.TrustHistoryRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, h := range rows {
+		s.trustID++
+		h.ID = s.trustID
+		s.history = append(s.history, *h)
+	}
+	return nil
+}
+
+// FindTrustHistoryByClientID returns the trust state transitions of a
+// client and its containers/devices ordered by id.
+func (s *MemoryStore) FindTrustHistoryByClientID(id int64) ([]typdefs.TrustHistoryRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rows := make([]typdefs.TrustHistoryRow, 0, 10)
+	for _, h := range s.history {
+		if h.ClientID == id {
+			rows = append(rows, h)
+		}
+	}
+	return rows, nil
+}
+
+// FindImaStates returns the ima log replay states of all clients.
+func (s *MemoryStore) FindImaStates() ([]typdefs.ImaStateRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := make([]typdefs.ImaStateRow, 0, len(s.states))
+	for _, st := range s.states {
+		states = append(states, st)
+	}
+	return states, nil
+}
+
+// SaveImaState inserts or replaces the ima log replay state of a client.
+func (s *MemoryStore) SaveImaState(st *typdefs.ImaStateRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[st.ClientID] = *st
+	return nil
+}
+
+// DeleteImaState deletes the ima log replay state of a client.
+func (s *MemoryStore) DeleteImaState(clientID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, clientID)
+	return nil
+}
+
+// parseClientInfo parses the json info used to search clients.
+func parseClientInfo(info string) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal([]byte(info), &v)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// containsClientInfo checks whether the client info contains sub, for the
+// stores which don't support postgres jsonb "@>" operator.
+func containsClientInfo(info string, sub interface{}) bool {
+	doc, err := parseClientInfo(info)
+	if err != nil {
+		return false
+	}
+	return containsJSON(doc, sub)
+}
+
+// containsJSON checks the json value doc contains the json value sub:
+//
+//	object: each key of sub is in doc and doc value contains sub value.
+//	array:  each element of sub is contained by one element of doc.
+//	others: sub equals to doc.
+func containsJSON(doc, sub interface{}) bool {
+	switch s := sub.(type) {
+	case map[string]interface{}:
+		d, ok := doc.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, sv := range s {
+			dv, ok := d[k]
+			if !ok || !containsJSON(dv, sv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		d, ok := doc.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, sv := range s {
+			found := false
+			for _, dv := range d {
+				if containsJSON(dv, sv) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(doc, sub)
+	}
+}
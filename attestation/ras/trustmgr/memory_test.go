@@ -0,0 +1,372 @@
+package trustmgr
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+// testStoreOperations runs the same client/report/base operations on
+// any kind of store to make sure all stores have the same behavior.
+func testStoreOperations(t *testing.T, s Store) {
+	infos := []string{
+		`{"name":"c1","os":{"type":"openEuler","ver":"21.09"},"tags":["a","b"]}`,
+		`{"name":"c2","os":{"type":"ubuntu","ver":"20.04"},"tags":["b"]}`,
+		`{"name":"c3","os":{"type":"openEuler","ver":"22.03"}}`,
+	}
+	for i, info := range infos {
+		c := typdefs.ClientRow{RegTime: time.Now(), Info: info, IKCert: info}
+		err := s.RegisterClient(&c)
+		if err != nil || c.ID == 0 {
+			t.Fatalf("test RegisterClient error at case %d, %v\n", i, err)
+		}
+	}
+	c, err := s.FindClientByIK(infos[1])
+	if err != nil || c.Info != infos[1] {
+		t.Fatalf("test FindClientByIK error, %v\n", err)
+	}
+	if _, err = s.FindClientByIK("none"); err == nil {
+		t.Errorf("test FindClientByIK none error\n")
+	}
+	c2, err := s.FindClientByID(c.ID)
+	if err != nil || c2.IKCert != infos[1] {
+		t.Errorf("test FindClientByID error, %v\n", err)
+	}
+	s.UnRegisterClientByID(c.ID)
+	cs, err := s.FindAllEnabledClients()
+	if err != nil || len(cs) != 2 {
+		t.Errorf("test FindAllEnabledClients error, %v %v\n", cs, err)
+	}
+
+	queries := []struct {
+		info string
+		num  int
+	}{
+		{`{}`, 3},
+		{`{"os":{"type":"openEuler"}}`, 2},
+		{`{"os":{"type":"openEuler","ver":"22.03"}}`, 1},
+		{`{"tags":["b"]}`, 2},
+		{`{"tags":["a","b"]}`, 1},
+		{`{"name":"c4"}`, 0},
+	}
+	for i, q := range queries {
+		cs, err = s.FindClientsByInfo(q.info)
+		if err != nil || len(cs) != q.num {
+			t.Errorf("test FindClientsByInfo error at case %d, %d %v\n", i, len(cs), err)
+		}
+	}
+	if _, err = s.FindClientsByInfo(`{"bad json"`); err == nil {
+		t.Errorf("test FindClientsByInfo bad json error\n")
+	}
+
+	results := []typdefs.ReportResult{
+		{Check: typdefs.CheckQuote, Passed: true},
+		{Check: typdefs.CheckBaseValue, BaseID: 5, Detail: "pcr 0 sha256 not equal",
+			Mismatches: []typdefs.Mismatch{{Type: typdefs.StrPcr, Name: "0", Alg: "sha256",
+				Reason: typdefs.MismatchNotEqual, Expected: []typdefs.HexBytes{{1}}, Actual: []byte{2}}}},
+	}
+	for i := 0; i < 3; i++ {
+		r := typdefs.ReportRow{ClientID: c.ID, CreateTime: time.Now(),
+			Validated: true, Trusted: i != 1, Quoted: "quoted", Results: results[:i]}
+		err = s.InsertReport(&r)
+		if err != nil || r.ID == 0 {
+			t.Fatalf("test InsertReport error at case %d, %v\n", i, err)
+		}
+	}
+	rs, err := s.FindReportsByClientID(c.ID)
+	if err != nil || len(rs) != 3 || rs[1].Trusted {
+		t.Fatalf("test FindReportsByClientID error, %v %v\n", rs, err)
+	}
+	r, err := s.FindReportByID(rs[0].ID)
+	if err != nil || r.Quoted != "quoted" || len(r.Results) != 0 {
+		t.Errorf("test FindReportByID error, %v\n", err)
+	}
+	r, err = s.FindReportByID(rs[2].ID)
+	if err != nil || len(r.Results) != 2 || !r.Results[0].Passed || r.Results[1].BaseID != 5 ||
+		len(r.Results[1].Mismatches) != 1 || r.Results[1].Mismatches[0].Actual[0] != 2 {
+		t.Errorf("test FindReportByID results error, %+v %v\n", r, err)
+	}
+	s.DeleteReportByID(rs[0].ID)
+	if _, err = s.FindReportByID(rs[0].ID); err == nil {
+		t.Errorf("test DeleteReportByID error\n")
+	}
+
+	b := typdefs.BaseRow{ClientID: c.ID, BaseType: "host", Uuid: "uuid-1",
+		CreateTime: time.Now(), Name: "base", Enabled: true, Pcr: "1:abc\n"}
+	err = s.InsertBaseValue(&b)
+	if err != nil {
+		t.Fatalf("test InsertBaseValue error, %v\n", err)
+	}
+	bs, err := s.FindBaseValuesByClientID(c.ID)
+	if err != nil || len(bs) != 1 || bs[0].Name != "base" {
+		t.Fatalf("test FindBaseValuesByClientID error, %v %v\n", bs, err)
+	}
+	b2, err := s.FindBaseValueByID(bs[0].ID)
+	if err != nil || b2.Pcr != b.Pcr {
+		t.Errorf("test FindBaseValueByID error, %v\n", err)
+	}
+	b2, err = s.FindBaseValueByUuid("uuid-1")
+	if err != nil || b2.ID != bs[0].ID {
+		t.Errorf("test FindBaseValueByUuid error, %v\n", err)
+	}
+	s.DeleteBaseValueByID(bs[0].ID)
+	if _, err = s.FindBaseValueByID(bs[0].ID); err == nil {
+		t.Errorf("test DeleteBaseValueByID error\n")
+	}
+
+	// only one host base value of a client is active.
+	lbs := []*typdefs.BaseRow{
+		{ClientID: c.ID, BaseType: "host", Enabled: true},
+		{ClientID: c.ID, BaseType: "host"},
+		{ClientID: c.ID, BaseType: "host", State: typdefs.BaseStateActive},
+		{ClientID: c.ID, BaseType: "host", State: typdefs.BaseStateActive, Author: "ras"},
+		{ClientID: c.ID, BaseType: "container", Uuid: "c1", Enabled: true},
+	}
+	for i := range lbs {
+		lbs[i].CreateTime = time.Now()
+	}
+	if err = s.InsertBaseValue(lbs[0]); err != nil {
+		t.Fatalf("test InsertBaseValue active error, %v\n", err)
+	}
+	if err = s.InsertBaseValues(lbs[1:]); err != nil {
+		t.Fatalf("test InsertBaseValues active error, %v\n", err)
+	}
+	states := []string{typdefs.BaseStateRetired, typdefs.BaseStateDraft, typdefs.BaseStateRetired,
+		typdefs.BaseStateActive, typdefs.BaseStateActive}
+	versions := []int{1, 2, 3, 4, 1}
+	for i := range lbs {
+		b2, err = s.FindBaseValueByID(lbs[i].ID)
+		if err != nil || b2.State != states[i] || b2.Enabled != (states[i] == typdefs.BaseStateActive) ||
+			b2.Version != versions[i] || b2.Author != lbs[i].Author || b2.UpdateTime.IsZero() {
+			t.Errorf("test base value state error at case %d, %+v %v\n", i, b2, err)
+		}
+	}
+	lbs[1].State = typdefs.BaseStateActive
+	lbs[1].Author = "admin"
+	lbs[1].UpdateTime = time.Now()
+	if err = s.UpdateBaseValueState(lbs[1]); err != nil || !lbs[1].Enabled {
+		t.Errorf("test UpdateBaseValueState error, %v\n", err)
+	}
+	abs, err := s.FindActiveBaseValues()
+	if err != nil || len(abs) != 2 || abs[0].ID != lbs[1].ID || abs[0].Author != "admin" || abs[1].ID != lbs[4].ID {
+		t.Errorf("test FindActiveBaseValues error, %v %v\n", abs, err)
+	}
+	if b2, _ = s.FindBaseValueByID(lbs[3].ID); b2.State != typdefs.BaseStateRetired || b2.Enabled {
+		t.Errorf("test UpdateBaseValueState retire old active error, %+v\n", b2)
+	}
+	if err = s.UpdateBaseValueState(&typdefs.BaseRow{ID: 1000, State: typdefs.BaseStateRetired}); err == nil {
+		t.Errorf("test UpdateBaseValueState not found error\n")
+	}
+	bs, _ = s.FindBaseValuesByClientID(c.ID)
+	if len(bs) != len(lbs) || bs[1].State != typdefs.BaseStateActive || bs[1].Version != 2 {
+		t.Errorf("test FindBaseValuesByClientID state error, %v\n", bs)
+	}
+
+	// the base values of node groups are apart from the ones of clients.
+	groups := []typdefs.GroupRow{
+		{Name: "g1", Priority: 1, Selector: `{"os":{"type":"openEuler"}}`},
+		{Name: "g2", Selector: `{}`},
+	}
+	for i := range groups {
+		groups[i].CreateTime = time.Now()
+		err = s.InsertGroup(&groups[i])
+		if err != nil || groups[i].ID == 0 {
+			t.Fatalf("test InsertGroup error at case %d, %v\n", i, err)
+		}
+	}
+	if err = s.InsertGroup(&typdefs.GroupRow{Name: "g1", Selector: `{}`}); err == nil {
+		t.Errorf("test InsertGroup duplicated name error\n")
+	}
+	gs, err := s.FindGroups()
+	if err != nil || len(gs) != 2 || gs[0].Name != "g1" || gs[0].Priority != 1 || gs[0].Selector != groups[0].Selector {
+		t.Errorf("test FindGroups error, %v %v\n", gs, err)
+	}
+	gbs := []*typdefs.BaseRow{
+		{GroupID: groups[0].ID, BaseType: "host", Enabled: true},
+		{GroupID: groups[1].ID, BaseType: "host", Enabled: true},
+		{GroupID: groups[0].ID, BaseType: "host", Enabled: true},
+	}
+	for i := range gbs {
+		gbs[i].CreateTime = time.Now()
+	}
+	if err = s.InsertBaseValues(gbs); err != nil {
+		t.Fatalf("test InsertBaseValues of groups error, %v\n", err)
+	}
+	gstates := []string{typdefs.BaseStateRetired, typdefs.BaseStateActive, typdefs.BaseStateActive}
+	gversions := []int{1, 1, 2}
+	for i := range gbs {
+		b2, err = s.FindBaseValueByID(gbs[i].ID)
+		if err != nil || b2.GroupID != gbs[i].GroupID || b2.ClientID != 0 || b2.State != gstates[i] ||
+			b2.Version != gversions[i] {
+			t.Errorf("test group base value error at case %d, %+v %v\n", i, b2, err)
+		}
+	}
+	gbs[0].State = typdefs.BaseStateActive
+	gbs[0].UpdateTime = time.Now()
+	if err = s.UpdateBaseValueState(gbs[0]); err != nil {
+		t.Errorf("test UpdateBaseValueState of group error, %v\n", err)
+	}
+	bs, err = s.FindBaseValuesByGroupID(groups[0].ID)
+	if err != nil || len(bs) != 2 || bs[0].State != typdefs.BaseStateActive || bs[1].State != typdefs.BaseStateRetired {
+		t.Errorf("test FindBaseValuesByGroupID error, %v %v\n", bs, err)
+	}
+	if abs, _ = s.FindActiveBaseValues(); len(abs) != 4 {
+		t.Errorf("test FindActiveBaseValues with groups error, %v\n", abs)
+	}
+	if bs, _ = s.FindBaseValuesByClientID(c.ID); len(bs) != len(lbs) || bs[1].State != typdefs.BaseStateActive {
+		t.Errorf("test client base values with groups error, %v\n", bs)
+	}
+	// the maintenance windows are kept after closed.
+	now := time.Now()
+	windows := []typdefs.MaintWindowRow{
+		{ClientID: c.ID, Parts: "pcr,ima", Author: "admin"},
+		{GroupID: groups[0].ID, Parts: "bios", Author: "admin"},
+	}
+	for i := range windows {
+		windows[i].StartTime = now
+		windows[i].EndTime = now.Add(time.Hour)
+		windows[i].CreateTime = now
+		err = s.InsertMaintWindow(&windows[i])
+		if err != nil || windows[i].ID == 0 {
+			t.Fatalf("test InsertMaintWindow error at case %d, %v\n", i, err)
+		}
+	}
+	windows[0].EndTime = now.Add(time.Minute)
+	windows[0].ClosedBy = "admin2"
+	if err = s.UpdateMaintWindow(&windows[0]); err != nil {
+		t.Errorf("test UpdateMaintWindow error, %v\n", err)
+	}
+	if err = s.UpdateMaintWindow(&typdefs.MaintWindowRow{ID: 100}); err != sql.ErrNoRows {
+		t.Errorf("test UpdateMaintWindow of unknown window error, %v\n", err)
+	}
+	ws, err := s.FindMaintWindows()
+	if err != nil || len(ws) != 2 || ws[0].ClientID != c.ID || ws[0].ClosedBy != "admin2" ||
+		!ws[0].EndTime.Equal(windows[0].EndTime) || ws[1].GroupID != groups[0].ID || ws[1].Parts != "bios" {
+		t.Errorf("test FindMaintWindows error, %v %v\n", ws, err)
+	}
+	wb := &typdefs.BaseRow{ClientID: c.ID, WindowID: windows[0].ID, BaseType: "host", CreateTime: now}
+	if err = s.InsertBaseValue(wb); err != nil {
+		t.Errorf("test InsertBaseValue in window error, %v\n", err)
+	}
+	if bs, err = s.FindBaseValuesByWindowID(windows[0].ID); err != nil || len(bs) != 1 ||
+		bs[0].ID != wb.ID || bs[0].ClientID != c.ID || bs[0].WindowID != windows[0].ID {
+		t.Errorf("test FindBaseValuesByWindowID error, %v %v\n", bs, err)
+	}
+	s.DeleteBaseValueByID(wb.ID)
+
+	// the group with base values and maintenance windows is kept.
+	if err = s.DeleteGroupByID(groups[0].ID); err == nil {
+		t.Errorf("test DeleteGroupByID in use error\n")
+	}
+	if ws, _ = s.FindMaintWindows(); len(ws) != 2 {
+		t.Errorf("test DeleteGroupByID maintenance windows error, %v\n", ws)
+	}
+	if bs, _ = s.FindBaseValuesByGroupID(groups[0].ID); len(bs) == 0 {
+		t.Errorf("test DeleteGroupByID base values error, %v\n", bs)
+	}
+	unused := &typdefs.GroupRow{Name: "g3", CreateTime: now, Selector: "{}"}
+	s.InsertGroup(unused)
+	if err = s.DeleteGroupByID(unused.ID); err != nil {
+		t.Errorf("test DeleteGroupByID error, %v\n", err)
+	}
+	if gs, _ = s.FindGroups(); len(gs) != 2 {
+		t.Errorf("test DeleteGroupByID error, %v\n", gs)
+	}
+
+	policies := []typdefs.PolicyRow{
+		{Name: "p2", Version: 1, Rules: "[]"},
+		{Name: "p1", Version: 2, Selector: `{"os":{"type":"openEuler"}}`, Enabled: true},
+		{Name: "p1", Version: 1},
+	}
+	for i := range policies {
+		policies[i].CreateTime = time.Now()
+		err = s.InsertPolicy(&policies[i])
+		if err != nil || policies[i].ID == 0 {
+			t.Fatalf("test InsertPolicy error at case %d, %v\n", i, err)
+		}
+	}
+	if err = s.InsertPolicy(&typdefs.PolicyRow{Name: "p1", Version: 1}); err == nil {
+		t.Errorf("test InsertPolicy duplicated version error\n")
+	}
+	ps, err := s.FindPolicies()
+	if err != nil || len(ps) != 3 || ps[0].Version != 1 || ps[1].Selector != policies[1].Selector ||
+		!ps[1].Enabled || ps[2].Rules != "[]" {
+		t.Errorf("test FindPolicies error, %v %v\n", ps, err)
+	}
+	s.DeletePolicyByName("p1")
+	if ps, _ = s.FindPolicies(); len(ps) != 1 || ps[0].Name != "p2" {
+		t.Errorf("test DeletePolicyByName error, %v\n", ps)
+	}
+
+	for i := uint64(1); i <= 2; i++ {
+		err = s.SaveImaState(&typdefs.ImaStateRow{ClientID: c.ID, UpdateTime: time.Now(),
+			Count: i, Alg: "sha256", Pcr: "abc", ResetCount: uint32(i)})
+		if err != nil {
+			t.Fatalf("test SaveImaState error at case %d, %v\n", i, err)
+		}
+	}
+	sts, err := s.FindImaStates()
+	if err != nil || len(sts) != 1 || sts[0].ClientID != c.ID || sts[0].Count != 2 || sts[0].Pcr != "abc" ||
+		sts[0].ResetCount != 2 {
+		t.Errorf("test FindImaStates error, %v %v\n", sts, err)
+	}
+	s.DeleteImaState(c.ID)
+	if sts, _ = s.FindImaStates(); len(sts) != 0 {
+		t.Errorf("test DeleteImaState error, %v\n", sts)
+	}
+
+	hs := []*typdefs.TrustHistoryRow{
+		{ClientID: c.ID, EntityType: typdefs.BaseTypeHost, FromState: typdefs.TrustStateUnknown,
+			ToState: typdefs.TrustStatePending, Reason: typdefs.TrustReasonRegistered, CreateTime: time.Now()},
+		{ClientID: c.ID, EntityType: typdefs.BaseTypeDevice, Uuid: "d1", FromState: typdefs.TrustStateUnknown,
+			ToState: typdefs.TrustStateUntrusted, Reason: typdefs.TrustReasonBaseMismatch, Detail: "nic", CreateTime: time.Now()},
+	}
+	err = s.InsertTrustHistory(hs)
+	if err != nil || hs[0].ID == 0 || hs[1].ID <= hs[0].ID {
+		t.Fatalf("test InsertTrustHistory error, %v\n", err)
+	}
+	rows, err := s.FindTrustHistoryByClientID(c.ID)
+	if err != nil || len(rows) != 2 || rows[0].ID != hs[0].ID || rows[1].Uuid != "d1" ||
+		rows[1].ToState != typdefs.TrustStateUntrusted || rows[1].Detail != "nic" {
+		t.Errorf("test FindTrustHistoryByClientID error, %v %v\n", rows, err)
+	}
+	if rows, _ = s.FindTrustHistoryByClientID(c.ID + 100); len(rows) != 0 {
+		t.Errorf("test FindTrustHistoryByClientID of unknown client error, %v\n", rows)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+	defer s.Close()
+	testStoreOperations(t, s)
+}
+
+func TestContainsJSON(t *testing.T) {
+	doc := `{"a":1,"b":{"c":"d","e":[1,2,{"f":true}]}}`
+	tests := []struct {
+		sub    string
+		result bool
+	}{
+		{`{}`, true},
+		{`{"a":1}`, true},
+		{`{"a":2}`, false},
+		{`{"a":"1"}`, false},
+		{`{"b":{"c":"d"}}`, true},
+		{`{"b":{"e":[2]}}`, true},
+		{`{"b":{"e":[{"f":true},1]}}`, true},
+		{`{"b":{"e":[3]}}`, false},
+		{`{"x":null}`, false},
+		{`[]`, false},
+	}
+	for i, test := range tests {
+		sub, err := parseClientInfo(test.sub)
+		if err != nil {
+			t.Fatalf("test parseClientInfo error at case %d, %v\n", i, err)
+		}
+		if containsClientInfo(doc, sub) != test.result {
+			t.Errorf("test containsClientInfo error at case %d\n", i)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: versioned database schema migrations of the sql stores.
+*/
+
+package trustmgr
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	sqlCreateSchemaVersion = `CREATE TABLE IF NOT EXISTS schema_version (version INTEGER PRIMARY KEY NOT NULL, name TEXT, applied TIMESTAMP)`
+	sqlFindSchemaVersion   = `SELECT COALESCE(MAX(version), 0) FROM schema_version`
+	sqlFindSchemaVersions  = `SELECT version, applied FROM schema_version ORDER BY version ASC`
+	sqlInsertSchemaVersion = `INSERT INTO schema_version(version, name, applied) VALUES ($1, $2, $3)`
+	sqlDeleteSchemaVersion = `DELETE FROM schema_version WHERE version=$1`
+)
+
+var (
+	// ErrSchemaTooNew means the database schema is created by a newer ras.
+	ErrSchemaTooNew = errors.New("database schema is newer than ras supports")
+	// ErrSchemaTooOld means the database schema needs to be migrated up.
+	ErrSchemaTooOld = errors.New("database schema is older than ras needs, please run 'ras migrate up'")
+)
+
+type (
+	// migration is one step of the schema changes, the up statements
+	// change schema from version-1 to version and down ones revert it.
+	migration struct {
+		version int
+		name    string
+		up      []string
+		down    []string
+	}
+
+	// MigrationStatus shows whether a schema migration has been applied.
+	MigrationStatus struct {
+		Version int
+		Name    string
+		Applied bool
+		// the time when it is applied, zero if not applied.
+		AppliedTime time.Time
+	}
+
+	// Migrator is implemented by the stores which keep a versioned schema.
+	Migrator interface {
+		// SchemaVersion returns the current schema version, 0 for empty database.
+		SchemaVersion() (int, error)
+		// LatestSchemaVersion returns the newest schema version ras supports.
+		LatestSchemaVersion() int
+		// MigrateUp applies all migrations which are not applied yet.
+		MigrateUp() error
+		// MigrateDown reverts the last applied migration.
+		MigrateDown() error
+		// MigrationStatus returns the status of all migrations ras knows.
+		MigrationStatus() ([]MigrationStatus, error)
+	}
+)
+
+// SchemaVersion returns the current schema version, 0 for empty database.
+func (s *sqlStore) SchemaVersion() (int, error) {
+	var v int
+	_, err := s.db.Exec(sqlCreateSchemaVersion)
+	if err != nil {
+		return 0, err
+	}
+	err = s.db.QueryRow(sqlFindSchemaVersion).Scan(&v)
+	if err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+// LatestSchemaVersion returns the newest schema version ras supports.
+func (s *sqlStore) LatestSchemaVersion() int {
+	if len(s.migrations) == 0 {
+		return 0
+	}
+	return s.migrations[len(s.migrations)-1].version
+}
+
+// MigrateUp applies all migrations which are not applied yet in order,
+// each one in a transaction with its version record.
+func (s *sqlStore) MigrateUp() error {
+	cur, err := s.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if cur > s.LatestSchemaVersion() {
+		return ErrSchemaTooNew
+	}
+	for _, m := range s.migrations {
+		if m.version <= cur {
+			continue
+		}
+		err = s.applyMigration(m.up, func(tx *sql.Tx) error {
+			_, err2 := tx.Exec(sqlInsertSchemaVersion, m.version, m.name, time.Now())
+			return err2
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MigrateDown reverts the last applied migration.
+func (s *sqlStore) MigrateDown() error {
+	cur, err := s.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if cur > s.LatestSchemaVersion() {
+		return ErrSchemaTooNew
+	}
+	for i := len(s.migrations) - 1; i >= 0; i-- {
+		m := s.migrations[i]
+		if m.version != cur {
+			continue
+		}
+		return s.applyMigration(m.down, func(tx *sql.Tx) error {
+			_, err2 := tx.Exec(sqlDeleteSchemaVersion, m.version)
+			return err2
+		})
+	}
+	return nil
+}
+
+// MigrationStatus returns the status of all migrations ras knows.
+func (s *sqlStore) MigrationStatus() ([]MigrationStatus, error) {
+	_, err := s.db.Exec(sqlCreateSchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(sqlFindSchemaVersions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var v int
+		var t time.Time
+		err2 := rows.Scan(&v, &t)
+		if err2 != nil {
+			return nil, err2
+		}
+		applied[v] = t
+	}
+	ms := make([]MigrationStatus, 0, len(s.migrations))
+	for _, m := range s.migrations {
+		t, ok := applied[m.version]
+		ms = append(ms, MigrationStatus{
+			Version:     m.version,
+			Name:        m.name,
+			Applied:     ok,
+			AppliedTime: t,
+		})
+	}
+	return ms, nil
+}
+
+// applyMigration executes the statements and updates the version
+// record in one transaction.
+func (s *sqlStore) applyMigration(stmts []string, record func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, q := range stmts {
+		_, err = tx.Exec(q)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	err = record(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// checkSchema makes sure the store schema is the latest one which ras
+// supports, migrates it up if autoMigrate is true.
+func checkSchema(m Migrator, autoMigrate bool) error {
+	cur, err := m.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	latest := m.LatestSchemaVersion()
+	switch {
+	case cur > latest:
+		return ErrSchemaTooNew
+	case cur == latest:
+		return nil
+	case !autoMigrate:
+		return ErrSchemaTooOld
+	}
+	return m.MigrateUp()
+}
@@ -0,0 +1,167 @@
+package trustmgr
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+func createTestSqliteStore(t *testing.T) *SqliteStore {
+	s, err := NewSqliteStore(filepath.Join(t.TempDir(), "ras.db"))
+	if err != nil {
+		t.Fatalf("test NewSqliteStore error, %v\n", err)
+	}
+	return s
+}
+
+func TestMigrateUpAndDown(t *testing.T) {
+	s := createTestSqliteStore(t)
+	defer s.Close()
+	latest := s.LatestSchemaVersion()
+	if latest != len(sqliteMigrations) || latest != len(pgMigrations) {
+		t.Fatalf("test LatestSchemaVersion error, %d\n", latest)
+	}
+	v, err := s.SchemaVersion()
+	if err != nil || v != 0 {
+		t.Fatalf("test SchemaVersion of empty database error, %d %v\n", v, err)
+	}
+	// migrate to version 1 and save some data like an old ras.
+	s.migrations = sqliteMigrations[:1]
+	err = s.MigrateUp()
+	if err != nil {
+		t.Fatalf("test MigrateUp to version 1 error, %v\n", err)
+	}
+	c := typdefs.ClientRow{RegTime: time.Now(), Info: `{}`, IKCert: "ik"}
+	err = s.RegisterClient(&c)
+	if err != nil {
+		t.Fatalf("test RegisterClient error, %v\n", err)
+	}
+	for i, b := range []string{`'u1', $2, true`, `'u2', $2, true`, `'u3', $2, false`} {
+		_, err = s.db.Exec(`INSERT INTO base(clientid, basetype, uuid, createtime, enabled, name, pcr, bios, ima) VALUES ($1, 'host', `+
+			b+`, 'b1', '', '', '')`, c.ID, time.Now())
+		if err != nil {
+			t.Fatalf("test insert base of version 1 error at case %d, %v\n", i, err)
+		}
+	}
+
+	// ras supports all migrations now, the data must be kept.
+	s.migrations = sqliteMigrations
+	ms, err := s.MigrationStatus()
+	if err != nil || len(ms) != latest || !ms[0].Applied || ms[1].Applied {
+		t.Fatalf("test MigrationStatus error, %v %v\n", ms, err)
+	}
+	err = s.MigrateUp()
+	if err != nil {
+		t.Fatalf("test MigrateUp error, %v\n", err)
+	}
+	v, _ = s.SchemaVersion()
+	if v != latest {
+		t.Errorf("test SchemaVersion after MigrateUp error, %d\n", v)
+	}
+	b, err := s.FindBaseValueByUuid("u1")
+	if err != nil || b.ClientID != c.ID || b.Verified || b.Trusted {
+		t.Errorf("test FindBaseValueByUuid after MigrateUp error, %v\n", err)
+	}
+	// only the latest enabled host base value is active.
+	states := []string{typdefs.BaseStateRetired, typdefs.BaseStateActive, typdefs.BaseStateDraft}
+	for i, uuid := range []string{"u1", "u2", "u3"} {
+		b, err = s.FindBaseValueByUuid(uuid)
+		if err != nil || b.State != states[i] || b.Enabled != (i == 1) || b.Version != i+1 || b.UpdateTime.IsZero() {
+			t.Errorf("test base state after MigrateUp error at case %d, %+v %v\n", i, b, err)
+		}
+	}
+	// foreign key refuses the base of an unknown client.
+	err = s.InsertBaseValue(&typdefs.BaseRow{ClientID: c.ID + 100, CreateTime: time.Now()})
+	if err == nil {
+		t.Errorf("test foreign key of base error\n")
+	}
+	// the base values of node groups are dropped with the groups.
+	g := typdefs.GroupRow{Name: "g", CreateTime: time.Now(), Selector: `{}`}
+	err = s.InsertGroup(&g)
+	if err == nil {
+		err = s.InsertBaseValue(&typdefs.BaseRow{GroupID: g.ID, BaseType: "host", CreateTime: time.Now()})
+	}
+	if err != nil {
+		t.Errorf("test insert group base error, %v\n", err)
+	}
+	// the base values updated in maintenance windows are kept with them.
+	w := typdefs.MaintWindowRow{ClientID: c.ID, StartTime: time.Now(), EndTime: time.Now().Add(time.Hour),
+		Parts: "ima", CreateTime: time.Now()}
+	err = s.InsertMaintWindow(&w)
+	if err == nil {
+		err = s.InsertBaseValue(&typdefs.BaseRow{ClientID: c.ID, WindowID: w.ID, BaseType: "host", CreateTime: time.Now()})
+	}
+	if err != nil {
+		t.Errorf("test insert window base error, %v\n", err)
+	}
+	if bs, _ := s.FindBaseValuesByWindowID(w.ID); len(bs) != 1 || bs[0].WindowID != w.ID || bs[0].ClientID != c.ID {
+		t.Errorf("test FindBaseValuesByWindowID error, %v\n", bs)
+	}
+	ms, _ = s.MigrationStatus()
+	for i, m := range ms {
+		if !m.Applied || m.AppliedTime.IsZero() {
+			t.Errorf("test MigrationStatus error at case %d\n", i)
+		}
+	}
+
+	for i := latest; i > 0; i-- {
+		err = s.MigrateDown()
+		if err != nil {
+			t.Fatalf("test MigrateDown from %d error, %v\n", i, err)
+		}
+		v, _ = s.SchemaVersion()
+		if v != i-1 {
+			t.Errorf("test SchemaVersion after MigrateDown error, %d\n", v)
+		}
+		// 5 base values are kept after maint_window is dropped, and the
+		// group one is dropped with node_group.
+		if i == 11 || i == 10 {
+			var n int
+			err = s.db.QueryRow(`SELECT COUNT(*) FROM base`).Scan(&n)
+			if err != nil || n != i-6 {
+				t.Errorf("test base values after MigrateDown from %d error, %d %v\n", i, n, err)
+			}
+		}
+		if i == 2 {
+			b, err = s.FindBaseValueByUuid("u1")
+			if err == nil {
+				t.Errorf("test verified column after MigrateDown error\n")
+			}
+		}
+	}
+	err = s.MigrateDown()
+	if err != nil {
+		t.Errorf("test MigrateDown of empty database error, %v\n", err)
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	s := createTestSqliteStore(t)
+	_, err := New(s, Options{SkipMigrate: true})
+	if err != ErrSchemaTooOld {
+		t.Errorf("test New with old schema error, %v\n", err)
+	}
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New with auto migration error, %v\n", err)
+	}
+	tm.Close()
+
+	// the schema is created by a newer ras.
+	s = createTestSqliteStore(t)
+	defer s.Close()
+	err = s.MigrateUp()
+	if err != nil {
+		t.Fatalf("test MigrateUp error, %v\n", err)
+	}
+	s.migrations = sqliteMigrations[:1]
+	_, err = New(s, Options{})
+	if err != ErrSchemaTooNew {
+		t.Errorf("test New with newer schema error, %v\n", err)
+	}
+	if s.MigrateUp() != ErrSchemaTooNew || s.MigrateDown() != ErrSchemaTooNew {
+		t.Errorf("test migrate newer schema error\n")
+	}
+}
@@ -0,0 +1,402 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: manage the versioned verification policies of client groups
+	and evaluate them against trust reports.
+*/
+
+package trustmgr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+)
+
+const (
+	// max unknown file names shown in the rule result detail.
+	maxUnknownFiles = 10
+)
+
+var (
+	// ErrPolicyNotFound means no policy has the name.
+	ErrPolicyNotFound = errors.New("policy not found")
+)
+
+type (
+	// PolicyError lists the failed rules of fail severity.
+	PolicyError struct {
+		Rules []string
+	}
+
+	// policyEntry is the latest version of a policy in trust manager.
+	policyEntry struct {
+		row      typdefs.PolicyRow
+		policy   *typdefs.Policy
+		selector interface{}
+	}
+
+	// policyInput is what the rules are evaluated against, the reference
+	// values are converted once on the first use.
+	policyInput struct {
+		report *typdefs.TrustReport
+		base   *typdefs.BaseRow
+		rv     *typdefs.RefValues
+		rvErr  error
+	}
+)
+
+func (e *PolicyError) Error() string {
+	return "policy check failed: " + strings.Join(e.Rules, ", ")
+}
+
+// Unwrap makes errors.Is(err, typdefs.ErrPolicyFail) work.
+func (e *PolicyError) Unwrap() error {
+	return typdefs.ErrPolicyFail
+}
+
+// newPolicyEntry parses the stored policy row.
+func newPolicyEntry(row *typdefs.PolicyRow) (*policyEntry, error) {
+	p := &typdefs.Policy{Name: row.Name, Enabled: row.Enabled}
+	if row.Selector != "" {
+		p.Selector = json.RawMessage(row.Selector)
+	}
+	err := json.Unmarshal([]byte(row.Rules), &p.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", typdefs.ErrPolicyWrong, err)
+	}
+	err = p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	e := &policyEntry{row: *row, policy: p}
+	if len(p.Selector) > 0 {
+		e.selector, _ = parseClientInfo(row.Selector)
+	}
+	return e, nil
+}
+
+// PolicyFromRow returns the JSON form of a stored policy version.
+func PolicyFromRow(row *typdefs.PolicyRow) (*typdefs.Policy, error) {
+	e, err := newPolicyEntry(row)
+	if err != nil {
+		return nil, err
+	}
+	return e.policy, nil
+}
+
+// loadPolicies reads the latest version of all policies from store.
+func (t *TrustManager) loadPolicies() error {
+	rows, err := t.store.FindPolicies()
+	if err != nil {
+		return err
+	}
+	latest := map[string]*policyEntry{}
+	for i := range rows {
+		e, err := newPolicyEntry(&rows[i])
+		if err != nil {
+			return fmt.Errorf("policy %s version %d: %w", rows[i].Name, rows[i].Version, err)
+		}
+		if old, ok := latest[e.row.Name]; !ok || old.row.Version < e.row.Version {
+			latest[e.row.Name] = e
+		}
+	}
+	t.policyMu.Lock()
+	t.policies = latest
+	t.policyMu.Unlock()
+	return nil
+}
+
+// SavePolicy validates and saves the policy as a new version, the version
+// of a new policy name is 1.
+func (t *TrustManager) SavePolicy(p *typdefs.Policy) (*typdefs.PolicyRow, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	rules, _ := json.Marshal(p.Rules)
+	row := typdefs.PolicyRow{
+		Name:       p.Name,
+		Version:    1,
+		CreateTime: time.Now(),
+		Enabled:    p.Enabled,
+		Selector:   string(p.Selector),
+		Rules:      string(rules),
+	}
+	t.policyMu.Lock()
+	defer t.policyMu.Unlock()
+	if old, ok := t.policies[p.Name]; ok {
+		row.Version = old.row.Version + 1
+	}
+	err = t.store.InsertPolicy(&row)
+	if err != nil {
+		return nil, err
+	}
+	e, err := newPolicyEntry(&row)
+	if err != nil {
+		return nil, err
+	}
+	t.policies[p.Name] = e
+	return &row, nil
+}
+
+// GetPolicies returns the latest version of all policies sorted by name.
+func (t *TrustManager) GetPolicies() []typdefs.PolicyRow {
+	t.policyMu.RLock()
+	defer t.policyMu.RUnlock()
+	rows := make([]typdefs.PolicyRow, 0, len(t.policies))
+	for _, e := range t.policies {
+		rows = append(rows, e.row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+	return rows
+}
+
+// FindPolicyVersions returns all versions of a policy in version order.
+func (t *TrustManager) FindPolicyVersions(name string) ([]typdefs.PolicyRow, error) {
+	rows, err := t.store.FindPolicies()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]typdefs.PolicyRow, 0, len(rows))
+	for _, row := range rows {
+		if row.Name == name {
+			res = append(res, row)
+		}
+	}
+	if len(res) == 0 {
+		return nil, ErrPolicyNotFound
+	}
+	return res, nil
+}
+
+// DeletePolicy deletes all versions of a policy.
+func (t *TrustManager) DeletePolicy(name string) error {
+	t.policyMu.Lock()
+	defer t.policyMu.Unlock()
+	if _, ok := t.policies[name]; !ok {
+		return ErrPolicyNotFound
+	}
+	err := t.store.DeletePolicyByName(name)
+	if err != nil {
+		return err
+	}
+	delete(t.policies, name)
+	return nil
+}
+
+// selectPolicies returns the latest enabled policies which select the
+// client of cache c by its registered client info, sorted by name.
+func (t *TrustManager) selectPolicies(c *cache.Cache) []*policyEntry {
+	t.policyMu.RLock()
+	all := make([]*policyEntry, 0, len(t.policies))
+	for _, e := range t.policies {
+		if e.row.Enabled {
+			all = append(all, e)
+		}
+	}
+	t.policyMu.RUnlock()
+	if len(all) == 0 {
+		return nil
+	}
+	info := c.GetClientInfo()
+	var res []*policyEntry
+	for _, e := range all {
+		if e.selector == nil || containsClientInfo(info, e.selector) {
+			res = append(res, e)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].row.Name < res[j].row.Name })
+	return res
+}
+
+// evaluatePolicies evaluates all rules of the policies which select the
+// client against report, the effective host base value of the client is
+// used by the rules which need it. It returns nil if no policy selects
+// the client.
+func (t *TrustManager) evaluatePolicies(c *cache.Cache, report *typdefs.TrustReport) (*typdefs.PolicyVerdict, error) {
+	entries := t.selectPolicies(c)
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	in := &policyInput{report: report, base: t.effectiveHostBase(c)}
+	v := typdefs.NewPolicyVerdict()
+	if in.base != nil {
+		v.BaseID = in.base.ID
+	}
+	for _, e := range entries {
+		for i := range e.policy.Rules {
+			r := &e.policy.Rules[i]
+			v.AddResult(e.row.Name, e.row.Version, r, in.evaluate(r))
+		}
+	}
+	return v, nil
+}
+
+// refValues returns the reference values of the base value.
+func (in *policyInput) refValues() (*typdefs.RefValues, error) {
+	if in.base == nil {
+		return nil, fmt.Errorf("no enabled host base value")
+	}
+	if in.rv == nil && in.rvErr == nil {
+		in.rv, in.rvErr = GetRefValues(in.base)
+		if in.rvErr != nil {
+			in.rvErr = fmt.Errorf("base value format wrong, error: %w", in.rvErr)
+		}
+	}
+	return in.rv, in.rvErr
+}
+
+// evaluate returns nil if the report satisfies the rule.
+func (in *policyInput) evaluate(r *typdefs.PolicyRule) error {
+	switch r.Type {
+	case typdefs.RuleSecureBoot:
+		state, err := replaySecureBoot(in.report)
+		if err != nil {
+			return err
+		}
+		if !state.Enabled {
+			return fmt.Errorf("secure boot is disabled")
+		}
+		return nil
+	case typdefs.RuleBiosPcr:
+		return checkBiosPcrs(in.report)
+	case typdefs.RuleClientInfo:
+		return checkClientInfo(in.report.ClientInfo, r.Field, r.Pattern)
+	}
+	rv, err := in.refValues()
+	if err != nil {
+		return err
+	}
+	switch r.Type {
+	case typdefs.RulePcr:
+		return verifyPcrs(in.report, rv, r.Pcrs)
+	case typdefs.RuleBios:
+		return verifyBIOS(in.report, rv)
+	case typdefs.RuleIma:
+		return verifyIMA(in.report, in.base.ImaMode, rv)
+	case typdefs.RuleImaUnknown:
+		return checkImaUnknown(in.report, rv.Ima, r.Paths)
+	}
+	return fmt.Errorf("unknown rule type %s", r.Type)
+}
+
+// verifyPcrs checks the pcrs, or all pcrs of reference values if pcrs is
+// empty, match the reference values, each pcr must have one.
+func verifyPcrs(report *typdefs.TrustReport, rv *typdefs.RefValues, pcrs []int) error {
+	if len(pcrs) == 0 {
+		return verifyPCR(report, rv)
+	}
+	sub := &typdefs.RefValues{Version: rv.Version}
+	for _, n := range pcrs {
+		found := false
+		for _, ref := range rv.Pcr {
+			if ref.Name == strconv.Itoa(n) {
+				sub.Pcr = append(sub.Pcr, ref)
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("pcr %d has no reference value", n)
+		}
+	}
+	return verifyPCR(report, sub)
+}
+
+// checkImaUnknown checks the files in ima log which aren't in the ima
+// reference values are all under the allowed paths.
+func checkImaUnknown(report *typdefs.TrustReport, refs []typdefs.RefValue, paths []string) error {
+	var unknown []string
+	imaLog := findManifest(report, typdefs.StrIma)
+	for _, ln := range bytes.Split(imaLog, typdefs.NewLine) {
+		e, err := typdefs.ParseImaEntry(ln)
+		if err != nil || e.IsViolation() || e.FileName == typdefs.StrBootAggr {
+			continue
+		}
+		if isKnownFile(refs, e.FileName) || isPathAllowed(paths, e.FileName) {
+			continue
+		}
+		unknown = append(unknown, e.FileName)
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	if len(unknown) > maxUnknownFiles {
+		return fmt.Errorf("unknown files: %s and %d more", strings.Join(unknown[:maxUnknownFiles], ", "),
+			len(unknown)-maxUnknownFiles)
+	}
+	return fmt.Errorf("unknown files: %s", strings.Join(unknown, ", "))
+}
+
+func isKnownFile(refs []typdefs.RefValue, name string) bool {
+	for i := range refs {
+		if refs[i].MatchName(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPathAllowed checks the file is one of paths, under one of them or
+// matches one of them as glob.
+func isPathAllowed(paths []string, name string) bool {
+	for _, p := range paths {
+		if name == p || strings.HasPrefix(name, strings.TrimSuffix(p, "/")+"/") {
+			return true
+		}
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkClientInfo checks the field of json client info matches pattern,
+// the field is a dot separated path of object keys, and the value which
+// isn't a string is matched in json.
+func checkClientInfo(info, field, pattern string) error {
+	var v interface{}
+	err := json.Unmarshal([]byte(info), &v)
+	if err != nil {
+		return fmt.Errorf("client info is not json")
+	}
+	for _, k := range strings.Split(field, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("client info %s not found", field)
+		}
+		if v, ok = m[k]; !ok {
+			return fmt.Errorf("client info %s not found", field)
+		}
+	}
+	s, ok := v.(string)
+	if !ok {
+		buf, _ := json.Marshal(v)
+		s = string(buf)
+	}
+	matched, err := regexp.MatchString(pattern, s)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return fmt.Errorf("client info %s %q doesn't match %q", field, s, pattern)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package trustmgr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+func TestPolicyManage(t *testing.T) {
+	s := NewMemoryStore()
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	p, _ := typdefs.ParsePolicy([]byte(`{"name":"p1","enabled":true,"rules":[` +
+		`{"name":"r","type":"bios","severity":"fail"}]}`))
+	for i := 1; i <= 2; i++ {
+		row, err := tm.SavePolicy(p)
+		if err != nil || row.Version != i || row.ID == 0 {
+			t.Fatalf("test SavePolicy error at version %d, %v\n", i, err)
+		}
+	}
+	p.Name = "p0"
+	tm.SavePolicy(p)
+	if _, err = tm.SavePolicy(&typdefs.Policy{Name: "p2"}); !errors.Is(err, typdefs.ErrPolicyWrong) {
+		t.Errorf("test SavePolicy wrong policy error, %v", err)
+	}
+	ps := tm.GetPolicies()
+	if len(ps) != 2 || ps[0].Name != "p0" || ps[1].Name != "p1" || ps[1].Version != 2 {
+		t.Errorf("test GetPolicies error, %v", ps)
+	}
+	vs, err := tm.FindPolicyVersions("p1")
+	if err != nil || len(vs) != 2 || vs[0].Version != 1 {
+		t.Errorf("test FindPolicyVersions error, %v %v", vs, err)
+	}
+	p2, err := PolicyFromRow(&vs[1])
+	if err != nil || p2.Name != "p1" || !p2.Enabled || len(p2.Rules) != 1 {
+		t.Errorf("test PolicyFromRow error, %v %v", p2, err)
+	}
+	tm.Close()
+
+	// the latest versions are loaded by a new trust manager.
+	tm, err = New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	p.Name = "p1"
+	row, err := tm.SavePolicy(p)
+	if err != nil || row.Version != 3 {
+		t.Errorf("test SavePolicy after reload error, %v %v", row, err)
+	}
+	if err = tm.DeletePolicy("p1"); err != nil {
+		t.Errorf("test DeletePolicy error, %v", err)
+	}
+	if err = tm.DeletePolicy("p1"); err != ErrPolicyNotFound {
+		t.Errorf("test DeletePolicy again error, %v", err)
+	}
+	if _, err = tm.FindPolicyVersions("p1"); err != ErrPolicyNotFound {
+		t.Errorf("test FindPolicyVersions of deleted policy error, %v", err)
+	}
+}
+
+func TestEvaluatePolicies(t *testing.T) {
+	const (
+		h1 = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+		h2 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	)
+	s := NewMemoryStore()
+	for _, info := range []string{`{"os":"openEuler"}`, `{"os":"ubuntu"}`} {
+		s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), Info: info})
+	}
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	c, _ := tm.GetCache(1)
+	report := &typdefs.TrustReport{ClientID: 1, ClientInfo: `{"os":"openEuler","kernel":{"ver":"5.10"}}`,
+		Manifests: []typdefs.Manifest{
+			{Key: typdefs.StrPcr, Value: []byte(h1 + " sha256 0\n" + h2 + " sha256 7\n")},
+			{Key: typdefs.StrIma, Value: []byte(
+				"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:" + h1 + " /usr/bin/ls\n" +
+					"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:" + h2 + " /tmp/a/b\n")},
+		}}
+
+	// no policy.
+	v, err := tm.evaluatePolicies(c, report)
+	if err != nil || v != nil {
+		t.Errorf("test evaluatePolicies without policy error, %v %v", v, err)
+	}
+	rule := func(name, typ, extra string) string {
+		return `{"name":"` + name + `","type":"` + typ + `","severity":"fail"` + extra + `}`
+	}
+	testCases := []struct {
+		policy string
+		result string
+	}{
+		// the rules which need base value fail without it.
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "pcr", "") + `]}`, typdefs.VerdictFail},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "clientinfo", `,"field":"kernel.ver","pattern":"^5\\."`) + `]}`,
+			typdefs.VerdictPass},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "clientinfo", `,"field":"os","pattern":"ubuntu"`) + `]}`,
+			typdefs.VerdictFail},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "clientinfo", `,"field":"os.type","pattern":"."`) + `]}`,
+			typdefs.VerdictFail},
+		// the disabled policy and the policy of other group are ignored.
+		{`{"name":"p","rules":[` + rule("r", "clientinfo", `,"field":"os","pattern":"ubuntu"`) + `]}`, ""},
+		{`{"name":"p","enabled":true,"selector":{"os":"ubuntu"},"rules":[` +
+			rule("r", "clientinfo", `,"field":"os","pattern":"ubuntu"`) + `]}`, ""},
+		{`{"name":"p","enabled":true,"selector":{"os":"openEuler"},"rules":[` +
+			`{"name":"r","type":"clientinfo","severity":"warn","field":"os","pattern":"ubuntu"}]}`, typdefs.VerdictWarn},
+	}
+	for i, tc := range testCases {
+		p, err := typdefs.ParsePolicy([]byte(tc.policy))
+		if err != nil {
+			t.Fatalf("test ParsePolicy error at case %d, %v\n", i, err)
+		}
+		tm.SavePolicy(p)
+		v, err = tm.evaluatePolicies(c, report)
+		if err != nil || (v == nil) != (tc.result == "") || (v != nil && v.Result != tc.result) {
+			t.Errorf("test evaluatePolicies error at case %d, %+v %v\n", i, v, err)
+		}
+	}
+
+	c.UpdateBase(&typdefs.BaseRow{ClientID: 1, BaseType: "host", Uuid: "old", Enabled: true,
+		CreateTime: time.Now().Add(-time.Hour), Pcr: "0:" + h2 + "\n"})
+	c.UpdateBase(&typdefs.BaseRow{ClientID: 1, BaseType: "host", Uuid: "disabled",
+		CreateTime: time.Now().Add(time.Hour), Pcr: "0:" + h2 + "\n"})
+	c.UpdateBase(&typdefs.BaseRow{ClientID: 1, BaseType: "host", Uuid: "new", Enabled: true,
+		CreateTime: time.Now(), Pcr: "0:" + h1 + "\n7:" + h1 + "\n",
+		Ima: "ima-ng sha256:" + h1 + " /usr/bin/ls\n"})
+	testCases = []struct {
+		policy string
+		result string
+	}{
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "pcr", `,"pcrs":[0]`) + `]}`, typdefs.VerdictPass},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "pcr", `,"pcrs":[0,7]`) + `]}`, typdefs.VerdictFail},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "pcr", `,"pcrs":[0,4]`) + `]}`, typdefs.VerdictFail},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "ima", "") + `]}`, typdefs.VerdictPass},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "imaunknown", `,"paths":["/tmp"]`) + `]}`, typdefs.VerdictPass},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "imaunknown", `,"paths":["/tmp/*/b"]`) + `]}`, typdefs.VerdictPass},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "imaunknown", `,"paths":["/tmp/*"]`) + `]}`, typdefs.VerdictFail},
+		{`{"name":"p","enabled":true,"rules":[` + rule("r", "imaunknown", `,"paths":["/var"]`) + `]}`, typdefs.VerdictFail},
+	}
+	for i, tc := range testCases {
+		p, _ := typdefs.ParsePolicy([]byte(tc.policy))
+		tm.SavePolicy(p)
+		v, err = tm.evaluatePolicies(c, report)
+		if err != nil || v == nil || v.Result != tc.result {
+			t.Errorf("test evaluatePolicies with base value error at case %d, %+v %v\n", i, v, err)
+		}
+	}
+
+	// all rules of all selected policies are in verdict.
+	p, _ := typdefs.ParsePolicy([]byte(`{"name":"q","enabled":true,"selector":{"os":"openEuler"},"rules":[` +
+		rule("r1", "pcr", `,"pcrs":[0]`) + `,` + `{"name":"r2","type":"imaunknown","severity":"info","paths":["/var"]}]}`))
+	tm.SavePolicy(p)
+	v, err = tm.evaluatePolicies(c, report)
+	if err != nil || v == nil || v.Result != typdefs.VerdictFail || len(v.Rules) != 3 ||
+		v.Rules[0].Policy != "p" || v.Rules[1].Rule != "r1" || !v.Rules[1].Passed ||
+		v.Rules[2].Passed || !strings.Contains(v.Rules[2].Detail, "/tmp/a/b") {
+		t.Errorf("test evaluatePolicies verdict error, %+v %v", v, err)
+	}
+	if fs := v.FailedRules(); len(fs) != 1 || fs[0] != "p/r" {
+		t.Errorf("test FailedRules error, %v", fs)
+	}
+}
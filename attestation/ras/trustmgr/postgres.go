@@ -0,0 +1,384 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: postgres implementation of the trust manager store.
+*/
+
+package trustmgr
+
+import (
+	"database/sql"
+	"fmt"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	_ "github.com/lib/pq"
+)
+
+const (
+	sqlPgRegisterClientByIK = `INSERT INTO client(regtime, deleted, info, ikcert) VALUES ($1, $2, $3, $4) RETURNING id`
+	sqlPgFindClientsByInfo  = `SELECT id, regtime, deleted, info, ikcert FROM client WHERE info @> $1`
+	sqlPgInsertPolicy       = sqlInsertPolicy + ` RETURNING id`
+	sqlPgInsertGroup        = sqlInsertGroup + ` RETURNING id`
+	sqlPgInsertMaintWindow  = sqlInsertMaintWindow + ` RETURNING id`
+
+	strPostgresDSN = "user=%s password=%s dbname=%s host=%s port=%d sslmode=disable"
+)
+
+var (
+	// all schema migrations of postgres, never change an existing one,
+	// append a new one with the next version instead.
+	pgMigrations = []migration{
+		{
+			version: 1,
+			name:    "create client, report and base tables",
+			up: []string{
+				`CREATE TABLE IF NOT EXISTS client (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    regtime TIMESTAMPTZ,
+    deleted BOOLEAN,
+    info JSONB,
+    ikcert TEXT
+)`,
+				`CREATE TABLE IF NOT EXISTS report (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    clientid BIGINT,
+    createtime TIMESTAMPTZ,
+    validated BOOLEAN,
+    trusted BOOLEAN,
+    quoted TEXT,
+    signature TEXT,
+    pcrlog TEXT,
+    bioslog TEXT,
+    imalog TEXT
+)`,
+				`CREATE TABLE IF NOT EXISTS base (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    clientid BIGINT,
+    basetype CHAR(10),
+    uuid CHAR(64),
+    createtime TIMESTAMPTZ,
+    enabled BOOLEAN,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT
+)`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS base`,
+				`DROP TABLE IF EXISTS report`,
+				`DROP TABLE IF EXISTS client`,
+			},
+		},
+		{
+			version: 2,
+			name:    "add base verified/trusted columns, foreign keys and indexes",
+			up: []string{
+				`ALTER TABLE base ADD COLUMN verified BOOLEAN DEFAULT false`,
+				`ALTER TABLE base ADD COLUMN trusted BOOLEAN DEFAULT false`,
+				`ALTER TABLE report ADD CONSTRAINT fk_report_client FOREIGN KEY (clientid) REFERENCES client(id) ON DELETE CASCADE`,
+				`ALTER TABLE base ADD CONSTRAINT fk_base_client FOREIGN KEY (clientid) REFERENCES client(id) ON DELETE CASCADE`,
+				// ik certificate may be longer than btree index limitation.
+				`CREATE INDEX idx_client_ikcert ON client USING hash (ikcert)`,
+				`CREATE INDEX idx_report_clientid ON report(clientid)`,
+				`CREATE INDEX idx_base_clientid ON base(clientid)`,
+				`CREATE INDEX idx_base_uuid ON base(uuid)`,
+			},
+			down: []string{
+				`DROP INDEX IF EXISTS idx_base_uuid`,
+				`DROP INDEX IF EXISTS idx_base_clientid`,
+				`DROP INDEX IF EXISTS idx_report_clientid`,
+				`DROP INDEX IF EXISTS idx_client_ikcert`,
+				`ALTER TABLE base DROP CONSTRAINT IF EXISTS fk_base_client`,
+				`ALTER TABLE report DROP CONSTRAINT IF EXISTS fk_report_client`,
+				`ALTER TABLE base DROP COLUMN IF EXISTS trusted`,
+				`ALTER TABLE base DROP COLUMN IF EXISTS verified`,
+			},
+		},
+		{
+			version: 3,
+			name:    "add base ima verification mode column",
+			up: []string{
+				`ALTER TABLE base ADD COLUMN imamode TEXT DEFAULT ''`,
+			},
+			down: []string{
+				`ALTER TABLE base DROP COLUMN IF EXISTS imamode`,
+			},
+		},
+		{
+			version: 4,
+			name:    "add report secure boot column",
+			up: []string{
+				`ALTER TABLE report ADD COLUMN secureboot TEXT DEFAULT ''`,
+			},
+			down: []string{
+				`ALTER TABLE report DROP COLUMN IF EXISTS secureboot`,
+			},
+		},
+		{
+			version: 5,
+			name:    "add base typed reference value column",
+			up: []string{
+				`ALTER TABLE base ADD COLUMN refvalue TEXT DEFAULT ''`,
+			},
+			down: []string{
+				`ALTER TABLE base DROP COLUMN IF EXISTS refvalue`,
+			},
+		},
+		{
+			version: 6,
+			name:    "add policy table and report verdict column",
+			up: []string{
+				`CREATE TABLE policy (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    name TEXT,
+    version INTEGER,
+    createtime TIMESTAMPTZ,
+    enabled BOOLEAN,
+    selector TEXT,
+    rules TEXT
+)`,
+				`CREATE UNIQUE INDEX idx_policy_name_version ON policy(name, version)`,
+				`ALTER TABLE report ADD COLUMN verdict TEXT DEFAULT ''`,
+			},
+			down: []string{
+				`ALTER TABLE report DROP COLUMN IF EXISTS verdict`,
+				`DROP TABLE IF EXISTS policy`,
+			},
+		},
+		{
+			version: 7,
+			name:    "add report_result table",
+			up: []string{
+				`CREATE TABLE report_result (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    reportid BIGINT REFERENCES report(id) ON DELETE CASCADE,
+    checkname TEXT,
+    baseid BIGINT,
+    passed BOOLEAN,
+    detail TEXT,
+    mismatches TEXT
+)`,
+				`CREATE INDEX idx_report_result_reportid ON report_result(reportid)`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS report_result`,
+			},
+		},
+		{
+			version: 8,
+			name:    "add ima_state table",
+			up: []string{
+				`CREATE TABLE ima_state (
+    clientid BIGINT PRIMARY KEY NOT NULL REFERENCES client(id) ON DELETE CASCADE,
+    updatetime TIMESTAMPTZ,
+    entries BIGINT,
+    alg TEXT,
+    pcr TEXT
+)`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS ima_state`,
+			},
+		},
+		{
+			version: 9,
+			name:    "add base lifecycle state, version and author columns",
+			up: []string{
+				`ALTER TABLE base ADD COLUMN state TEXT DEFAULT ''`,
+				`ALTER TABLE base ADD COLUMN version INTEGER DEFAULT 0`,
+				`ALTER TABLE base ADD COLUMN author TEXT DEFAULT ''`,
+				`ALTER TABLE base ADD COLUMN updatetime TIMESTAMPTZ`,
+				`UPDATE base SET state=CASE WHEN enabled THEN 'active' ELSE 'draft' END, updatetime=createtime, version=(SELECT COUNT(*) FROM base b WHERE b.clientid=base.clientid AND b.basetype=base.basetype AND b.id<=base.id)`,
+				`UPDATE base SET state='retired', enabled=false WHERE basetype='host' AND state='active' AND id<(SELECT MAX(b.id) FROM base b WHERE b.clientid=base.clientid AND b.basetype='host' AND b.state='active')`,
+				`CREATE UNIQUE INDEX idx_base_active_host ON base(clientid) WHERE basetype='host' AND state='active'`,
+			},
+			down: []string{
+				`DROP INDEX IF EXISTS idx_base_active_host`,
+				`ALTER TABLE base DROP COLUMN IF EXISTS updatetime`,
+				`ALTER TABLE base DROP COLUMN IF EXISTS author`,
+				`ALTER TABLE base DROP COLUMN IF EXISTS version`,
+				`ALTER TABLE base DROP COLUMN IF EXISTS state`,
+			},
+		},
+		{
+			version: 10,
+			name:    "add node_group table and base group column",
+			up: []string{
+				`CREATE TABLE node_group (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    name TEXT UNIQUE,
+    createtime TIMESTAMPTZ,
+    priority INTEGER,
+    selector TEXT
+)`,
+				`ALTER TABLE base ADD COLUMN groupid BIGINT REFERENCES node_group(id) ON DELETE CASCADE`,
+				`CREATE INDEX idx_base_groupid ON base(groupid)`,
+				`CREATE UNIQUE INDEX idx_base_active_group ON base(groupid) WHERE basetype='host' AND state='active' AND groupid IS NOT NULL`,
+			},
+			down: []string{
+				`DELETE FROM base WHERE groupid IS NOT NULL`,
+				`DROP INDEX IF EXISTS idx_base_active_group`,
+				`DROP INDEX IF EXISTS idx_base_groupid`,
+				`ALTER TABLE base DROP COLUMN IF EXISTS groupid`,
+				`DROP TABLE IF EXISTS node_group`,
+			},
+		},
+		{
+			version: 11,
+			name:    "add maint_window table and base window column",
+			up: []string{
+				`CREATE TABLE maint_window (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    groupid BIGINT REFERENCES node_group(id) ON DELETE CASCADE,
+    starttime TIMESTAMPTZ,
+    endtime TIMESTAMPTZ,
+    parts TEXT,
+    author TEXT,
+    createtime TIMESTAMPTZ,
+    closedby TEXT DEFAULT ''
+)`,
+				`ALTER TABLE base ADD COLUMN windowid BIGINT REFERENCES maint_window(id) ON DELETE SET NULL`,
+				`CREATE INDEX idx_base_windowid ON base(windowid)`,
+			},
+			down: []string{
+				`DROP INDEX IF EXISTS idx_base_windowid`,
+				`ALTER TABLE base DROP COLUMN IF EXISTS windowid`,
+				`DROP TABLE IF EXISTS maint_window`,
+			},
+		},
+		{
+			version: 12,
+			name:    "add trust_history table",
+			up: []string{
+				`CREATE TABLE trust_history (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    entitytype TEXT,
+    uuid TEXT DEFAULT '',
+    fromstate TEXT,
+    tostate TEXT,
+    reason TEXT,
+    detail TEXT DEFAULT '',
+    createtime TIMESTAMPTZ
+)`,
+				`CREATE INDEX idx_trust_history_clientid ON trust_history(clientid)`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS trust_history`,
+			},
+		},
+		{
+			version: 13,
+			name:    "add ima_state tpm reset count column",
+			up: []string{
+				`ALTER TABLE ima_state ADD COLUMN resetcount BIGINT DEFAULT 0`,
+			},
+			down: []string{
+				`ALTER TABLE ima_state DROP COLUMN IF EXISTS resetcount`,
+			},
+		},
+		{
+			version: 14,
+			name:    "refuse deleting node groups with base values or windows",
+			up: []string{
+				`ALTER TABLE base DROP CONSTRAINT IF EXISTS base_groupid_fkey`,
+				`ALTER TABLE base ADD CONSTRAINT base_groupid_fkey FOREIGN KEY (groupid) REFERENCES node_group(id)`,
+				`ALTER TABLE maint_window DROP CONSTRAINT IF EXISTS maint_window_groupid_fkey`,
+				`ALTER TABLE maint_window ADD CONSTRAINT maint_window_groupid_fkey FOREIGN KEY (groupid) REFERENCES node_group(id)`,
+			},
+			down: []string{
+				`ALTER TABLE base DROP CONSTRAINT IF EXISTS base_groupid_fkey`,
+				`ALTER TABLE base ADD CONSTRAINT base_groupid_fkey FOREIGN KEY (groupid) REFERENCES node_group(id) ON DELETE CASCADE`,
+				`ALTER TABLE maint_window DROP CONSTRAINT IF EXISTS maint_window_groupid_fkey`,
+				`ALTER TABLE maint_window ADD CONSTRAINT maint_window_groupid_fkey FOREIGN KEY (groupid) REFERENCES node_group(id) ON DELETE CASCADE`,
+			},
+		},
+		{
+			version: 15,
+			name:    "keep maintenance windows of base values",
+			up: []string{
+				`ALTER TABLE base DROP CONSTRAINT IF EXISTS base_windowid_fkey`,
+				`ALTER TABLE base ADD CONSTRAINT base_windowid_fkey FOREIGN KEY (windowid) REFERENCES maint_window(id)`,
+			},
+			down: []string{
+				`ALTER TABLE base DROP CONSTRAINT IF EXISTS base_windowid_fkey`,
+				`ALTER TABLE base ADD CONSTRAINT base_windowid_fkey FOREIGN KEY (windowid) REFERENCES maint_window(id) ON DELETE SET NULL`,
+			},
+		},
+	}
+)
+
+type (
+	// PostgresStore saves clients, reports and base values into postgres database.
+	PostgresStore struct {
+		sqlStore
+	}
+)
+
+// GetPostgresDSN returns the postgres connection string made by parameters.
+func GetPostgresDSN(host string, port int, user, password, dbname string) string {
+	return fmt.Sprintf(strPostgresDSN, user, password, dbname, host, port)
+}
+
+// NewPostgresStore opens a postgres database connection pool by dsn,
+// the schema is checked and migrated by trust manager or ras migrate.
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresStore{sqlStore{db: db, migrations: pgMigrations, returning: true, rowLocks: true}}, nil
+}
+
+// RegisterClient inserts a new client and sets its id.
+func (s *PostgresStore) RegisterClient(c *typdefs.ClientRow) error {
+	return s.db.QueryRow(sqlPgRegisterClientByIK, c.RegTime,
+		c.Deleted, c.Info, c.IKCert).Scan(&c.ID)
+}
+
+// InsertPolicy inserts a policy version and sets its id.
+func (s *PostgresStore) InsertPolicy(p *typdefs.PolicyRow) error {
+	return s.db.QueryRow(sqlPgInsertPolicy, p.Name, p.Version,
+		p.CreateTime, p.Enabled, p.Selector, p.Rules).Scan(&p.ID)
+}
+
+// InsertGroup inserts a node group and sets its id.
+func (s *PostgresStore) InsertGroup(g *typdefs.GroupRow) error {
+	return s.db.QueryRow(sqlPgInsertGroup, g.Name, g.CreateTime,
+		g.Priority, g.Selector).Scan(&g.ID)
+}
+
+// InsertMaintWindow inserts a maintenance window and sets its id.
+func (s *PostgresStore) InsertMaintWindow(w *typdefs.MaintWindowRow) error {
+	return s.db.QueryRow(sqlPgInsertMaintWindow, nullID(w.ClientID), nullID(w.GroupID),
+		w.StartTime, w.EndTime, w.Parts, w.Author, w.CreateTime, w.ClosedBy).Scan(&w.ID)
+}
+
+// FindClientsByInfo gets clients from database ref by info,
+// info must be a json string like `{"key": "value"}`.
+func (s *PostgresStore) FindClientsByInfo(info string) ([]typdefs.ClientRow, error) {
+	rows, err := s.db.Query(sqlPgFindClientsByInfo, info)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cs := make([]typdefs.ClientRow, 0, 10)
+	for rows.Next() {
+		c := typdefs.ClientRow{}
+		err2 := rows.Scan(&c.ID, &c.RegTime, &c.Deleted, &c.Info, &c.IKCert)
+		if err2 != nil {
+			return nil, err2
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
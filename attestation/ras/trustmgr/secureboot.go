@@ -0,0 +1,157 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: check the secure boot state of report against the policy.
+*/
+
+package trustmgr
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+// SecureBootError lists the reasons why the secure boot state of report
+// doesn't satisfy the policy.
+type SecureBootError struct {
+	Reasons []string
+}
+
+func (e *SecureBootError) Error() string {
+	return "secure boot check failed: " + strings.Join(e.Reasons, "; ")
+}
+
+// Unwrap makes errors.Is(err, typdefs.ErrSecureBootFail) work.
+func (e *SecureBootError) Unwrap() error {
+	return typdefs.ErrSecureBootFail
+}
+
+// secureBootResult is saved in the secureboot column of report.
+type secureBootResult struct {
+	State   *typdefs.SecureBootState `json:"state,omitempty"`
+	Reasons []string                 `json:"reasons,omitempty"`
+}
+
+// checkSecureBoot replays the pcr 7 events of bios log, checks the replayed
+// pcr 7 matches the quoted pcr log, and checks the secure boot state by policy.
+// The empty policy never fails, the state is just recorded if possible.
+func checkSecureBoot(policy *typdefs.SecureBootPolicy, report *typdefs.TrustReport, row *typdefs.ReportRow) error {
+	res := secureBootResult{}
+	state, err := replaySecureBoot(report)
+	if err != nil {
+		res.Reasons = append(res.Reasons, err.Error())
+	} else {
+		res.State = state
+		if !policy.IsEmpty() {
+			res.Reasons = policyReasons(policy, state)
+		}
+	}
+	buf, _ := json.Marshal(res)
+	row.SecureBoot = string(buf)
+	if policy.IsEmpty() || len(res.Reasons) == 0 {
+		return nil
+	}
+	return &SecureBootError{Reasons: res.Reasons}
+}
+
+// replaySecureBoot gets the secure boot state from bios log of report.
+func replaySecureBoot(report *typdefs.TrustReport) (*typdefs.SecureBootState, error) {
+	events, err := typdefs.ParseBiosEventLog(findManifest(report, typdefs.StrBios))
+	if err != nil {
+		return nil, fmt.Errorf("decode bios log: %v", err)
+	}
+	state, err := typdefs.ReplaySecureBoot(events)
+	if err != nil {
+		return nil, fmt.Errorf("replay pcr %d: %v", typdefs.SecureBootPcr, err)
+	}
+	sels, err := getQuotedSelections(report)
+	if err != nil {
+		return nil, err
+	}
+	// like checkBiosPcrs, the banks which aren't quoted are skipped, but at
+	// least one of them must be quoted and match.
+	pcrs, _ := pcrLogToBankMaps(findManifest(report, typdefs.StrPcr))
+	matched := false
+	for alg, v := range state.Pcr7 {
+		want, ok := pcrs[alg][typdefs.SecureBootPcr]
+		if !ok || !isPcrQuoted(sels, alg, typdefs.SecureBootPcr) {
+			continue
+		}
+		if !strings.EqualFold(want, hex.EncodeToString(v)) {
+			return nil, fmt.Errorf("replayed %s pcr %d doesn't match pcr log", alg, typdefs.SecureBootPcr)
+		}
+		matched = true
+	}
+	if !matched {
+		return nil, fmt.Errorf("no quoted pcr %d bank of bios log", typdefs.SecureBootPcr)
+	}
+	return state, nil
+}
+
+// policyReasons returns all the policy requirements which the state
+// doesn't satisfy.
+func policyReasons(policy *typdefs.SecureBootPolicy, state *typdefs.SecureBootState) []string {
+	var reasons []string
+	if policy.Required && !state.Enabled {
+		reasons = append(reasons, "secure boot is disabled")
+	}
+	if len(policy.PKOwners) > 0 {
+		if s := checkSigOwners(state.PK, policy.PKOwners); s != "" {
+			reasons = append(reasons, typdefs.EfiVarPK+" "+s)
+		}
+	}
+	if len(policy.KEKOwners) > 0 {
+		if s := checkSigOwners(state.KEK, policy.KEKOwners); s != "" {
+			reasons = append(reasons, typdefs.EfiVarKEK+" "+s)
+		}
+	}
+	revoked := make(map[string]bool, len(state.Dbx))
+	for _, sig := range state.Dbx {
+		revoked[hex.EncodeToString(sig.Digest)] = true
+	}
+	for _, h := range policy.DbxHashes {
+		if !revoked[strings.ToLower(h)] {
+			reasons = append(reasons, fmt.Sprintf("%s is not revoked in %s", h, typdefs.EfiVarDbx))
+		}
+	}
+	return reasons
+}
+
+// checkSigOwners checks every signature is owned by one of owners, which
+// are owner guids, certificate subjects or common names.
+func checkSigOwners(sigs []typdefs.EfiSignature, owners []string) string {
+	if len(sigs) == 0 {
+		return "is empty"
+	}
+	for _, sig := range sigs {
+		if !matchSigOwner(&sig, owners) {
+			name := sig.CommonName
+			if name == "" {
+				name = sig.Owner
+			}
+			return fmt.Sprintf("owner %s is not allowed", name)
+		}
+	}
+	return ""
+}
+
+func matchSigOwner(sig *typdefs.EfiSignature, owners []string) bool {
+	for _, o := range owners {
+		if strings.EqualFold(o, sig.Owner) || (sig.Subject != "" && o == sig.Subject) ||
+			(sig.CommonName != "" && o == sig.CommonName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,603 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: embedded sqlite implementation of the trust manager store.
+*/
+
+package trustmgr
+
+import (
+	"database/sql"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	sqliteDriver            = "sqlite3"
+	sqliteOptions           = "?_busy_timeout=5000&_foreign_keys=1"
+	sqlSqliteRegisterClient = `INSERT INTO client(regtime, deleted, info, ikcert) VALUES ($1, $2, $3, $4)`
+	sqlSqliteFindAllClients = `SELECT id, regtime, deleted, info, ikcert FROM client`
+)
+
+var (
+	// all schema migrations of sqlite, never change an existing one,
+	// append a new one with the next version instead. sqlite can't add
+	// foreign key to an existing table, so rebuild the table for it.
+	sqliteMigrations = []migration{
+		{
+			version: 1,
+			name:    "create client, report and base tables",
+			up: []string{
+				`CREATE TABLE IF NOT EXISTS client (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    regtime TIMESTAMP,
+    deleted BOOLEAN,
+    info TEXT,
+    ikcert TEXT
+)`,
+				`CREATE TABLE IF NOT EXISTS report (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT,
+    createtime TIMESTAMP,
+    validated BOOLEAN,
+    trusted BOOLEAN,
+    quoted TEXT,
+    signature TEXT,
+    pcrlog TEXT,
+    bioslog TEXT,
+    imalog TEXT
+)`,
+				`CREATE TABLE IF NOT EXISTS base (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT,
+    basetype TEXT,
+    uuid TEXT,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT
+)`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS base`,
+				`DROP TABLE IF EXISTS report`,
+				`DROP TABLE IF EXISTS client`,
+			},
+		},
+		{
+			version: 2,
+			name:    "add base verified/trusted columns, foreign keys and indexes",
+			up: []string{
+				`CREATE TABLE report_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    createtime TIMESTAMP,
+    validated BOOLEAN,
+    trusted BOOLEAN,
+    quoted TEXT,
+    signature TEXT,
+    pcrlog TEXT,
+    bioslog TEXT,
+    imalog TEXT
+)`,
+				`INSERT INTO report_new SELECT id, clientid, createtime, validated, trusted, quoted, signature, pcrlog, bioslog, imalog FROM report`,
+				`DROP TABLE report`,
+				`ALTER TABLE report_new RENAME TO report`,
+				`CREATE TABLE base_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    basetype TEXT,
+    uuid TEXT,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    verified BOOLEAN DEFAULT false,
+    trusted BOOLEAN DEFAULT false,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT
+)`,
+				`INSERT INTO base_new(id, clientid, basetype, uuid, createtime, enabled, name, pcr, bios, ima) SELECT id, clientid, basetype, uuid, createtime, enabled, name, pcr, bios, ima FROM base`,
+				`DROP TABLE base`,
+				`ALTER TABLE base_new RENAME TO base`,
+				`CREATE INDEX idx_client_ikcert ON client(ikcert)`,
+				`CREATE INDEX idx_report_clientid ON report(clientid)`,
+				`CREATE INDEX idx_base_clientid ON base(clientid)`,
+				`CREATE INDEX idx_base_uuid ON base(uuid)`,
+			},
+			down: []string{
+				`DROP INDEX IF EXISTS idx_client_ikcert`,
+				`CREATE TABLE report_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT,
+    createtime TIMESTAMP,
+    validated BOOLEAN,
+    trusted BOOLEAN,
+    quoted TEXT,
+    signature TEXT,
+    pcrlog TEXT,
+    bioslog TEXT,
+    imalog TEXT
+)`,
+				`INSERT INTO report_old SELECT id, clientid, createtime, validated, trusted, quoted, signature, pcrlog, bioslog, imalog FROM report`,
+				`DROP TABLE report`,
+				`ALTER TABLE report_old RENAME TO report`,
+				`CREATE TABLE base_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT,
+    basetype TEXT,
+    uuid TEXT,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT
+)`,
+				`INSERT INTO base_old SELECT id, clientid, basetype, uuid, createtime, enabled, name, pcr, bios, ima FROM base`,
+				`DROP TABLE base`,
+				`ALTER TABLE base_old RENAME TO base`,
+			},
+		},
+		{
+			version: 3,
+			name:    "add base ima verification mode column",
+			up: []string{
+				`ALTER TABLE base ADD COLUMN imamode TEXT DEFAULT ''`,
+			},
+			down: []string{
+				`CREATE TABLE base_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    basetype TEXT,
+    uuid TEXT,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    verified BOOLEAN DEFAULT false,
+    trusted BOOLEAN DEFAULT false,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT
+)`,
+				`INSERT INTO base_old SELECT id, clientid, basetype, uuid, createtime, enabled, verified, trusted, name, pcr, bios, ima FROM base`,
+				`DROP TABLE base`,
+				`ALTER TABLE base_old RENAME TO base`,
+				`CREATE INDEX idx_base_clientid ON base(clientid)`,
+				`CREATE INDEX idx_base_uuid ON base(uuid)`,
+			},
+		},
+		{
+			version: 4,
+			name:    "add report secure boot column",
+			up: []string{
+				`ALTER TABLE report ADD COLUMN secureboot TEXT DEFAULT ''`,
+			},
+			down: []string{
+				`CREATE TABLE report_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    createtime TIMESTAMP,
+    validated BOOLEAN,
+    trusted BOOLEAN,
+    quoted TEXT,
+    signature TEXT,
+    pcrlog TEXT,
+    bioslog TEXT,
+    imalog TEXT
+)`,
+				`INSERT INTO report_old SELECT id, clientid, createtime, validated, trusted, quoted, signature, pcrlog, bioslog, imalog FROM report`,
+				`DROP TABLE report`,
+				`ALTER TABLE report_old RENAME TO report`,
+				`CREATE INDEX idx_report_clientid ON report(clientid)`,
+			},
+		},
+		{
+			version: 5,
+			name:    "add base typed reference value column",
+			up: []string{
+				`ALTER TABLE base ADD COLUMN refvalue TEXT DEFAULT ''`,
+			},
+			down: []string{
+				`CREATE TABLE base_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    basetype TEXT,
+    uuid TEXT,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    verified BOOLEAN DEFAULT false,
+    trusted BOOLEAN DEFAULT false,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT,
+    imamode TEXT DEFAULT ''
+)`,
+				`INSERT INTO base_old SELECT id, clientid, basetype, uuid, createtime, enabled, verified, trusted, name, pcr, bios, ima, imamode FROM base`,
+				`DROP TABLE base`,
+				`ALTER TABLE base_old RENAME TO base`,
+				`CREATE INDEX idx_base_clientid ON base(clientid)`,
+				`CREATE INDEX idx_base_uuid ON base(uuid)`,
+			},
+		},
+		{
+			version: 6,
+			name:    "add policy table and report verdict column",
+			up: []string{
+				`CREATE TABLE policy (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT,
+    version INTEGER,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    selector TEXT,
+    rules TEXT
+)`,
+				`CREATE UNIQUE INDEX idx_policy_name_version ON policy(name, version)`,
+				`ALTER TABLE report ADD COLUMN verdict TEXT DEFAULT ''`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS policy`,
+				`CREATE TABLE report_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    createtime TIMESTAMP,
+    validated BOOLEAN,
+    trusted BOOLEAN,
+    quoted TEXT,
+    signature TEXT,
+    pcrlog TEXT,
+    bioslog TEXT,
+    imalog TEXT,
+    secureboot TEXT DEFAULT ''
+)`,
+				`INSERT INTO report_old SELECT id, clientid, createtime, validated, trusted, quoted, signature, pcrlog, bioslog, imalog, secureboot FROM report`,
+				`DROP TABLE report`,
+				`ALTER TABLE report_old RENAME TO report`,
+				`CREATE INDEX idx_report_clientid ON report(clientid)`,
+			},
+		},
+		{
+			version: 7,
+			name:    "add report_result table",
+			up: []string{
+				`CREATE TABLE report_result (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    reportid BIGINT REFERENCES report(id) ON DELETE CASCADE,
+    checkname TEXT,
+    baseid BIGINT,
+    passed BOOLEAN,
+    detail TEXT,
+    mismatches TEXT
+)`,
+				`CREATE INDEX idx_report_result_reportid ON report_result(reportid)`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS report_result`,
+			},
+		},
+		{
+			version: 8,
+			name:    "add ima_state table",
+			up: []string{
+				`CREATE TABLE ima_state (
+    clientid BIGINT PRIMARY KEY NOT NULL REFERENCES client(id) ON DELETE CASCADE,
+    updatetime TIMESTAMP,
+    entries BIGINT,
+    alg TEXT,
+    pcr TEXT
+)`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS ima_state`,
+			},
+		},
+		{
+			version: 9,
+			name:    "add base lifecycle state, version and author columns",
+			up: []string{
+				`ALTER TABLE base ADD COLUMN state TEXT DEFAULT ''`,
+				`ALTER TABLE base ADD COLUMN version INTEGER DEFAULT 0`,
+				`ALTER TABLE base ADD COLUMN author TEXT DEFAULT ''`,
+				`ALTER TABLE base ADD COLUMN updatetime TIMESTAMP`,
+				`UPDATE base SET state=CASE WHEN enabled THEN 'active' ELSE 'draft' END, updatetime=createtime, version=(SELECT COUNT(*) FROM base b WHERE b.clientid=base.clientid AND b.basetype=base.basetype AND b.id<=base.id)`,
+				`UPDATE base SET state='retired', enabled=false WHERE basetype='host' AND state='active' AND id<(SELECT MAX(b.id) FROM base b WHERE b.clientid=base.clientid AND b.basetype='host' AND b.state='active')`,
+				`CREATE UNIQUE INDEX idx_base_active_host ON base(clientid) WHERE basetype='host' AND state='active'`,
+			},
+			down: []string{
+				`DROP INDEX IF EXISTS idx_base_active_host`,
+				`CREATE TABLE base_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    basetype TEXT,
+    uuid TEXT,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    verified BOOLEAN DEFAULT false,
+    trusted BOOLEAN DEFAULT false,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT,
+    imamode TEXT DEFAULT '',
+    refvalue TEXT DEFAULT ''
+)`,
+				`INSERT INTO base_old SELECT id, clientid, basetype, uuid, createtime, enabled, verified, trusted, name, pcr, bios, ima, imamode, refvalue FROM base`,
+				`DROP TABLE base`,
+				`ALTER TABLE base_old RENAME TO base`,
+				`CREATE INDEX idx_base_clientid ON base(clientid)`,
+				`CREATE INDEX idx_base_uuid ON base(uuid)`,
+			},
+		},
+		{
+			version: 10,
+			name:    "add node_group table and base group column",
+			up: []string{
+				`CREATE TABLE node_group (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT UNIQUE,
+    createtime TIMESTAMP,
+    priority INTEGER,
+    selector TEXT
+)`,
+				`ALTER TABLE base ADD COLUMN groupid BIGINT REFERENCES node_group(id) ON DELETE CASCADE`,
+				`CREATE INDEX idx_base_groupid ON base(groupid)`,
+				`CREATE UNIQUE INDEX idx_base_active_group ON base(groupid) WHERE basetype='host' AND state='active' AND groupid IS NOT NULL`,
+			},
+			down: []string{
+				`DROP INDEX IF EXISTS idx_base_active_group`,
+				`DROP INDEX IF EXISTS idx_base_groupid`,
+				`CREATE TABLE base_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    basetype TEXT,
+    uuid TEXT,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    verified BOOLEAN DEFAULT false,
+    trusted BOOLEAN DEFAULT false,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT,
+    imamode TEXT DEFAULT '',
+    refvalue TEXT DEFAULT '',
+    state TEXT DEFAULT '',
+    version INTEGER DEFAULT 0,
+    author TEXT DEFAULT '',
+    updatetime TIMESTAMP
+)`,
+				`INSERT INTO base_old SELECT id, clientid, basetype, uuid, createtime, enabled, verified, trusted, name, pcr, bios, ima, imamode, refvalue, state, version, author, updatetime FROM base WHERE groupid IS NULL`,
+				`DROP TABLE base`,
+				`ALTER TABLE base_old RENAME TO base`,
+				`CREATE INDEX idx_base_clientid ON base(clientid)`,
+				`CREATE INDEX idx_base_uuid ON base(uuid)`,
+				`CREATE UNIQUE INDEX idx_base_active_host ON base(clientid) WHERE basetype='host' AND state='active'`,
+				`DROP TABLE IF EXISTS node_group`,
+			},
+		},
+		{
+			version: 11,
+			name:    "add maint_window table and base window column",
+			up: []string{
+				`CREATE TABLE maint_window (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    groupid BIGINT REFERENCES node_group(id) ON DELETE CASCADE,
+    starttime TIMESTAMP,
+    endtime TIMESTAMP,
+    parts TEXT,
+    author TEXT,
+    createtime TIMESTAMP,
+    closedby TEXT DEFAULT ''
+)`,
+				`ALTER TABLE base ADD COLUMN windowid BIGINT REFERENCES maint_window(id) ON DELETE SET NULL`,
+				`CREATE INDEX idx_base_windowid ON base(windowid)`,
+			},
+			down: []string{
+				`DROP INDEX IF EXISTS idx_base_windowid`,
+				`DROP INDEX IF EXISTS idx_base_active_group`,
+				`DROP INDEX IF EXISTS idx_base_groupid`,
+				`CREATE TABLE base_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    basetype TEXT,
+    uuid TEXT,
+    createtime TIMESTAMP,
+    enabled BOOLEAN,
+    verified BOOLEAN DEFAULT false,
+    trusted BOOLEAN DEFAULT false,
+    name TEXT,
+    pcr TEXT,
+    bios TEXT,
+    ima TEXT,
+    imamode TEXT DEFAULT '',
+    refvalue TEXT DEFAULT '',
+    state TEXT DEFAULT '',
+    version INTEGER DEFAULT 0,
+    author TEXT DEFAULT '',
+    updatetime TIMESTAMP,
+    groupid BIGINT REFERENCES node_group(id) ON DELETE CASCADE
+)`,
+				`INSERT INTO base_old SELECT id, clientid, basetype, uuid, createtime, enabled, verified, trusted, name, pcr, bios, ima, imamode, refvalue, state, version, author, updatetime, groupid FROM base`,
+				`DROP TABLE base`,
+				`ALTER TABLE base_old RENAME TO base`,
+				`CREATE INDEX idx_base_clientid ON base(clientid)`,
+				`CREATE INDEX idx_base_uuid ON base(uuid)`,
+				`CREATE UNIQUE INDEX idx_base_active_host ON base(clientid) WHERE basetype='host' AND state='active'`,
+				`CREATE INDEX idx_base_groupid ON base(groupid)`,
+				`CREATE UNIQUE INDEX idx_base_active_group ON base(groupid) WHERE basetype='host' AND state='active' AND groupid IS NOT NULL`,
+				`DROP TABLE IF EXISTS maint_window`,
+			},
+		},
+		{
+			version: 12,
+			name:    "add trust_history table",
+			up: []string{
+				`CREATE TABLE trust_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    clientid BIGINT REFERENCES client(id) ON DELETE CASCADE,
+    entitytype TEXT,
+    uuid TEXT DEFAULT '',
+    fromstate TEXT,
+    tostate TEXT,
+    reason TEXT,
+    detail TEXT DEFAULT '',
+    createtime TIMESTAMP
+)`,
+				`CREATE INDEX idx_trust_history_clientid ON trust_history(clientid)`,
+			},
+			down: []string{
+				`DROP TABLE IF EXISTS trust_history`,
+			},
+		},
+		{
+			version: 13,
+			name:    "add ima_state tpm reset count column",
+			up: []string{
+				`ALTER TABLE ima_state ADD COLUMN resetcount BIGINT DEFAULT 0`,
+			},
+			down: []string{
+				`CREATE TABLE ima_state_old (
+    clientid BIGINT PRIMARY KEY NOT NULL REFERENCES client(id) ON DELETE CASCADE,
+    updatetime TIMESTAMP,
+    entries BIGINT,
+    alg TEXT,
+    pcr TEXT
+)`,
+				`INSERT INTO ima_state_old SELECT clientid, updatetime, entries, alg, pcr FROM ima_state`,
+				`DROP TABLE ima_state`,
+				`ALTER TABLE ima_state_old RENAME TO ima_state`,
+			},
+		},
+		{
+			version: 14,
+			name:    "refuse deleting node groups with base values or windows",
+			up: []string{
+				`CREATE TRIGGER trg_node_group_in_use BEFORE DELETE ON node_group
+WHEN EXISTS (SELECT 1 FROM base WHERE groupid=OLD.id) OR EXISTS (SELECT 1 FROM maint_window WHERE groupid=OLD.id)
+BEGIN
+    SELECT RAISE(ABORT, 'node group has base values or maintenance windows');
+END`,
+			},
+			down: []string{
+				`DROP TRIGGER IF EXISTS trg_node_group_in_use`,
+			},
+		},
+		{
+			version: 15,
+			name:    "keep maintenance windows of base values",
+			up: []string{
+				`CREATE TRIGGER trg_maint_window_in_use BEFORE DELETE ON maint_window
+WHEN EXISTS (SELECT 1 FROM base WHERE windowid=OLD.id)
+BEGIN
+    SELECT RAISE(ABORT, 'maintenance window has base values');
+END`,
+			},
+			down: []string{
+				`DROP TRIGGER IF EXISTS trg_maint_window_in_use`,
+			},
+		},
+	}
+)
+
+type (
+	// SqliteStore saves clients, reports and base values into an embedded
+	// sqlite database file, it doesn't need a database server.
+	SqliteStore struct {
+		sqlStore
+	}
+)
+
+// NewSqliteStore opens the sqlite database file, the schema is checked
+// and migrated by trust manager or ras migrate.
+func NewSqliteStore(file string) (*SqliteStore, error) {
+	db, err := sql.Open(sqliteDriver, file+sqliteOptions)
+	if err != nil {
+		return nil, err
+	}
+	// sqlite locks the whole database file for writing, serialize all
+	// accesses to avoid the busy error from concurrent store workers.
+	db.SetMaxOpenConns(1)
+	return &SqliteStore{sqlStore{db: db, migrations: sqliteMigrations}}, nil
+}
+
+// RegisterClient inserts a new client and sets its id.
+func (s *SqliteStore) RegisterClient(c *typdefs.ClientRow) error {
+	res, err := s.db.Exec(sqlSqliteRegisterClient, c.RegTime, c.Deleted, c.Info, c.IKCert)
+	if err != nil {
+		return err
+	}
+	c.ID, err = res.LastInsertId()
+	return err
+}
+
+// InsertPolicy inserts a policy version and sets its id.
+func (s *SqliteStore) InsertPolicy(p *typdefs.PolicyRow) error {
+	res, err := s.db.Exec(sqlInsertPolicy, p.Name, p.Version,
+		p.CreateTime, p.Enabled, p.Selector, p.Rules)
+	if err != nil {
+		return err
+	}
+	p.ID, err = res.LastInsertId()
+	return err
+}
+
+// InsertGroup inserts a node group and sets its id.
+func (s *SqliteStore) InsertGroup(g *typdefs.GroupRow) error {
+	res, err := s.db.Exec(sqlInsertGroup, g.Name, g.CreateTime, g.Priority, g.Selector)
+	if err != nil {
+		return err
+	}
+	g.ID, err = res.LastInsertId()
+	return err
+}
+
+// InsertMaintWindow inserts a maintenance window and sets its id.
+func (s *SqliteStore) InsertMaintWindow(w *typdefs.MaintWindowRow) error {
+	res, err := s.db.Exec(sqlInsertMaintWindow, nullID(w.ClientID), nullID(w.GroupID),
+		w.StartTime, w.EndTime, w.Parts, w.Author, w.CreateTime, w.ClosedBy)
+	if err != nil {
+		return err
+	}
+	w.ID, err = res.LastInsertId()
+	return err
+}
+
+// FindClientsByInfo gets clients from database ref by info, info must be
+// a json string like `{"key": "value"}`. sqlite doesn't support jsonb, so
+// check the containment of each client info like postgres "@>" operator.
+func (s *SqliteStore) FindClientsByInfo(info string) ([]typdefs.ClientRow, error) {
+	sub, err := parseClientInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(sqlSqliteFindAllClients)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cs := make([]typdefs.ClientRow, 0, 10)
+	for rows.Next() {
+		c := typdefs.ClientRow{}
+		err2 := rows.Scan(&c.ID, &c.RegTime, &c.Deleted, &c.Info, &c.IKCert)
+		if err2 != nil {
+			return nil, err2
+		}
+		if containsClientInfo(c.Info, sub) {
+			cs = append(cs, c)
+		}
+	}
+	return cs, nil
+}
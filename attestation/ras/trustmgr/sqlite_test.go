@@ -0,0 +1,171 @@
+package trustmgr
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+func TestSqliteStore(t *testing.T) {
+	s, err := NewSqliteStore(filepath.Join(t.TempDir(), "ras.db"))
+	if err != nil {
+		t.Fatalf("test NewSqliteStore error, %v\n", err)
+	}
+	defer s.Close()
+	err = s.MigrateUp()
+	if err != nil {
+		t.Fatalf("test MigrateUp error, %v\n", err)
+	}
+	testStoreOperations(t, s)
+}
+
+func TestSqliteStoreReopen(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ras.db")
+	s, err := NewSqliteStore(file)
+	if err != nil {
+		t.Fatalf("test NewSqliteStore error, %v\n", err)
+	}
+	err = s.MigrateUp()
+	if err != nil {
+		t.Fatalf("test MigrateUp error, %v\n", err)
+	}
+	c := typdefs.ClientRow{RegTime: time.Now(), Info: `{}`, IKCert: "ik"}
+	err = s.RegisterClient(&c)
+	if err != nil {
+		t.Fatalf("test RegisterClient error, %v\n", err)
+	}
+	s.Close()
+	// the existing tables and data must be kept.
+	s, err = NewSqliteStore(file)
+	if err != nil {
+		t.Fatalf("test NewSqliteStore reopen error, %v\n", err)
+	}
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error, %v\n", err)
+	}
+	defer tm.Close()
+	_, err = tm.GetCache(c.ID)
+	if err != nil {
+		t.Errorf("test GetCache after reopen error, %v\n", err)
+	}
+}
+
+func TestSqliteStoreBatchInsert(t *testing.T) {
+	s, err := NewSqliteStore(filepath.Join(t.TempDir(), "ras.db"))
+	if err != nil {
+		t.Fatalf("test NewSqliteStore error, %v\n", err)
+	}
+	defer s.Close()
+	err = s.MigrateUp()
+	if err != nil {
+		t.Fatalf("test MigrateUp error, %v\n", err)
+	}
+	c := typdefs.ClientRow{RegTime: time.Now(), Info: `{}`, IKCert: "ik"}
+	err = s.RegisterClient(&c)
+	if err != nil {
+		t.Fatalf("test RegisterClient error, %v\n", err)
+	}
+	// more rows than one statement can hold.
+	reports := make([]*typdefs.ReportRow, 250)
+	for i := range reports {
+		reports[i] = &typdefs.ReportRow{ClientID: c.ID, CreateTime: time.Now()}
+	}
+	bases := make([]*typdefs.BaseRow, 100)
+	for i := range bases {
+		bases[i] = &typdefs.BaseRow{ClientID: c.ID, BaseType: "host", CreateTime: time.Now()}
+	}
+	if err = s.InsertReports(reports); err != nil {
+		t.Errorf("test InsertReports error, %v\n", err)
+	}
+	if err = s.InsertBaseValues(bases); err != nil {
+		t.Errorf("test InsertBaseValues error, %v\n", err)
+	}
+	rs, _ := s.FindReportsByClientID(c.ID)
+	bs, _ := s.FindBaseValuesByClientID(c.ID)
+	if len(rs) != len(reports) || len(bs) != len(bases) {
+		t.Errorf("test batch insert error, %d reports, %d bases\n", len(rs), len(bs))
+	}
+	for i := range rs {
+		if rs[i].ID != reports[i].ID {
+			t.Errorf("test batch insert id error at case %d, %d %d\n", i, rs[i].ID, reports[i].ID)
+		}
+	}
+	for i := range bs {
+		if bs[i].ID != bases[i].ID {
+			t.Errorf("test batch insert base id error at case %d, %d %d\n", i, bs[i].ID, bases[i].ID)
+		}
+	}
+	// a bad row fails the whole batch.
+	reports = append(reports, &typdefs.ReportRow{ClientID: c.ID + 100})
+	if err = s.InsertReports(reports); err == nil {
+		t.Errorf("test InsertReports with bad row error\n")
+	}
+	rs, _ = s.FindReportsByClientID(c.ID)
+	if len(rs) != 250 {
+		t.Errorf("test batch insert rollback error, %d reports\n", len(rs))
+	}
+}
+
+func TestSqliteStoreConcurrentActiveBase(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ras.db")
+	s, err := NewSqliteStore(file)
+	if err != nil {
+		t.Fatalf("test NewSqliteStore error, %v\n", err)
+	}
+	err = s.MigrateUp()
+	if err != nil {
+		t.Fatalf("test MigrateUp error, %v\n", err)
+	}
+	c := typdefs.ClientRow{RegTime: time.Now(), Info: `{}`, IKCert: "ik"}
+	err = s.RegisterClient(&c)
+	if err != nil {
+		t.Fatalf("test RegisterClient error, %v\n", err)
+	}
+	tm, err := New(s, Options{StoreWorkers: 4, StoreBatchSize: 1})
+	if err != nil {
+		t.Fatalf("test New error, %v\n", err)
+	}
+	// two active host base values of one client are saved at the same time.
+	const rounds = 10
+	var wg sync.WaitGroup
+	for i := 0; i < rounds; i++ {
+		for j := 0; j < 2; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tm.SaveBaseValue(&typdefs.BaseRow{ClientID: c.ID, BaseType: typdefs.BaseTypeHost,
+					State: typdefs.BaseStateActive, Enabled: true, CreateTime: time.Now()})
+			}()
+		}
+		wg.Wait()
+	}
+	tm.Close()
+	if st := tm.GetStoreStats(); st.Saved != 2*rounds || st.Dropped != 0 {
+		t.Errorf("test concurrent active base stats error, %v\n", st)
+	}
+	// the trust manager closes the store.
+	s, err = NewSqliteStore(file)
+	if err != nil {
+		t.Fatalf("test NewSqliteStore reopen error, %v\n", err)
+	}
+	defer s.Close()
+	bs, err := s.FindBaseValuesByClientID(c.ID)
+	if err != nil || len(bs) != 2*rounds {
+		t.Fatalf("test concurrent active base error, %d %v\n", len(bs), err)
+	}
+	versions := map[int]bool{}
+	var active []typdefs.BaseRow
+	for _, b := range bs {
+		versions[b.Version] = true
+		if b.State == typdefs.BaseStateActive {
+			active = append(active, b)
+		}
+	}
+	if len(versions) != len(bs) || len(active) != 1 || active[0].Version != len(bs) {
+		t.Errorf("test concurrent active base state error, %d versions, %+v\n", len(versions), active)
+	}
+}
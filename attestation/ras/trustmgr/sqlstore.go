@@ -0,0 +1,717 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: common database/sql implementation of the trust manager store.
+*/
+
+package trustmgr
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+const (
+	// for database management sql
+	sqlFindAllEnabledClients    = `SELECT id, regtime, info, ikcert FROM client WHERE deleted=false`
+	sqlFindClientByID           = `SELECT regtime, deleted, info, ikcert FROM client WHERE id=$1`
+	sqlFindClientFullByIK       = `SELECT id, regtime, deleted, info FROM client WHERE ikcert=$1`
+	sqlFindReportsByClientID    = `SELECT id, clientid, createtime, validated, trusted FROM report WHERE clientid=$1 ORDER BY createtime ASC`
+	sqlFindReportByID           = `SELECT id, clientid, createtime, validated, trusted, quoted, signature, pcrlog, bioslog, imalog, secureboot, verdict FROM report WHERE id=$1`
+	sqlFindBaseValuesByClientID = `SELECT id, COALESCE(clientid, 0), COALESCE(groupid, 0), COALESCE(windowid, 0), basetype, uuid, createtime, name, enabled, verified, trusted, state, version, author, updatetime FROM base WHERE clientid=$1 ORDER BY createtime ASC`
+	sqlFindBaseValuesByGroupID  = `SELECT id, COALESCE(clientid, 0), COALESCE(groupid, 0), COALESCE(windowid, 0), basetype, uuid, createtime, name, enabled, verified, trusted, state, version, author, updatetime FROM base WHERE groupid=$1 ORDER BY createtime ASC`
+	sqlFindBaseValuesByWindowID = `SELECT id, COALESCE(clientid, 0), COALESCE(groupid, 0), COALESCE(windowid, 0), basetype, uuid, createtime, name, enabled, verified, trusted, state, version, author, updatetime FROM base WHERE windowid=$1 ORDER BY createtime ASC`
+	sqlFindBaseValueByID        = `SELECT id, COALESCE(clientid, 0), COALESCE(groupid, 0), COALESCE(windowid, 0), basetype, uuid, createtime, name, enabled, verified, trusted, pcr, bios, ima, imamode, refvalue, state, version, author, updatetime FROM base WHERE id=$1 ORDER BY createtime ASC`
+	sqlFindBaseValueByUuid      = `SELECT id, COALESCE(clientid, 0), COALESCE(groupid, 0), COALESCE(windowid, 0), basetype, uuid, createtime, name, enabled, verified, trusted, pcr, bios, ima, imamode, refvalue, state, version, author, updatetime FROM base WHERE uuid=$1`
+	sqlFindActiveBaseValues     = `SELECT id, COALESCE(clientid, 0), COALESCE(groupid, 0), COALESCE(windowid, 0), basetype, uuid, createtime, name, enabled, verified, trusted, pcr, bios, ima, imamode, refvalue, state, version, author, updatetime FROM base WHERE state='active' ORDER BY id`
+	sqlFindBaseMaxVersion       = `SELECT COALESCE(MAX(version), 0) FROM base WHERE clientid=$1 AND basetype=$2`
+	sqlFindGroupBaseMaxVersion  = `SELECT COALESCE(MAX(version), 0) FROM base WHERE groupid=$1 AND basetype=$2`
+	sqlRetireActiveHostBase     = `UPDATE base SET state='retired', enabled=false, updatetime=$1 WHERE clientid=$2 AND basetype='host' AND state='active' AND id<>$3`
+	sqlRetireActiveGroupBase    = `UPDATE base SET state='retired', enabled=false, updatetime=$1 WHERE groupid=$2 AND basetype='host' AND state='active' AND id<>$3`
+	sqlLockClientByID           = `SELECT id FROM client WHERE id=$1 FOR UPDATE`
+	sqlLockGroupByID            = `SELECT id FROM node_group WHERE id=$1 FOR UPDATE`
+	sqlUpdateBaseValueState     = `UPDATE base SET state=$1, enabled=$2, author=$3, updatetime=$4 WHERE id=$5`
+	sqlDeleteReportByID         = `DELETE FROM report WHERE id=$1`
+	sqlDeleteBaseValueByID      = `DELETE FROM base WHERE id=$1`
+	sqlUnRegisterClientByID     = `UPDATE client SET deleted=true WHERE id=$1`
+	sqlUpdateClientByID         = `UPDATE client SET info=$2 WHERE id=$1`
+	sqlInsertTrustReports       = `INSERT INTO report(clientid, createtime, validated, trusted, quoted, signature, pcrlog, bioslog, imalog, secureboot, verdict) VALUES `
+	sqlInsertBases              = `INSERT INTO base(clientid, groupid, windowid, basetype, uuid, createtime, enabled, verified, trusted, name, pcr, bios, ima, imamode, refvalue, state, version, author, updatetime) VALUES `
+	sqlInsertPolicy             = `INSERT INTO policy(name, version, createtime, enabled, selector, rules) VALUES ($1, $2, $3, $4, $5, $6)`
+	sqlFindPolicies             = `SELECT id, name, version, createtime, enabled, selector, rules FROM policy ORDER BY name, version`
+	sqlDeletePolicyByName       = `DELETE FROM policy WHERE name=$1`
+	sqlInsertReportResults      = `INSERT INTO report_result(reportid, checkname, baseid, passed, detail, mismatches) VALUES `
+	sqlFindReportResults        = `SELECT checkname, baseid, passed, detail, mismatches FROM report_result WHERE reportid=$1 ORDER BY id`
+	sqlFindImaStates            = `SELECT clientid, updatetime, entries, alg, pcr, resetcount FROM ima_state`
+	sqlSaveImaState             = `INSERT INTO ima_state(clientid, updatetime, entries, alg, pcr, resetcount) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT(clientid) DO UPDATE SET updatetime=excluded.updatetime, entries=excluded.entries, alg=excluded.alg, pcr=excluded.pcr, resetcount=excluded.resetcount`
+	sqlDeleteImaState           = `DELETE FROM ima_state WHERE clientid=$1`
+	sqlInsertGroup              = `INSERT INTO node_group(name, createtime, priority, selector) VALUES ($1, $2, $3, $4)`
+	sqlFindGroups               = `SELECT id, name, createtime, priority, selector FROM node_group ORDER BY id`
+	sqlDeleteGroupByID          = `DELETE FROM node_group WHERE id=$1`
+	sqlInsertMaintWindow        = `INSERT INTO maint_window(clientid, groupid, starttime, endtime, parts, author, createtime, closedby) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	sqlFindMaintWindows         = `SELECT id, COALESCE(clientid, 0), COALESCE(groupid, 0), starttime, endtime, parts, author, createtime, closedby FROM maint_window ORDER BY id`
+	sqlUpdateMaintWindow        = `UPDATE maint_window SET endtime=$1, closedby=$2 WHERE id=$3`
+	sqlInsertTrustHistory       = `INSERT INTO trust_history(clientid, entitytype, uuid, fromstate, tostate, reason, detail, createtime) VALUES `
+	sqlFindTrustHistory         = `SELECT id, clientid, entitytype, uuid, fromstate, tostate, reason, detail, createtime FROM trust_history WHERE clientid=$1 ORDER BY id`
+	reportColumns               = 11
+	baseColumns                 = 19
+	reportResultColumns         = 6
+	trustHistoryColumns         = 8
+	// max parameters in one sql statement, sqlite supports 999 at least.
+	maxSqlParams = 999
+)
+
+type (
+	// sqlStore implements the Store by database/sql, it is shared by
+	// all sql databases and each one only overrides the different parts.
+	sqlStore struct {
+		db *sql.DB
+		// ordered schema migrations of this kind of database.
+		migrations []migration
+		// returning means the database supports "INSERT ... RETURNING id",
+		// otherwise the ids are got by LastInsertId.
+		returning bool
+		// rowLocks means the database supports "SELECT ... FOR UPDATE",
+		// otherwise all writes are serialized by the only connection.
+		rowLocks bool
+	}
+)
+
+// Close closes the database connection pool.
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+// FindAllEnabledClients returns all clients which are not deleted.
+func (s *sqlStore) FindAllEnabledClients() ([]typdefs.ClientRow, error) {
+	rows, err := s.db.Query(sqlFindAllEnabledClients)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cs := make([]typdefs.ClientRow, 0, constRacDefault)
+	for rows.Next() {
+		c := typdefs.ClientRow{}
+		err2 := rows.Scan(&c.ID, &c.RegTime, &c.Info, &c.IKCert)
+		if err2 != nil {
+			return nil, err2
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+// UnRegisterClientByID marks a client as deleted.
+func (s *sqlStore) UnRegisterClientByID(id int64) error {
+	_, err := s.db.Exec(sqlUnRegisterClientByID, id)
+	return err
+}
+
+// FindClientByIK gets client from database by ik.
+func (s *sqlStore) FindClientByIK(ikCert string) (*typdefs.ClientRow, error) {
+	c := typdefs.ClientRow{IKCert: ikCert}
+	err := s.db.QueryRow(sqlFindClientFullByIK, ikCert).Scan(&c.ID,
+		&c.RegTime, &c.Deleted, &c.Info)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// FindClientByID gets client from database by id.
+func (s *sqlStore) FindClientByID(id int64) (*typdefs.ClientRow, error) {
+	c := typdefs.ClientRow{ID: id}
+	err := s.db.QueryRow(sqlFindClientByID, id).Scan(&c.RegTime,
+		&c.Deleted, &c.Info, &c.IKCert)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// InsertReport saves a trust report into database and sets its id.
+func (s *sqlStore) InsertReport(v *typdefs.ReportRow) error {
+	return s.InsertReports([]*typdefs.ReportRow{v})
+}
+
+// InsertReports saves a batch of trust reports and their check results
+// by multi-row inserts in one transaction, sets the ids of reports, none
+// of them is saved if any error happens.
+func (s *sqlStore) InsertReports(rows []*typdefs.ReportRow) error {
+	args := make([]interface{}, 0, len(rows)*reportColumns)
+	for _, v := range rows {
+		args = append(args, v.ClientID, v.CreateTime, v.Validated, v.Trusted,
+			v.Quoted, v.Signature, v.PcrLog, v.BiosLog, v.ImaLog, v.SecureBoot, v.Verdict)
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		ids, err := s.insertRows(tx, sqlInsertTrustReports, reportColumns, args)
+		if err != nil {
+			return err
+		}
+		var resArgs []interface{}
+		for i, v := range rows {
+			v.ID = ids[i]
+			for _, r := range v.Results {
+				mismatches := ""
+				if len(r.Mismatches) > 0 {
+					buf, _ := json.Marshal(r.Mismatches)
+					mismatches = string(buf)
+				}
+				resArgs = append(resArgs, v.ID, r.Check, r.BaseID, r.Passed, r.Detail, mismatches)
+			}
+		}
+		_, err = s.insertRows(tx, sqlInsertReportResults, reportResultColumns, resArgs)
+		return err
+	})
+}
+
+// FindReportsByClientID returns all reports by a specific client id.
+func (s *sqlStore) FindReportsByClientID(id int64) ([]typdefs.ReportRow, error) {
+	rows, err := s.db.Query(sqlFindReportsByClientID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	reports := make([]typdefs.ReportRow, 0, 100)
+	for rows.Next() {
+		res := typdefs.ReportRow{}
+		err2 := rows.Scan(&res.ID, &res.ClientID,
+			&res.CreateTime, &res.Validated, &res.Trusted)
+		if err2 != nil {
+			return nil, err2
+		}
+		reports = append(reports, res)
+	}
+	return reports, nil
+}
+
+// FindReportByID returns the report with its check results by a specific
+// report id.
+func (s *sqlStore) FindReportByID(id int64) (*typdefs.ReportRow, error) {
+	report := typdefs.ReportRow{}
+	err := s.db.QueryRow(sqlFindReportByID, id).Scan(&report.ID, &report.ClientID,
+		&report.CreateTime, &report.Validated, &report.Trusted, &report.Quoted,
+		&report.Signature, &report.PcrLog, &report.BiosLog, &report.ImaLog, &report.SecureBoot,
+		&report.Verdict)
+	if err != nil {
+		return nil, err
+	}
+	report.Results, err = s.findReportResults(id)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// findReportResults returns the check results of a specific report id.
+func (s *sqlStore) findReportResults(id int64) ([]typdefs.ReportResult, error) {
+	rows, err := s.db.Query(sqlFindReportResults, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var results []typdefs.ReportResult
+	for rows.Next() {
+		r := typdefs.ReportResult{}
+		var mismatches string
+		err2 := rows.Scan(&r.Check, &r.BaseID, &r.Passed, &r.Detail, &mismatches)
+		if err2 != nil {
+			return nil, err2
+		}
+		if mismatches != "" {
+			err2 = json.Unmarshal([]byte(mismatches), &r.Mismatches)
+			if err2 != nil {
+				return nil, err2
+			}
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// DeleteReportByID deletes a specific report by report id.
+func (s *sqlStore) DeleteReportByID(id int64) error {
+	_, err := s.db.Exec(sqlDeleteReportByID, id)
+	return err
+}
+
+// InsertBaseValue saves a base value into database and sets its id.
+func (s *sqlStore) InsertBaseValue(v *typdefs.BaseRow) error {
+	return s.InsertBaseValues([]*typdefs.BaseRow{v})
+}
+
+// InsertBaseValues saves a batch of base values by multi-row inserts in
+// one transaction and sets their ids and versions, none of them is saved
+// if any error happens. An active host base value retires the old active
+// one of the same client.
+func (s *sqlStore) InsertBaseValues(rows []*typdefs.BaseRow) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		err := s.lockBaseOwners(tx, rows)
+		if err != nil {
+			return err
+		}
+		err = prepareBaseValues(rows, func(v *typdefs.BaseRow) (int, error) {
+			var version int
+			var err error
+			if v.GroupID != 0 {
+				err = tx.QueryRow(sqlFindGroupBaseMaxVersion, v.GroupID, v.BaseType).Scan(&version)
+			} else {
+				err = tx.QueryRow(sqlFindBaseMaxVersion, v.ClientID, v.BaseType).Scan(&version)
+			}
+			return version, err
+		})
+		if err != nil {
+			return err
+		}
+		args := make([]interface{}, 0, len(rows)*baseColumns)
+		for _, v := range rows {
+			err = retireActiveHostBase(tx, v, 0)
+			if err != nil {
+				return err
+			}
+			args = append(args, nullID(v.ClientID), nullID(v.GroupID), nullID(v.WindowID), v.BaseType, v.Uuid, v.CreateTime,
+				v.Enabled, v.Verified, v.Trusted, v.Name, v.Pcr, v.Bios, v.Ima, v.ImaMode, v.RefValue,
+				v.State, v.Version, v.Author, v.UpdateTime)
+		}
+		ids, err := s.insertRows(tx, sqlInsertBases, baseColumns, args)
+		if err != nil {
+			return err
+		}
+		for i, v := range rows {
+			v.ID = ids[i]
+		}
+		return nil
+	})
+}
+
+// lockBaseOwners locks the client and node group rows which own the base
+// values until the transaction ends, so the concurrent transactions of the
+// same owner get the versions and retire the active host base value one
+// by one. The rows are locked in id order to avoid deadlocks.
+func (s *sqlStore) lockBaseOwners(tx *sql.Tx, rows []*typdefs.BaseRow) error {
+	if !s.rowLocks {
+		return nil
+	}
+	clients := map[int64]bool{}
+	groups := map[int64]bool{}
+	for _, v := range rows {
+		if v.GroupID != 0 {
+			groups[v.GroupID] = true
+		} else if v.ClientID != 0 {
+			clients[v.ClientID] = true
+		}
+	}
+	err := lockRowsByID(tx, sqlLockClientByID, clients)
+	if err != nil {
+		return err
+	}
+	return lockRowsByID(tx, sqlLockGroupByID, groups)
+}
+
+func lockRowsByID(tx *sql.Tx, query string, ids map[int64]bool) error {
+	sorted := make([]int64, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, id := range sorted {
+		_, err := tx.Exec(query, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retireActiveHostBase retires the active host base values of the client
+// or node group of v except the one of id if v is an active host one.
+func retireActiveHostBase(tx *sql.Tx, v *typdefs.BaseRow, id int64) error {
+	if v.BaseType != typdefs.BaseTypeHost || v.State != typdefs.BaseStateActive {
+		return nil
+	}
+	var err error
+	if v.GroupID != 0 {
+		_, err = tx.Exec(sqlRetireActiveGroupBase, v.UpdateTime, v.GroupID, id)
+	} else {
+		_, err = tx.Exec(sqlRetireActiveHostBase, v.UpdateTime, v.ClientID, id)
+	}
+	return err
+}
+
+// nullID returns NULL for the id 0, which references nothing.
+func nullID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// inTx calls f in a transaction, which is committed if f returns nil and
+// is rolled back otherwise.
+func (s *sqlStore) inTx(f func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = f(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertRows executes the insert prefix with the args of several rows in
+// tx, splits them into statements which have at most maxSqlParams
+// parameters, and returns the ids of the inserted rows in order.
+func (s *sqlStore) insertRows(tx *sql.Tx, prefix string, cols int, args []interface{}) ([]int64, error) {
+	ids := make([]int64, 0, len(args)/cols)
+	step := (maxSqlParams / cols) * cols
+	for len(args) > 0 {
+		n := step
+		if n > len(args) {
+			n = len(args)
+		}
+		query := multiRowValues(prefix, cols, n/cols)
+		if s.returning {
+			rows, err := tx.Query(query+" RETURNING id", args[:n]...)
+			if err != nil {
+				return nil, err
+			}
+			for rows.Next() {
+				var id int64
+				if err = rows.Scan(&id); err != nil {
+					rows.Close()
+					return nil, err
+				}
+				ids = append(ids, id)
+			}
+			rows.Close()
+			if err = rows.Err(); err != nil {
+				return nil, err
+			}
+		} else {
+			res, err := tx.Exec(query, args[:n]...)
+			if err != nil {
+				return nil, err
+			}
+			last, err := res.LastInsertId()
+			if err != nil {
+				return nil, err
+			}
+			// the rows of one statement get the consecutive ids.
+			for i := int64(n/cols - 1); i >= 0; i-- {
+				ids = append(ids, last-i)
+			}
+		}
+		args = args[n:]
+	}
+	return ids, nil
+}
+
+// multiRowValues appends the numbered placeholders of rows to the prefix,
+// like "($1, $2), ($3, $4)".
+func multiRowValues(prefix string, cols, rows int) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j := 1; j <= cols; j++ {
+			if j > 1 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "$%d", i*cols+j)
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// FindBaseValuesByClientID returns all base values by a specific client id.
+func (s *sqlStore) FindBaseValuesByClientID(id int64) ([]typdefs.BaseRow, error) {
+	return s.findBaseValues(sqlFindBaseValuesByClientID, id)
+}
+
+// FindBaseValuesByGroupID returns all base values by a specific node group id.
+func (s *sqlStore) FindBaseValuesByGroupID(id int64) ([]typdefs.BaseRow, error) {
+	return s.findBaseValues(sqlFindBaseValuesByGroupID, id)
+}
+
+// FindBaseValuesByWindowID returns all base values updated in a specific
+// maintenance window.
+func (s *sqlStore) FindBaseValuesByWindowID(id int64) ([]typdefs.BaseRow, error) {
+	return s.findBaseValues(sqlFindBaseValuesByWindowID, id)
+}
+
+// findBaseValues returns the base values without content found by query.
+func (s *sqlStore) findBaseValues(query string, id int64) ([]typdefs.BaseRow, error) {
+	rows, err := s.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	basevalues := make([]typdefs.BaseRow, 0, 20)
+	for rows.Next() {
+		res := typdefs.BaseRow{}
+		err2 := rows.Scan(&res.ID, &res.ClientID, &res.GroupID, &res.WindowID, &res.BaseType, &res.Uuid, &res.CreateTime,
+			&res.Name, &res.Enabled, &res.Verified, &res.Trusted,
+			&res.State, &res.Version, &res.Author, &res.UpdateTime)
+		if err2 != nil {
+			return nil, err2
+		}
+		basevalues = append(basevalues, res)
+	}
+	return basevalues, nil
+}
+
+// rowScanner is *sql.Row or *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanBaseValue scans all columns of a base value.
+func scanBaseValue(row rowScanner) (*typdefs.BaseRow, error) {
+	basevalue := &typdefs.BaseRow{}
+	err := row.Scan(&basevalue.ID,
+		&basevalue.ClientID, &basevalue.GroupID, &basevalue.WindowID, &basevalue.BaseType, &basevalue.Uuid, &basevalue.CreateTime, &basevalue.Name,
+		&basevalue.Enabled, &basevalue.Verified, &basevalue.Trusted, &basevalue.Pcr, &basevalue.Bios, &basevalue.Ima, &basevalue.ImaMode, &basevalue.RefValue,
+		&basevalue.State, &basevalue.Version, &basevalue.Author, &basevalue.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+	return basevalue, nil
+}
+
+// FindBaseValueByID returns a specific base value by base value id.
+func (s *sqlStore) FindBaseValueByID(id int64) (*typdefs.BaseRow, error) {
+	return scanBaseValue(s.db.QueryRow(sqlFindBaseValueByID, id))
+}
+
+// FindBaseValueByUuid returns a specific base value by base value uuid.
+func (s *sqlStore) FindBaseValueByUuid(uuid string) (*typdefs.BaseRow, error) {
+	return scanBaseValue(s.db.QueryRow(sqlFindBaseValueByUuid, uuid))
+}
+
+// FindActiveBaseValues returns the active base values of all clients and
+// node groups.
+func (s *sqlStore) FindActiveBaseValues() ([]typdefs.BaseRow, error) {
+	rows, err := s.db.Query(sqlFindActiveBaseValues)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []typdefs.BaseRow
+	for rows.Next() {
+		v, err2 := scanBaseValue(rows)
+		if err2 != nil {
+			return nil, err2
+		}
+		res = append(res, *v)
+	}
+	return res, rows.Err()
+}
+
+// UpdateBaseValueState saves the state, author and update time of base
+// value, activating a host base value retires the old active one of the
+// same client or node group in one transaction.
+func (s *sqlStore) UpdateBaseValueState(v *typdefs.BaseRow) error {
+	v.Enabled = v.State == typdefs.BaseStateActive
+	return s.inTx(func(tx *sql.Tx) error {
+		err := s.lockBaseOwners(tx, []*typdefs.BaseRow{v})
+		if err != nil {
+			return err
+		}
+		err = retireActiveHostBase(tx, v, v.ID)
+		if err != nil {
+			return err
+		}
+		res, err := tx.Exec(sqlUpdateBaseValueState, v.State, v.Enabled, v.Author, v.UpdateTime, v.ID)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			err = sql.ErrNoRows
+		}
+		return err
+	})
+}
+
+// DeleteBaseValueByID deletes a specific base value by base value id.
+func (s *sqlStore) DeleteBaseValueByID(id int64) error {
+	_, err := s.db.Exec(sqlDeleteBaseValueByID, id)
+	return err
+}
+
+// FindPolicies returns all versions of all policies ordered by name and
+// version.
+func (s *sqlStore) FindPolicies() ([]typdefs.PolicyRow, error) {
+	rows, err := s.db.Query(sqlFindPolicies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	policies := make([]typdefs.PolicyRow, 0, 10)
+	for rows.Next() {
+		p := typdefs.PolicyRow{}
+		err2 := rows.Scan(&p.ID, &p.Name, &p.Version, &p.CreateTime,
+			&p.Enabled, &p.Selector, &p.Rules)
+		if err2 != nil {
+			return nil, err2
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// DeletePolicyByName deletes all versions of a policy.
+func (s *sqlStore) DeletePolicyByName(name string) error {
+	_, err := s.db.Exec(sqlDeletePolicyByName, name)
+	return err
+}
+
+// FindImaStates returns the ima log replay states of all clients.
+func (s *sqlStore) FindImaStates() ([]typdefs.ImaStateRow, error) {
+	rows, err := s.db.Query(sqlFindImaStates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	states := make([]typdefs.ImaStateRow, 0, constRacDefault)
+	for rows.Next() {
+		st := typdefs.ImaStateRow{}
+		err2 := rows.Scan(&st.ClientID, &st.UpdateTime, &st.Count, &st.Alg, &st.Pcr, &st.ResetCount)
+		if err2 != nil {
+			return nil, err2
+		}
+		states = append(states, st)
+	}
+	return states, rows.Err()
+}
+
+// SaveImaState inserts or replaces the ima log replay state of a client.
+func (s *sqlStore) SaveImaState(st *typdefs.ImaStateRow) error {
+	_, err := s.db.Exec(sqlSaveImaState, st.ClientID, st.UpdateTime, st.Count, st.Alg, st.Pcr, st.ResetCount)
+	return err
+}
+
+// DeleteImaState deletes the ima log replay state of a client.
+func (s *sqlStore) DeleteImaState(clientID int64) error {
+	_, err := s.db.Exec(sqlDeleteImaState, clientID)
+	return err
+}
+
+// FindGroups returns all node groups ordered by id.
+func (s *sqlStore) FindGroups() ([]typdefs.GroupRow, error) {
+	rows, err := s.db.Query(sqlFindGroups)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	groups := make([]typdefs.GroupRow, 0, 10)
+	for rows.Next() {
+		g := typdefs.GroupRow{}
+		err2 := rows.Scan(&g.ID, &g.Name, &g.CreateTime, &g.Priority, &g.Selector)
+		if err2 != nil {
+			return nil, err2
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// DeleteGroupByID deletes a node group, the database refuses it if the
+// group still has base values or maintenance windows.
+func (s *sqlStore) DeleteGroupByID(id int64) error {
+	_, err := s.db.Exec(sqlDeleteGroupByID, id)
+	return err
+}
+
+// FindMaintWindows returns all maintenance windows ordered by id.
+func (s *sqlStore) FindMaintWindows() ([]typdefs.MaintWindowRow, error) {
+	rows, err := s.db.Query(sqlFindMaintWindows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	windows := make([]typdefs.MaintWindowRow, 0, 10)
+	for rows.Next() {
+		w := typdefs.MaintWindowRow{}
+		err2 := rows.Scan(&w.ID, &w.ClientID, &w.GroupID, &w.StartTime, &w.EndTime,
+			&w.Parts, &w.Author, &w.CreateTime, &w.ClosedBy)
+		if err2 != nil {
+			return nil, err2
+		}
+		windows = append(windows, w)
+	}
+	return windows, rows.Err()
+}
+
+// UpdateMaintWindow saves the end time and closer of a maintenance window.
+func (s *sqlStore) UpdateMaintWindow(w *typdefs.MaintWindowRow) error {
+	res, err := s.db.Exec(sqlUpdateMaintWindow, w.EndTime, w.ClosedBy, w.ID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err == nil && n == 0 {
+		err = sql.ErrNoRows
+	}
+	return err
+}
+
+// InsertTrustHistory saves a batch of trust state transitions by multi-row
+// inserts in one transaction and sets their ids.
+func (s *sqlStore) InsertTrustHistory(rows []*typdefs.TrustHistoryRow) error {
+	args := make([]interface{}, 0, len(rows)*trustHistoryColumns)
+	for _, h := range rows {
+		args = append(args, h.ClientID, h.EntityType, h.Uuid, h.FromState,
+			h.ToState, h.Reason, h.Detail, h.CreateTime)
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		ids, err := s.insertRows(tx, sqlInsertTrustHistory, trustHistoryColumns, args)
+		if err != nil {
+			return err
+		}
+		for i, h := range rows {
+			h.ID = ids[i]
+		}
+		return nil
+	})
+}
+
+// FindTrustHistoryByClientID returns the trust state transitions of a
+// client and its containers/devices ordered by id.
+func (s *sqlStore) FindTrustHistoryByClientID(id int64) ([]typdefs.TrustHistoryRow, error) {
+	rows, err := s.db.Query(sqlFindTrustHistory, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	history := make([]typdefs.TrustHistoryRow, 0, 10)
+	for rows.Next() {
+		h := typdefs.TrustHistoryRow{}
+		err2 := rows.Scan(&h.ID, &h.ClientID, &h.EntityType, &h.Uuid, &h.FromState,
+			&h.ToState, &h.Reason, &h.Detail, &h.CreateTime)
+		if err2 != nil {
+			return nil, err2
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
@@ -0,0 +1,234 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: bounded queue which saves reports and base values into store.
+*/
+
+package trustmgr
+
+import (
+	"sync/atomic"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/logger"
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+const (
+	// max wait time between two retries of saving.
+	maxStoreRetryBackoff = 5 * time.Second
+)
+
+type (
+	// StoreStats counts the reports and base values handled by store pipe.
+	StoreStats struct {
+		// Queued is the number of rows put into the queue.
+		Queued uint64 `json:"queued"`
+		// Saved is the number of rows saved into store.
+		Saved uint64 `json:"saved"`
+		// Dropped is the number of rows lost because the queue is full,
+		// the pipe is closed or the store keeps failing.
+		Dropped uint64 `json:"dropped"`
+		// Retries is the number of retries after store errors.
+		Retries uint64 `json:"retries"`
+		// QueueLength is the number of rows waiting to be saved now.
+		QueueLength int `json:"queuelength"`
+	}
+)
+
+// normalizeOptions fills the unset store pipe options with defaults.
+func normalizeOptions(opts Options) Options {
+	if opts.StoreWorkers <= 0 {
+		opts.StoreWorkers = defaultStoreWorkers
+	}
+	if opts.StoreQueueDepth <= 0 {
+		opts.StoreQueueDepth = defaultStoreQueueDepth
+	}
+	if opts.StoreBatchSize <= 0 {
+		opts.StoreBatchSize = defaultStoreBatchSize
+	}
+	if opts.StoreRetries < 0 {
+		opts.StoreRetries = 0
+	} else if opts.StoreRetries == 0 {
+		opts.StoreRetries = defaultStoreRetries
+	}
+	if opts.StoreRetryBackoff <= 0 {
+		opts.StoreRetryBackoff = defaultStoreRetryBackoff
+	}
+	if opts.EnqueueTimeout <= 0 {
+		opts.EnqueueTimeout = defaultEnqueueTimeout
+	}
+	return opts
+}
+
+func (t *TrustManager) createStorePipe(opts Options) {
+	t.opts = normalizeOptions(opts)
+	t.queue = make(chan interface{}, t.opts.StoreQueueDepth)
+	for i := 0; i < t.opts.StoreWorkers; i++ {
+		t.wg.Add(1)
+		go t.handleStorePipe()
+	}
+}
+
+// releaseStorePipe stops accepting new rows and waits until all queued
+// rows are saved, returns false if the pipe has been released already.
+func (t *TrustManager) releaseStorePipe() bool {
+	t.queueMu.Lock()
+	if t.closed {
+		t.queueMu.Unlock()
+		return false
+	}
+	t.closed = true
+	close(t.queue)
+	t.queueMu.Unlock()
+	t.wg.Wait()
+	return true
+}
+
+// pushToStorePipe puts a report or base value into the queue, waits at
+// most EnqueueTimeout if the queue is full and drops it after that.
+func (t *TrustManager) pushToStorePipe(v interface{}) {
+	t.queueMu.RLock()
+	defer t.queueMu.RUnlock()
+	if t.closed {
+		t.dropRows(1, "store pipe is closed")
+		return
+	}
+	select {
+	case t.queue <- v:
+		atomic.AddUint64(&t.stats.Queued, 1)
+		return
+	default:
+	}
+	timer := time.NewTimer(t.opts.EnqueueTimeout)
+	defer timer.Stop()
+	select {
+	case t.queue <- v:
+		atomic.AddUint64(&t.stats.Queued, 1)
+	case <-timer.C:
+		t.dropRows(1, "store queue is full")
+	}
+}
+
+// SaveBaseValue saves the base value into store asynchronously.
+func (t *TrustManager) SaveBaseValue(row *typdefs.BaseRow) {
+	t.pushToStorePipe(row)
+}
+
+// GetStoreStats returns the current counters and queue length of the
+// store pipe.
+func (t *TrustManager) GetStoreStats() StoreStats {
+	return StoreStats{
+		Queued:      atomic.LoadUint64(&t.stats.Queued),
+		Saved:       atomic.LoadUint64(&t.stats.Saved),
+		Dropped:     atomic.LoadUint64(&t.stats.Dropped),
+		Retries:     atomic.LoadUint64(&t.stats.Retries),
+		QueueLength: len(t.queue),
+	}
+}
+
+func (t *TrustManager) dropRows(n int, reason string) {
+	atomic.AddUint64(&t.stats.Dropped, uint64(n))
+	logger.L.Sugar().Warnf("drop %d reports/base values, %s", n, reason)
+}
+
+// handleStorePipe takes rows from the queue until it is closed and
+// drained, saves the rows which are ready together as a batch.
+func (t *TrustManager) handleStorePipe() {
+	defer t.wg.Done()
+	batch := make([]interface{}, 0, t.opts.StoreBatchSize)
+	for v := range t.queue {
+		batch = append(batch, v)
+	collect:
+		for len(batch) < t.opts.StoreBatchSize {
+			select {
+			case v2, ok := <-t.queue:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, v2)
+			default:
+				break collect
+			}
+		}
+		t.saveBatch(batch)
+		batch = batch[:0]
+	}
+}
+
+func (t *TrustManager) saveBatch(batch []interface{}) {
+	reports := make([]*typdefs.ReportRow, 0, len(batch))
+	bases := make([]*typdefs.BaseRow, 0, len(batch))
+	for _, em := range batch {
+		switch v := em.(type) {
+		case *typdefs.ReportRow:
+			reports = append(reports, v)
+		case *typdefs.BaseRow:
+			bases = append(bases, v)
+		}
+	}
+	if len(reports) > 0 {
+		t.saveWithRetry(len(reports), "trust report", func() error {
+			return t.store.InsertReports(reports)
+		})
+	}
+	if len(bases) > 0 {
+		t.saveWithRetry(len(bases), "base", func() error {
+			return t.store.InsertBaseValues(bases)
+		})
+		for _, v := range bases {
+			t.updateCacheBase(v)
+		}
+	}
+}
+
+// saveWithRetry calls save and retries it with exponential backoff when
+// it fails, the n rows are dropped if all retries fail.
+func (t *TrustManager) saveWithRetry(n int, name string, save func() error) {
+	backoff := t.opts.StoreRetryBackoff
+	for i := 0; ; i++ {
+		err := save()
+		if err == nil {
+			atomic.AddUint64(&t.stats.Saved, uint64(n))
+			return
+		}
+		if i >= t.opts.StoreRetries {
+			logger.L.Sugar().Errorf("insert %s error, %v", name, err)
+			t.dropRows(n, "store keeps failing")
+			return
+		}
+		logger.L.Sugar().Warnf("insert %s error, retry after %v, %v", name, backoff, err)
+		atomic.AddUint64(&t.stats.Retries, 1)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxStoreRetryBackoff {
+			backoff = maxStoreRetryBackoff
+		}
+	}
+}
+
+// updateCacheBase puts the new base value into the cache of its client
+// if the client is registered, or into its node group, only the active
+// host base value is used to verify reports, the others are removed.
+func (t *TrustManager) updateCacheBase(v *typdefs.BaseRow) {
+	if v.GroupID != 0 {
+		t.updateGroupBase(v)
+		return
+	}
+	c, err := t.GetCache(v.ClientID)
+	if err != nil {
+		return
+	}
+	if v.BaseType == typdefs.BaseTypeHost && v.State != typdefs.BaseStateActive {
+		c.RemoveHostBase(v.ID)
+		return
+	}
+	c.UpdateBase(v)
+}
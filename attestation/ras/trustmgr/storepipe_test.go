@@ -0,0 +1,182 @@
+package trustmgr
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/logger"
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+var errTestStore = errors.New("test store error")
+
+func init() {
+	// store pipe logs the dropped rows and store errors.
+	if logger.L == nil {
+		logger.L = logger.NewDebugLogger("")
+	}
+}
+
+// testPipeStore wraps the memory store, it fails the first fails batch
+// inserts and blocks all inserts until block is closed if it is not nil.
+type testPipeStore struct {
+	*MemoryStore
+	mu      sync.Mutex
+	fails   int
+	batches []int
+	block   chan struct{}
+}
+
+func (s *testPipeStore) InsertReports(rows []*typdefs.ReportRow) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	if s.fails > 0 {
+		s.fails--
+		s.mu.Unlock()
+		return errTestStore
+	}
+	s.batches = append(s.batches, len(rows))
+	s.mu.Unlock()
+	return s.MemoryStore.InsertReports(rows)
+}
+
+func newTestPipeStore() *testPipeStore {
+	return &testPipeStore{MemoryStore: NewMemoryStore()}
+}
+
+func TestNormalizeOptions(t *testing.T) {
+	opts := normalizeOptions(Options{})
+	if opts.StoreWorkers != defaultStoreWorkers ||
+		opts.StoreQueueDepth != defaultStoreQueueDepth ||
+		opts.StoreBatchSize != defaultStoreBatchSize ||
+		opts.StoreRetries != defaultStoreRetries ||
+		opts.StoreRetryBackoff != defaultStoreRetryBackoff ||
+		opts.EnqueueTimeout != defaultEnqueueTimeout {
+		t.Errorf("test normalizeOptions default error, %v", opts)
+	}
+	opts = normalizeOptions(Options{StoreWorkers: 2, StoreRetries: -1})
+	if opts.StoreWorkers != 2 || opts.StoreRetries != 0 {
+		t.Errorf("test normalizeOptions error, %v", opts)
+	}
+}
+
+func TestStorePipeDrainOnClose(t *testing.T) {
+	s := newTestPipeStore()
+	s.block = make(chan struct{})
+	tm, err := New(s, Options{StoreWorkers: 1, StoreBatchSize: 10})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	for i := 0; i < 25; i++ {
+		tm.pushToStorePipe(&typdefs.ReportRow{ClientID: 1})
+	}
+	close(s.block)
+	tm.Close()
+	rs, _ := s.FindReportsByClientID(1)
+	if len(rs) != 25 {
+		t.Errorf("test drain on close error, %d saved", len(rs))
+	}
+	st := tm.GetStoreStats()
+	if st.Queued != 25 || st.Saved != 25 || st.Dropped != 0 {
+		t.Errorf("test store stats error, %v", st)
+	}
+	for i, n := range s.batches {
+		if n > 10 {
+			t.Errorf("test batch size error at batch %d, %d rows", i, n)
+		}
+	}
+	if len(s.batches) >= 25 {
+		t.Errorf("test batch insert error, %d batches", len(s.batches))
+	}
+	// push after close is dropped, close again does nothing.
+	tm.pushToStorePipe(&typdefs.ReportRow{ClientID: 1})
+	if tm.GetStoreStats().Dropped != 1 {
+		t.Errorf("test push after close error")
+	}
+	if err = tm.Close(); err != nil {
+		t.Errorf("test close twice error %v", err)
+	}
+}
+
+func TestStorePipeQueueFull(t *testing.T) {
+	s := newTestPipeStore()
+	s.block = make(chan struct{})
+	tm, err := New(s, Options{
+		StoreWorkers:    1,
+		StoreQueueDepth: 2,
+		StoreBatchSize:  1,
+		EnqueueTimeout:  10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	// one row is taken by the blocked worker, two rows fill the queue.
+	for i := 0; i < 6; i++ {
+		tm.pushToStorePipe(&typdefs.ReportRow{ClientID: 1})
+	}
+	if n := tm.GetStoreStats().QueueLength; n != 2 {
+		t.Errorf("test queue length error, %d\n", n)
+	}
+	close(s.block)
+	tm.Close()
+	st := tm.GetStoreStats()
+	if st.Queued+st.Dropped != 6 || st.Dropped < 3 || st.Saved != st.Queued {
+		t.Errorf("test queue full error, %v", st)
+	}
+}
+
+func TestStorePipeRetry(t *testing.T) {
+	testCases := []struct {
+		fails   int
+		retries int
+		saved   uint64
+		dropped uint64
+		retried uint64
+	}{
+		{0, 2, 1, 0, 0},
+		{2, 2, 1, 0, 2},
+		{3, 2, 0, 1, 2},
+		{1, -1, 0, 1, 0},
+	}
+	for i := 0; i < len(testCases); i++ {
+		s := newTestPipeStore()
+		s.fails = testCases[i].fails
+		tm, err := New(s, Options{
+			StoreWorkers:      1,
+			StoreRetries:      testCases[i].retries,
+			StoreRetryBackoff: time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("test New error %v", err)
+		}
+		tm.pushToStorePipe(&typdefs.ReportRow{ClientID: 1})
+		tm.Close()
+		st := tm.GetStoreStats()
+		if st.Saved != testCases[i].saved || st.Dropped != testCases[i].dropped ||
+			st.Retries != testCases[i].retried {
+			t.Errorf("test store retry error at case %d, %v\n", i, st)
+		}
+	}
+}
+
+func TestMultiRowValues(t *testing.T) {
+	testCases := []struct {
+		cols   int
+		rows   int
+		result string
+	}{
+		{1, 1, "X ($1)"},
+		{2, 2, "X ($1, $2), ($3, $4)"},
+		{3, 1, "X ($1, $2, $3)"},
+	}
+	for i := 0; i < len(testCases); i++ {
+		r := multiRowValues("X ", testCases[i].cols, testCases[i].rows)
+		if r != testCases[i].result {
+			t.Errorf("test multiRowValues error at case %d, %s\n", i, r)
+		}
+	}
+}
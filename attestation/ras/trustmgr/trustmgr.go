@@ -27,11 +27,8 @@ package trustmgr
 
 import (
 	"bytes"
-	"crypto"
-	"crypto/rsa"
-	"crypto/sha256"
 
-	"database/sql"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -41,121 +38,246 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
+	"gitee.com/openeuler/kunpengsecl/attestation/common/cryptotools"
 	"gitee.com/openeuler/kunpengsecl/attestation/common/logger"
 	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
 	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
 	"gitee.com/openeuler/kunpengsecl/attestation/ras/config"
 	"github.com/google/go-tpm/tpm2"
-	_ "github.com/lib/pq"
+	"github.com/google/go-tpm/tpmutil"
 )
 
 const (
 	constRacDefault = 5000
-
-	// for database management sql
-	sqlRegisterClientByIK       = `INSERT INTO client(regtime, deleted, info, ikcert) VALUES ($1, $2, $3, $4) RETURNING id`
-	sqlFindAllEnabledClients    = `SELECT id, regtime, ikcert FROM client WHERE deleted=false`
-	sqlFindClientByID           = `SELECT regtime, deleted, info, ikcert FROM client WHERE id=$1`
-	sqlFindClientIDByIK         = `SELECT id FROM client WHERE ikcert=$1`
-	sqlFindClientFullByIK       = `SELECT id, regtime, deleted, info FROM client WHERE ikcert=$1`
-	sqlFindClientsByInfo        = `SELECT id, regtime, deleted, info, ikcert FROM client WHERE info @> $1`
-	sqlFindReportsByClientID    = `SELECT id, clientid, createtime, validated, trusted FROM report WHERE clientid=$1 ORDER BY createtime ASC`
-	sqlFindReportByID           = `SELECT id, clientid, createtime, validated, trusted, quoted, signature, pcrlog, bioslog, imalog FROM report WHERE id=$1`
-	sqlFindBaseValuesByClientID = `SELECT id, basetype, uuid, createtime, name, enabled FROM base WHERE clientid=$1 ORDER BY createtime ASC`
-	sqlFindBaseValueByID        = `SELECT id, clientid, basetype, uuid, createtime, name, enabled, pcr, bios, ima FROM base WHERE id=$1 ORDER BY createtime ASC`
-	sqlFindBaseValueByUuid      = `SELECT id, clientid, basetype, uuid, createtime, name, enabled, pcr, bios, ima FROM base WHERE uuid=$1`
-	sqlDeleteReportByID         = `DELETE FROM report WHERE id=$1`
-	sqlDeleteBaseValueByID      = `DELETE FROM base WHERE id=$1`
-	sqlUnRegisterClientByID     = `UPDATE client SET deleted=true WHERE id=$1`
-	sqlUpdateClientByID         = `UPDATE client SET info=$2 WHERE id=$1`
-	sqlInsertTrustReport        = `INSERT INTO report(clientid, createtime, validated, trusted, quoted, signature, pcrlog, bioslog, imalog) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	sqlInsertBase               = `INSERT INTO base(clientid, basetype, uuid, createtime, enabled, name, pcr, bios, ima) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	// TPM_GENERATED_VALUE, the magic of TPMS_ATTEST structure
+	tpmGeneratedValue = 0xff544347
+	// template, file hash and file name of one ima base value line
+	imaBaseItemNum = 3
+	// default parameters of the store pipe
+	defaultStoreWorkers      = 20
+	defaultStoreQueueDepth   = 10000
+	defaultStoreBatchSize    = 100
+	defaultStoreRetries      = 3
+	defaultStoreRetryBackoff = 100 * time.Millisecond
+	defaultEnqueueTimeout    = time.Second
 )
 
 type (
+	// Store saves clients status information, backup of cache and support
+	// rest api search operations... (level two)
+	Store interface {
+		// Close releases the store resources.
+		Close() error
+		// FindAllEnabledClients returns all clients which are not deleted.
+		FindAllEnabledClients() ([]typdefs.ClientRow, error)
+		// RegisterClient saves a new client and sets its id.
+		RegisterClient(c *typdefs.ClientRow) error
+		// UnRegisterClientByID marks a client as deleted.
+		UnRegisterClientByID(id int64) error
+		// FindClientByIK gets client by ik certificate.
+		FindClientByIK(ikCert string) (*typdefs.ClientRow, error)
+		// FindClientByID gets client by id.
+		FindClientByID(id int64) (*typdefs.ClientRow, error)
+		// FindClientsByInfo gets clients whose info contains the json info.
+		FindClientsByInfo(info string) ([]typdefs.ClientRow, error)
+		// InsertReport saves a trust report with its check results and
+		// sets its id.
+		InsertReport(row *typdefs.ReportRow) error
+		// InsertReports saves a batch of trust reports like InsertReport.
+		InsertReports(rows []*typdefs.ReportRow) error
+		// FindReportsByClientID returns all reports of a client.
+		FindReportsByClientID(id int64) ([]typdefs.ReportRow, error)
+		// FindReportByID returns the report with its check results by
+		// report id.
+		FindReportByID(id int64) (*typdefs.ReportRow, error)
+		// DeleteReportByID deletes the report by report id.
+		DeleteReportByID(id int64) error
+		// InsertBaseValue saves a base value and sets its id.
+		InsertBaseValue(row *typdefs.BaseRow) error
+		// InsertBaseValues saves a batch of base values and sets their ids.
+		InsertBaseValues(rows []*typdefs.BaseRow) error
+		// FindBaseValuesByClientID returns all base values of a client.
+		FindBaseValuesByClientID(id int64) ([]typdefs.BaseRow, error)
+		// FindBaseValuesByGroupID returns all base values of a node group.
+		FindBaseValuesByGroupID(id int64) ([]typdefs.BaseRow, error)
+		// FindBaseValuesByWindowID returns all base values updated in a
+		// maintenance window.
+		FindBaseValuesByWindowID(id int64) ([]typdefs.BaseRow, error)
+		// FindBaseValueByID returns the base value by base value id.
+		FindBaseValueByID(id int64) (*typdefs.BaseRow, error)
+		// FindBaseValueByUuid returns the base value by base value uuid.
+		FindBaseValueByUuid(uuid string) (*typdefs.BaseRow, error)
+		// DeleteBaseValueByID deletes the base value by base value id.
+		DeleteBaseValueByID(id int64) error
+		// FindActiveBaseValues returns the active base values of all clients
+		// and node groups.
+		FindActiveBaseValues() ([]typdefs.BaseRow, error)
+		// UpdateBaseValueState saves the lifecycle state of a base value,
+		// activating a host base value retires the old active one atomically.
+		UpdateBaseValueState(row *typdefs.BaseRow) error
+		// InsertPolicy saves a policy version and sets its id.
+		InsertPolicy(p *typdefs.PolicyRow) error
+		// FindPolicies returns all versions of all policies.
+		FindPolicies() ([]typdefs.PolicyRow, error)
+		// DeletePolicyByName deletes all versions of a policy.
+		DeletePolicyByName(name string) error
+		// InsertGroup saves a node group and sets its id.
+		InsertGroup(g *typdefs.GroupRow) error
+		// FindGroups returns all node groups.
+		FindGroups() ([]typdefs.GroupRow, error)
+		// DeleteGroupByID deletes a node group which has no base value
+		// or maintenance window.
+		DeleteGroupByID(id int64) error
+		// InsertMaintWindow saves a maintenance window and sets its id.
+		InsertMaintWindow(w *typdefs.MaintWindowRow) error
+		// FindMaintWindows returns all maintenance windows.
+		FindMaintWindows() ([]typdefs.MaintWindowRow, error)
+		// UpdateMaintWindow saves the end time and closer of a maintenance
+		// window.
+		UpdateMaintWindow(w *typdefs.MaintWindowRow) error
+		// FindImaStates returns the ima log replay states of all clients.
+		FindImaStates() ([]typdefs.ImaStateRow, error)
+		// SaveImaState inserts or replaces the ima log replay state of a client.
+		SaveImaState(st *typdefs.ImaStateRow) error
+		// DeleteImaState deletes the ima log replay state of a client.
+		DeleteImaState(clientID int64) error
+		// InsertTrustHistory saves a batch of trust state transitions and
+		// sets their ids.
+		InsertTrustHistory(rows []*typdefs.TrustHistoryRow) error
+		// FindTrustHistoryByClientID returns the trust state transitions of
+		// a client and its containers/devices in time order.
+		FindTrustHistoryByClientID(id int64) ([]typdefs.TrustHistoryRow, error)
+	}
+
+	// Options controls the trust manager creation.
+	Options struct {
+		// StoreWorkers is the number of goroutines saving reports and
+		// base values into store, defaultStoreWorkers if not set.
+		StoreWorkers int
+		// StoreQueueDepth is the max number of reports and base values
+		// waiting to be saved, defaultStoreQueueDepth if not set.
+		StoreQueueDepth int
+		// StoreBatchSize is the max number of rows saved in one insert,
+		// defaultStoreBatchSize if not set.
+		StoreBatchSize int
+		// StoreRetries is the retry times when saving fails, the rows are
+		// dropped after that, defaultStoreRetries if not set and no retry
+		// if negative.
+		StoreRetries int
+		// StoreRetryBackoff is the first wait time before retrying, it is
+		// doubled for each retry, defaultStoreRetryBackoff if not set.
+		StoreRetryBackoff time.Duration
+		// EnqueueTimeout is the max time to wait when the queue is full,
+		// the row is dropped after that, defaultEnqueueTimeout if not set.
+		EnqueueTimeout time.Duration
+		// SkipMigrate doesn't migrate an old store schema up automatically,
+		// New fails instead and the schema should be migrated by ras migrate.
+		SkipMigrate bool
+	}
+
 	// TrustManager handles all clients information.
 	TrustManager struct {
 		// control the cache accessing
 		mu sync.Mutex
 		// all clients status cache. (level one)
 		cache map[int64]*cache.Cache
-		// save clients status information, backup of cache and support
-		// rest api search operations... (level two)
-		db *sql.DB
+		// save clients status information. (level two)
+		store Store
+		// store pipe, a bounded queue handled by limited workers which
+		// save reports and base values into store in batches.
+		opts    Options
+		queueMu sync.RWMutex
+		queue   chan interface{}
+		closed  bool
+		wg      sync.WaitGroup
+		stats   StoreStats
+		// the latest version of all policies by name.
+		policyMu sync.RWMutex
+		policies map[string]*policyEntry
+		// serialize the base value lifecycle changes.
+		baseMu sync.Mutex
+		// all node groups by id with their active host base values.
+		groupMu sync.RWMutex
+		groups  map[int64]*groupEntry
+		// the maintenance windows which are not closed yet by id.
+		windowMu sync.RWMutex
+		windows  map[int64]*typdefs.MaintWindowRow
 	}
 )
 
-var (
-	tmgr *TrustManager = nil
-)
-
-// CreateTrustManager creates a new trust manager with a global cache
-// and a database connection poll to enhance performance.
-func CreateTrustManager(dbType, dbConfig string) {
-	var err error
-	var id int64
-	var ik string
-	var regtime time.Time
-	if tmgr != nil {
-		return
+// New creates a new trust manager with a cache of all enabled clients
+// read from store and starts the store pipe workers. If store has a
+// versioned schema, it must not be newer than ras supports.
+func New(store Store, opts Options) (*TrustManager, error) {
+	if store == nil {
+		return nil, typdefs.ErrParameterWrong
+	}
+	if m, ok := store.(Migrator); ok {
+		err := checkSchema(m, !opts.SkipMigrate)
+		if err != nil {
+			return nil, err
+		}
 	}
-	tmgr = &TrustManager{}
-	tmgr.db, err = sql.Open(dbType, dbConfig)
+	// read clients info from store into cache.
+	rows, err := store.FindAllEnabledClients()
 	if err != nil {
-		return
+		return nil, err
 	}
-	tmgr.mu.Lock()
-	tmgr.cache = make(map[int64]*cache.Cache, constRacDefault)
-	tmgr.mu.Unlock()
-	// read clients info from database into cache.
-	rows, err := tmgr.db.Query(sqlFindAllEnabledClients)
+	t := &TrustManager{
+		cache: make(map[int64]*cache.Cache, constRacDefault),
+		store: store,
+	}
+	for _, row := range rows {
+		c := cache.NewCache()
+		c.SetRegTime(row.RegTime.Format(typdefs.StrTimeFormat))
+		c.SetIKeyCert(row.IKCert)
+		c.SetClientInfo(row.Info)
+		t.cache[row.ID] = c
+	}
+	err = t.loadPolicies()
 	if err != nil {
-		tmgr.db.Close()
-		tmgr.mu.Lock()
-		tmgr.cache = nil
-		tmgr.mu.Unlock()
-		tmgr = nil
-		return
+		return nil, err
 	}
-	for rows.Next() {
-		err = rows.Scan(&id, &regtime, &ik)
-		if err == nil {
-			c := cache.NewCache()
-			c.SetRegTime(regtime.Format(typdefs.StrTimeFormat))
-			c.SetIKeyCert(ik)
-			tmgr.cache[id] = c
-		}
+	err = t.loadImaStates()
+	if err != nil {
+		return nil, err
+	}
+	err = t.loadGroups()
+	if err != nil {
+		return nil, err
+	}
+	t.resolveClientGroups()
+	err = t.loadBaseValues()
+	if err != nil {
+		return nil, err
+	}
+	err = t.loadMaintWindows()
+	if err != nil {
+		return nil, err
 	}
-	createStorePipe(dbType, dbConfig)
+	t.createStorePipe(opts)
+	return t, nil
 }
 
-// ReleaseTrustManager releases the manager database connection.
-func ReleaseTrustManager() {
-	if tmgr == nil {
-		return
-	}
-	if tmgr.db != nil {
-		tmgr.db.Close()
-		tmgr.db = nil
+// Close saves all queued reports and base values, then stops the store
+// pipe workers and releases the store.
+func (t *TrustManager) Close() error {
+	if !t.releaseStorePipe() {
+		return nil
 	}
-	tmgr.mu.Lock()
-	tmgr.cache = nil
-	tmgr.mu.Unlock()
-	tmgr = nil
-	releaseStorePipe()
+	t.mu.Lock()
+	t.cache = nil
+	t.mu.Unlock()
+	return t.store.Close()
 }
 
 // GetCache returns the client cache ref by id or nil if not find.
-func GetCache(id int64) (*cache.Cache, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	tmgr.mu.Lock()
-	defer tmgr.mu.Unlock()
-	c, ok := tmgr.cache[id]
+func (t *TrustManager) GetCache(id int64) (*cache.Cache, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c, ok := t.cache[id]
 	if ok {
 		return c, nil
 	}
@@ -164,266 +286,212 @@ func GetCache(id int64) (*cache.Cache, error) {
 
 // GetAllNodes returns all clients cache information from "f" to "t"
 // and returns a list nodes to rest api.
-func GetAllNodes(f, t int64) (typdefs.ArrNodeInfo, error) {
+func (t *TrustManager) GetAllNodes(f, to int64) (typdefs.ArrNodeInfo, error) {
 	var nodes typdefs.ArrNodeInfo
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	tmgr.mu.Lock()
-	defer tmgr.mu.Unlock()
-	for i, v := range tmgr.cache {
-		if f <= i && i < t {
+	caches := map[int64]*cache.Cache{}
+	t.mu.Lock()
+	for i, v := range t.cache {
+		if f <= i && i < to {
+			st := v.GetTrustState()
 			n := typdefs.NodeInfo{
 				ID:        i,
 				RegTime:   v.GetRegTime(),
 				Online:    v.GetOnline(),
 				IPAddress: typdefs.GetIP(),
-				Trusted:   v.GetTrusted(),
+				Trusted:   st.State == typdefs.TrustStateTrusted,
+				State:     st.State,
+				Reason:    st.Reason,
 			}
 			nodes = append(nodes, n)
+			caches[i] = v
 		}
 	}
+	t.mu.Unlock()
+	// the states may expire when they are read.
+	for i, v := range caches {
+		t.saveTrustHistory(i, v)
+	}
 	sort.Sort(nodes)
 	return nodes, nil
 }
 
 // UpdateAllNodes lets all clients to update configuration from ras in next heart beat.
-func UpdateAllNodes() {
-	if tmgr == nil {
-		return
-	}
-	tmgr.mu.Lock()
-	defer tmgr.mu.Unlock()
-	for _, n := range tmgr.cache {
+func (t *TrustManager) UpdateAllNodes() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, n := range t.cache {
 		n.SetCommands(typdefs.CmdSendConfig)
 	}
 }
 
 // RegisterClientByIK registers a new client by ikCert and info. if there is a existing ik, register will fail.
-func RegisterClientByIK(ikCert, info string) (*typdefs.ClientRow, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	c := typdefs.ClientRow{IKCert: ikCert}
-	err := tmgr.db.QueryRow(sqlFindClientIDByIK, ikCert).Scan(&c.ID)
+func (t *TrustManager) RegisterClientByIK(ikCert, info string) (*typdefs.ClientRow, error) {
+	_, err := t.store.FindClientByIK(ikCert)
 	if err == nil {
 		return nil, typdefs.ErrAlreadyRegistered
 	}
-	c = typdefs.ClientRow{
+	c := typdefs.ClientRow{
 		RegTime: time.Now(),
 		Deleted: false,
 		Info:    info,
 		IKCert:  ikCert,
 	}
-	err = tmgr.db.QueryRow(sqlRegisterClientByIK, c.RegTime,
-		c.Deleted, c.Info, c.IKCert).Scan(&c.ID)
+	err = t.store.RegisterClient(&c)
 	if err != nil {
 		return nil, err
 	}
 	ca := cache.NewCache()
 	ca.SetRegTime(c.RegTime.Format(typdefs.StrTimeFormat))
 	ca.SetIKeyCert(ikCert)
-	tmgr.mu.Lock()
-	tmgr.cache[c.ID] = ca
-	tmgr.mu.Unlock()
+	ca.SetClientInfo(info)
+	ca.SetGroupID(t.clientGroupID(info))
+	ca.SetTrustState(typdefs.TrustStatePending, typdefs.TrustReasonRegistered, "")
+	t.mu.Lock()
+	t.cache[c.ID] = ca
+	t.mu.Unlock()
+	t.saveTrustHistory(c.ID, ca)
 	return &c, nil
 }
 
-func UnRegisterClientByID(id int64) {
-	_, err := GetCache(id)
+// UnRegisterClientByID removes a client from cache and marks it deleted in store.
+func (t *TrustManager) UnRegisterClientByID(id int64) {
+	_, err := t.GetCache(id)
 	if err != nil {
 		return
 	}
-	tmgr.mu.Lock()
-	delete(tmgr.cache, id)
-	tmgr.mu.Unlock()
-	tmgr.db.Exec(sqlUnRegisterClientByID, id)
+	t.mu.Lock()
+	delete(t.cache, id)
+	t.mu.Unlock()
+	t.store.UnRegisterClientByID(id)
 }
 
-// FindClientByIK gets client from database by ak.
-func FindClientByIK(ikCert string) (*typdefs.ClientRow, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	c := typdefs.ClientRow{IKCert: ikCert}
-	err := tmgr.db.QueryRow(sqlFindClientFullByIK, ikCert).Scan(&c.ID,
-		&c.RegTime, &c.Deleted, &c.Info)
-	if err != nil {
-		return nil, err
-	}
-	return &c, nil
+// FindClientByIK gets client from store by ik.
+func (t *TrustManager) FindClientByIK(ikCert string) (*typdefs.ClientRow, error) {
+	return t.store.FindClientByIK(ikCert)
 }
 
-// FindClientByID gets client from database by id.
-func FindClientByID(id int64) (*typdefs.ClientRow, error) {
-	_, err := GetCache(id)
-	if err != nil {
-		return nil, err
-	}
-	c := typdefs.ClientRow{ID: id}
-	err = tmgr.db.QueryRow(sqlFindClientByID, id).Scan(&c.RegTime,
-		&c.Deleted, &c.Info, &c.IKCert)
+// FindClientByID gets client from store by id.
+func (t *TrustManager) FindClientByID(id int64) (*typdefs.ClientRow, error) {
+	_, err := t.GetCache(id)
 	if err != nil {
 		return nil, err
 	}
-	return &c, nil
+	return t.store.FindClientByID(id)
 }
 
-// FindClientsByInfo gets clients from database ref by info,
+// FindClientsByInfo gets clients from store ref by info,
 // info must be a json string like `{"key": "value"}`.
-func FindClientsByInfo(info string) ([]typdefs.ClientRow, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	rows, err := tmgr.db.Query(sqlFindClientsByInfo, info)
-	if err != nil {
-		return nil, err
-	}
-	cs := make([]typdefs.ClientRow, 0, 10)
-	for rows.Next() {
-		c := typdefs.ClientRow{}
-		err2 := rows.Scan(&c.ID, &c.RegTime, &c.Deleted, &c.Info, &c.IKCert)
-		if err2 != nil {
-			return nil, err2
-		}
-		cs = append(cs, c)
-	}
-	return cs, nil
+func (t *TrustManager) FindClientsByInfo(info string) ([]typdefs.ClientRow, error) {
+	return t.store.FindClientsByInfo(info)
 }
 
 // FindReportsByClientID returns all reports by a specific client id.
-func FindReportsByClientID(id int64) ([]typdefs.ReportRow, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	rows, err := tmgr.db.Query(sqlFindReportsByClientID, id)
-	if err != nil {
-		return nil, err
-	}
-	reports := make([]typdefs.ReportRow, 0, 100)
-	for rows.Next() {
-		res := typdefs.ReportRow{}
-		err2 := rows.Scan(&res.ID, &res.ClientID,
-			&res.CreateTime, &res.Validated, &res.Trusted)
-		if err2 != nil {
-			return nil, err2
-		}
-		reports = append(reports, res)
-	}
-	return reports, nil
+func (t *TrustManager) FindReportsByClientID(id int64) ([]typdefs.ReportRow, error) {
+	return t.store.FindReportsByClientID(id)
 }
 
 // FindReportByID returns the report by a specific report id.
-func FindReportByID(id int64) (*typdefs.ReportRow, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	report := typdefs.ReportRow{}
-	err := tmgr.db.QueryRow(sqlFindReportByID, id).Scan(&report.ID, &report.ClientID,
-		&report.CreateTime, &report.Validated, &report.Trusted, &report.Quoted,
-		&report.Signature, &report.PcrLog, &report.BiosLog, &report.ImaLog)
-	if err != nil {
-		return nil, err
-	}
-	return &report, nil
+func (t *TrustManager) FindReportByID(id int64) (*typdefs.ReportRow, error) {
+	return t.store.FindReportByID(id)
 }
 
 // DeleteReportByID deletes a specific report by report id.
-func DeleteReportByID(id int64) error {
-	if tmgr == nil {
-		return typdefs.ErrParameterWrong
-	}
-	_, err := tmgr.db.Exec(sqlDeleteReportByID, id)
-	if err != nil {
-		return err
-	}
-	return nil
+func (t *TrustManager) DeleteReportByID(id int64) error {
+	return t.store.DeleteReportByID(id)
 }
 
 // FindBaseValuesByClientID returns all base values by a specific client id.
-func FindBaseValuesByClientID(id int64) ([]typdefs.BaseRow, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	rows, err := tmgr.db.Query(sqlFindBaseValuesByClientID, id)
-	if err != nil {
-		return nil, err
-	}
-	basevalues := make([]typdefs.BaseRow, 0, 20)
-	for rows.Next() {
-		res := typdefs.BaseRow{}
-		err2 := rows.Scan(&res.ID, &res.BaseType, &res.Uuid,
-			&res.CreateTime, &res.Name, &res.Enabled)
-		if err2 != nil {
-			return nil, err2
-		}
-		basevalues = append(basevalues, res)
-	}
-	return basevalues, nil
+func (t *TrustManager) FindBaseValuesByClientID(id int64) ([]typdefs.BaseRow, error) {
+	return t.store.FindBaseValuesByClientID(id)
 }
 
 // FindBaseValueByID returns a specific base value by base value id.
-func FindBaseValueByID(id int64) (*typdefs.BaseRow, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	basevalue := &typdefs.BaseRow{}
-	err := tmgr.db.QueryRow(sqlFindBaseValueByID, id).Scan(&basevalue.ID,
-		&basevalue.ClientID, &basevalue.BaseType, &basevalue.Uuid, &basevalue.CreateTime, &basevalue.Name,
-		&basevalue.Enabled, &basevalue.Pcr, &basevalue.Bios, &basevalue.Ima)
-	if err != nil {
-		return nil, err
-	}
-	return basevalue, nil
+func (t *TrustManager) FindBaseValueByID(id int64) (*typdefs.BaseRow, error) {
+	return t.store.FindBaseValueByID(id)
 }
 
 // FindBaseValueByUuid returns a specific base value by base value uuid.
-func FindBaseValueByUuid(uuid string) (*typdefs.BaseRow, error) {
-	if tmgr == nil {
-		return nil, typdefs.ErrParameterWrong
-	}
-	basevalue := &typdefs.BaseRow{}
-	err := tmgr.db.QueryRow(sqlFindBaseValueByUuid, uuid).Scan(&basevalue.ID,
-		&basevalue.ClientID, &basevalue.BaseType, &basevalue.Uuid, &basevalue.CreateTime, &basevalue.Name,
-		&basevalue.Enabled, &basevalue.Pcr, &basevalue.Bios, &basevalue.Ima)
+func (t *TrustManager) FindBaseValueByUuid(uuid string) (*typdefs.BaseRow, error) {
+	return t.store.FindBaseValueByUuid(uuid)
+}
+
+// DeleteBaseValueByID deletes a specific base value by base value id.
+func (t *TrustManager) DeleteBaseValueByID(id int64) error {
+	err := t.store.DeleteBaseValueByID(id)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return basevalue, nil
+	t.removeGroupBase(id)
+	return nil
 }
 
-// DeleteBaseValueByID deletes a specific base value by base value id.
-func DeleteBaseValueByID(id int64) error {
-	if tmgr == nil {
-		return typdefs.ErrParameterWrong
+// GetPcrSelection returns the pcr selection which the client should quote,
+// it is the client own one or the default one in config.
+func (t *TrustManager) GetPcrSelection(id int64) string {
+	c, err := t.GetCache(id)
+	if err == nil && c.GetPcrSelection() != "" {
+		return c.GetPcrSelection()
+	}
+	return config.GetPcrSelection()
+}
+
+// SetPcrSelection sets the pcr selection of client and lets it update
+// configuration in next heart beat, empty sel means the default one.
+func (t *TrustManager) SetPcrSelection(id int64, sel string) error {
+	c, err := t.GetCache(id)
+	if err != nil {
+		return err
 	}
-	_, err := tmgr.db.Exec(sqlDeleteBaseValueByID, id)
+	sels, err := typdefs.ParsePcrSelections(sel)
 	if err != nil {
 		return err
 	}
+	c.SetPcrSelection(typdefs.FormatPcrSelections(sels))
+	c.SetCommands(typdefs.CmdSendConfig)
 	return nil
 }
 
 // HandleHeartbeat handles the heat beat request, update client cache and reply some commands.
-func HandleHeartbeat(id int64) (uint64, uint64, error) {
-	c, err := GetCache(id)
+func (t *TrustManager) HandleHeartbeat(id int64) (uint64, uint64, error) {
+	c, err := t.GetCache(id)
 	if err != nil {
 		return 0, 0, err
 	}
+	c.UpdateOnline(config.GetOnlineDuration())
 	c.UpdateHeartBeat(config.GetHBDuration())
-	cmd := c.GetCommands()
+	t.saveTrustHistory(id, c)
+	cmd := c.TakeCommands()
 	nonce := c.GetNonce()
-	c.ClearCommands()
 	return cmd, nonce, nil
 }
 
-// ValidateReport validates the report and returns the result.
-// use the short broken algorithm once one part doesn't match base.
-func ValidateReport(report *typdefs.TrustReport) (bool, error) {
-	c, err := GetCache(report.ClientID)
+// ValidateReport validates the report and returns the result, the client
+// trust state is changed by the result with the failure reason unless the
+// report isn't for the current nonce or its ima log must be resent.
+func (t *TrustManager) ValidateReport(report *typdefs.TrustReport) (bool, error) {
+	c, err := t.GetCache(report.ClientID)
 	if err != nil {
 		return false, err
 	}
+	trusted, err := t.validateReport(c, report)
+	// the client resends the full ima log at once if its incremental ima
+	// log doesn't match the replay state, which isn't a failed report.
+	if !errors.Is(err, typdefs.ErrNonceNotMatch) && !errors.Is(err, typdefs.ErrImaStateMismatch) {
+		state, reason := reportTrustState(err)
+		detail := ""
+		if err != nil {
+			detail = err.Error()
+		}
+		c.SetTrustState(state, reason, detail)
+	}
+	t.saveTrustHistory(report.ClientID, c)
+	return trusted, err
+}
+
+// validateReport validates the report by the client cache c.
+// use the short broken algorithm once one part doesn't match base.
+func (t *TrustManager) validateReport(c *cache.Cache, report *typdefs.TrustReport) (bool, error) {
+	var err error
 	// 1. use cache to check Nonce value.
 	if !c.CompareNonce(report.Nonce) {
 		return false, typdefs.ErrNonceNotMatch
@@ -432,125 +500,398 @@ func ValidateReport(report *typdefs.TrustReport) (bool, error) {
 		ClientID:   report.ClientID,
 		CreateTime: time.Now(),
 	}
-	// 2. check the Quoted/Signature
+	// 2. check the Quoted/Signature, from here on the failed report is
+	// saved as untrusted with the result of the failed check.
 	_, err = checkQuote(c, report, row)
 	if err != nil {
-		return false, err
+		return t.failReport(c, row, typdefs.CheckQuote, err)
 	}
+	row.Results = append(row.Results, typdefs.NewReportResult(typdefs.CheckQuote, 0, nil))
 	// 3. check pcr log
-	_, err = checkPcrLog(report, row)
+	_, err = checkPcrLog(t.GetPcrSelection(report.ClientID), report, row)
 	if err != nil {
-		return false, err
+		return t.failReport(c, row, typdefs.CheckPcrLog, err)
 	}
-	// 4. check bios and ima log
+	row.Results = append(row.Results, typdefs.NewReportResult(typdefs.CheckPcrLog, 0, nil))
+	// 4. check bios and ima log, replay the ima log from the replay
+	// state of client if it only has the entries after the acknowledged.
 	_, err = checkBiosAndImaLog(report, row)
 	if err != nil {
+		return t.failReport(c, row, typdefs.CheckLogs, err)
+	}
+	// the incremental ima log which must be resent isn't saved.
+	err = t.updateImaState(c, report)
+	if errors.Is(err, typdefs.ErrImaStateMismatch) {
+		return false, err
+	}
+	if err != nil {
+		return t.failReport(c, row, typdefs.CheckLogs, err)
+	}
+	row.Results = append(row.Results, typdefs.NewReportResult(typdefs.CheckLogs, 0, nil))
+	// 5. in strict mode, the pcrs replayed from bios log must equal the
+	// quoted pcrs.
+	if config.GetStrictPcr() {
+		err = checkBiosPcrs(report)
+		if err != nil {
+			return t.failReport(c, row, typdefs.CheckBiosPcr, err)
+		}
+		row.Results = append(row.Results, typdefs.NewReportResult(typdefs.CheckBiosPcr, 0, nil))
+	}
+	// 6. verify the report by all host base values of the client, or the
+	// one of its node group if it has none, the result of each one is saved
+	// with report. The parts being updated automatically aren't verified.
+	w, auto := t.autoUpdate(c, report.ClientID)
+	if !auto || (w != nil && !w.AllowsAll()) {
+		results, err := t.verifyReport(c, report, w)
+		if err != nil {
+			return t.failReport(c, row, typdefs.CheckBaseValue, err)
+		}
+		row.Results = append(row.Results, results...)
+	}
+	// 7. verify the running containers and devices by their base values,
+	// the results don't change the trust of client.
+	row.Results = append(row.Results, t.verifyContainers(c, report)...)
+	row.Results = append(row.Results, t.verifyDevices(c, report)...)
+	// the report is saved as untrusted if any host base value fails.
+	err = checkBaseResults(row.Results)
+	if err != nil {
+		t.saveReport(c, row, false)
+		return false, err
+	}
+	// 8. check secure boot state, the report is saved as untrusted
+	// with the failure reasons if it doesn't satisfy the policy.
+	err = checkSecureBoot(config.GetSecureBootPolicy(), report, row)
+	row.Results = append(row.Results, typdefs.NewReportResult(typdefs.CheckSecureBoot, 0, err))
+	if err != nil {
+		t.saveReport(c, row, false)
 		return false, err
 	}
+	// 9. evaluate the policies which select the client, the report is
+	// saved as untrusted with the verdict if any fail rule fails.
+	verdict, err := t.evaluatePolicies(c, report)
+	if err != nil {
+		return t.failReport(c, row, typdefs.CheckPolicy, err)
+	}
+	if verdict != nil {
+		buf, _ := json.Marshal(verdict)
+		row.Verdict = string(buf)
+		if verdict.Result == typdefs.VerdictFail {
+			err = &PolicyError{Rules: verdict.FailedRules()}
+		}
+		row.Results = append(row.Results, typdefs.NewReportResult(typdefs.CheckPolicy, verdict.BaseID, err))
+		if err != nil {
+			t.saveReport(c, row, false)
+			return false, err
+		}
+	}
+	t.saveReport(c, row, true)
+	return true, nil
+}
+
+// BaseValueError lists why the report doesn't match the host base values.
+type BaseValueError struct {
+	Details []string
+}
+
+func (e *BaseValueError) Error() string {
+	return "base value check failed: " + strings.Join(e.Details, "; ")
+}
+
+// Unwrap makes errors.Is(err, typdefs.ErrBaseValueFail) work.
+func (e *BaseValueError) Unwrap() error {
+	return typdefs.ErrBaseValueFail
+}
+
+// checkBaseResults returns a BaseValueError if any host base value check
+// of results fails.
+func checkBaseResults(results []typdefs.ReportResult) error {
+	var details []string
+	for _, r := range results {
+		if r.Check == typdefs.CheckBaseValue && !r.Passed {
+			details = append(details, fmt.Sprintf("base %d: %s", r.BaseID, r.Detail))
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	return &BaseValueError{Details: details}
+}
+
+// failReport saves the report row as untrusted with the failed result of
+// check, the pcrs which don't match bios log are saved as its mismatches.
+func (t *TrustManager) failReport(c *cache.Cache, row *typdefs.ReportRow, check string, err error) (bool, error) {
+	res := typdefs.NewReportResult(check, 0, err)
+	var bioErr *BiosPcrError
+	if errors.As(err, &bioErr) {
+		res.Mismatches = bioErr.mismatches()
+	}
+	row.Results = append(row.Results, res)
+	t.saveReport(c, row, false)
+	return false, err
+}
+
+// saveReport saves the validated report and updates the client status.
+func (t *TrustManager) saveReport(c *cache.Cache, row *typdefs.ReportRow, trusted bool) {
 	row.Validated = true
-	row.Trusted = true
-	c.SetTrusted(true)
+	row.Trusted = trusted
 	c.UpdateTrustReport(config.GetTrustDuration())
 	c.UpdateOnline(config.GetOnlineDuration())
-	go pushToStorePipe(row)
-	return true, nil
+	t.pushToStorePipe(row)
 }
 
 func checkQuote(c *cache.Cache, report *typdefs.TrustReport, row *typdefs.ReportRow) (bool, error) {
 	if len(report.Quoted) == 0 || len(report.Signature) == 0 {
 		return false, typdefs.ErrParameterWrong
 	}
-	signature := new(tpm2.Signature)
-	err := json.Unmarshal(report.Signature, signature)
+	err := checkOneQuote(c, report, report.Quoted, report.Signature)
 	if err != nil {
 		return false, err
 	}
+	// the quotes of other pcr banks must be signed and fresh too.
+	for _, q := range report.Quotes {
+		err = checkOneQuote(c, report, q.Quoted, q.Signature)
+		if err != nil {
+			return false, err
+		}
+	}
+	row.Signature = string(report.Signature)
+	row.Quoted = hex.EncodeToString(report.Quoted)
+	return true, nil
+}
 
-	/*alg := config.GetDigestAlgorithm()
-	h, err := typdefs.GetHFromAlg(alg)
+// checkOneQuote checks the signature and freshness of one pcr bank quote.
+func checkOneQuote(c *cache.Cache, report *typdefs.TrustReport, quoted, sig []byte) error {
+	if len(quoted) == 0 || len(sig) == 0 {
+		return typdefs.ErrParameterWrong
+	}
+	signature := new(tpm2.Signature)
+	err := json.Unmarshal(sig, signature)
 	if err != nil {
-		return false, err
-	}*/
-	h := sha256.New()
-	h.Write(report.Quoted)
-	datahash := h.Sum(nil)
+		return err
+	}
 	ikCert := c.GetIKeyCert()
 	if ikCert == nil {
-		return false, typdefs.ErrIKCertNull
-	}
-	/*
-		switch alg {
-		case typdefs.Sha1AlgStr:
-			err = rsa.VerifyPKCS1v15(ikCert.PublicKey.(*rsa.PublicKey),
-				crypto.SHA1, datahash, signature.RSA.Signature)
-		case typdefs.Sha256AlgStr:
-			err = rsa.VerifyPKCS1v15(ikCert.PublicKey.(*rsa.PublicKey),
-				crypto.SHA256, datahash, signature.RSA.Signature)
-		case typdefs.Sm3AlgStr:
-			// set hash parameter 0 because sm3 is not supported in crypto package
-			err = rsa.VerifyPKCS1v15(ikCert.PublicKey.(*rsa.PublicKey),
-				0, datahash, signature.RSA.Signature)
-		default:
-			return false, typdefs.ErrNotSupportAlg
-		}*/
-	err = rsa.VerifyPKCS1v15(ikCert.PublicKey.(*rsa.PublicKey),
-		crypto.SHA256, datahash, signature.RSA.Signature)
+		return typdefs.ErrIKCertNull
+	}
+	// dispatch to the verifier of ik signature scheme and hash algorithm.
+	err = cryptotools.VerifySignature(ikCert.PublicKey, quoted, signature)
 	if err != nil {
-		return false, err
+		return err
 	}
-	row.Signature = string(report.Signature)
-	row.Quoted = hex.EncodeToString(report.Quoted)
-	return true, nil
+	// check the quote is generated freshly for this report, not replayed.
+	return checkQuoteFreshness(c, report, quoted)
 }
 
-func pcrLogToMap(pcrLog []byte) map[int]string {
-	m := make(map[int]string, typdefs.PcrMaxNum)
+// getReportDigestAlg returns the digest algorithm which hashes the trust
+// report input, it is the one ras configures for clients. The one claimed
+// in client info isn't used, so a client can't downgrade it.
+func getReportDigestAlg() string {
+	alg := config.GetDigestAlgorithm()
+	if alg == "" {
+		alg = typdefs.Sha1AlgStr
+	}
+	return alg
+}
+
+// parseQuoteHeader parses the header of TPMS_ATTEST structure in quoted and
+// returns the qualified signer and extra data fields.
+//
+//	TPMS_ATTEST:
+//	  magic(4) type(2) qualifiedSigner(TPM2B_NAME) extraData(TPM2B_DATA) ...
+func parseQuoteHeader(quoted []byte) ([]byte, []byte, error) {
+	var magic uint32
+	var typ tpmutil.Tag
+	var signer, extra tpmutil.U16Bytes
+	buf := bytes.NewBuffer(quoted)
+	err := tpmutil.UnpackBuf(buf, &magic, &typ)
+	if err != nil {
+		return nil, nil, typdefs.ErrQuoteFormatWrong
+	}
+	if magic != tpmGeneratedValue {
+		return nil, nil, typdefs.ErrQuoteMagicWrong
+	}
+	if typ != tpm2.TagAttestQuote {
+		return nil, nil, typdefs.ErrQuoteTypeWrong
+	}
+	err = tpmutil.UnpackBuf(buf, &signer, &extra)
+	if err != nil {
+		return nil, nil, typdefs.ErrQuoteFormatWrong
+	}
+	return signer, extra, nil
+}
+
+// getIKQualifiedName calculates the qualified name of ik, which is created
+// as a primary key under endorsement hierarchy, so
+//
+//	QN = nameAlg || H_nameAlg(TPM_RH_ENDORSEMENT || ikName)
+func getIKQualifiedName(ikName []byte) ([]byte, error) {
+	if len(ikName) < 2 {
+		return nil, typdefs.ErrParameterWrong
+	}
+	algStr := getAlgStr(tpm2.Algorithm(binary.BigEndian.Uint16(ikName)))
+	h, err := typdefs.GetHFromAlg(algStr)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(tpm2.HandleEndorsement))
+	h.Write(b)
+	h.Write(ikName)
+	return append(append([]byte{}, ikName[:2]...), h.Sum(nil)...), nil
+}
+
+// getAlgStr returns the name of a tpm hash algorithm, empty if not supported.
+func getAlgStr(alg tpm2.Algorithm) string {
+	switch alg {
+	case tpm2.AlgSHA1:
+		return typdefs.Sha1AlgStr
+	case tpm2.AlgSHA256:
+		return typdefs.Sha256AlgStr
+	case cryptotools.AlgSM3:
+		return typdefs.Sm3AlgStr
+	}
+	return ""
+}
+
+// checkQuoteFreshness checks the quote header, the extra data must be the hash
+// of client id, nonce and client info of this report which binds the quote to
+// current nonce, and the qualified signer must be the registered ik.
+func checkQuoteFreshness(c *cache.Cache, report *typdefs.TrustReport, quoted []byte) error {
+	signer, extra, err := parseQuoteHeader(quoted)
+	if err != nil {
+		return err
+	}
+	tRepIn := typdefs.TrustReportInput{
+		ClientID:   report.ClientID,
+		Nonce:      report.Nonce,
+		ClientInfo: report.ClientInfo,
+	}
+	repHash, err := tRepIn.Hash(getReportDigestAlg())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(repHash, extra) {
+		return typdefs.ErrQuoteExtraDataNotMatch
+	}
+	ikCert := c.GetIKeyCert()
+	if ikCert == nil {
+		return typdefs.ErrIKCertNull
+	}
+	// ik certificate issued by old version PCA doesn't have ik name, the
+	// signer can't be checked and it is allowed only by configuration.
+	ikName := cryptotools.GetIKNameFromCert(ikCert)
+	if ikName == nil {
+		if !config.GetAllowLegacyIKCert() {
+			return typdefs.ErrIKNameNull
+		}
+		logger.L.Sugar().Warnf("client %d ik cert has no ik name, skip checking quote qualified signer",
+			report.ClientID)
+		return nil
+	}
+	qn, err := getIKQualifiedName(ikName)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(qn, signer) {
+		return typdefs.ErrQualifiedSignerNotMatch
+	}
+	return nil
+}
+
+// pcrLogToBankMaps parses the pcr log lines "value alg index" into maps of
+// pcr values keyed by pcr bank algorithm and pcr index, also returns the
+// algorithms in the order they appear.
+func pcrLogToBankMaps(pcrLog []byte) (map[string]map[int]string, []string) {
+	m := make(map[string]map[int]string, 2)
+	algs := []string{}
 	lines := bytes.Split(pcrLog, typdefs.NewLine)
 	for _, line := range lines {
 		words := bytes.Split(line, typdefs.Space)
 		if len(words) == 3 {
 			i, err := strconv.Atoi(string(words[2]))
 			if err == nil {
-				m[i] = string(words[0])
+				alg := string(words[1])
+				if m[alg] == nil {
+					m[alg] = make(map[int]string, typdefs.PcrMaxNum)
+					algs = append(algs, alg)
+				}
+				m[alg][i] = string(words[0])
 			}
 		}
 	}
-	return m
+	return m, algs
 }
 
-func checkPcrLog(report *typdefs.TrustReport, row *typdefs.ReportRow) (bool, error) {
-	pcrLog := findManifest(report, typdefs.StrPcr)
-	pcrMap := pcrLogToMap(pcrLog)
-	//use PCRselection to calculate PCRdigest
-	parsedQuote, err := tpm2.DecodeAttestationData(report.Quoted)
-	if err != nil {
-		return false, err
+// pcrLogToMap returns the pcr values of the first pcr bank in pcr log.
+func pcrLogToMap(pcrLog []byte) map[int]string {
+	m, algs := pcrLogToBankMaps(pcrLog)
+	if len(algs) == 0 {
+		return make(map[int]string)
 	}
-	//combine all pcrs
-	temp := []byte{}
-	for _, PCRid := range parsedQuote.AttestedQuoteInfo.PCRSelection.PCRs {
-		pcrValueBytes, err2 := hex.DecodeString(pcrMap[PCRid])
-		if err2 != nil {
+	return m[algs[0]]
+}
+
+// checkPcrLog recalculates the pcr digest of each quoted pcr bank with its
+// hash algorithm by pcr log, and checks the quoted pcrs cover the client
+// pcr selection.
+func checkPcrLog(sel string, report *typdefs.TrustReport, row *typdefs.ReportRow) (bool, error) {
+	pcrLog := findManifest(report, typdefs.StrPcr)
+	pcrMap, _ := pcrLogToBankMaps(pcrLog)
+	quotes := append([]typdefs.Quote{{Quoted: report.Quoted}}, report.Quotes...)
+	quotedSels := make([]typdefs.PcrSelection, 0, len(quotes))
+	for _, q := range quotes {
+		//use PCRselection to calculate PCRdigest
+		parsedQuote, err := tpm2.DecodeAttestationData(q.Quoted)
+		if err != nil {
 			return false, err
 		}
-		temp = append(temp, pcrValueBytes...)
+		pcrSel := parsedQuote.AttestedQuoteInfo.PCRSelection
+		alg := getAlgStr(pcrSel.Hash)
+		h, err := typdefs.GetHFromAlg(alg)
+		if err != nil {
+			return false, err
+		}
+		//combine all pcrs of this bank and calculate new pcr digest
+		for _, PCRid := range pcrSel.PCRs {
+			pcrValueBytes, err2 := hex.DecodeString(pcrMap[alg][PCRid])
+			if err2 != nil {
+				return false, err2
+			}
+			h.Write(pcrValueBytes)
+		}
+		if !bytes.Equal(h.Sum(nil), parsedQuote.AttestedQuoteInfo.PCRDigest) {
+			return false, typdefs.ErrPCRNotMatch
+		}
+		quotedSels = append(quotedSels, typdefs.PcrSelection{HashAlg: alg, PCRs: pcrSel.PCRs})
 	}
-	//calculate new pcr digest
-	/*alg := config.GetDigestAlgorithm()
-	h1, err := typdefs.GetHFromAlg(alg)
+	err := checkPcrSelection(sel, quotedSels)
 	if err != nil {
 		return false, err
-	}*/
-	h1 := sha256.New()
-	h1.Write(temp)
-	newDigestBytes := h1.Sum(nil)
-	if !bytes.Equal(newDigestBytes, parsedQuote.AttestedQuoteInfo.PCRDigest) {
-		return false, typdefs.ErrPCRNotMatch
 	}
 	row.PcrLog = string(pcrLog)
 	return true, nil
 }
 
+// checkPcrSelection checks at least one pcr bank of selection sel is quoted
+// with all its selected pcrs, empty sel accepts any pcr bank.
+func checkPcrSelection(sel string, quoted []typdefs.PcrSelection) error {
+	want, err := typdefs.ParsePcrSelections(sel)
+	if err != nil {
+		return err
+	}
+	if len(want) == 0 {
+		return nil
+	}
+	for i := range want {
+		for j := range quoted {
+			if quoted[j].Contains(&want[i]) {
+				return nil
+			}
+		}
+	}
+	return typdefs.ErrPcrBankNotQuoted
+}
+
 func findManifest(report *typdefs.TrustReport, key string) []byte {
 	for _, m := range report.Manifests {
 		if m.Key == key {
@@ -569,87 +910,268 @@ func checkBiosAndImaLog(report *typdefs.TrustReport, row *typdefs.ReportRow) (bo
 	imaLog := findManifest(report, typdefs.StrIma)
 	row.BiosLog = string(btLog)
 	row.ImaLog = string(imaLog)
-	return typdefs.ExtendPCRWithIMALog(pcrs, imaLog, config.GetDigestAlgorithm())
+	// the incremental ima log doesn't start with boot aggregate.
+	if report.ImaOffset > 0 {
+		_, err := typdefs.ReplayIMALog(pcrs, imaLog, imaDigestAlg())
+		return err == nil, err
+	}
+	return typdefs.ExtendPCRWithIMALog(pcrs, imaLog, imaDigestAlg())
+}
+
+// PcrMismatch is one pcr whose value replayed from bios log doesn't equal
+// the quoted value, Quoted is empty if the pcr isn't quoted.
+type PcrMismatch struct {
+	Pcr       int
+	Alg       string
+	Quoted    string
+	Replayed  string
+	LastEvent string
+}
+
+// BiosPcrError lists all the pcrs replayed from bios log which don't equal
+// the quoted pcrs.
+type BiosPcrError struct {
+	Mismatches []PcrMismatch
+}
+
+func (e *BiosPcrError) Error() string {
+	parts := make([]string, 0, len(e.Mismatches))
+	for _, m := range e.Mismatches {
+		quoted := m.Quoted
+		if quoted == "" {
+			quoted = "not quoted"
+		}
+		parts = append(parts, fmt.Sprintf("pcr %d %s replayed %s, quoted %s, last event %s",
+			m.Pcr, m.Alg, m.Replayed, quoted, m.LastEvent))
+	}
+	return typdefs.ErrBiosPcrNotMatch.Error() + ": " + strings.Join(parts, "; ")
 }
 
-func HandleBaseValue(report *typdefs.TrustReport) error {
-	// if this client's AutoUpdate is true, save base value of rac which in the update list
-	if tmgr.cache[report.ClientID].GetIsAutoUpdate() {
+// Unwrap makes errors.Is(err, typdefs.ErrBiosPcrNotMatch) work.
+func (e *BiosPcrError) Unwrap() error {
+	return typdefs.ErrBiosPcrNotMatch
+}
+
+// mismatches returns the pcrs as report result mismatches, the replayed
+// value is expected and the quoted one is actual.
+func (e *BiosPcrError) mismatches() []typdefs.Mismatch {
+	res := make([]typdefs.Mismatch, 0, len(e.Mismatches))
+	for _, m := range e.Mismatches {
+		mm := typdefs.Mismatch{Type: typdefs.StrPcr, Name: strconv.Itoa(m.Pcr), Alg: m.Alg,
+			Reason: typdefs.MismatchNotEqual}
+		if v, err := hex.DecodeString(m.Replayed); err == nil {
+			mm.Expected = []typdefs.HexBytes{v}
+		}
+		if m.Quoted == "" {
+			mm.Reason = typdefs.MismatchNotFound
+		} else if v, err := hex.DecodeString(m.Quoted); err == nil {
+			mm.Actual = v
+		}
+		res = append(res, mm)
+	}
+	return res
+}
+
+// checkBiosPcrs replays the bios log and checks every pcr it covers equals
+// the quoted pcr of the same bank. The banks which aren't quoted are
+// skipped, but at least one of the bios log banks must be quoted.
+func checkBiosPcrs(report *typdefs.TrustReport) error {
+	bLog := findManifest(report, typdefs.StrBios)
+	if len(bLog) == 0 {
+		return nil
+	}
+	events, err := typdefs.ParseBiosEventLog(bLog)
+	if err != nil {
+		return err
+	}
+	replay := typdefs.ReplayBiosEvents(events)
+	if len(replay.Pcrs) == 0 {
+		return nil
+	}
+	sels, err := getQuotedSelections(report)
+	if err != nil {
+		return err
+	}
+	pcrMap, _ := pcrLogToBankMaps(findManifest(report, typdefs.StrPcr))
+	bioErr := &BiosPcrError{}
+	checked := false
+	for _, sel := range sels {
+		pcrs, ok := replay.Pcrs[sel.HashAlg]
+		if !ok {
+			continue
+		}
+		checked = true
+		quoted := make(map[int]bool, len(sel.PCRs))
+		for _, i := range sel.PCRs {
+			quoted[i] = true
+		}
+		idx := make([]int, 0, len(pcrs))
+		for i := range pcrs {
+			idx = append(idx, i)
+		}
+		sort.Ints(idx)
+		for _, i := range idx {
+			v := hex.EncodeToString(pcrs[i])
+			q := ""
+			if quoted[i] {
+				q = pcrMap[sel.HashAlg][i]
+			}
+			if !strings.EqualFold(q, v) {
+				bioErr.Mismatches = append(bioErr.Mismatches, PcrMismatch{Pcr: i, Alg: sel.HashAlg,
+					Quoted: q, Replayed: v, LastEvent: replay.LastEvents[i].ID})
+			}
+		}
+	}
+	if !checked {
+		return fmt.Errorf("%w: no pcr bank of bios log is quoted", typdefs.ErrBiosPcrNotMatch)
+	}
+	if len(bioErr.Mismatches) > 0 {
+		return bioErr
+	}
+	return nil
+}
+
+// getQuotedSelections returns the pcr selections of all quotes in report.
+func getQuotedSelections(report *typdefs.TrustReport) ([]typdefs.PcrSelection, error) {
+	quotes := append([]typdefs.Quote{{Quoted: report.Quoted}}, report.Quotes...)
+	sels := make([]typdefs.PcrSelection, 0, len(quotes))
+	for _, q := range quotes {
+		parsedQuote, err := tpm2.DecodeAttestationData(q.Quoted)
+		if err != nil {
+			return nil, err
+		}
+		pcrSel := parsedQuote.AttestedQuoteInfo.PCRSelection
+		sels = append(sels, typdefs.PcrSelection{HashAlg: getAlgStr(pcrSel.Hash), PCRs: pcrSel.PCRs})
+	}
+	return sels, nil
+}
+
+// HandleBaseValue extracts/records the base value from report or verifies report by base values.
+func (t *TrustManager) HandleBaseValue(report *typdefs.TrustReport) error {
+	c, err := t.GetCache(report.ClientID)
+	if err != nil {
+		return err
+	}
+	// if this client's AutoUpdate is true or it is in a maintenance window,
+	// save base value of rac which in the update list
+	if w, auto := t.autoUpdate(c, report.ClientID); auto {
 		{
-			err := recordAutoUpdateReport(report)
+			err := t.recordAutoUpdateReport(report, w)
 			if err != nil {
 				return err
 			}
 		}
 	} else {
 		// if this client's AutoUpdate is false, and if this is the first report of this RAC, extract base value
-		isFirstReport, err := isFirstReport(report.ClientID)
+		isFirstReport, err := t.isFirstReport(report.ClientID)
 		if err != nil {
 			return err
 		}
-		baseValue := typdefs.BaseRow{}
+		var groupBase *typdefs.BaseRow
 		if isFirstReport {
+			groupBase = t.groupHostBase(c)
+		}
+		baseValue := typdefs.BaseRow{}
+		// the client verified by the host base value of its node group
+		// doesn't need its own one.
+		if isFirstReport && groupBase == nil {
 			// this is first report, so oldBase is nil
 			err = extract(report, nil, &baseValue)
 			if err != nil {
 				return err
 			}
-			// TODO:1.保证一个ClientID的基准值同时只有一个Enabled=TRUE
-			// 2.完善Name字段
+			// the base value of first report is a draft until it is
+			// approved and activated by user.
+			// TODO: 完善Name字段
 			baseValue.ClientID = report.ClientID
+			baseValue.BaseType = typdefs.BaseTypeHost
 			baseValue.CreateTime = time.Now()
 			baseValue.Enabled = false
+			baseValue.State = typdefs.BaseStateDraft
+			baseValue.Author = typdefs.BaseAuthorRas
 			baseValue.Verified = false
 			baseValue.Trusted = false
-			SaveBaseValue(&baseValue)
-		} else {
-			verifyReport(report)
+			t.SaveBaseValue(&baseValue)
 		}
+		// the following reports are verified by ValidateReport.
 	}
 	return nil
 }
 
-// Traverse the base values ​​in the cache and compare with report,
-// save the result in the cache
-func verifyReport(report *typdefs.TrustReport) {
-	for _, base := range tmgr.cache[report.ClientID].HostBase {
-		err := Verify(base, report)
-		base.Verified = true
-		if err != nil {
-			base.Trusted = false
-		} else {
-			base.Trusted = true
-		}
+// verifyReport verifies report by each host base value in the cache,
+// saves the results into the cache and returns them as report results.
+// The client without its own host base value is verified by the active
+// one of its node group, which is shared and not changed by the result.
+// The parts which the maintenance window w allows to change are skipped.
+func (t *TrustManager) verifyReport(c *cache.Cache, report *typdefs.TrustReport,
+	w *typdefs.MaintWindowRow) ([]typdefs.ReportResult, error) {
+	var results []typdefs.ReportResult
+	c.VerifyHostBases(func(base *typdefs.BaseRow) error {
+		err := verifyExcept(base, report, w)
+		results = append(results, typdefs.NewReportResult(typdefs.CheckBaseValue, base.ID, err))
+		return err
+	})
+	if len(results) > 0 {
+		return results, nil
+	}
+	base := t.groupHostBase(c)
+	if base == nil {
+		return nil, nil
 	}
+	err := verifyExcept(base, report, w)
+	return []typdefs.ReportResult{typdefs.NewReportResult(typdefs.CheckBaseValue, base.ID, err)}, nil
 }
 
-func recordAutoUpdateReport(report *typdefs.TrustReport) error {
-
-	c, err := GetCache(report.ClientID)
+// recordAutoUpdateReport extracts a new host base value from report and
+// saves it as the active one if it changes. In the maintenance window w,
+// only the parts allowed by w change and the new base value is attributed
+// to w and its author, it is shared by the node group of w if the client
+// is verified by the group one.
+func (t *TrustManager) recordAutoUpdateReport(report *typdefs.TrustReport, w *typdefs.MaintWindowRow) error {
+	c, err := t.GetCache(report.ClientID)
 	if err != nil {
 		return err
 	}
-	bases := c.HostBase
-	newBase := typdefs.BaseRow{ClientID: report.ClientID}
-	oldBase := typdefs.BaseRow{}
-	// If the client's basevalue exists in the cache,
-	// the extraction template is consistent with the newest one.
+	newBase := typdefs.BaseRow{ClientID: report.ClientID, BaseType: typdefs.BaseTypeHost}
+	// If the client's basevalue exists, the extraction template is
+	// consistent with the newest one.
 	// Otherwise, read extraction template from config.
-	if len(bases) != 0 {
-		oldBase = *bases[len(bases)-1]
+	oldBase := t.effectiveHostBase(c)
+	if oldBase == nil {
+		oldBase = &typdefs.BaseRow{}
 	}
-	err = extract(report, &oldBase, &newBase)
+	err = extract(report, oldBase, &newBase)
 	if err != nil {
 		return err
 	}
-	// TODO:1.保证一个ClientID的基准值同时只有一个Enabled=TRUE
-	// 2.完善Name字段
-	if isBaseUpdate(&oldBase, &newBase) {
+	newBase.Author = typdefs.BaseAuthorRas
+	if w != nil {
+		if !w.Allows(typdefs.WindowPartPcr) {
+			newBase.Pcr = oldBase.Pcr
+		}
+		if !w.Allows(typdefs.WindowPartBios) {
+			newBase.Bios = oldBase.Bios
+		}
+		if !w.Allows(typdefs.WindowPartIma) {
+			newBase.Ima = oldBase.Ima
+		}
+		if w.GroupID != 0 && oldBase.GroupID == w.GroupID {
+			newBase.ClientID = 0
+			newBase.GroupID = w.GroupID
+		}
+		newBase.WindowID = w.ID
+		newBase.Author = w.Author
+	}
+	// the updated base value is activated directly and retires the old
+	// active one when it is saved.
+	// TODO: 完善Name字段
+	if isBaseUpdate(oldBase, &newBase) {
 		newBase.CreateTime = time.Now()
 		newBase.Enabled = true
+		newBase.State = typdefs.BaseStateActive
 		newBase.Verified = true
 		newBase.Trusted = true
-		SaveBaseValue(&newBase)
+		t.SaveBaseValue(&newBase)
 	}
 
 	return nil
@@ -671,16 +1193,13 @@ func isBaseUpdate(oldBase *typdefs.BaseRow, newBase *typdefs.BaseRow) bool {
 	return false
 }
 
-func isFirstReport(clientId int64) (bool, error) {
-	if tmgr == nil {
-		return false, typdefs.ErrParameterWrong
-	}
-	rows, err := tmgr.db.Query(sqlFindReportsByClientID, clientId)
+func (t *TrustManager) isFirstReport(clientId int64) (bool, error) {
+	rows, err := t.store.FindReportsByClientID(clientId)
 	if err != nil {
 		return false, err
 	}
 	// because isFirstReport is judged after saving report, len(rows) == 1
-	if !rows.Next() {
+	if len(rows) == 0 {
 		return true, nil
 	}
 
@@ -692,30 +1211,64 @@ func extract(report *typdefs.TrustReport, oldBase, newBase *typdefs.BaseRow) err
 	newBase.Pcr = extractPCR(report, oldBase)
 	newBase.Bios = extractBIOS(report, oldBase)
 	newBase.Ima = extractIMA(report, oldBase)
+	newBase.ImaMode = oldBase.ImaMode
 	return nil
 }
 
+// Verify checks the report by the reference values of base value, the
+// legacy text base value is converted to reference values first.
 func Verify(baseValue *typdefs.BaseRow, report *typdefs.TrustReport) error {
+	return verifyExcept(baseValue, report, nil)
+}
 
-	if err := verifyPCR(report, baseValue); err != nil {
-		return fmt.Errorf("pcr manifest verification failed, error: %s", err)
+// verifyExcept is Verify but skips the parts which the maintenance window
+// w allows to change, nil w skips nothing.
+func verifyExcept(baseValue *typdefs.BaseRow, report *typdefs.TrustReport, w *typdefs.MaintWindowRow) error {
+	rv, err := GetRefValues(baseValue)
+	if err != nil {
+		return fmt.Errorf("base value format wrong, error: %w", err)
+	}
+	if !w.Allows(typdefs.WindowPartPcr) {
+		if err := verifyPCR(report, rv); err != nil {
+			return fmt.Errorf("pcr manifest verification failed, error: %w", err)
+		}
 	}
-	if err := verifyBIOS(report, baseValue); err != nil {
-		return fmt.Errorf("bios manifest verification failed, error: %s", err)
+	if !w.Allows(typdefs.WindowPartBios) {
+		if err := verifyBIOS(report, rv); err != nil {
+			return fmt.Errorf("bios manifest verification failed, error: %w", err)
+		}
 	}
-	if err := verifyIMA(report, baseValue); err != nil {
-		return fmt.Errorf("ima manifest verification failed, error: %s", err)
+	if !w.Allows(typdefs.WindowPartIma) {
+		if err := verifyIMA(report, baseValue.ImaMode, rv); err != nil {
+			return fmt.Errorf("ima manifest verification failed, error: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// GetRefValues returns the typed reference values of base value, they are
+// converted from the legacy text if the base value has none.
+func GetRefValues(base *typdefs.BaseRow) (*typdefs.RefValues, error) {
+	if base.RefValue != "" {
+		return typdefs.ParseRefValues([]byte(base.RefValue))
+	}
+	return typdefs.ConvertLegacyRefValues(base.Pcr, base.Bios, base.Ima, config.GetDigestAlgorithm())
+}
+
+// GetExtractRulesFromPcr returns the pcr indexes of the "index:value"
+// lines in pcr base value.
 func GetExtractRulesFromPcr(pcrlog string) []int {
 	res := []int{}
-	lines := bytes.Split([]byte(pcrlog), typdefs.NewLine)
-	for _, line := range lines {
-		v, _ := strconv.Atoi(string(line[0]))
-		res = append(res, v)
+	for _, line := range strings.Split(pcrlog, "\n") {
+		i := strings.Index(line, ":")
+		if i <= 0 {
+			continue
+		}
+		v, err := strconv.Atoi(line[:i])
+		if err == nil {
+			res = append(res, v)
+		}
 	}
 	return res
 }
@@ -738,118 +1291,144 @@ func extractPCR(report *typdefs.TrustReport, base *typdefs.BaseRow) string {
 	return buf.String()
 }
 
-func verifyPCR(report *typdefs.TrustReport, base *typdefs.BaseRow) error {
-	pcrLog := findManifest(report, typdefs.StrPcr)
-	rPcrMap := pcrLogToMap(pcrLog)
-	bPcrMap := pcrLogToMap([]byte(base.Pcr))
-
-	for i, s := range rPcrMap {
-		if s != bPcrMap[i] {
-			return errors.New(fmt.Sprintf("pcr %d not equal.", i))
+// verifyPCR checks every pcr reference value with the pcr of the same bank
+// in report pcr log, all mismatched pcrs are returned in *MismatchError.
+func verifyPCR(report *typdefs.TrustReport, rv *typdefs.RefValues) error {
+	pcrMap, _ := pcrLogToBankMaps(findManifest(report, typdefs.StrPcr))
+	misErr := &typdefs.MismatchError{}
+	for i := range rv.Pcr {
+		ref := &rv.Pcr[i]
+		n, _ := strconv.Atoi(ref.Name)
+		v, ok := pcrMap[ref.Alg][n]
+		if !ok {
+			if !ref.Optional {
+				misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typdefs.StrPcr,
+					Name: ref.Name, Reason: typdefs.MismatchNotFound, Alg: ref.Alg})
+			}
+			continue
+		}
+		d, err := hex.DecodeString(v)
+		if err != nil || !ref.MatchDigest(ref.Alg, d) {
+			misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typdefs.StrPcr,
+				Name: ref.Name, Reason: typdefs.MismatchNotEqual, Alg: ref.Alg,
+				Expected: ref.Digests, Actual: d})
 		}
 	}
+	if len(misErr.Mismatches) > 0 {
+		return misErr
+	}
 	return nil
 }
 
 // The bios string in BaseRow has the following fields, separated by space:
-//   column 1: hash type
-//	 column 2: filedata-hash
-// 	 column 3: filename-hint
+//
+//	column 1: event name, the stable ID of event, like "7:EV_EFI_VARIABLE_DRIVER_CONFIG:PK"
+//	column 2: sha1 hash
+//	column 3: sha256:sha256 hash
+//	column 4: sm3:sm3 hash, optional
+//
+// the hash which doesn't exist in bios log is "N/A".
+// The former event name "<type hex>-<index>" is also accepted in the
+// extract rules and base value, and is replaced by the stable ID after
+// extraction.
 func extractBIOS(report *typdefs.TrustReport, base *typdefs.BaseRow) string {
 	biosNames := getBiosExtractTemplate(base)
-	used := make([]bool, len(biosNames))
+	events, err := typdefs.ParseBiosEventLog(findManifest(report, typdefs.StrBios))
+	if err != nil {
+		return ""
+	}
+	used := make([]bool, len(events))
 	var buf bytes.Buffer
-	// reset manifest to append extract result
-	bLog := findManifest(report, typdefs.StrBios)
-	btLog, _ := typdefs.TransformBIOSBinLogToTxt(bLog)
-	lines := bytes.Split(btLog, typdefs.NewLine)
-	for _, ln := range lines {
-		words := bytes.Split(ln, typdefs.Space)
-		for i, bn := range biosNames {
-			if used[i] {
+	for _, bn := range biosNames {
+		for i, e := range events {
+			if used[i] || !e.MatchName(bn) {
 				continue
 			}
-			if bn == string(words[2]) {
-				used[i] = true
-				buf.WriteString(bn + " ") //name sha1Hash sha256:sha256Hash
-				buf.WriteString(string(words[3]) + " ")
-				buf.WriteString(string(words[4]))
-				buf.WriteString("\n")
-				break
+			used[i] = true
+			//name sha1Hash sha256:sha256Hash [sm3:sm3Hash]
+			buf.WriteString(e.ID + " " + e.Digest(typdefs.Sha1AlgStr))
+			buf.WriteString(" " + typdefs.Sha256AlgStr + ":" + e.Digest(typdefs.Sha256AlgStr))
+			if _, ok := e.Digests[typdefs.Sm3AlgStr]; ok {
+				buf.WriteString(" " + typdefs.Sm3AlgStr + ":" + e.Digest(typdefs.Sm3AlgStr))
 			}
+			buf.WriteString("\n")
+			break
 		}
 	}
 	return buf.String()
 }
 
-// There are multiple situations
-// bios may have one or two type of hash : sha1 N/A ;  N/A sha256 ;  sha1 sha256
-// There are four cases of return value:
-// 			0: means no errors
-// 			1: means their sha1 hash not equal
-// 			2: means their sha1 hash not equal
-// 			3: means their type of hash not equal, can't verify
-func compareBiosHash(words1, words2 [][]byte) int {
-	if string(words1[3]) != "N/A" && string(words2[3]) != "N/A" {
-		// if both base and report have sha1 hash in bios, return the result of their comparison
-		if bytes.Equal(words1[3], words1[3]) {
-			return 0
-		} else {
-			return 1
-		}
-
+// verifyBIOS checks the bios events matched by each bios reference value.
+// The reference value whose algorithm isn't in the event is skipped, but
+// each matched event must be compared by at least one reference value.
+// All mismatched events are returned in *MismatchError.
+func verifyBIOS(report *typdefs.TrustReport, rv *typdefs.RefValues) error {
+	if len(rv.Bios) == 0 {
+		return nil
 	}
-	if string(words1[4]) != "N/A" && string(words2[4]) != "N/A" {
-		// if both base and report have sha256 hash in bios, return the result of their comparison
-		if bytes.Equal(words1[4], words1[4]) {
-			return 0
-		} else {
-			return 2
-		}
-
+	events, err := typdefs.ParseBiosEventLog(findManifest(report, typdefs.StrBios))
+	if err != nil {
+		return err
 	}
-	// if there are no same type of hash in bios, return 3
-	return 3
-}
-
-func verifyBIOS(report *typdefs.TrustReport, base *typdefs.BaseRow) error {
-	bLog := findManifest(report, typdefs.StrBios)
-	btLog1, _ := typdefs.TransformBIOSBinLogToTxt(bLog)
-	btLog2 := ([]byte)(base.Bios)
-	lines1 := bytes.Split(btLog1, typdefs.NewLine)
-	lines2 := bytes.Split(btLog2, typdefs.NewLine)
-	used := make([]bool, len(lines2))
-	for _, ln1 := range lines1 {
-		words1 := bytes.Split(ln1, typdefs.Space)
-		for i, ln2 := range lines2 {
-			if used[i] {
+	misErr := &typdefs.MismatchError{}
+	compared := map[*typdefs.BiosEvent]bool{}
+	for i := range rv.Bios {
+		ref := &rv.Bios[i]
+		found := false
+		for _, e := range events {
+			if !matchBiosEvent(ref, e) {
 				continue
 			}
-			words2 := bytes.Split(ln2, typdefs.Space)
-			if bytes.Equal(words1[2], words2[2]) {
-				used[i] = true
-				res := compareBiosHash(words1, words2)
-				switch res {
-				case 0:
-					continue
-				case 1:
-					return fmt.Errorf("%s sha1 hash not equal", string(words1[2]))
-				case 2:
-					return fmt.Errorf("%s sha256 hash not equal", string(words1[2]))
-				case 3:
-					return fmt.Errorf("there are no the same type of hash")
+			found = true
+			d, ok := e.Digests[ref.Alg]
+			if !ok {
+				if _, ok = compared[e]; !ok {
+					compared[e] = false
 				}
+				continue
+			}
+			if !ref.MatchDigest(ref.Alg, d) {
+				misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typdefs.StrBios,
+					Name: e.ID, Reason: typdefs.MismatchNotEqual, Alg: ref.Alg,
+					Expected: ref.Digests, Actual: d})
 			}
+			compared[e] = true
+		}
+		if !found && !ref.Optional {
+			misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typdefs.StrBios,
+				Name: ref.Name, Reason: typdefs.MismatchNotFound})
+		}
+	}
+	for _, e := range events {
+		if v, ok := compared[e]; ok && !v {
+			misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typdefs.StrBios,
+				Name: e.ID, Reason: typdefs.MismatchNoAlg})
 		}
 	}
+	if len(misErr.Mismatches) > 0 {
+		return misErr
+	}
 	return nil
 }
 
+// matchBiosEvent checks whether the bios reference value matches the event,
+// the exact name may be the stable ID or the former name of event.
+func matchBiosEvent(ref *typdefs.RefValue, e *typdefs.BiosEvent) bool {
+	if ref.Match == typdefs.RefMatchGlob {
+		return ref.MatchName(e.ID)
+	}
+	return e.MatchName(ref.Name)
+}
+
+// GetExtractRulesFromBios returns the event names in bios base value.
 func GetExtractRulesFromBios(bioslog string) []string {
 	var biosNames []string
 	lines := bytes.Split([]byte(bioslog), typdefs.NewLine)
 	for _, ln := range lines {
-		words := bytes.Split(ln, typdefs.Space)
+		words := bytes.Fields(ln)
+		if len(words) == 0 {
+			continue
+		}
 		biosNames = append(biosNames, string(words[0]))
 	}
 	return biosNames
@@ -877,8 +1456,10 @@ func GetExtractRulesFromIma(imalog string) []string {
 	var imaNames []string
 	lines := bytes.Split([]byte(imalog), typdefs.NewLine)
 	for _, ln := range lines {
-		words := bytes.Split(ln, typdefs.Space)
-		imaNames = append(imaNames, string(words[2]))
+		words := bytes.SplitN(ln, typdefs.Space, imaBaseItemNum)
+		if len(words) == imaBaseItemNum {
+			imaNames = append(imaNames, string(words[2]))
+		}
 	}
 	return imaNames
 }
@@ -901,9 +1482,10 @@ func getIMAExtractTemplate(oldBase *typdefs.BaseRow) []string {
 }
 
 // The ima string in BaseRow has the following fields, separated by space:
-//   column 1: hash type
-//	 column 2: filedata-hash
-// 	 column 3: filename-hint
+//
+//	column 1: template name
+//	column 2: filedata-hash
+//	column 3: filename-hint, may contain spaces
 func extractIMA(report *typdefs.TrustReport, base *typdefs.BaseRow) string {
 	imaNames := getIMAExtractTemplate(base)
 	used := make([]bool, len(imaNames))
@@ -912,16 +1494,19 @@ func extractIMA(report *typdefs.TrustReport, base *typdefs.BaseRow) string {
 	imaLog := findManifest(report, typdefs.StrIma)
 	lines := bytes.Split(imaLog, typdefs.NewLine)
 	for _, ln := range lines {
-		words := bytes.Split(ln, typdefs.Space)
+		e, err := typdefs.ParseImaEntry(ln)
+		if err != nil {
+			continue
+		}
 		for i, in := range imaNames {
 			if used[i] {
 				continue
 			}
-			if string(words[4]) == in {
+			if e.FileName == in {
 				used[i] = true
-				buf.WriteString(string(words[2]) + " ")
-				buf.WriteString(string(words[3]) + " ")
-				buf.WriteString(string(words[4]))
+				buf.WriteString(e.Template + " ")
+				buf.WriteString(e.FileHashString() + " ")
+				buf.WriteString(e.FileName)
 				buf.WriteString("\n")
 				break
 			}
@@ -930,127 +1515,147 @@ func extractIMA(report *typdefs.TrustReport, base *typdefs.BaseRow) string {
 	return buf.String()
 }
 
-func verifyIMA(report *typdefs.TrustReport, base *typdefs.BaseRow) error {
-	imaLog1 := findManifest(report, typdefs.StrIma)
-	imaLog2 := []byte(base.Ima)
-	lines1 := bytes.Split(imaLog1, typdefs.NewLine)
-	lines2 := bytes.Split(imaLog2, typdefs.NewLine)
-	used := make([]bool, len(lines2))
-	for _, ln1 := range lines1 {
-		words1 := bytes.Split(ln1, typdefs.Space)
-		for i, ln2 := range lines2 {
-			if used[i] {
-				continue
-			}
-			words2 := bytes.Split(ln2, typdefs.Space)
-			if bytes.Equal(words1[2], words2[2]) {
-				used[i] = true
-				if !bytes.Equal(words1[3], words2[3]) {
-					return fmt.Errorf("%s hash not equal", string(words1[2]))
-				}
-			}
-		}
-	}
-	return nil
-}
-
-const (
-	maxStoreWorker = 20
-)
+// errImaUnsigned means the ima log entry has no file signature.
+var errImaUnsigned = errors.New("file is not signed")
 
-var (
-	dbIndex int64              = 0
-	chDb    []chan interface{} = nil
-	storeDb *sql.DB            = nil
-)
+// ImaAppraisalError lists the files in ima log which are not signed or
+// whose signatures can't be verified by the ima keyring.
+type ImaAppraisalError struct {
+	Unsigned  []string
+	BadSigned []string
+}
 
-func createStorePipe(dbType, dbConfig string) {
-	var err error
-	if storeDb != nil {
-		return
+func (e *ImaAppraisalError) Error() string {
+	var parts []string
+	if len(e.Unsigned) > 0 {
+		parts = append(parts, "unsigned files: "+strings.Join(e.Unsigned, ", "))
 	}
-	storeDb, err = sql.Open(dbType, dbConfig)
-	if err != nil {
-		storeDb = nil
-		return
-	}
-	chDb = make([]chan interface{}, maxStoreWorker)
-	for i := 0; i < maxStoreWorker; i++ {
-		chDb[i] = make(chan interface{})
-		go handleStorePipe(i)
+	if len(e.BadSigned) > 0 {
+		parts = append(parts, "bad signature files: "+strings.Join(e.BadSigned, ", "))
 	}
+	return strings.Join(parts, "; ")
 }
 
-func releaseStorePipe() {
-	if chDb != nil {
-		for i := 0; i < maxStoreWorker; i++ {
-			close(chDb[i])
-			chDb[i] = nil
+// verifyIMA checks the ima log in report by the ima verification mode of
+// base value, the hash list, the file signatures, either or both of them.
+func verifyIMA(report *typdefs.TrustReport, mode string, rv *typdefs.RefValues) error {
+	keyring := config.GetImaKeyring()
+	if keyring == nil {
+		keyring = cryptotools.NewImaKeyring()
+	}
+	switch mode {
+	case typdefs.ImaModeSignature:
+		return appraiseIMA(keyring, report, rv.Ima, false)
+	case typdefs.ImaModeSignatureOrHash:
+		return appraiseIMA(keyring, report, rv.Ima, true)
+	case typdefs.ImaModeBoth:
+		err := verifyIMAHash(report, rv.Ima)
+		if err != nil {
+			return err
 		}
-		chDb = nil
-	}
-	if storeDb != nil {
-		storeDb.Close()
-		storeDb = nil
+		return appraiseIMA(keyring, report, rv.Ima, false)
 	}
+	return verifyIMAHash(report, rv.Ima)
 }
 
-func pushToStorePipe(v interface{}) {
-	if chDb != nil {
-		i := atomic.AddInt64(&dbIndex, 1)
-		i = i % maxStoreWorker
-		chDb[i] <- v
+// verifyIMAHash checks the file hash of each ima log entry matched by the
+// ima reference values is accepted by one of them, and the reference values
+// which aren't optional must match at least one entry, except in the
+// incremental ima log whose former entries were checked before. All
+// mismatched files are returned in *MismatchError.
+func verifyIMAHash(report *typdefs.TrustReport, refs []typdefs.RefValue) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	misErr := &typdefs.MismatchError{}
+	found := make([]bool, len(refs))
+	imaLog := findManifest(report, typdefs.StrIma)
+	for _, ln := range bytes.Split(imaLog, typdefs.NewLine) {
+		e, err := typdefs.ParseImaEntry(ln)
+		if err != nil {
+			continue
+		}
+		matched, accepted := false, false
+		var expected []typdefs.HexBytes
+		for i := range refs {
+			if !refs[i].MatchName(e.FileName) {
+				continue
+			}
+			found[i], matched = true, true
+			accepted = accepted || refs[i].MatchDigest(e.FileHashAlg, e.FileHash)
+			if refs[i].Alg == e.FileHashAlg {
+				expected = append(expected, refs[i].Digests...)
+			}
+		}
+		if matched && !accepted {
+			misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typdefs.StrIma,
+				Name: e.FileName, Reason: typdefs.MismatchNotEqual, Alg: e.FileHashAlg,
+				Expected: expected, Actual: e.FileHash})
+		}
+	}
+	for i := range refs {
+		if !found[i] && !refs[i].Optional && report.ImaOffset == 0 {
+			misErr.Mismatches = append(misErr.Mismatches, typdefs.Mismatch{Type: typdefs.StrIma,
+				Name: refs[i].Name, Reason: typdefs.MismatchNotFound})
+		}
+	}
+	if len(misErr.Mismatches) > 0 {
+		return misErr
 	}
+	return nil
 }
 
-func SaveBaseValue(row *typdefs.BaseRow) {
-	go pushToStorePipe(row)
+// acceptImaEntry checks whether the file hash of ima log entry is accepted
+// by any ima reference value of the same file name.
+func acceptImaEntry(refs []typdefs.RefValue, e *typdefs.ImaEntry) bool {
+	for i := range refs {
+		if refs[i].MatchName(e.FileName) && refs[i].MatchDigest(e.FileHashAlg, e.FileHash) {
+			return true
+		}
+	}
+	return false
 }
 
-func handleStorePipe(i int) {
-	for {
-		if chDb == nil {
-			return
+// appraiseIMA checks the signatures of all measured files in ima log by the
+// ima keyring. If useList is true, a file without valid signature is still
+// trusted when its hash is accepted by the ima reference values.
+func appraiseIMA(keyring *cryptotools.ImaKeyring, report *typdefs.TrustReport,
+	refs []typdefs.RefValue, useList bool) error {
+	appErr := &ImaAppraisalError{}
+	imaLog := findManifest(report, typdefs.StrIma)
+	for _, ln := range bytes.Split(imaLog, typdefs.NewLine) {
+		e, err := typdefs.ParseImaEntry(ln)
+		if err != nil || e.IsViolation() || e.Template == typdefs.StrImaBuf ||
+			e.FileName == typdefs.StrBootAggr {
+			continue
 		}
-		em := <-chDb[i]
-		if storeDb == nil {
-			return
+		err = verifyImaEntrySig(keyring, e)
+		if err == nil {
+			continue
 		}
-		switch v := em.(type) {
-		case *typdefs.ReportRow:
-			res, err := storeDb.Exec(sqlInsertTrustReport,
-				v.ClientID, v.CreateTime, v.Validated, v.Trusted,
-				v.Quoted, v.Signature, v.PcrLog, v.BiosLog, v.ImaLog)
-			if err != nil {
-				logger.L.Sugar().Errorf("insert trust report error, result %v, %v", res, err)
-			}
-		case *typdefs.BaseRow:
-			// 这里之前只是把新增加的基准值保存到数据库中，我觉得还要把它更新到cache中
-			// 根据clientID查询该client是否已经注册，如果未注册直接返回，
-			// 已注册则把其添加到对应的cache节点中，再存储到数据库中。
-			res, err := storeDb.Exec(sqlInsertBase, v.ClientID, v.BaseType, v.Uuid, v.CreateTime,
-				v.Enabled, v.Name, v.Pcr, v.Bios, v.Ima)
-			if err != nil {
-				logger.L.Sugar().Errorf("insert base error, result %v, %v", res, err)
-			}
-			switch v.BaseType {
-			case "host":
-				//如果新添加的基准值是host类型，把新的基准值替换原来的
-				tmgr.cache[v.ClientID].HostBase[0] = v
-			case "container":
-				for i, base := range tmgr.cache[v.ClientID].ContainerBases {
-					if base.Uuid == v.Uuid {
-						tmgr.cache[v.ClientID].ContainerBases[i] = v
-					}
-				}
-			case "device":
-				for i, base := range tmgr.cache[v.ClientID].DeviceBases {
-					if base.Uuid == v.Uuid {
-						tmgr.cache[v.ClientID].DeviceBases[i] = v
-						break
-					}
-				}
-			}
+		if useList && acceptImaEntry(refs, e) {
+			continue
+		}
+		if err == errImaUnsigned {
+			appErr.Unsigned = append(appErr.Unsigned, e.FileName)
+		} else {
+			appErr.BadSigned = append(appErr.BadSigned, fmt.Sprintf("%s(%v)", e.FileName, err))
 		}
 	}
+	if len(appErr.Unsigned) > 0 || len(appErr.BadSigned) > 0 {
+		return appErr
+	}
+	return nil
+}
+
+// verifyImaEntrySig verifies the ima signature or module appended signature
+// of one ima log entry.
+func verifyImaEntrySig(keyring *cryptotools.ImaKeyring, e *typdefs.ImaEntry) error {
+	if len(e.Signature) > 0 {
+		return keyring.VerifyImaSig(e.Signature, e.FileHashAlg, e.FileHash)
+	}
+	if len(e.Modsig) > 0 {
+		return keyring.VerifyModsig(e.Modsig, e.ModsigHashAlg, e.ModsigHash)
+	}
+	return errImaUnsigned
 }
@@ -1,5 +1,35 @@
 package trustmgr
 
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/cryptotools"
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/config"
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+)
+
 /*
 import (
 	"encoding/json"
@@ -117,3 +147,1167 @@ func TestJson(t *testing.T) {
 	fmt.Printf("aa:%v\n", aa)
 }
 */
+
+const (
+	testClientInfo = `{"client_name":"test_client","digestAlg":"sha256"}`
+)
+
+var (
+	// sha256 name of a fake ik: nameAlg(0x000b) || digest
+	testIKName = append([]byte{0x00, 0x0b}, make([]byte, sha256.Size)...)
+)
+
+type testQuoteParams struct {
+	magic  uint32
+	typ    tpmutil.Tag
+	signer []byte
+	extra  []byte
+}
+
+// createTestIK creates a software rsa key as ik and its certificate which
+// saves the ik name, returns the key and a cache holds the certificate.
+func createTestIK(t *testing.T, ikName []byte) (*rsa.PrivateKey, *cache.Cache) {
+	key := createTestRSAKey(t)
+	return key, createTestIKCache(t, key, ikName)
+}
+
+// createTestIKCache creates the ik certificate of key and a cache holds it.
+func createTestIKCache(t *testing.T, key crypto.Signer, ikName []byte) *cache.Cache {
+	c := cache.NewCache()
+	c.SetIKeyCert(createTestIKCert(t, key, ikName))
+	return c
+}
+
+// createTestIKCert creates the pem ik certificate of key which saves the
+// ik name.
+func createTestIKCert(t *testing.T, key crypto.Signer, ikName []byte) string {
+	exts := []pkix.Extension{}
+	if ikName != nil {
+		ext, err2 := cryptotools.NewIKNameExtension(ikName)
+		if err2 != nil {
+			t.Fatalf("create ik name extension error, %v", err2)
+		}
+		exts = append(exts, ext)
+	}
+	tmpl := x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		NotBefore:       time.Now(),
+		NotAfter:        time.Now().AddDate(1, 0, 0),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtraExtensions: exts,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("create ik cert error, %v", err)
+	}
+	pemCert, err := cryptotools.EncodeKeyCertToPEM(der)
+	if err != nil {
+		t.Fatalf("encode ik cert error, %v", err)
+	}
+	return string(pemCert)
+}
+
+// testReportClient is a client registered by a software ik, it creates
+// the trust reports which pass the quote and log checks.
+type testReportClient struct {
+	key *rsa.PrivateKey
+	id  int64
+	c   *cache.Cache
+}
+
+func newTestReportClient(t *testing.T, tm *TrustManager) *testReportClient {
+	key := createTestRSAKey(t)
+	row, err := tm.RegisterClientByIK(createTestIKCert(t, key, testIKName), testClientInfo)
+	if err != nil {
+		t.Fatalf("register client error, %v", err)
+	}
+	c, err := tm.GetCache(row.ID)
+	if err != nil {
+		t.Fatalf("get client cache error, %v", err)
+	}
+	return &testReportClient{key: key, id: row.ID, c: c}
+}
+
+// verdicts returns the client transitions to trusted or untrusted, the
+// others are caused by the 0 durations without config.
+func (rc *testReportClient) verdicts(t *testing.T, tm *TrustManager) []typdefs.TrustHistoryRow {
+	info, err := tm.GetTrustInfo(rc.id)
+	if err != nil {
+		t.Fatalf("get trust info error, %v", err)
+	}
+	var rows []typdefs.TrustHistoryRow
+	for _, h := range info.History {
+		if h.ToState == typdefs.TrustStateTrusted || h.ToState == typdefs.TrustStateUntrusted {
+			rows = append(rows, h)
+		}
+	}
+	return rows
+}
+
+// createTestFullImaLog returns a full ima log which starts with the boot
+// aggregate of the empty bios log and has n ima-ng entries, and the sha1
+// ima pcr after it.
+func createTestFullImaLog(t *testing.T, n int) (string, []byte) {
+	aggr := typdefs.NewPcrGroups().AggregateSha1(0, 8)
+	names := []string{"sha1:" + aggr + " boot_aggregate"}
+	for i := 0; i < n; i++ {
+		names = append(names, fmt.Sprintf("sha1:%040x /usr/bin/f%d", i+1, i))
+	}
+	pcr := make([]byte, sha1.Size)
+	log := ""
+	for _, name := range names {
+		e, err := typdefs.ParseImaEntry([]byte("10 " + strings.Repeat("11", sha1.Size) + " ima-ng " + name))
+		if err != nil {
+			t.Fatalf("parse ima entry error, %v", err)
+		}
+		d, _ := e.TemplateDigest(typdefs.Sha1AlgStr)
+		log += "10 " + hex.EncodeToString(d) + " ima-ng " + name + "\n"
+		h := sha1.New()
+		h.Write(pcr)
+		h.Write(d)
+		pcr = h.Sum(nil)
+	}
+	return log, pcr
+}
+
+// report returns a trust report for the current nonce whose quote of sha1
+// pcr 0-7 and 10 is signed by the ik, pcr 10 is imaValue and the others
+// are 0 as the empty bios log.
+func (rc *testReportClient) report(t *testing.T, ima string, imaValue []byte, offset uint64) *typdefs.TrustReport {
+	nonce := rc.c.GetNonce()
+	pcrs := []int{0, 1, 2, 3, 4, 5, 6, 7, imaPcr}
+	h := sha1.New()
+	pcrLog := ""
+	for _, i := range pcrs {
+		v := make([]byte, sha1.Size)
+		if i == imaPcr {
+			v = imaValue
+		}
+		h.Write(v)
+		pcrLog += fmt.Sprintf("%s %s %02d\n", hex.EncodeToString(v), typdefs.Sha1AlgStr, i)
+	}
+	quoted := createTestPcrQuote(t, createTestReportHash(t, rc.id, nonce, testClientInfo),
+		tpm2.AlgSHA1, pcrs, h.Sum(nil))
+	return &typdefs.TrustReport{
+		ClientID:   rc.id,
+		Nonce:      nonce,
+		ClientInfo: testClientInfo,
+		Quoted:     quoted,
+		Signature:  signTestQuote(t, rc.key, quoted),
+		ImaOffset:  offset,
+		Manifests: []typdefs.Manifest{
+			{Key: typdefs.StrPcr, Value: []byte(pcrLog)},
+			{Key: typdefs.StrIma, Value: []byte(ima)},
+		},
+	}
+}
+
+// createTestQuote packs a TPMS_ATTEST structure as TPM2_Quote does.
+func createTestQuote(t *testing.T, p *testQuoteParams) []byte {
+	pcrDigest := make([]byte, sha256.Size)
+	quoted, err := tpmutil.Pack(p.magic, p.typ, tpmutil.U16Bytes(p.signer),
+		tpmutil.U16Bytes(p.extra), uint64(1), uint32(0), uint32(0), byte(1),
+		uint64(0), uint32(1), tpm2.AlgSHA256, byte(3), []byte{0xff, 0xff, 0xff},
+		tpmutil.U16Bytes(pcrDigest))
+	if err != nil {
+		t.Fatalf("pack quote error, %v", err)
+	}
+	return quoted
+}
+
+func signTestQuote(t *testing.T, key *rsa.PrivateKey, quoted []byte) []byte {
+	digest := sha256.Sum256(quoted)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("sign quote error, %v", err)
+	}
+	jsonSig, err := json.Marshal(&tpm2.Signature{
+		Alg: tpm2.AlgRSASSA,
+		RSA: &tpm2.SignatureRSA{HashAlg: tpm2.AlgSHA256, Signature: sig},
+	})
+	if err != nil {
+		t.Fatalf("marshal signature error, %v", err)
+	}
+	return jsonSig
+}
+
+func createTestReportHash(t *testing.T, id int64, nonce uint64, ci string) []byte {
+	tRepIn := typdefs.TrustReportInput{ClientID: id, Nonce: nonce, ClientInfo: ci}
+	h, err := tRepIn.Hash(getReportDigestAlg())
+	if err != nil {
+		t.Fatalf("hash report input error, %v", err)
+	}
+	return h
+}
+
+func TestCheckQuote(t *testing.T) {
+	const id, nonce, newNonce = 1, 0x1122334455667788, 0x8877665544332211
+	key, c := createTestIK(t, testIKName)
+	qn, err := getIKQualifiedName(testIKName)
+	if err != nil {
+		t.Fatalf("get ik qualified name error, %v", err)
+	}
+	repHash := createTestReportHash(t, id, nonce, testClientInfo)
+	tampered := append([]byte{}, repHash...)
+	tampered[0] ^= 0xff
+	// the algorithm claimed in client info isn't used.
+	tRepIn := typdefs.TrustReportInput{ClientID: id, Nonce: nonce, ClientInfo: testClientInfo}
+	claimed, err := tRepIn.Hash(typdefs.Sha256AlgStr)
+	if err != nil {
+		t.Fatalf("hash report input error, %v", err)
+	}
+	wrongSigner := append([]byte{}, qn...)
+	wrongSigner[len(wrongSigner)-1] ^= 0xff
+
+	testCases := []struct {
+		params testQuoteParams
+		nonce  uint64
+		ci     string
+		err    error
+	}{
+		// valid quote
+		{testQuoteParams{tpmGeneratedValue, tpm2.TagAttestQuote, qn, repHash},
+			nonce, testClientInfo, nil},
+		// replayed quote for a new nonce
+		{testQuoteParams{tpmGeneratedValue, tpm2.TagAttestQuote, qn, repHash},
+			newNonce, testClientInfo, typdefs.ErrQuoteExtraDataNotMatch},
+		// client info is modified after quote
+		{testQuoteParams{tpmGeneratedValue, tpm2.TagAttestQuote, qn, repHash},
+			nonce, `{"client_name":"fake","digestAlg":"sha256"}`, typdefs.ErrQuoteExtraDataNotMatch},
+		// tampered extra data
+		{testQuoteParams{tpmGeneratedValue, tpm2.TagAttestQuote, qn, tampered},
+			nonce, testClientInfo, typdefs.ErrQuoteExtraDataNotMatch},
+		// extra data hashed by the algorithm in client info, not the configured one
+		{testQuoteParams{tpmGeneratedValue, tpm2.TagAttestQuote, qn, claimed},
+			nonce, testClientInfo, typdefs.ErrQuoteExtraDataNotMatch},
+		// wrong magic
+		{testQuoteParams{0x12345678, tpm2.TagAttestQuote, qn, repHash},
+			nonce, testClientInfo, typdefs.ErrQuoteMagicWrong},
+		// not a quote
+		{testQuoteParams{tpmGeneratedValue, tpm2.TagAttestCreation, qn, repHash},
+			nonce, testClientInfo, typdefs.ErrQuoteTypeWrong},
+		// quote is signed by another key
+		{testQuoteParams{tpmGeneratedValue, tpm2.TagAttestQuote, wrongSigner, repHash},
+			nonce, testClientInfo, typdefs.ErrQualifiedSignerNotMatch},
+	}
+	for i, tc := range testCases {
+		quoted := createTestQuote(t, &tc.params)
+		report := &typdefs.TrustReport{
+			ClientID:   id,
+			Nonce:      tc.nonce,
+			ClientInfo: tc.ci,
+			Quoted:     quoted,
+			Signature:  signTestQuote(t, key, quoted),
+		}
+		row := &typdefs.ReportRow{}
+		_, err = checkQuote(c, report, row)
+		if err != tc.err {
+			t.Errorf("test checkQuote error at case %d, want %v, get %v\n", i, tc.err, err)
+		}
+	}
+}
+
+func TestCheckQuoteSignature(t *testing.T) {
+	const id, nonce = 1, 0x1122334455667788
+	key, c := createTestIK(t, testIKName)
+	qn, _ := getIKQualifiedName(testIKName)
+	repHash := createTestReportHash(t, id, nonce, testClientInfo)
+	quoted := createTestQuote(t, &testQuoteParams{tpmGeneratedValue,
+		tpm2.TagAttestQuote, qn, repHash})
+	signature := signTestQuote(t, key, quoted)
+	// modify the quoted after signing
+	quoted[len(quoted)-1] ^= 0xff
+	report := &typdefs.TrustReport{
+		ClientID:   id,
+		Nonce:      nonce,
+		ClientInfo: testClientInfo,
+		Quoted:     quoted,
+		Signature:  signature,
+	}
+	_, err := checkQuote(c, report, &typdefs.ReportRow{})
+	if err == nil {
+		t.Errorf("test checkQuote with tampered quote error\n")
+	}
+}
+
+func TestCheckQuoteLegacyIKCert(t *testing.T) {
+	const id, nonce = 1, 0x1122334455667788
+	// ik certificate issued by old PCA has no ik name, the signer check is
+	// skipped only if it is allowed by configuration.
+	config.LoadConfigs()
+	defer config.SetAllowLegacyIKCert(false)
+	key, c := createTestIK(t, nil)
+	repHash := createTestReportHash(t, id, nonce, testClientInfo)
+	quoted := createTestQuote(t, &testQuoteParams{tpmGeneratedValue,
+		tpm2.TagAttestQuote, []byte{0x00, 0x0b, 0x01}, repHash})
+	report := &typdefs.TrustReport{
+		ClientID:   id,
+		Nonce:      nonce,
+		ClientInfo: testClientInfo,
+		Quoted:     quoted,
+		Signature:  signTestQuote(t, key, quoted),
+	}
+	testCases := []struct {
+		allow bool
+		err   error
+	}{
+		{false, typdefs.ErrIKNameNull},
+		{true, nil},
+	}
+	for i, tc := range testCases {
+		config.SetAllowLegacyIKCert(tc.allow)
+		_, err := checkQuote(c, report, &typdefs.ReportRow{})
+		if err != tc.err {
+			t.Errorf("test checkQuote with legacy ik cert error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func TestGetReportDigestAlg(t *testing.T) {
+	alg := config.GetDigestAlgorithm()
+	if alg == "" {
+		alg = typdefs.Sha1AlgStr
+	}
+	if getReportDigestAlg() != alg {
+		t.Errorf("test getReportDigestAlg error, %s\n", getReportDigestAlg())
+	}
+}
+
+func TestCheckQuoteECDSA(t *testing.T) {
+	const id, nonce = 1, 0x1122334455667788
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ecc ik error, %v", err)
+	}
+	c := createTestIKCache(t, key, testIKName)
+	qn, _ := getIKQualifiedName(testIKName)
+	repHash := createTestReportHash(t, id, nonce, testClientInfo)
+	quoted := createTestQuote(t, &testQuoteParams{tpmGeneratedValue,
+		tpm2.TagAttestQuote, qn, repHash})
+	digest := sha256.Sum256(quoted)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("sign quote error, %v", err)
+	}
+	jsonSig, _ := json.Marshal(&tpm2.Signature{
+		Alg: tpm2.AlgECDSA,
+		ECC: &tpm2.SignatureECC{HashAlg: tpm2.AlgSHA256, R: r, S: s},
+	})
+	report := &typdefs.TrustReport{
+		ClientID:   id,
+		Nonce:      nonce,
+		ClientInfo: testClientInfo,
+		Quoted:     quoted,
+		Signature:  jsonSig,
+	}
+	_, err = checkQuote(c, report, &typdefs.ReportRow{})
+	if err != nil {
+		t.Errorf("test checkQuote with ecdsa ik error, %v\n", err)
+	}
+	// rsa signature scheme doesn't match ecc ik
+	report.Signature = signTestQuote(t, createTestRSAKey(t), quoted)
+	_, err = checkQuote(c, report, &typdefs.ReportRow{})
+	if err == nil {
+		t.Errorf("test checkQuote with mismatched signature scheme error\n")
+	}
+}
+
+func createTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key error, %v", err)
+	}
+	return key
+}
+
+func TestNewTrustManager(t *testing.T) {
+	_, err := New(nil, Options{})
+	if err != typdefs.ErrParameterWrong {
+		t.Errorf("test New with nil store error %v", err)
+	}
+	s := NewMemoryStore()
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), IKCert: "ik1"})
+	s.RegisterClient(&typdefs.ClientRow{RegTime: time.Now(), IKCert: "ik2"})
+	s.UnRegisterClientByID(2)
+	tm, err := New(s, Options{StoreWorkers: 2})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	if _, err = tm.GetCache(1); err != nil {
+		t.Errorf("test GetCache of enabled client error %v", err)
+	}
+	if _, err = tm.GetCache(2); err != typdefs.ErrDoesnotRegistered {
+		t.Errorf("test GetCache of deleted client error %v", err)
+	}
+	tm.Close()
+}
+
+func TestTrustManagerClients(t *testing.T) {
+	tm1, err := New(NewMemoryStore(), Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm1.Close()
+	tm2, err := New(NewMemoryStore(), Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm2.Close()
+
+	c, err := tm1.RegisterClientByIK("ik1", testClientInfo)
+	if err != nil {
+		t.Fatalf("test RegisterClientByIK error %v", err)
+	}
+	_, err = tm1.RegisterClientByIK("ik1", testClientInfo)
+	if err != typdefs.ErrAlreadyRegistered {
+		t.Errorf("test RegisterClientByIK twice error %v", err)
+	}
+	// two managers are independent.
+	if _, err = tm2.GetCache(c.ID); err != typdefs.ErrDoesnotRegistered {
+		t.Errorf("test GetCache in another manager error %v", err)
+	}
+	nodes, _ := tm1.GetAllNodes(0, 10)
+	if len(nodes) != 1 || nodes[0].ID != c.ID {
+		t.Errorf("test GetAllNodes error %v", nodes)
+	}
+	row, err := tm1.FindClientByID(c.ID)
+	if err != nil || row.IKCert != "ik1" {
+		t.Errorf("test FindClientByID error %v", err)
+	}
+	tm1.UnRegisterClientByID(c.ID)
+	if _, err = tm1.GetCache(c.ID); err != typdefs.ErrDoesnotRegistered {
+		t.Errorf("test UnRegisterClientByID error %v", err)
+	}
+	if _, err = tm1.FindClientByID(c.ID); err == nil {
+		t.Errorf("test FindClientByID after unregister error")
+	}
+}
+
+func TestTrustManagerSaveBaseValue(t *testing.T) {
+	s := NewMemoryStore()
+	tm, err := New(s, Options{StoreWorkers: 1})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	tm.SaveBaseValue(&typdefs.BaseRow{ClientID: 1, BaseType: "container"})
+	for i := 0; i < 100; i++ {
+		bs, _ := s.FindBaseValuesByClientID(1)
+		if len(bs) == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("test SaveBaseValue doesn't save base value into store")
+}
+
+// TestTrustManagerConcurrentClients simulates lots of clients which send
+// heart beats and base values while rest api reads the cache, run it with
+// "go test -race" to check the data races.
+func TestTrustManagerConcurrentClients(t *testing.T) {
+	const clients = 2000
+	tm, err := New(NewMemoryStore(), Options{StoreWorkers: 4})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	ids := make([]int64, clients)
+	for i := 0; i < clients; i++ {
+		c, err := tm.RegisterClientByIK(fmt.Sprintf("ik-%d", i), `{}`)
+		if err != nil {
+			t.Fatalf("test RegisterClientByIK error %v", err)
+		}
+		ids[i] = c.ID
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				tm.HandleHeartbeat(id)
+				tm.SaveBaseValue(&typdefs.BaseRow{ClientID: id, BaseType: "host"})
+				tm.SaveBaseValue(&typdefs.BaseRow{ClientID: id, BaseType: "container", Uuid: "c"})
+				c, err := tm.GetCache(id)
+				if err != nil {
+					continue
+				}
+				c.VerifyHostBases(func(base *typdefs.BaseRow) error { return nil })
+				for _, b := range c.GetHostBases() {
+					b.Trusted = false
+				}
+				c.GetContainerBases()
+			}
+		}(ids[i])
+	}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tm.UpdateAllNodes()
+			tm.GetAllNodes(0, clients+1)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// unregister some clients while they are working.
+		for i := 0; i < clients; i += 100 {
+			tm.UnRegisterClientByID(ids[i])
+		}
+	}()
+	wg.Wait()
+	tm.Close()
+	st := tm.GetStoreStats()
+	if st.Saved+st.Dropped != clients*10 {
+		t.Errorf("test concurrent clients store stats error, %v", st)
+	}
+}
+
+// createTestPcrQuote packs a TPMS_ATTEST structure which quotes pcrs of
+// one pcr bank with the pcr digest.
+func createTestPcrQuote(t *testing.T, extra []byte, alg tpm2.Algorithm, pcrs []int, digest []byte) []byte {
+	return createTestResetQuote(t, extra, alg, pcrs, digest, 0)
+}
+
+// createTestResetQuote is like createTestPcrQuote, and the quote is made
+// after the tpm is reset resets times. It is signed by the ik of testIKName.
+func createTestResetQuote(t *testing.T, extra []byte, alg tpm2.Algorithm, pcrs []int, digest []byte,
+	resets uint32) []byte {
+	bitmap := make([]byte, 3)
+	for _, i := range pcrs {
+		bitmap[i/8] |= 1 << uint(i%8)
+	}
+	qn, err := getIKQualifiedName(testIKName)
+	if err != nil {
+		t.Fatalf("get ik qualified name error, %v", err)
+	}
+	quoted, err := tpmutil.Pack(uint32(tpmGeneratedValue), tpm2.TagAttestQuote,
+		tpmutil.U16Bytes(qn), tpmutil.U16Bytes(extra), uint64(1), resets,
+		uint32(0), byte(1), uint64(0), uint32(1), alg, byte(3), bitmap,
+		tpmutil.U16Bytes(digest))
+	if err != nil {
+		t.Fatalf("pack quote error, %v", err)
+	}
+	return quoted
+}
+
+// createTestPcrBank returns the pcr log lines and quoted pcr digest of
+// pcrs in one bank, each pcr value is filled by its index plus v.
+func createTestPcrBank(t *testing.T, alg string, pcrs []int, v byte) (string, []byte) {
+	h, err := typdefs.GetHFromAlg(alg)
+	if err != nil {
+		t.Fatalf("get hash error, %v", err)
+	}
+	var log string
+	for _, i := range pcrs {
+		pcr := make([]byte, typdefs.SupportAlgAndLenMap[alg])
+		pcr[0] = byte(i) + v
+		h.Write(pcr)
+		log += fmt.Sprintf("%s %s %02d\n", hex.EncodeToString(pcr), alg, i)
+	}
+	return log, h.Sum(nil)
+}
+
+func TestCheckPcrLog(t *testing.T) {
+	pcrs := []int{0, 1, 2, 3}
+	shaLog, shaDigest := createTestPcrBank(t, typdefs.Sha256AlgStr, pcrs, 0)
+	sm3Log, sm3Digest := createTestPcrBank(t, typdefs.Sm3AlgStr, pcrs, 0)
+	badLog, _ := createTestPcrBank(t, typdefs.Sm3AlgStr, pcrs, 1)
+	shaQuote := createTestPcrQuote(t, nil, tpm2.AlgSHA256, pcrs, shaDigest)
+	sm3Quote := createTestPcrQuote(t, nil, cryptotools.AlgSM3, pcrs, sm3Digest)
+	testCases := []struct {
+		log    string
+		quoted []byte
+		quotes []typdefs.Quote
+		sel    string
+		err    error
+	}{
+		{shaLog, shaQuote, nil, "", nil},
+		{sm3Log, sm3Quote, nil, "", nil},
+		{shaLog + sm3Log, shaQuote, []typdefs.Quote{{Quoted: sm3Quote}}, "", nil},
+		{shaLog + sm3Log, shaQuote, []typdefs.Quote{{Quoted: sm3Quote}}, "sm3:0-3", nil},
+		{shaLog + sm3Log, shaQuote, []typdefs.Quote{{Quoted: sm3Quote}}, "sha1:0-3;sha256:0", nil},
+		{shaLog + badLog, shaQuote, []typdefs.Quote{{Quoted: sm3Quote}}, "", typdefs.ErrPCRNotMatch},
+		{shaLog, shaQuote, nil, "sm3:0-3", typdefs.ErrPcrBankNotQuoted},
+		{shaLog, shaQuote, nil, "sha256:0-7", typdefs.ErrPcrBankNotQuoted},
+		// pcr log of sha256 bank can't match the sm3 quote
+		{shaLog, sm3Quote, nil, "", typdefs.ErrPCRNotMatch},
+	}
+	for i, tc := range testCases {
+		report := &typdefs.TrustReport{
+			Quoted:    tc.quoted,
+			Quotes:    tc.quotes,
+			Manifests: []typdefs.Manifest{{Key: typdefs.StrPcr, Value: []byte(tc.log)}},
+		}
+		row := &typdefs.ReportRow{}
+		_, err := checkPcrLog(tc.sel, report, row)
+		if err != tc.err {
+			t.Errorf("test checkPcrLog error at case %d, want %v, get %v\n", i, tc.err, err)
+		}
+		if err == nil && row.PcrLog != tc.log {
+			t.Errorf("test checkPcrLog pcr log error at case %d\n", i)
+		}
+	}
+}
+
+func TestCheckQuoteMultiBank(t *testing.T) {
+	const id, nonce = 1, 0x1122334455667788
+	key, c := createTestIK(t, testIKName)
+	repHash := createTestReportHash(t, id, nonce, testClientInfo)
+	shaQuote := createTestPcrQuote(t, repHash, tpm2.AlgSHA256, []int{0}, nil)
+	sm3Quote := createTestPcrQuote(t, repHash, cryptotools.AlgSM3, []int{0}, nil)
+	oldQuote := createTestPcrQuote(t, []byte("old"), cryptotools.AlgSM3, []int{0}, nil)
+	testCases := []struct {
+		quotes []typdefs.Quote
+		err    error
+	}{
+		{nil, nil},
+		{[]typdefs.Quote{{Quoted: sm3Quote, Signature: signTestQuote(t, key, sm3Quote)}}, nil},
+		{[]typdefs.Quote{{Quoted: sm3Quote}}, typdefs.ErrParameterWrong},
+		{[]typdefs.Quote{{Quoted: oldQuote, Signature: signTestQuote(t, key, oldQuote)}}, typdefs.ErrQuoteExtraDataNotMatch},
+	}
+	for i, tc := range testCases {
+		report := &typdefs.TrustReport{
+			ClientID:   id,
+			Nonce:      nonce,
+			ClientInfo: testClientInfo,
+			Quoted:     shaQuote,
+			Signature:  signTestQuote(t, key, shaQuote),
+			Quotes:     tc.quotes,
+		}
+		_, err := checkQuote(c, report, &typdefs.ReportRow{})
+		if err != tc.err {
+			t.Errorf("test checkQuote multi bank error at case %d, want %v, get %v\n", i, tc.err, err)
+		}
+	}
+	// a tampered sm3 quote fails the signature check.
+	bad := append([]byte{}, sm3Quote...)
+	bad[len(bad)-1] ^= 0xff
+	report := &typdefs.TrustReport{
+		ClientID:   id,
+		Nonce:      nonce,
+		ClientInfo: testClientInfo,
+		Quoted:     shaQuote,
+		Signature:  signTestQuote(t, key, shaQuote),
+		Quotes:     []typdefs.Quote{{Quoted: bad, Signature: signTestQuote(t, key, sm3Quote)}},
+	}
+	if _, err := checkQuote(c, report, &typdefs.ReportRow{}); err == nil {
+		t.Errorf("test checkQuote with tampered bank quote error")
+	}
+}
+
+func TestTrustManagerPcrSelection(t *testing.T) {
+	tm, err := New(NewMemoryStore(), Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	c, err := tm.RegisterClientByIK("ik", `{}`)
+	if err != nil {
+		t.Fatalf("test RegisterClientByIK error %v", err)
+	}
+	if tm.GetPcrSelection(c.ID) != config.GetPcrSelection() {
+		t.Errorf("test default pcr selection error")
+	}
+	if err = tm.SetPcrSelection(c.ID, "sm3:7,0-3"); err != nil {
+		t.Errorf("test SetPcrSelection error %v", err)
+	}
+	if tm.GetPcrSelection(c.ID) != "sm3:0-3,7" {
+		t.Errorf("test GetPcrSelection error, %s", tm.GetPcrSelection(c.ID))
+	}
+	ca, _ := tm.GetCache(c.ID)
+	if ca.GetCommands()&typdefs.CmdSendConfig == 0 {
+		t.Errorf("test SetPcrSelection doesn't notify client")
+	}
+	if err = tm.SetPcrSelection(c.ID, "md5:0"); err == nil {
+		t.Errorf("test SetPcrSelection with wrong selection error")
+	}
+	if err = tm.SetPcrSelection(c.ID+1, "sm3:0"); err == nil {
+		t.Errorf("test SetPcrSelection for unknown client error")
+	}
+}
+
+func TestExtractAndVerifyIMA(t *testing.T) {
+	const (
+		h1 = "sha256:ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+		h2 = "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	)
+	imaLog := "10 c1072321eb7a5620111708ee1f58ea2ffd52de06 ima-sig " + h1 + " /usr/bin/ls 030204a1b2c3d400020102\n" +
+		"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng " + h1 + " /usr/bin/a b\n"
+	report := &typdefs.TrustReport{
+		Manifests: []typdefs.Manifest{{Key: typdefs.StrIma, Value: []byte(imaLog)}},
+	}
+	oldBase := &typdefs.BaseRow{Ima: "ima-sig " + h2 + " /usr/bin/ls\nima-ng " + h2 + " /usr/bin/a b\n"}
+	ima := extractIMA(report, oldBase)
+	want := "ima-sig " + h1 + " /usr/bin/ls\nima-ng " + h1 + " /usr/bin/a b\n"
+	if ima != want {
+		t.Errorf("test extractIMA error, %q", ima)
+	}
+	testCases := []struct {
+		base   string
+		result bool
+	}{
+		{want, true},
+		{oldBase.Ima, false},
+		{"ima-ng " + h2 + " /usr/bin/other\n", true},
+		{"", true},
+	}
+	for i := 0; i < len(testCases); i++ {
+		rv, err := GetRefValues(&typdefs.BaseRow{Ima: testCases[i].base})
+		if err == nil {
+			err = verifyIMA(report, "", rv)
+		}
+		if (err == nil) != testCases[i].result {
+			t.Errorf("test verifyIMA error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func TestExtractAndVerifyBIOS(t *testing.T) {
+	bios, err := ioutil.ReadFile("../../rac/cmd/raagent/binary_bios_measurements")
+	if err != nil {
+		t.Fatalf("read bios log error, %v", err)
+	}
+	report := &typdefs.TrustReport{
+		Manifests: []typdefs.Manifest{{Key: typdefs.StrBios, Value: bios}},
+	}
+	// the legacy names are replaced by the stable ids.
+	oldBase := &typdefs.BaseRow{Bios: "8-0 N/A sha256:N/A\n7:EV_EFI_VARIABLE_DRIVER_CONFIG:PK N/A sha256:N/A\n"}
+	base := extractBIOS(report, oldBase)
+	lines := strings.Split(strings.TrimSpace(base), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "0:EV_S_CRTM_VERSION ") ||
+		!strings.HasPrefix(lines[1], "7:EV_EFI_VARIABLE_DRIVER_CONFIG:PK ") {
+		t.Fatalf("test extractBIOS error, %q", base)
+	}
+	words := strings.Fields(lines[1])
+	testCases := []struct {
+		base   string
+		result bool
+	}{
+		{base, true},
+		{"", true},
+		{words[0] + " " + words[1] + " sha256:N/A\n", true},
+		{words[0] + " N/A " + words[2] + "\n", true},
+		{words[0] + " N/A sha256:N/A\n", false},
+		{words[0] + " 00 " + words[2] + "\n", false},
+		{words[0] + " " + words[1] + " sha256:00\n", false},
+		{"80000001-3 " + words[1] + " " + words[2] + "\n", true},
+		{"7:EV_EFI_VARIABLE_DRIVER_CONFIG:PK#2 " + words[1] + " " + words[2] + "\n", false},
+		{words[0] + "\n", false},
+	}
+	for i := 0; i < len(testCases); i++ {
+		rv, err := GetRefValues(&typdefs.BaseRow{Bios: testCases[i].base})
+		if err == nil {
+			err = verifyBIOS(report, rv)
+		}
+		if (err == nil) != testCases[i].result {
+			t.Errorf("test verifyBIOS error at case %d, %v\n", i, err)
+		}
+	}
+}
+
+func createTestImaSig(t *testing.T, key *rsa.PrivateKey, cert *x509.Certificate, digest []byte) string {
+	id, err := cryptotools.ImaKeyID(cert.RawSubjectPublicKeyInfo)
+	if err != nil {
+		t.Fatalf("get ima key id error, %v", err)
+	}
+	sig, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest)
+	hdr := []byte{0x03, 0x02, 0x04, byte(id >> 24), byte(id >> 16), byte(id >> 8), byte(id),
+		byte(len(sig) >> 8), byte(len(sig))}
+	return hex.EncodeToString(append(hdr, sig...))
+}
+
+func TestAppraiseIMA(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test file signing"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	cert, _ := x509.ParseCertificate(der)
+	keyring := cryptotools.NewImaKeyring()
+	if _, err := keyring.AddCertData(der); err != nil {
+		t.Fatalf("add signing cert error, %v", err)
+	}
+	d1 := sha256.Sum256([]byte("signed"))
+	d2 := sha256.Sum256([]byte("unsigned"))
+	d3 := sha256.Sum256([]byte("bad"))
+	h1, h2, h3 := hex.EncodeToString(d1[:]), hex.EncodeToString(d2[:]), hex.EncodeToString(d3[:])
+	const th = "10 0123456789abcdef0123456789abcdef01234567 "
+	imaLog := th + "ima-ng sha256:" + h2 + " boot_aggregate\n" +
+		th + "ima-sig sha256:" + h1 + " /usr/bin/signed " + createTestImaSig(t, key, cert, d1[:]) + "\n" +
+		th + "ima-ng sha256:" + h2 + " /usr/bin/unsigned\n" +
+		th + "ima-sig sha256:" + h3 + " /usr/bin/bad " + createTestImaSig(t, key, cert, d1[:]) + "\n"
+	report := &typdefs.TrustReport{
+		Manifests: []typdefs.Manifest{{Key: typdefs.StrIma, Value: []byte(imaLog)}},
+	}
+	testCases := []struct {
+		ima       string
+		useList   bool
+		unsigned  int
+		badSigned int
+	}{
+		{"", true, 1, 1},
+		{"ima-ng sha256:" + h2 + " /usr/bin/unsigned\n", true, 0, 1},
+		{"ima-ng sha256:" + h2 + " /usr/bin/unsigned\n", false, 1, 1},
+		{"ima-sig sha256:" + h3 + " /usr/bin/bad\nima-ng sha256:" + h2 + " /usr/bin/unsigned\n", true, 0, 0},
+		{"ima-ng sha256:" + h1 + " /usr/bin/unsigned\n", true, 1, 1},
+	}
+	for i, tc := range testCases {
+		rv, _ := GetRefValues(&typdefs.BaseRow{Ima: tc.ima})
+		err := appraiseIMA(keyring, report, rv.Ima, tc.useList)
+		if tc.unsigned == 0 && tc.badSigned == 0 {
+			if err != nil {
+				t.Errorf("test appraiseIMA error at case %d, %v\n", i, err)
+			}
+			continue
+		}
+		appErr, ok := err.(*ImaAppraisalError)
+		if !ok || len(appErr.Unsigned) != tc.unsigned || len(appErr.BadSigned) != tc.badSigned {
+			t.Errorf("test appraiseIMA result error at case %d, %v\n", i, err)
+		}
+	}
+	// without any key, the signed file isn't trusted in signature mode.
+	err := verifyIMA(report, typdefs.ImaModeSignature, &typdefs.RefValues{})
+	if appErr, ok := err.(*ImaAppraisalError); !ok || len(appErr.BadSigned) != 2 {
+		t.Errorf("test verifyIMA signature mode error, %v", err)
+	}
+	// signature mode doesn't trust the unsigned file even if it is in the
+	// hash list, signature-or-hash mode does.
+	rv, _ := GetRefValues(&typdefs.BaseRow{Ima: "ima-ng sha256:" + h2 + " /usr/bin/unsigned\n"})
+	err = verifyIMA(report, typdefs.ImaModeSignature, rv)
+	if appErr, ok := err.(*ImaAppraisalError); !ok || len(appErr.Unsigned) != 1 {
+		t.Errorf("test verifyIMA signature mode with list error, %v", err)
+	}
+	err = verifyIMA(report, typdefs.ImaModeSignatureOrHash, rv)
+	if appErr, ok := err.(*ImaAppraisalError); !ok || len(appErr.Unsigned) != 0 || len(appErr.BadSigned) != 2 {
+		t.Errorf("test verifyIMA signature-or-hash mode error, %v", err)
+	}
+	// both mode checks the hash list first.
+	rv, _ = GetRefValues(&typdefs.BaseRow{Ima: "ima-ng sha256:" + h1 + " /usr/bin/unsigned\n"})
+	err = verifyIMA(report, typdefs.ImaModeBoth, rv)
+	if _, ok := err.(*ImaAppraisalError); ok || err == nil {
+		t.Errorf("test verifyIMA both mode error, %v", err)
+	}
+}
+
+func TestCheckSecureBoot(t *testing.T) {
+	bios, err := ioutil.ReadFile("../../rac/cmd/raagent/binary_bios_measurements")
+	if err != nil {
+		t.Fatalf("read bios log error, %v", err)
+	}
+	events, _ := typdefs.ParseBiosEventLog(bios)
+	state, err := typdefs.ReplaySecureBoot(events)
+	if err != nil {
+		t.Fatalf("replay secure boot error, %v", err)
+	}
+	pcr7 := hex.EncodeToString(state.Pcr7[typdefs.Sha256AlgStr])
+	goodPcr := fmt.Sprintf("%s sha256 7\n", pcr7)
+	badPcr := fmt.Sprintf("%s sha256 7\n", strings.Repeat("0", len(pcr7)))
+	testCases := []struct {
+		policy *typdefs.SecureBootPolicy
+		pcrLog string
+		result bool
+	}{
+		{nil, goodPcr, true},
+		{&typdefs.SecureBootPolicy{}, badPcr, true},
+		{&typdefs.SecureBootPolicy{Required: true}, goodPcr, false},
+		{&typdefs.SecureBootPolicy{DbxHashes: []string{"00"}}, goodPcr, false},
+		{&typdefs.SecureBootPolicy{PKOwners: []string{"nobody"}}, goodPcr, false},
+		{&typdefs.SecureBootPolicy{DbxHashes: []string{}}, badPcr, true},
+		{&typdefs.SecureBootPolicy{PKOwners: []string{"nobody"}}, badPcr, false},
+	}
+	quoted := createTestPcrQuote(t, nil, tpm2.AlgSHA256, []int{typdefs.SecureBootPcr}, nil)
+	for i, tc := range testCases {
+		report := &typdefs.TrustReport{Quoted: quoted, Manifests: []typdefs.Manifest{
+			{Key: typdefs.StrBios, Value: bios},
+			{Key: typdefs.StrPcr, Value: []byte(tc.pcrLog)},
+		}}
+		row := &typdefs.ReportRow{}
+		err = checkSecureBoot(tc.policy, report, row)
+		if (err == nil) != tc.result || row.SecureBoot == "" {
+			t.Errorf("test checkSecureBoot error at case %d, %v\n", i, err)
+		}
+		if err != nil && !errors.Is(err, typdefs.ErrSecureBootFail) {
+			t.Errorf("test checkSecureBoot error type at case %d, %v\n", i, err)
+		}
+	}
+
+	// the replayed pcr 7 must match the quoted pcr 7 of at least one bank.
+	replayCases := []struct {
+		pcrLog string
+		pcrs   []int
+		result bool
+	}{
+		{goodPcr, []int{typdefs.SecureBootPcr}, true},
+		{badPcr, []int{typdefs.SecureBootPcr}, false},
+		{goodPcr, []int{0}, false},
+		{"", []int{typdefs.SecureBootPcr}, false},
+	}
+	for i, tc := range replayCases {
+		report := &typdefs.TrustReport{
+			Quoted: createTestPcrQuote(t, nil, tpm2.AlgSHA256, tc.pcrs, nil),
+			Manifests: []typdefs.Manifest{
+				{Key: typdefs.StrBios, Value: bios},
+				{Key: typdefs.StrPcr, Value: []byte(tc.pcrLog)},
+			}}
+		if _, err = replaySecureBoot(report); (err == nil) != tc.result {
+			t.Errorf("test replaySecureBoot error at case %d, %v\n", i, err)
+		}
+	}
+
+	// the pk owners are checked against the signatures in PK.
+	pk := []typdefs.EfiSignature{{Owner: "77fa9abd-0359-4d32-bd60-28f4e78f784b", CommonName: "Test PK"}}
+	if checkSigOwners(pk, []string{"Test PK"}) != "" ||
+		checkSigOwners(pk, []string{"77FA9ABD-0359-4D32-BD60-28F4E78F784B"}) != "" ||
+		checkSigOwners(pk, []string{"Other"}) == "" || checkSigOwners(nil, []string{"Test PK"}) == "" {
+		t.Errorf("test checkSigOwners error\n")
+	}
+}
+
+func TestCheckBiosPcrs(t *testing.T) {
+	bios, err := ioutil.ReadFile("../../rac/cmd/raagent/binary_bios_measurements")
+	if err != nil {
+		t.Fatalf("read bios log error, %v", err)
+	}
+	events, _ := typdefs.ParseBiosEventLog(bios)
+	replay := typdefs.ReplayBiosEvents(events)
+	var pcrs []int
+	var goodLog, badLog string
+	for i := 0; i < typdefs.PcrMaxNum; i++ {
+		v, ok := replay.Pcrs[typdefs.Sha256AlgStr][i]
+		if !ok {
+			continue
+		}
+		pcrs = append(pcrs, i)
+		goodLog += fmt.Sprintf("%s sha256 %d\n", hex.EncodeToString(v), i)
+		if i == typdefs.SecureBootPcr {
+			v = make([]byte, typdefs.Sha256DigestLen)
+		}
+		badLog += fmt.Sprintf("%s sha256 %d\n", hex.EncodeToString(v), i)
+	}
+	shaQuote := createTestPcrQuote(t, nil, tpm2.AlgSHA256, pcrs, nil)
+	partQuote := createTestPcrQuote(t, nil, tpm2.AlgSHA256, pcrs[:len(pcrs)-1], nil)
+	sm3Quote := createTestPcrQuote(t, nil, cryptotools.AlgSM3, pcrs, nil)
+	last := pcrs[len(pcrs)-1]
+	testCases := []struct {
+		bios       []byte
+		log        string
+		quoted     []byte
+		err        error
+		mismatches []int
+	}{
+		{bios, goodLog, shaQuote, nil, nil},
+		{nil, "", sm3Quote, nil, nil},
+		{bios, badLog, shaQuote, typdefs.ErrBiosPcrNotMatch, []int{typdefs.SecureBootPcr}},
+		{bios, goodLog, partQuote, typdefs.ErrBiosPcrNotMatch, []int{last}},
+		{bios, goodLog, sm3Quote, typdefs.ErrBiosPcrNotMatch, nil},
+	}
+	for i, tc := range testCases {
+		report := &typdefs.TrustReport{
+			Quoted: tc.quoted,
+			Manifests: []typdefs.Manifest{
+				{Key: typdefs.StrBios, Value: tc.bios},
+				{Key: typdefs.StrPcr, Value: []byte(tc.log)},
+			},
+		}
+		err = checkBiosPcrs(report)
+		if !errors.Is(err, tc.err) || (tc.err == nil) != (err == nil) {
+			t.Errorf("test checkBiosPcrs error at case %d, %v\n", i, err)
+			continue
+		}
+		if tc.mismatches == nil {
+			continue
+		}
+		bioErr, ok := err.(*BiosPcrError)
+		if !ok || len(bioErr.Mismatches) != len(tc.mismatches) {
+			t.Errorf("test checkBiosPcrs mismatches error at case %d, %v\n", i, err)
+			continue
+		}
+		res := bioErr.mismatches()
+		for j, m := range bioErr.Mismatches {
+			if m.Pcr != tc.mismatches[j] || m.LastEvent != replay.LastEvents[m.Pcr].ID {
+				t.Errorf("test checkBiosPcrs mismatch error at case %d, %+v\n", i, m)
+			}
+			if res[j].Type != typdefs.StrPcr || res[j].Name != strconv.Itoa(m.Pcr) || len(res[j].Expected) != 1 ||
+				(m.Quoted == "") != (res[j].Reason == typdefs.MismatchNotFound) {
+				t.Errorf("test checkBiosPcrs result mismatch error at case %d, %+v\n", i, res[j])
+			}
+		}
+	}
+}
+
+func TestVerifyRefValues(t *testing.T) {
+	const (
+		h1 = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+		h2 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	)
+	imaLog := "10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:" + h1 + " /usr/bin/ls\n" +
+		"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:" + h2 + " /usr/bin/cat\n"
+	pcrLog := h1 + " sha256 0\n" + h2 + " sha256 1\n"
+	report := &typdefs.TrustReport{Manifests: []typdefs.Manifest{
+		{Key: typdefs.StrPcr, Value: []byte(pcrLog)},
+		{Key: typdefs.StrIma, Value: []byte(imaLog)},
+	}}
+	ref := func(name, digests, extra string) string {
+		return `{"name":"` + name + `","alg":"sha256","digests":[` + digests + `]` + extra + `}`
+	}
+	testCases := []struct {
+		refValue string
+		result   bool
+	}{
+		{`{"version":1}`, true},
+		{`{"version":1,"pcr":[` + ref("0", `"`+h1+`"`, "") + `,` + ref("1", `"`+h1+`","`+h2+`"`, "") + `]}`, true},
+		{`{"version":1,"pcr":[` + ref("1", `"`+h1+`"`, "") + `]}`, false},
+		{`{"version":1,"pcr":[` + ref("2", `"`+h1+`"`, "") + `]}`, false},
+		{`{"version":1,"pcr":[` + ref("2", `"`+h1+`"`, `,"optional":true`) + `]}`, true},
+		{`{"version":1,"ima":[` + ref("/usr/bin/*", `"`+h1+`","`+h2+`"`, `,"match":"glob"`) + `]}`, true},
+		{`{"version":1,"ima":[` + ref("/usr/bin/*", `"`+h1+`"`, `,"match":"glob"`) + `]}`, false},
+		// the exact entry accepts the file which the glob entry doesn't.
+		{`{"version":1,"ima":[` + ref("/usr/bin/*", `"`+h1+`"`, `,"match":"glob"`) + `,` +
+			ref("/usr/bin/cat", `"`+h2+`"`, "") + `]}`, true},
+		{`{"version":1,"ima":[` + ref("/usr/bin/vi", `"`+h1+`"`, "") + `]}`, false},
+		{`{"version":1,"ima":[` + ref("/usr/bin/vi", `"`+h1+`"`, `,"optional":true`) + `]}`, true},
+		{`{"version":2}`, false},
+	}
+	for i, tc := range testCases {
+		err := Verify(&typdefs.BaseRow{RefValue: tc.refValue}, report)
+		if (err == nil) != tc.result {
+			t.Errorf("test Verify by reference values error at case %d, %v\n", i, err)
+		}
+	}
+	// the legacy pcr base value of the same bank.
+	err := Verify(&typdefs.BaseRow{Pcr: "0:" + h1 + "\n1:" + h2 + "\n"}, report)
+	if err != nil {
+		t.Errorf("test Verify legacy pcr base value error, %v", err)
+	}
+	rules := GetExtractRulesFromPcr("0:" + h1 + "\n10:" + h2 + "\n")
+	if len(rules) != 2 || rules[0] != 0 || rules[1] != 10 || len(GetExtractRulesFromPcr("")) != 0 {
+		t.Errorf("test GetExtractRulesFromPcr error, %v", rules)
+	}
+}
+
+func TestVerifyMismatches(t *testing.T) {
+	const (
+		h1 = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+		h2 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	)
+	report := &typdefs.TrustReport{Manifests: []typdefs.Manifest{
+		{Key: typdefs.StrPcr, Value: []byte(h1 + " sha256 0\n")},
+		{Key: typdefs.StrIma, Value: []byte(
+			"10 e7ed1e7c9f2e7700220a03b33171d35dc94296dc ima-ng sha256:" + h1 + " /usr/bin/ls\n")},
+	}}
+	rv := `{"version":1,"pcr":[{"name":"0","alg":"sha256","digests":["` + h2 + `"]},` +
+		`{"name":"2","alg":"sha256","digests":["` + h2 + `"]}],` +
+		`"ima":[{"name":"/usr/bin/ls","alg":"sha256","digests":["` + h2 + `"]},` +
+		`{"name":"/usr/bin/vi","alg":"sha256","digests":["` + h2 + `"]}]}`
+	err := Verify(&typdefs.BaseRow{RefValue: rv}, report)
+	res := typdefs.NewReportResult(typdefs.CheckBaseValue, 3, err)
+	if res.Passed || res.BaseID != 3 || len(res.Mismatches) != 2 {
+		t.Fatalf("test pcr mismatches error, %+v\n", res)
+	}
+	m := res.Mismatches[0]
+	if m.Type != typdefs.StrPcr || m.Name != "0" || m.Reason != typdefs.MismatchNotEqual ||
+		hex.EncodeToString(m.Actual) != h1 || len(m.Expected) != 1 || hex.EncodeToString(m.Expected[0]) != h2 {
+		t.Errorf("test pcr not equal mismatch error, %+v\n", m)
+	}
+	if m = res.Mismatches[1]; m.Name != "2" || m.Reason != typdefs.MismatchNotFound || len(m.Actual) != 0 {
+		t.Errorf("test pcr not found mismatch error, %+v\n", m)
+	}
+
+	// the ima log is checked after all pcrs match.
+	rv = `{"version":1,"ima":[{"name":"/usr/bin/ls","alg":"sha256","digests":["` + h2 + `"]},` +
+		`{"name":"/usr/bin/vi","alg":"sha256","digests":["` + h2 + `"]}]}`
+	err = Verify(&typdefs.BaseRow{RefValue: rv}, report)
+	res = typdefs.NewReportResult(typdefs.CheckBaseValue, 3, err)
+	if res.Passed || len(res.Mismatches) != 2 {
+		t.Fatalf("test ima mismatches error, %+v\n", res)
+	}
+	if m = res.Mismatches[0]; m.Type != typdefs.StrIma || m.Name != "/usr/bin/ls" ||
+		m.Reason != typdefs.MismatchNotEqual || hex.EncodeToString(m.Actual) != h1 {
+		t.Errorf("test ima not equal mismatch error, %+v\n", m)
+	}
+	if m = res.Mismatches[1]; m.Name != "/usr/bin/vi" || m.Reason != typdefs.MismatchNotFound {
+		t.Errorf("test ima not found mismatch error, %+v\n", m)
+	}
+}
+
+func TestValidateReportBaseMismatch(t *testing.T) {
+	tm, err := New(NewMemoryStore(), Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	rc := newTestReportClient(t, tm)
+	ima, pcr := createTestFullImaLog(t, 1)
+	trusted, err := tm.ValidateReport(rc.report(t, ima, pcr, 0))
+	if err != nil || !trusted {
+		t.Fatalf("test ValidateReport without host base value error, %v %v\n", trusted, err)
+	}
+
+	// the report which doesn't match the host base value isn't trusted.
+	rc.c.UpdateBase(&typdefs.BaseRow{ID: 7, ClientID: rc.id, BaseType: typdefs.BaseTypeHost, Enabled: true,
+		RefValue: `{"version":1,"pcr":[{"name":"0","alg":"sha1","digests":["` + strings.Repeat("a5", sha1.Size) + `"]}]}`})
+	trusted, err = tm.ValidateReport(rc.report(t, ima, pcr, 0))
+	var bErr *BaseValueError
+	vs := rc.verdicts(t, tm)
+	if last := vs[len(vs)-1]; trusted || !errors.As(err, &bErr) || !errors.Is(err, typdefs.ErrBaseValueFail) ||
+		len(bErr.Details) != 1 || last.ToState != typdefs.TrustStateUntrusted ||
+		last.Reason != typdefs.TrustReasonBaseMismatch {
+		t.Errorf("test ValidateReport with host base mismatch error, %v %v %+v\n", trusted, err, last)
+	}
+}
+
+func TestValidateReportSaveFailed(t *testing.T) {
+	s := NewMemoryStore()
+	tm, err := New(s, Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	rc := newTestReportClient(t, tm)
+	ima, pcr := createTestFullImaLog(t, 1)
+	forged := append([]byte{}, pcr...)
+	forged[0] ^= 0xff
+	testCases := []struct {
+		report func() *typdefs.TrustReport
+		check  string
+	}{
+		{func() *typdefs.TrustReport {
+			r := rc.report(t, ima, pcr, 0)
+			r.Signature = signTestQuote(t, createTestRSAKey(t), r.Quoted)
+			return r
+		}, typdefs.CheckQuote},
+		{func() *typdefs.TrustReport { return rc.report(t, ima, forged, 0) }, typdefs.CheckLogs},
+	}
+	for i, tc := range testCases {
+		if _, err = tm.ValidateReport(tc.report()); err == nil {
+			t.Errorf("test ValidateReport error at case %d, no error\n", i)
+		}
+	}
+	// the incremental ima log which must be resent isn't saved.
+	tm.ValidateReport(rc.report(t, "", pcr, 5))
+	tm.Close()
+	rs, err := s.FindReportsByClientID(rc.id)
+	if err != nil || len(rs) != len(testCases) {
+		t.Fatalf("test saved failed reports error, %v %v\n", rs, err)
+	}
+	// the store workers may save the reports in any order, so match them by check.
+	saved := map[string]typdefs.ReportResult{}
+	for _, row := range rs {
+		r, err := s.FindReportByID(row.ID)
+		if err != nil || r.Trusted || !r.Validated || len(r.Results) == 0 {
+			t.Errorf("test saved failed report error, %+v %v\n", r, err)
+			continue
+		}
+		res := r.Results[len(r.Results)-1]
+		saved[res.Check] = res
+	}
+	for i, tc := range testCases {
+		if res, ok := saved[tc.check]; !ok || res.Passed || res.Detail == "" {
+			t.Errorf("test saved failed result error at case %d, %+v\n", i, res)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+/*
+kunpengsecl licensed under the Mulan PSL v2.
+You can use this software according to the terms and conditions of
+the Mulan PSL v2. You may obtain a copy of Mulan PSL v2 at:
+    http://license.coscl.org.cn/MulanPSL2
+THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+See the Mulan PSL v2 for more details.
+
+Description: save the trust state transitions of clients, containers and
+	devices into trust_history and show their current states with history.
+*/
+
+package trustmgr
+
+import (
+	"errors"
+	"sort"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/logger"
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+	"gitee.com/openeuler/kunpengsecl/attestation/ras/cache"
+)
+
+var (
+	// ErrUuidNotFound means no container/device of the uuid is attested
+	// or has base value.
+	ErrUuidNotFound = errors.New("container/device not found")
+)
+
+// saveTrustHistory saves the trust state transitions kept in the cache c
+// of client id into store.
+func (t *TrustManager) saveTrustHistory(id int64, c *cache.Cache) {
+	rows := c.TakeTrustHistory()
+	if len(rows) == 0 {
+		return
+	}
+	for _, h := range rows {
+		h.ClientID = id
+	}
+	err := t.store.InsertTrustHistory(rows)
+	if err != nil {
+		logger.L.Sugar().Errorf("insert trust history of client %d error, %v", id, err)
+	}
+}
+
+// reportTrustState returns the host trust state and its reason by the
+// result err of trust report validation.
+func reportTrustState(err error) (string, string) {
+	var sbErr *SecureBootError
+	var pErr *PolicyError
+	var bErr *BaseValueError
+	switch {
+	case err == nil:
+		return typdefs.TrustStateTrusted, typdefs.TrustReasonReportVerified
+	case errors.As(err, &sbErr):
+		return typdefs.TrustStateUntrusted, typdefs.TrustReasonSecureBoot
+	case errors.As(err, &pErr):
+		return typdefs.TrustStateUntrusted, typdefs.TrustReasonPolicy
+	case errors.As(err, &bErr):
+		return typdefs.TrustStateUntrusted, typdefs.TrustReasonBaseMismatch
+	}
+	return typdefs.TrustStateUntrusted, typdefs.TrustReasonReportInvalid
+}
+
+// GetTrustInfo returns the current trust state of client id with the
+// history of the client itself.
+func (t *TrustManager) GetTrustInfo(id int64) (*typdefs.TrustInfo, error) {
+	c, err := t.GetCache(id)
+	if err != nil {
+		return nil, err
+	}
+	history, err := t.findTrustHistory(id, c)
+	if err != nil {
+		return nil, err
+	}
+	return newTrustInfo(typdefs.BaseTypeHost, "", c.GetTrustState(), history), nil
+}
+
+// GetUuidTrustInfos returns the current trust states of the containers or
+// devices of client id chosen by baseType with their history ordered by
+// uuid, both the attested ones and the ones having base values are shown.
+func (t *TrustManager) GetUuidTrustInfos(id int64, baseType string) ([]typdefs.TrustInfo, error) {
+	c, err := t.GetCache(id)
+	if err != nil {
+		return nil, err
+	}
+	history, err := t.findTrustHistory(id, c)
+	if err != nil {
+		return nil, err
+	}
+	states := c.GetUuidTrustStates(baseType)
+	bases := c.GetContainerBases()
+	if baseType == typdefs.BaseTypeDevice {
+		bases = c.GetDeviceBases()
+	}
+	for _, b := range bases {
+		if _, ok := states[b.Uuid]; !ok {
+			states[b.Uuid] = typdefs.NewTrustStatus()
+		}
+	}
+	uuids := make([]string, 0, len(states))
+	for uuid := range states {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	infos := make([]typdefs.TrustInfo, 0, len(uuids))
+	for _, uuid := range uuids {
+		infos = append(infos, *newTrustInfo(baseType, uuid, states[uuid], history))
+	}
+	return infos, nil
+}
+
+// GetUuidTrustInfo returns the current trust state of the container/device
+// of uuid with its history, it is found in the attested ones of all clients
+// or by its base value.
+func (t *TrustManager) GetUuidTrustInfo(uuid string) (*typdefs.TrustInfo, error) {
+	t.mu.Lock()
+	ids := make([]int64, 0, len(t.cache))
+	for id := range t.cache {
+		ids = append(ids, id)
+	}
+	t.mu.Unlock()
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		c, err := t.GetCache(id)
+		if err != nil {
+			continue
+		}
+		for _, baseType := range []string{typdefs.BaseTypeContainer, typdefs.BaseTypeDevice} {
+			st, ok := c.GetUuidTrustStates(baseType)[uuid]
+			if !ok {
+				continue
+			}
+			history, err := t.findTrustHistory(id, c)
+			if err != nil {
+				return nil, err
+			}
+			return newTrustInfo(baseType, uuid, st, history), nil
+		}
+	}
+	base, err := t.store.FindBaseValueByUuid(uuid)
+	if err != nil || base.ClientID == 0 {
+		return nil, ErrUuidNotFound
+	}
+	if _, err = t.GetCache(base.ClientID); err != nil {
+		return nil, ErrUuidNotFound
+	}
+	return newTrustInfo(base.BaseType, uuid, typdefs.NewTrustStatus(), nil), nil
+}
+
+// findTrustHistory saves the transitions kept in cache c of client id and
+// returns all the trust history of the client.
+func (t *TrustManager) findTrustHistory(id int64, c *cache.Cache) ([]typdefs.TrustHistoryRow, error) {
+	t.saveTrustHistory(id, c)
+	return t.store.FindTrustHistoryByClientID(id)
+}
+
+// newTrustInfo returns the trust info of the entity with its transitions
+// picked from history.
+func newTrustInfo(entityType, uuid string, st typdefs.TrustStatus, history []typdefs.TrustHistoryRow) *typdefs.TrustInfo {
+	info := &typdefs.TrustInfo{
+		EntityType:  entityType,
+		Uuid:        uuid,
+		TrustStatus: st,
+		History:     []typdefs.TrustHistoryRow{},
+	}
+	for _, h := range history {
+		if h.EntityType == entityType && h.Uuid == uuid {
+			info.History = append(info.History, h)
+		}
+	}
+	return info
+}
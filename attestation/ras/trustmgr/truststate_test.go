@@ -0,0 +1,119 @@
+package trustmgr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gitee.com/openeuler/kunpengsecl/attestation/common/typdefs"
+)
+
+func TestReportTrustState(t *testing.T) {
+	testCases := []struct {
+		err    error
+		state  string
+		reason string
+	}{
+		{nil, typdefs.TrustStateTrusted, typdefs.TrustReasonReportVerified},
+		{&SecureBootError{Reasons: []string{"shim"}}, typdefs.TrustStateUntrusted, typdefs.TrustReasonSecureBoot},
+		{fmt.Errorf("check: %w", &PolicyError{Rules: []string{"r1"}}), typdefs.TrustStateUntrusted, typdefs.TrustReasonPolicy},
+		{&BaseValueError{Details: []string{"base 1: pcr"}}, typdefs.TrustStateUntrusted, typdefs.TrustReasonBaseMismatch},
+		{typdefs.ErrPCRNotMatch, typdefs.TrustStateUntrusted, typdefs.TrustReasonReportInvalid},
+	}
+	for i, tc := range testCases {
+		state, reason := reportTrustState(tc.err)
+		if state != tc.state || reason != tc.reason {
+			t.Errorf("test reportTrustState error at case %d, %s %s\n", i, state, reason)
+		}
+	}
+}
+
+func TestTrustInfo(t *testing.T) {
+	nic := strings.Repeat("b1", 32)
+	tm, err := New(NewMemoryStore(), Options{})
+	if err != nil {
+		t.Fatalf("test New error %v", err)
+	}
+	defer tm.Close()
+	row, err := tm.RegisterClientByIK("ik", `{}`)
+	if err != nil {
+		t.Fatalf("test RegisterClientByIK error %v", err)
+	}
+	c, err := tm.GetCache(row.ID)
+	if err != nil {
+		t.Fatalf("test GetCache error %v", err)
+	}
+	c.UpdateOnline(time.Hour)
+
+	info, err := tm.GetTrustInfo(row.ID)
+	if err != nil || info.State != typdefs.TrustStatePending || len(info.History) != 1 ||
+		info.History[0].FromState != typdefs.TrustStateUnknown || info.History[0].Reason != typdefs.TrustReasonRegistered {
+		t.Fatalf("test GetTrustInfo after register error, %+v %v", info, err)
+	}
+
+	// a report for an old nonce doesn't change the trust state.
+	report := &typdefs.TrustReport{ClientID: row.ID, Nonce: c.GetNonce() + 1}
+	_, err = tm.ValidateReport(report)
+	if !errors.Is(err, typdefs.ErrNonceNotMatch) || c.GetTrustState().State != typdefs.TrustStatePending {
+		t.Errorf("test ValidateReport with old nonce error, %v %+v\n", err, c.GetTrustState())
+	}
+	// the saved bad report expires the online state at once without config.
+	report.Nonce = c.GetNonce()
+	_, err = tm.ValidateReport(report)
+	info, _ = tm.GetTrustInfo(row.ID)
+	if err == nil || len(info.History) < 2 || info.History[1].ToState != typdefs.TrustStateUntrusted ||
+		info.History[1].Reason != typdefs.TrustReasonReportInvalid || info.History[1].Detail != err.Error() {
+		t.Errorf("test ValidateReport with bad quote error, %v %+v\n", err, info)
+	}
+	c.UpdateOnline(time.Hour)
+
+	c.UpdateBase(&typdefs.BaseRow{ID: 1, BaseType: typdefs.BaseTypeDevice, Uuid: "d1", Enabled: true,
+		RefValue: `{"version":1,"firmware":[{"name":"nic","alg":"sha256","digests":["` + nic + `"]}]}`})
+	c.UpdateBase(&typdefs.BaseRow{ID: 2, BaseType: typdefs.BaseTypeDevice, Uuid: "d2", Enabled: true,
+		RefValue: `{"version":1,"firmware":[]}`})
+	device := func(uuid, firmware string) typdefs.UuidManifest {
+		return typdefs.UuidManifest{Uuid: uuid, Manifests: []typdefs.Manifest{
+			{Key: typdefs.StrFirmware, Value: []byte(firmware)}}}
+	}
+	tm.verifyDevices(c, &typdefs.TrustReport{Devices: []typdefs.UuidManifest{
+		device("d1", "sha256:"+nic+" nic\n"), device("d3", "sha256:"+nic+" gpu\n")}})
+	tm.verifyDevices(c, &typdefs.TrustReport{Devices: []typdefs.UuidManifest{
+		device("d1", "sha256:"+strings.Repeat("b2", 32)+" nic\n")}})
+
+	infos, err := tm.GetUuidTrustInfos(row.ID, typdefs.BaseTypeDevice)
+	results := []struct {
+		uuid    string
+		state   string
+		reason  string
+		history int
+	}{
+		{"d1", typdefs.TrustStateUntrusted, typdefs.TrustReasonBaseMismatch, 2},
+		{"d2", typdefs.TrustStateUnknown, "", 0},
+		{"d3", typdefs.TrustStateUntrusted, typdefs.TrustReasonBaseNotFound, 1},
+	}
+	if err != nil || len(infos) != len(results) {
+		t.Fatalf("test GetUuidTrustInfos error, %+v %v", infos, err)
+	}
+	for i, r := range results {
+		if infos[i].EntityType != typdefs.BaseTypeDevice || infos[i].Uuid != r.uuid || infos[i].State != r.state ||
+			infos[i].Reason != r.reason || len(infos[i].History) != r.history {
+			t.Errorf("test GetUuidTrustInfos error at case %d, %+v\n", i, infos[i])
+		}
+	}
+	if infos, err = tm.GetUuidTrustInfos(row.ID, typdefs.BaseTypeContainer); err != nil || len(infos) != 0 {
+		t.Errorf("test GetUuidTrustInfos of containers error, %+v %v\n", infos, err)
+	}
+
+	info, err = tm.GetUuidTrustInfo("d3")
+	if err != nil || info.EntityType != typdefs.BaseTypeDevice || info.State != typdefs.TrustStateUntrusted {
+		t.Errorf("test GetUuidTrustInfo error, %+v %v\n", info, err)
+	}
+	if _, err = tm.GetUuidTrustInfo("d9"); !errors.Is(err, ErrUuidNotFound) {
+		t.Errorf("test GetUuidTrustInfo not found error, %v\n", err)
+	}
+	if _, err = tm.GetTrustInfo(row.ID + 100); err == nil {
+		t.Errorf("test GetTrustInfo of unknown client error\n")
+	}
+}
@@ -9,6 +9,8 @@ CASENAME=${FILENAME%.*}
 # echo ${FILENAME%.*}, ${FILENAME##*.}
 
 TESTDIR_PREFIX=${TESTDIR_PREFIX:-kunpengsecl-test}
+# ras database type: postgres|sqlite|memory, sqlite and memory don't need a database server
+export DBTYPE=${DBTYPE:-postgres}
 
 RACPKG=${PROJROOT}/attestation/rac/pkg
 RAAGENT=${RACPKG}/raagent
@@ -20,6 +20,7 @@ cp ${TBPRO} ${DST}
 mkdir -p ${DST}/ras
 cp ${RAS} ${DST}/ras
 cp ${RASCONF} ${DST}/ras
+sed -i "s/^  type: postgres$/  type: ${DBTYPE}/" ${DST}/ras/config.yaml
 cp ${RASAUTHKEY} ${DST}/ras
 
 # prepare rahub
@@ -60,6 +60,7 @@ install -m 555 %{_builddir}/%{name}-%{version}/attestation/rac/pkg/raagent %{bui
 install -m 555 %{_builddir}/%{name}-%{version}/attestation/rac/pkg/rahub %{buildroot}/usr/bin/
 install -m 555 %{_builddir}/%{name}-%{version}/attestation/rac/pkg/tbprovisioner %{buildroot}/usr/bin/
 install -m 555 %{_builddir}/%{name}-%{version}/attestation/ras/pkg/ras %{buildroot}/usr/bin/
+install -m 555 %{_builddir}/%{name}-%{version}/attestation/ras/pkg/ras-refgen %{buildroot}/usr/bin/
 
 install -m 644 %{_builddir}/%{name}-%{version}/attestation/rac/cmd/raagent/config.yaml %{buildroot}/etc/attestation/rac/
 install -m 644 %{_builddir}/%{name}-%{version}/attestation/rac/cmd/rahub/config.yaml %{buildroot}/etc/attestation/rahub/
@@ -67,7 +68,6 @@ install -m 644 %{_builddir}/%{name}-%{version}/attestation/ras/cmd/ras/config.ya
 
 install -m 555 %{_builddir}/%{name}-%{version}/attestation/quick-scripts/prepare-database-env.sh %{buildroot}/usr/share/attestation/ras/
 install -m 555 %{_builddir}/%{name}-%{version}/attestation/quick-scripts/clear-database.sh %{buildroot}/usr/share/attestation/ras/
-install -m 555 %{_builddir}/%{name}-%{version}/attestation/quick-scripts/createTable.sql %{buildroot}/usr/share/attestation/ras/
 install -m 555 %{_builddir}/%{name}-%{version}/attestation/quick-scripts/clearTable.sql %{buildroot}/usr/share/attestation/ras/
 install -m 555 %{_builddir}/%{name}-%{version}/attestation/quick-scripts/dropTable.sql %{buildroot}/usr/share/attestation/ras/
 install -m 555 %{_builddir}/%{name}-%{version}/attestation/quick-scripts/integritytools/*.sh %{buildroot}/usr/share/attestation/rac/
@@ -107,10 +107,10 @@ install -m 644 %{_builddir}/%{name}-%{version}/LICENSE %{buildroot}/usr/share/do
 
 %files   ras
 %{_bindir}/ras
+%{_bindir}/ras-refgen
 %{_sysconfdir}/attestation/ras/config.yaml
 %{_datadir}/attestation/ras/prepare-database-env.sh
 %{_datadir}/attestation/ras/clear-database.sh
-%{_datadir}/attestation/ras/createTable.sql
 %{_datadir}/attestation/ras/clearTable.sql
 %{_datadir}/attestation/ras/dropTable.sql
 %{_docdir}/attestation/ras/README.md